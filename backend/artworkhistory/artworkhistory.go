@@ -0,0 +1,175 @@
+package artworkhistory
+
+import (
+	"aura/config"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SourceName = "history"
+	// ImageIDPrefix is prepended to every history image ID (the file path relative to the history folder)
+	ImageIDPrefix = "history_"
+
+	defaultMaxVersions = 5
+	historyFileName    = "history.json"
+)
+
+var (
+	// FolderPath is where the replaced artwork is stored, one sub folder per library and item
+	FolderPath string
+
+	// Guards the read-modify-write of history.json files
+	historyMu sync.Mutex
+)
+
+// Version is a piece of artwork that was on the media server before AURA replaced it
+type Version struct {
+	ID            string     `json:"id"`
+	ImageType     string     `json:"image_type"` // poster, backdrop, season_poster, special_season_poster, titlecard
+	SeasonNumber  *int       `json:"season_number,omitempty"`
+	EpisodeNumber *int       `json:"episode_number,omitempty"`
+	FileName      string     `json:"file_name,omitempty"`
+	Image         *ImageRef  `json:"image,omitempty"` // Set instead of FileName when the artwork was an image AURA applied, it is read from its image source
+	ContentType   string     `json:"content_type"`
+	Hash          string     `json:"hash"`
+	CapturedAt    time.Time  `json:"captured_at"`
+	ReplacedBy    ReplacedBy `json:"replaced_by"`
+	Original      bool       `json:"original,omitempty"` // The first capture of the image, the artwork from before AURA. It is never pruned.
+}
+
+// ImageRef points to an image of an image source
+type ImageRef struct {
+	Source   string    `json:"source"`
+	ImageID  string    `json:"image_id"`
+	Modified time.Time `json:"modified"`
+}
+
+// ReplacedBy describes the image that was applied over a Version
+type ReplacedBy struct {
+	Source        string    `json:"source"`
+	ImageID       string    `json:"image_id"`
+	ImageModified time.Time `json:"image_modified"`
+	SetID         string    `json:"set_id,omitempty"`
+	SetTitle      string    `json:"set_title,omitempty"`
+	SetCreator    string    `json:"set_creator,omitempty"`
+}
+
+// ItemHistory is every captured Version for a single media item, newest first
+type ItemHistory struct {
+	TMDB_ID      string    `json:"tmdb_id"`
+	LibraryTitle string    `json:"library_title"`
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	Year         int       `json:"year"`
+	Edition      string    `json:"edition,omitempty"`
+	Versions     []Version `json:"versions"`
+}
+
+func init() {
+	FolderPath = path.Join(config.ConfigPath, "artwork-history")
+}
+
+// IsEnabled returns true when the current artwork should be captured before it is replaced
+func IsEnabled(ctx context.Context) bool {
+	return config.Current(ctx).Images.ArtworkHistory.Enabled
+}
+
+func maxVersions(ctx context.Context) int {
+	if config.Current(ctx).Images.ArtworkHistory.MaxVersions > 0 {
+		return config.Current(ctx).Images.ArtworkHistory.MaxVersions
+	}
+	return defaultMaxVersions
+}
+
+// IsHistoryImage returns true if the image file points to a captured version
+func IsHistoryImage(imageFile models.ImageFile) bool {
+	return strings.HasPrefix(imageFile.ID, ImageIDPrefix)
+}
+
+// SlotKey identifies which image of an item a Version belongs to (e.g. "poster" or "titlecard_S01E02")
+func SlotKey(imageType string, seasonNumber, episodeNumber *int) string {
+	switch imageType {
+	case "season_poster", "special_season_poster":
+		if seasonNumber != nil {
+			return fmt.Sprintf("%s_S%02d", imageType, *seasonNumber)
+		}
+	case "titlecard":
+		if seasonNumber != nil && episodeNumber != nil {
+			return fmt.Sprintf("%s_S%02dE%02d", imageType, *seasonNumber, *episodeNumber)
+		}
+	}
+	return imageType
+}
+
+// ImageFileForVersion builds the Image File used to re-apply a Version to the item through the normal apply flow
+func ImageFileForVersion(item models.MediaItem, version Version) models.ImageFile {
+	if version.Image != nil {
+		return models.ImageFile{
+			ID:            version.Image.ImageID,
+			Type:          version.ImageType,
+			Modified:      version.Image.Modified,
+			ItemTMDB_ID:   item.TMDB_ID,
+			SeasonNumber:  version.SeasonNumber,
+			EpisodeNumber: version.EpisodeNumber,
+			Source:        version.Image.Source,
+		}
+	}
+	return models.ImageFile{
+		ID:            ImageIDPrefix + filepath.ToSlash(filepath.Join(itemFolderName(item), version.FileName)),
+		Type:          version.ImageType,
+		Modified:      version.CapturedAt,
+		ItemTMDB_ID:   item.TMDB_ID,
+		SeasonNumber:  version.SeasonNumber,
+		EpisodeNumber: version.EpisodeNumber,
+		Source:        SourceName,
+	}
+}
+
+// itemFolderName returns the folder of an item relative to FolderPath
+func itemFolderName(item models.MediaItem) string {
+	itemFolder := fmt.Sprintf("%s_%s", item.Type, item.TMDB_ID)
+	if item.Edition != "" {
+		itemFolder = fmt.Sprintf("%s_%s", itemFolder, item.Edition)
+	}
+	return filepath.Join(sanitizeFolderName(item.LibraryTitle), sanitizeFolderName(itemFolder))
+}
+
+func sanitizeFolderName(name string) string {
+	return strings.TrimSpace(utils.InvalidFolderChars.ReplaceAllString(name, "_"))
+}
+
+// getImagePath resolves an image ID to a file path inside the history folder
+// An empty path is returned if the image ID points outside of the history folder
+func getImagePath(imageID string) string {
+	return utils.PathInFolder(FolderPath, strings.TrimPrefix(imageID, ImageIDPrefix))
+}
+
+type replacingSetKey struct{}
+
+// WithReplacingSet records the set that is being applied, so that captured versions can show which set replaced them
+func WithReplacingSet(ctx context.Context, set models.BaseSetInfo) context.Context {
+	return context.WithValue(ctx, replacingSetKey{}, set)
+}
+
+// NewReplacedBy describes the image that is about to be applied, including the set from WithReplacingSet if there is one
+func NewReplacedBy(ctx context.Context, imageFile models.ImageFile, source string) ReplacedBy {
+	replacedBy := ReplacedBy{
+		Source:        source,
+		ImageID:       imageFile.ID,
+		ImageModified: imageFile.Modified,
+	}
+	if set, ok := ctx.Value(replacingSetKey{}).(models.BaseSetInfo); ok {
+		replacedBy.SetID = set.ID
+		replacedBy.SetTitle = set.Title
+		replacedBy.SetCreator = set.UserCreated
+	}
+	return replacedBy
+}
@@ -0,0 +1,305 @@
+package artworkhistory
+
+import (
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SaveVersion stores the artwork that is about to be replaced on the media server.
+// Nothing is stored when the artwork is identical to the newest version of the same image.
+// Only the newest MaxVersions versions of each image are kept, along with the first capture (the original).
+func SaveVersion(ctx context.Context, item models.MediaItem, replacedImage models.ImageFile, replacedBy ReplacedBy, imageData []byte) (version Version, saved bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Artwork History: Saving current %s for %s", replacedImage.Type, utils.MediaItemInfo(item)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	if len(imageData) == 0 {
+		logAction.SetError("No image data to save", "The media server returned an empty image", nil)
+		return version, false, *logAction.Error
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	history, Err := readItemHistory(ctx, item)
+	if Err.Message != "" {
+		return version, false, Err
+	}
+
+	hashBytes := sha256.Sum256(imageData)
+	hash := hex.EncodeToString(hashBytes[:])
+	slot := SlotKey(replacedImage.Type, replacedImage.SeasonNumber, replacedImage.EpisodeNumber)
+	firstCapture := true
+	for i, existing := range history.Versions {
+		if SlotKey(existing.ImageType, existing.SeasonNumber, existing.EpisodeNumber) != slot {
+			continue
+		}
+		firstCapture = false
+		// Versions are newest first, so the first match is the current one
+		if existing.Hash == hash {
+			// Record what replaced it this time, SaveAppliedVersion relies on the newest ReplacedBy
+			existing.ReplacedBy = replacedBy
+			history.Versions[i] = existing
+			Err = writeItemHistory(ctx, item, history)
+			if Err.Message != "" {
+				return existing, false, Err
+			}
+			logAction.AppendResult("skipped", "artwork unchanged since the last capture")
+			return existing, false, logging.LogErrorInfo{}
+		}
+		break
+	}
+
+	contentType := http.DetectContentType(imageData)
+	now := time.Now()
+	version = Version{
+		ID:            fmt.Sprintf("%s_%d", slot, now.UnixNano()),
+		ImageType:     replacedImage.Type,
+		SeasonNumber:  replacedImage.SeasonNumber,
+		EpisodeNumber: replacedImage.EpisodeNumber,
+		ContentType:   contentType,
+		Hash:          hash,
+		CapturedAt:    now,
+		ReplacedBy:    replacedBy,
+		Original:      firstCapture,
+	}
+	version.FileName = version.ID + utils.GetExtensionFromContentType(contentType)
+
+	itemFolder := filepath.Join(FolderPath, itemFolderName(item))
+	Err = utils.CreateFolderIfNotExists(ctx, itemFolder)
+	if Err.Message != "" {
+		return version, false, Err
+	}
+	if err := os.WriteFile(filepath.Join(itemFolder, version.FileName), imageData, 0644); err != nil {
+		logAction.SetError("Failed to write artwork history image", "Ensure the config folder is writable", map[string]any{
+			"error": err.Error(),
+			"path":  filepath.Join(itemFolder, version.FileName),
+		})
+		return version, false, *logAction.Error
+	}
+
+	Err = addVersion(ctx, item, replacedImage, history, version)
+	if Err.Message != "" {
+		return version, false, Err
+	}
+
+	logAction.AppendResult("version_id", version.ID)
+	return version, true, logging.LogErrorInfo{}
+}
+
+// SaveAppliedVersion stores the artwork that is about to be replaced without fetching it from the media server.
+// This is only possible when the current artwork is the image AURA applied last, which is then stored as a reference to its image source.
+// known is false when the current artwork is not known and has to be captured with SaveVersion.
+func SaveAppliedVersion(ctx context.Context, item models.MediaItem, replacedImage models.ImageFile, replacedBy ReplacedBy) (version Version, saved bool, known bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Artwork History: Saving applied %s for %s", replacedImage.Type, utils.MediaItemInfo(item)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	history, Err := readItemHistory(ctx, item)
+	if Err.Message != "" {
+		return version, false, false, Err
+	}
+
+	slot := SlotKey(replacedImage.Type, replacedImage.SeasonNumber, replacedImage.EpisodeNumber)
+	var newest *Version
+	for i := range history.Versions {
+		if SlotKey(history.Versions[i].ImageType, history.Versions[i].SeasonNumber, history.Versions[i].EpisodeNumber) == slot {
+			newest = &history.Versions[i]
+			break
+		}
+	}
+	// History images are pruned, so a reference to one could stop working
+	if newest == nil || newest.ReplacedBy.ImageID == "" || newest.ReplacedBy.Source == SourceName {
+		return version, false, false, logging.LogErrorInfo{}
+	}
+
+	current := newest.ReplacedBy
+	if current.ImageID == replacedImage.ID && current.ImageModified.Equal(replacedImage.Modified) {
+		logAction.AppendResult("skipped", "the same image is applied again")
+		return *newest, false, true, logging.LogErrorInfo{}
+	}
+
+	now := time.Now()
+	version = Version{
+		ID:            fmt.Sprintf("%s_%d", slot, now.UnixNano()),
+		ImageType:     replacedImage.Type,
+		SeasonNumber:  replacedImage.SeasonNumber,
+		EpisodeNumber: replacedImage.EpisodeNumber,
+		Image:         &ImageRef{Source: current.Source, ImageID: current.ImageID, Modified: current.ImageModified},
+		CapturedAt:    now,
+		ReplacedBy:    replacedBy,
+	}
+
+	Err = addVersion(ctx, item, replacedImage, history, version)
+	if Err.Message != "" {
+		return version, false, true, Err
+	}
+
+	logAction.AppendResult("version_id", version.ID)
+	return version, true, true, logging.LogErrorInfo{}
+}
+
+// addVersion adds a new version to the history, prunes the old ones and writes the history file. historyMu must be held.
+func addVersion(ctx context.Context, item models.MediaItem, replacedImage models.ImageFile, history ItemHistory, version Version) (Err logging.LogErrorInfo) {
+	history.Title = item.Title
+	history.Year = item.Year
+	history.Versions = append([]Version{version}, history.Versions...)
+	// A version that is being reverted to is read after this capture, so it is never pruned here
+	keepFileName := ""
+	if IsHistoryImage(replacedImage) {
+		keepFileName = filepath.Base(filepath.FromSlash(replacedImage.ID))
+	}
+	history.Versions = pruneVersions(ctx, filepath.Join(FolderPath, itemFolderName(item)), history.Versions, keepFileName)
+
+	return writeItemHistory(ctx, item, history)
+}
+
+// GetItemHistory returns every captured version for an item, newest first
+func GetItemHistory(ctx context.Context, item models.MediaItem) (history ItemHistory, Err logging.LogErrorInfo) {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	return readItemHistory(ctx, item)
+}
+
+// GetVersion returns a single captured version of an item
+func GetVersion(ctx context.Context, item models.MediaItem, versionID string) (version Version, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Artwork History: Getting version '%s' for %s", versionID, utils.MediaItemInfo(item)), logging.LevelTrace)
+	defer logAction.Complete()
+
+	history, Err := GetItemHistory(ctx, item)
+	if Err.Message != "" {
+		return version, Err
+	}
+	for _, v := range history.Versions {
+		if v.ID == versionID {
+			return v, logging.LogErrorInfo{}
+		}
+	}
+
+	logAction.SetError("Artwork history version not found", "The version may have been removed because only the newest versions are kept", map[string]any{
+		"version_id": versionID,
+	})
+	return version, *logAction.Error
+}
+
+// GetImage reads a captured version from disk
+func GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	_, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Artwork History: Reading Image '%s'", imageFile.ID), logging.LevelTrace)
+	defer logAction.Complete()
+
+	imagePath := getImagePath(imageFile.ID)
+	if !IsHistoryImage(imageFile) || imagePath == "" {
+		logAction.SetError("Invalid Artwork History Image ID", "The image ID does not point to a file inside the artwork history folder", map[string]any{"image_id": imageFile.ID})
+		return nil, "", *logAction.Error
+	}
+
+	imageData, err := os.ReadFile(imagePath)
+	if err != nil {
+		logAction.SetError("Failed to read artwork history image", "The version may have been removed because only the newest versions are kept", map[string]any{
+			"error": err.Error(),
+			"path":  imagePath,
+		})
+		return nil, "", *logAction.Error
+	}
+
+	return imageData, http.DetectContentType(imageData), logging.LogErrorInfo{}
+}
+
+// pruneVersions drops the oldest versions of each image beyond MaxVersions and deletes their files.
+// The original version of each image is kept and not counted.
+func pruneVersions(ctx context.Context, itemFolder string, versions []Version, keepFileName string) []Version {
+	limit := maxVersions(ctx)
+	kept := []Version{}
+	perSlot := map[string]int{}
+	for _, v := range versions {
+		if v.Original {
+			kept = append(kept, v)
+			continue
+		}
+		slot := SlotKey(v.ImageType, v.SeasonNumber, v.EpisodeNumber)
+		perSlot[slot]++
+		if perSlot[slot] <= limit || (v.FileName != "" && v.FileName == keepFileName) {
+			kept = append(kept, v)
+			continue
+		}
+		if v.FileName == "" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(itemFolder, v.FileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logging.LOGGER.Warn().Timestamp().Str("file", v.FileName).Err(err).Msg("Failed to remove old artwork history image")
+		}
+	}
+	return kept
+}
+
+func readItemHistory(ctx context.Context, item models.MediaItem) (history ItemHistory, Err logging.LogErrorInfo) {
+	history = ItemHistory{
+		TMDB_ID:      item.TMDB_ID,
+		LibraryTitle: item.LibraryTitle,
+		Type:         item.Type,
+		Title:        item.Title,
+		Year:         item.Year,
+		Edition:      item.Edition,
+		Versions:     []Version{},
+	}
+
+	historyPath := filepath.Join(FolderPath, itemFolderName(item), historyFileName)
+	data, err := os.ReadFile(historyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, logging.LogErrorInfo{}
+	}
+	if err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Artwork History: Reading history file", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Failed to read artwork history", "Ensure the config folder is readable", map[string]any{
+			"error": err.Error(),
+			"path":  historyPath,
+		})
+		return history, *logAction.Error
+	}
+	if err := json.Unmarshal(data, &history); err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Artwork History: Parsing history file", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Failed to parse artwork history", "The history file may be corrupted", map[string]any{
+			"error": err.Error(),
+			"path":  historyPath,
+		})
+		return history, *logAction.Error
+	}
+	if history.Versions == nil {
+		history.Versions = []Version{}
+	}
+	return history, logging.LogErrorInfo{}
+}
+
+func writeItemHistory(ctx context.Context, item models.MediaItem, history ItemHistory) (Err logging.LogErrorInfo) {
+	_, logAction := logging.AddSubActionToContext(ctx, "Artwork History: Writing history file", logging.LevelTrace)
+	defer logAction.Complete()
+
+	historyPath := filepath.Join(FolderPath, itemFolderName(item), historyFileName)
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		logAction.SetError("Failed to encode artwork history", "Check the logs for more details", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	if err := os.WriteFile(historyPath, data, 0644); err != nil {
+		logAction.SetError("Failed to write artwork history", "Ensure the config folder is writable", map[string]any{
+			"error": err.Error(),
+			"path":  historyPath,
+		})
+		return *logAction.Error
+	}
+	return logging.LogErrorInfo{}
+}
@@ -0,0 +1,37 @@
+package artworkhistory
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"time"
+)
+
+// History is the image source used to re-apply captured artwork.
+// It has no sets of its own, it only serves the images of captured versions.
+type History struct{}
+
+func (h *History) GetItemSets(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string, filter models.ImageFilter) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	return []models.SetRef{}, map[string]models.IncludedItem{}, logging.LogErrorInfo{}
+}
+
+func (h *History) GetSetByID(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	return set, includedItems, logging.LogErrorInfo{
+		Message: "Artwork history does not have sets",
+		Help:    "Use the artwork history revert to re-apply a captured version",
+		Detail:  map[string]any{"set_id": setID},
+	}
+}
+
+func (h *History) GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	return GetImage(ctx, imageFile)
+}
+
+// Captured versions are not reachable by the Media Server, so they always have to be uploaded
+func (h *History) GetImageURL(ctx context.Context, imageFile models.ImageFile) (imageURL string, Err logging.LogErrorInfo) {
+	return "", logging.LogErrorInfo{}
+}
+
+func (h *History) GetSetUpdatedAt(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (updatedAt time.Time, Err logging.LogErrorInfo) {
+	return updatedAt, logging.LogErrorInfo{}
+}
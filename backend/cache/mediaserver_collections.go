@@ -110,9 +110,26 @@ func (msc *MediaServerCollectionsCache) UpsertCollection(collection *models.Coll
 		lib = make(map[string]*models.CollectionItem)
 		msc.collections[collection.LibraryTitle] = lib
 	}
+	// The items of a collection are filled in separately, so an upsert without items keeps the cached ones
+	if existing := lib[collection.RatingKey]; existing != nil && len(collection.MediaItems) == 0 {
+		collection.MediaItems = existing.MediaItems
+	}
 	lib[collection.RatingKey] = collection
 }
 
+// ClearCollectionItems empties the items of every collection of a library, so a full refresh can fill them in again
+// without keeping items that were taken out of a collection
+func (msc *MediaServerCollectionsCache) ClearCollectionItems(libraryTitle string) {
+	msc.mu.Lock()
+	defer msc.mu.Unlock()
+
+	for _, collection := range msc.collections[libraryTitle] {
+		if collection != nil {
+			collection.MediaItems = nil
+		}
+	}
+}
+
 func (msc *MediaServerCollectionsCache) UpdateMediaItemInCollectionByIndex(collectionIndex string, item *models.MediaItem) {
 	msc.mu.Lock()
 	defer msc.mu.Unlock()
@@ -173,3 +190,37 @@ func (msc *MediaServerCollectionsCache) UpdateMediaItemInCollectionByTitle(colle
 	}
 
 }
+
+// RemoveCollectionsNotIn removes the collections of a library whose rating key is not in ratingKeys.
+// Used after a full refresh so collections deleted on the media server don't linger.
+func (msc *MediaServerCollectionsCache) RemoveCollectionsNotIn(libraryTitle string, ratingKeys map[string]bool) (removed int) {
+	msc.mu.Lock()
+	defer msc.mu.Unlock()
+
+	lib := msc.collections[libraryTitle]
+	for ratingKey := range lib {
+		if !ratingKeys[ratingKey] {
+			delete(lib, ratingKey)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RemoveLibrariesNotIn removes the collections of every library whose title is not in titles
+func (msc *MediaServerCollectionsCache) RemoveLibrariesNotIn(titles []string) (removed int) {
+	msc.mu.Lock()
+	defer msc.mu.Unlock()
+
+	keep := make(map[string]bool, len(titles))
+	for _, title := range titles {
+		keep[title] = true
+	}
+	for title, lib := range msc.collections {
+		if !keep[title] {
+			removed += len(lib)
+			delete(msc.collections, title)
+		}
+	}
+	return removed
+}
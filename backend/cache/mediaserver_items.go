@@ -14,7 +14,9 @@ var LibraryStore *MediaServerLibraryCache
 type MediaServerLibraryCache struct {
 	sections       map[string]*models.LibrarySection // Key: Library Title
 	mu             sync.RWMutex
+	ratingKeyIndex map[string]ratingKeyIndexEntry // Key: Rating Key of an item, season or episode. Rebuilt on first use after the sections change.
 	LastFullUpdate int64
+	LastSync       int64 // Last full or incremental refresh
 }
 
 // NewLibraryCache creates a new LibraryCache instance
@@ -36,6 +38,7 @@ func init() {
 func (c *MediaServerLibraryCache) UpdateSection(section *models.LibrarySection) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.ratingKeyIndex = nil
 
 	// Check if section already exists
 	// If it does, we need to update it
@@ -74,26 +77,28 @@ func (c *MediaServerLibraryCache) UpdateSection(section *models.LibrarySection)
 	}
 }
 
-// UpdateMediaItem updates a specific media item in a section
+// UpdateMediaItem updates a specific media item in a section.
+// The Rating Key index is updated for this item only, so it doesn't have to be rebuilt.
 func (c *MediaServerLibraryCache) UpdateMediaItem(sectionTitle string, item *models.MediaItem) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	// Check if section exists
 	if section, exists := c.sections[sectionTitle]; exists {
-		// Create a map of existing items for O(1) lookup
-		existingItems := make(map[string]*models.MediaItem)
+		itemKey := mediaItemCacheKey(item)
 		for i := range section.MediaItems {
-			existingItems[mediaItemCacheKey(&section.MediaItems[i])] = &section.MediaItems[i]
-		}
-		if existingItem, found := existingItems[mediaItemCacheKey(item)]; found {
-			// Update existing item
-			*existingItem = *item
-		} else {
-			// Append new item
-			section.MediaItems = append(section.MediaItems, *item)
-			section.TotalSize = len(section.MediaItems)
+			if mediaItemCacheKey(&section.MediaItems[i]) == itemKey {
+				// Update existing item
+				c.unindexItem(sectionTitle, i)
+				section.MediaItems[i] = *item
+				c.indexItem(sectionTitle, i)
+				return
+			}
 		}
+		// Append new item
+		section.MediaItems = append(section.MediaItems, *item)
+		section.TotalSize = len(section.MediaItems)
+		c.indexItem(sectionTitle, len(section.MediaItems)-1)
 	}
 }
 
@@ -162,13 +167,78 @@ func (c *MediaServerLibraryCache) GetAllSectionsSortedByTitle() []*models.Librar
 func (c *MediaServerLibraryCache) RemoveSectionByTitle(title string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.ratingKeyIndex = nil
 	delete(c.sections, title)
 }
 
+// RemoveSectionsNotIn removes every section whose title is not in titles.
+// Used after a full refresh so libraries removed from the config don't linger.
+func (c *MediaServerLibraryCache) RemoveSectionsNotIn(titles []string) (removed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ratingKeyIndex = nil
+
+	keep := make(map[string]bool, len(titles))
+	for _, title := range titles {
+		keep[title] = true
+	}
+	for title := range c.sections {
+		if !keep[title] {
+			delete(c.sections, title)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RemoveItemsNotIn removes the items of a section that are not in keys (see MediaItemCacheKey).
+// UpdateSection never removes items, so a full refresh uses this to drop the items
+// that are no longer on the media server.
+func (c *MediaServerLibraryCache) RemoveItemsNotIn(sectionTitle string, keys map[string]bool) (removed int) {
+	return c.removeItemsNotKept(sectionTitle, func(item *models.MediaItem) bool {
+		return keys[mediaItemCacheKey(item)]
+	})
+}
+
+// RemoveItemsWithRatingKeyNotIn removes the items of a section whose Rating Key is not in ratingKeys.
+// Used by the incremental refresh to drop the items that were removed from the media server.
+func (c *MediaServerLibraryCache) RemoveItemsWithRatingKeyNotIn(sectionTitle string, ratingKeys map[string]bool) (removed int) {
+	return c.removeItemsNotKept(sectionTitle, func(item *models.MediaItem) bool {
+		return ratingKeys[item.RatingKey]
+	})
+}
+
+// removeItemsNotKept removes the items of a section for which keep returns false
+func (c *MediaServerLibraryCache) removeItemsNotKept(sectionTitle string, keep func(item *models.MediaItem) bool) (removed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ratingKeyIndex = nil
+
+	section, exists := c.sections[sectionTitle]
+	if !exists {
+		return 0
+	}
+
+	// Build a new slice, items handed out by the getters may still point into the old one
+	kept := make([]models.MediaItem, 0, len(section.MediaItems))
+	for _, item := range section.MediaItems {
+		if keep(&item) {
+			kept = append(kept, item)
+		}
+	}
+	removed = len(section.MediaItems) - len(kept)
+	if removed > 0 {
+		section.MediaItems = kept
+		section.TotalSize = len(kept)
+	}
+	return removed
+}
+
 // ClearAllSections removes all sections from the cache
 func (c *MediaServerLibraryCache) ClearAllSections() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.ratingKeyIndex = nil
 	c.sections = make(map[string]*models.LibrarySection)
 }
 
@@ -179,6 +249,11 @@ func mediaItemCacheKey(item *models.MediaItem) string {
 	return item.TMDB_ID + "|" + item.Edition
 }
 
+// MediaItemCacheKey returns the key a media item is cached under within its section
+func MediaItemCacheKey(item *models.MediaItem) string {
+	return mediaItemCacheKey(item)
+}
+
 // GetMediaItemFromSectionByTMDBID retrieves a media item by TMDB ID from a specific section.
 // If multiple editions exist for the TMDB ID, an arbitrary one is returned -
 // callers that need a specific edition should use GetMediaItemFromSectionByTMDBIDAndEdition.
@@ -225,6 +300,103 @@ func (c *MediaServerLibraryCache) GetMediaItemFromSectionByTMDBIDAndEdition(sect
 	return &models.MediaItem{}, false
 }
 
+type ratingKeyIndexEntry struct {
+	sectionTitle string
+	itemIndex    int    // Index of the item in section.MediaItems
+	itemType     string // "movie", "show", "season" or "episode"
+}
+
+// GetMediaItemByAnyRatingKey finds the movie or show that a Rating Key belongs to.
+// The Rating Key can be the one of the item itself or of one of its seasons or episodes.
+// itemType is the type of the item the Rating Key belongs to.
+func (c *MediaServerLibraryCache) GetMediaItemByAnyRatingKey(ratingKey string) (item models.MediaItem, itemType string, found bool) {
+	c.mu.RLock()
+	if c.ratingKeyIndex != nil {
+		defer c.mu.RUnlock()
+		return c.lookupRatingKey(ratingKey)
+	}
+	c.mu.RUnlock()
+
+	// The index has to be rebuilt, another caller may have done so before we got the write lock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ratingKeyIndex == nil {
+		c.buildRatingKeyIndex()
+	}
+	return c.lookupRatingKey(ratingKey)
+}
+
+// lookupRatingKey gets the item a Rating Key belongs to from the index. c.mu must be held and the index must be built.
+func (c *MediaServerLibraryCache) lookupRatingKey(ratingKey string) (item models.MediaItem, itemType string, found bool) {
+	entry, exists := c.ratingKeyIndex[ratingKey]
+	if !exists {
+		return models.MediaItem{}, "", false
+	}
+	section, exists := c.sections[entry.sectionTitle]
+	if !exists || entry.itemIndex >= len(section.MediaItems) {
+		return models.MediaItem{}, "", false
+	}
+	return section.MediaItems[entry.itemIndex], entry.itemType, true
+}
+
+// buildRatingKeyIndex indexes the Rating Keys of every item, season and episode. c.mu must be held for writing.
+func (c *MediaServerLibraryCache) buildRatingKeyIndex() {
+	c.ratingKeyIndex = make(map[string]ratingKeyIndexEntry)
+	for sectionTitle, section := range c.sections {
+		for i := range section.MediaItems {
+			c.indexItem(sectionTitle, i)
+		}
+	}
+}
+
+// indexItem adds the Rating Keys of an item, its seasons and its episodes to the index, if the index is built.
+// c.mu must be held for writing.
+func (c *MediaServerLibraryCache) indexItem(sectionTitle string, itemIndex int) {
+	if c.ratingKeyIndex == nil {
+		return
+	}
+	item := &c.sections[sectionTitle].MediaItems[itemIndex]
+	if item.RatingKey != "" {
+		c.ratingKeyIndex[item.RatingKey] = ratingKeyIndexEntry{sectionTitle, itemIndex, item.Type}
+	}
+	if item.Series == nil {
+		return
+	}
+	for _, season := range item.Series.Seasons {
+		if season.RatingKey != "" {
+			c.ratingKeyIndex[season.RatingKey] = ratingKeyIndexEntry{sectionTitle, itemIndex, "season"}
+		}
+		for _, episode := range season.Episodes {
+			if episode.RatingKey != "" {
+				c.ratingKeyIndex[episode.RatingKey] = ratingKeyIndexEntry{sectionTitle, itemIndex, "episode"}
+			}
+		}
+	}
+}
+
+// unindexItem removes the Rating Keys of an item, its seasons and its episodes from the index, if the index is built.
+// c.mu must be held for writing.
+func (c *MediaServerLibraryCache) unindexItem(sectionTitle string, itemIndex int) {
+	if c.ratingKeyIndex == nil {
+		return
+	}
+	item := &c.sections[sectionTitle].MediaItems[itemIndex]
+	ratingKeys := []string{item.RatingKey}
+	if item.Series != nil {
+		for _, season := range item.Series.Seasons {
+			ratingKeys = append(ratingKeys, season.RatingKey)
+			for _, episode := range season.Episodes {
+				ratingKeys = append(ratingKeys, episode.RatingKey)
+			}
+		}
+	}
+	for _, ratingKey := range ratingKeys {
+		if entry, exists := c.ratingKeyIndex[ratingKey]; exists && entry.sectionTitle == sectionTitle && entry.itemIndex == itemIndex {
+			delete(c.ratingKeyIndex, ratingKey)
+		}
+	}
+}
+
 func (c *MediaServerLibraryCache) GetMediaItemByRatingKey(ratingKey string) (*models.MediaItem, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// Folder where the library cache snapshot is kept
+var SnapshotFolderPath string
+
+const (
+	snapshotFileName = "library-cache.json.gz"
+	snapshotVersion  = 1
+)
+
+func init() {
+	SnapshotFolderPath = path.Join(config.ConfigPath, "library-cache")
+}
+
+// librarySnapshot is the on-disk copy of the library and collections caches
+type librarySnapshot struct {
+	Version     int    `json:"version"`
+	SavedAt     int64  `json:"saved_at"`
+	MediaServer string `json:"media_server"` // Type and URL of the media server the snapshot was taken from
+
+	LibraryLastFullUpdate     int64                   `json:"library_last_full_update"`
+	LibraryLastSync           int64                   `json:"library_last_sync,omitempty"`
+	Sections                  []models.LibrarySection `json:"sections"`
+	CollectionsLastFullUpdate int64                   `json:"collections_last_full_update"`
+	Collections               []models.CollectionItem `json:"collections"`
+}
+
+func snapshotFilePath() string {
+	return filepath.Join(SnapshotFolderPath, snapshotFileName)
+}
+
+// snapshotMediaServerKey identifies the media server a snapshot belongs to,
+// so a snapshot is not served after the media server is changed
+func snapshotMediaServerKey(ctx context.Context) string {
+	ms := config.Current(ctx).MediaServer
+	return ms.Type + "|" + ms.URL
+}
+
+// SaveLibrarySnapshot writes the library and collections caches to disk.
+// It should be called after a full refresh, so the next start can serve the cache right away.
+func SaveLibrarySnapshot(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Saving Library Cache Snapshot", logging.LevelDebug)
+	defer logAction.Complete()
+
+	snapshot := librarySnapshot{
+		Version:     snapshotVersion,
+		SavedAt:     time.Now().Unix(),
+		MediaServer: snapshotMediaServerKey(ctx),
+	}
+
+	// Encode while holding the read locks, the cached sections are shared
+	LibraryStore.mu.RLock()
+	snapshot.LibraryLastFullUpdate = LibraryStore.LastFullUpdate
+	snapshot.LibraryLastSync = LibraryStore.LastSync
+	snapshot.Sections = make([]models.LibrarySection, 0, len(LibraryStore.sections))
+	for _, section := range LibraryStore.sections {
+		snapshot.Sections = append(snapshot.Sections, *section)
+	}
+	CollectionsStore.mu.RLock()
+	snapshot.CollectionsLastFullUpdate = CollectionsStore.LastFullUpdate
+	snapshot.Collections = []models.CollectionItem{}
+	for _, lib := range CollectionsStore.collections {
+		for _, coll := range lib {
+			snapshot.Collections = append(snapshot.Collections, *coll)
+		}
+	}
+	data, err := json.Marshal(snapshot)
+	CollectionsStore.mu.RUnlock()
+	LibraryStore.mu.RUnlock()
+	if err != nil {
+		logAction.SetError("Failed to encode library cache snapshot", "Check the logs for more details", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	if err := os.MkdirAll(SnapshotFolderPath, 0755); err != nil {
+		logAction.SetError("Failed to create library cache folder", "Ensure the config folder is writable", map[string]any{
+			"error": err.Error(),
+			"path":  SnapshotFolderPath,
+		})
+		return *logAction.Error
+	}
+
+	// Write to a temp file first so a crash mid-write never leaves a broken snapshot behind
+	tmpPath := snapshotFilePath() + ".tmp"
+	if err := writeGzipFile(tmpPath, data); err != nil {
+		os.Remove(tmpPath)
+		logAction.SetError("Failed to write library cache snapshot", "Ensure the config folder is writable", map[string]any{
+			"error": err.Error(),
+			"path":  tmpPath,
+		})
+		return *logAction.Error
+	}
+	if err := os.Rename(tmpPath, snapshotFilePath()); err != nil {
+		os.Remove(tmpPath)
+		logAction.SetError("Failed to replace library cache snapshot", "Ensure the config folder is writable", map[string]any{
+			"error": err.Error(),
+			"path":  snapshotFilePath(),
+		})
+		return *logAction.Error
+	}
+
+	logAction.AppendResult("sections", len(snapshot.Sections))
+	logAction.AppendResult("collections", len(snapshot.Collections))
+	logAction.AppendResult("size_bytes", len(data))
+	return logging.LogErrorInfo{}
+}
+
+// LoadLibrarySnapshot fills the library and collections caches from the snapshot on disk.
+// LastFullUpdate is set to when the snapshot was taken.
+// Returns false if there is no usable snapshot, in which case the caches are left untouched.
+func LoadLibrarySnapshot(ctx context.Context) (loaded bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Loading Library Cache Snapshot", logging.LevelDebug)
+	defer logAction.Complete()
+
+	data, err := readGzipFile(snapshotFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			logAction.AppendResult("snapshot", "none found")
+		} else {
+			logAction.AppendWarning("error", "Failed to read library cache snapshot: "+err.Error())
+		}
+		return false
+	}
+
+	var snapshot librarySnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		logAction.AppendWarning("error", "Failed to parse library cache snapshot: "+err.Error())
+		return false
+	}
+	if snapshot.Version != snapshotVersion {
+		logAction.AppendResult("snapshot", "ignored, saved by a different version")
+		return false
+	}
+	if snapshot.MediaServer != snapshotMediaServerKey(ctx) {
+		logAction.AppendResult("snapshot", "ignored, taken from a different media server")
+		return false
+	}
+
+	// Only the libraries that are still configured are served
+	configured := make(map[string]bool)
+	for _, lib := range config.Current(ctx).MediaServer.Libraries {
+		configured[lib.Title] = true
+	}
+
+	sections := make(map[string]*models.LibrarySection, len(snapshot.Sections))
+	for i := range snapshot.Sections {
+		if configured[snapshot.Sections[i].Title] {
+			sections[snapshot.Sections[i].Title] = &snapshot.Sections[i]
+		}
+	}
+	collections := make(map[string]map[string]*models.CollectionItem)
+	for i := range snapshot.Collections {
+		coll := &snapshot.Collections[i]
+		if !configured[coll.LibraryTitle] {
+			continue
+		}
+		if collections[coll.LibraryTitle] == nil {
+			collections[coll.LibraryTitle] = make(map[string]*models.CollectionItem)
+		}
+		collections[coll.LibraryTitle][coll.RatingKey] = coll
+	}
+
+	LibraryStore.mu.Lock()
+	LibraryStore.sections = sections
+	LibraryStore.LastFullUpdate = snapshot.LibraryLastFullUpdate
+	LibraryStore.LastSync = snapshot.LibraryLastSync
+	LibraryStore.mu.Unlock()
+
+	CollectionsStore.mu.Lock()
+	CollectionsStore.collections = collections
+	CollectionsStore.LastFullUpdate = snapshot.CollectionsLastFullUpdate
+	CollectionsStore.mu.Unlock()
+
+	logAction.AppendResult("saved_at", time.Unix(snapshot.SavedAt, 0).Format(time.RFC3339))
+	logAction.AppendResult("sections", len(sections))
+	logAction.AppendResult("collections", len(snapshot.Collections))
+	return true
+}
+
+func writeGzipFile(filePath string, data []byte) error {
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(f)
+	if _, err := zw.Write(data); err != nil {
+		zw.Close()
+		f.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readGzipFile(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
@@ -1,20 +1,18 @@
 package config
 
-import "aura/models"
+import (
+	"aura/models"
+	"sync/atomic"
+)
 
 var (
 	// Config State Variables
-	Current    Config
+	// The config itself is served as an immutable snapshot, see Current and Latest in snapshot.go
 	ConfigPath string = ""
 
-	// Flags to track config loading and validation status
-	Loaded           bool   = false
-	Valid            bool   = false
-	MediaServerValid bool   = true
-	MediaServerName  string = ""
-	MediuxValid      bool   = true
-	AppFullyLoaded   bool   = false
-	AppLoadingStep   string = ""
+	// Flags to track app loading. Config loading and validation is tracked in Config.Status.
+	AppFullyLoaded atomic.Bool
+	AppLoadingStep AtomicString
 
 	// App Details
 	AppName    string = ""
@@ -25,6 +23,8 @@ var (
 )
 
 type Config struct {
+	Status Status `json:"-" yaml:"-"` // Loading and validation status of this config, never saved.
+
 	Auth          Config_Auth              `json:"auth" yaml:"Auth,omitempty"`                     // Authentication settings.
 	Logging       Config_Logging           `json:"logging" yaml:"Logging,omitempty"`               // Logging configuration settings.
 	MediaServer   Config_MediaServer       `json:"media_server" yaml:"MediaServer,omitempty"`      // Media server integration settings.
@@ -36,6 +36,22 @@ type Config struct {
 	Notifications Config_Notifications     `json:"notifications" yaml:"Notifications,omitempty"`   // Notification settings.
 	SonarrRadarr  Config_SonarrRadarr_Apps `json:"sonarr_radarr" yaml:"SonarrRadarr,omitempty"`    // List of Sonarr/Radarr instances to integrate with.
 	Database      Config_Database          `json:"database" yaml:"Database,omitempty"`             // Database configuration settings.
+	Health        Config_Health            `json:"health" yaml:"Health,omitempty"`                 // Readiness check settings.
+}
+
+// Status tracks whether the config was loaded and is valid.
+// It is part of the snapshot, so it always describes the config it is stored with.
+type Status struct {
+	Loaded           bool   // config.yaml was read
+	Valid            bool   // The config passed validation
+	MediaServerValid bool   // The media server connection works
+	MediaServerName  string // Name the media server reported
+	MediuxValid      bool   // The MediUX token works
+}
+
+// Ready returns true when the config is loaded, valid and both the media server and MediUX connections work
+func (s Status) Ready() bool {
+	return s.Loaded && s.Valid && s.MediaServerValid && s.MediuxValid
 }
 
 type Config_Dev struct {
@@ -78,33 +94,77 @@ type Config_Auth_OIDC struct {
 }
 
 type Config_Logging struct {
-	Level string `json:"level" yaml:"Level"`         // Logging level (e.g., TRACE, DEBUG, INFO, WARN, ERROR).
-	File  string `json:"file" yaml:"File,omitempty"` // File path for logging output.
+	Level      string `json:"level" yaml:"Level"`                                 // Logging level (e.g., TRACE, DEBUG, INFO, WARN, ERROR).
+	File       string `json:"file" yaml:"File,omitempty"`                         // File path for logging output.
+	MaxSizeMB  int    `json:"max_size_mb,omitempty" yaml:"MaxSizeMB,omitempty"`   // Size in megabytes the log file is rotated at. Defaults to 25.
+	MaxBackups int    `json:"max_backups,omitempty" yaml:"MaxBackups,omitempty"`  // Number of rotated log files to keep. Defaults to 7.
+	MaxAgeDays int    `json:"max_age_days,omitempty" yaml:"MaxAgeDays,omitempty"` // Days to keep rotated log files. Defaults to 14.
+	Compress   bool   `json:"compress,omitempty" yaml:"Compress,omitempty"`       // Gzip rotated log files.
+
+	Tracing Config_Logging_Tracing `json:"tracing" yaml:"Tracing,omitempty"` // OpenTelemetry trace export settings.
+}
+
+type Config_Logging_Tracing struct {
+	Enabled     bool              `json:"enabled" yaml:"Enabled"`                              // Export every route and job as a trace over OTLP/HTTP.
+	Endpoint    string            `json:"endpoint,omitempty" yaml:"Endpoint,omitempty"`        // OTLP/HTTP endpoint of the collector (e.g. http://tempo:4318).
+	ServiceName string            `json:"service_name,omitempty" yaml:"ServiceName,omitempty"` // service.name of the exported spans. Defaults to aura.
+	Headers     map[string]string `json:"headers,omitempty" yaml:"Headers,omitempty"`          // Extra headers sent to the collector (e.g. an auth token).
 }
 
 type Config_MediaServer struct {
-	Type                         string                  `json:"type" yaml:"Type"`                                                      // Type of media server (e.g., plex, emby, jellyfin).
-	URL                          string                  `json:"url" yaml:"URL"`                                                        // Base URL of the media server. This is either the IP:Port or the domain name (e.g., plex.domain.com).
-	ApiToken                     string                  `json:"api_token" yaml:"ApiToken"`                                             // Authentication token for accessing the media server.
-	Libraries                    []models.LibrarySection `json:"libraries,omitempty" yaml:"Libraries,omitempty"`                        // List of media server libraries to manage.
-	UserID                       string                  `json:"user_id,omitempty" yaml:"UserID,omitempty"`                             // User ID for accessing the media server. This is used for Emby and Jellyfin servers.
-	EnableSortByEpisodeAddedDate bool                    `json:"enable_sort_by_episode_added_date" yaml:"EnableSortByEpisodeAddedDate"` // Whether to check episodes for added date when getting Media Items. This is only for Plex servers.
-	EnablePlexEventListener      bool                    `json:"enable_plex_event_listener" yaml:"EnablePlexEventListener"`             // Whether to enable the Plex event listener for reapplying images on refresh. Plex exclusive feature.
+	Type                            string                  `json:"type" yaml:"Type"`                                                           // Type of media server (e.g., plex, emby, jellyfin).
+	URL                             string                  `json:"url" yaml:"URL"`                                                             // Base URL of the media server. This is either the IP:Port or the domain name (e.g., plex.domain.com).
+	ApiToken                        string                  `json:"api_token" yaml:"ApiToken"`                                                  // Authentication token for accessing the media server.
+	Libraries                       []models.LibrarySection `json:"libraries,omitempty" yaml:"Libraries,omitempty"`                             // List of media server libraries to manage.
+	UserID                          string                  `json:"user_id,omitempty" yaml:"UserID,omitempty"`                                  // User ID for accessing the media server. This is used for Emby and Jellyfin servers.
+	EnableSortByEpisodeAddedDate    bool                    `json:"enable_sort_by_episode_added_date" yaml:"EnableSortByEpisodeAddedDate"`      // Whether to check episodes for added date when getting Media Items. This is only for Plex servers.
+	EnablePlexEventListener         bool                    `json:"enable_plex_event_listener" yaml:"EnablePlexEventListener"`                  // Whether to enable the Plex event listener for reapplying images on refresh. Plex exclusive feature.
+	EnableEmbyJellyfinEventListener bool                    `json:"enable_emby_jellyfin_event_listener" yaml:"EnableEmbyJellyfinEventListener"` // Whether to enable the Emby/Jellyfin event listener for reapplying images on refresh. Emby and Jellyfin only.
 }
 
 type Config_Mediux struct {
-	ApiToken        string `json:"api_token" yaml:"ApiToken"`               // Authentication token for accessing MediUX services.
-	DownloadQuality string `json:"download_quality" yaml:"DownloadQuality"` // Quality of the media to download from MediUX (Options: "original", "optimized") Defaults to "optimized".
+	ApiToken            string `json:"api_token" yaml:"ApiToken"`                        // Authentication token for accessing MediUX services.
+	DownloadQuality     string `json:"download_quality" yaml:"DownloadQuality"`          // Quality of the media to download from MediUX (Options: "original", "optimized") Defaults to "optimized".
+	EnableEventListener bool   `json:"enable_event_listener" yaml:"EnableEventListener"` // Whether to listen for MediUX updates to saved sets and check their items right away.
 }
 
 type Config_AutoDownload struct {
 	Enabled bool   `json:"enabled" yaml:"Enabled"`               // Whether auto-download is enabled.
 	Cron    string `json:"cron,omitempty" yaml:"Cron,omitempty"` // Cron expression for scheduling auto-downloads.
+
+	AutoApply Config_AutoDownload_AutoApply `json:"auto_apply" yaml:"AutoApply,omitempty"` // Settings for automatically applying sets to newly added items.
+}
+
+type Config_AutoDownload_AutoApply struct {
+	Enabled               bool                                   `json:"enabled" yaml:"Enabled"`                                // Whether to apply a set to newly added items automatically.
+	Creators              []Config_AutoDownload_AutoApplyCreator `json:"creators" yaml:"Creators,omitempty"`                    // Preferred set creators, highest ranked first.
+	FallbackToMostPopular bool                                   `json:"fallback_to_most_popular" yaml:"FallbackToMostPopular"` // Use the most popular set when none of the preferred creators has a set for the item.
+	SelectedTypes         Config_AutoDownload_AutoApplyTypes     `json:"selected_types" yaml:"SelectedTypes"`                   // Image types to apply from the picked set.
+	AutoDownload          bool                                   `json:"auto_download" yaml:"AutoDownload"`                     // Whether the saved set is checked for updates by AutoDownload.
+}
+
+type Config_AutoDownload_AutoApplyCreator struct {
+	Username  string   `json:"username" yaml:"Username"`                       // MediUX username of the creator.
+	Libraries []string `json:"libraries,omitempty" yaml:"Libraries,omitempty"` // Only use this creator for these libraries. Empty means all libraries.
+	Types     []string `json:"types,omitempty" yaml:"Types,omitempty"`         // Only use this creator for these item types (movie, show). Empty means all types.
+	Languages []string `json:"languages,omitempty" yaml:"Languages,omitempty"` // Only use sets with images in one of these languages. Empty means any language.
+}
+
+type Config_AutoDownload_AutoApplyTypes struct {
+	Poster              bool `json:"poster" yaml:"Poster"`                             // Apply the poster.
+	Backdrop            bool `json:"backdrop" yaml:"Backdrop"`                         // Apply the backdrop.
+	SeasonPoster        bool `json:"season_poster" yaml:"SeasonPoster"`                // Apply season posters.
+	SpecialSeasonPoster bool `json:"special_season_poster" yaml:"SpecialSeasonPoster"` // Apply the special season poster.
+	Titlecard           bool `json:"titlecard" yaml:"Titlecard"`                       // Apply titlecards.
 }
 
 type Config_Images struct {
-	CacheImages       Config_CacheImages       `json:"cache_images" yaml:"CacheImages"`              // Settings for caching images.
-	SaveImagesLocally Config_SaveImagesLocally `json:"save_images_locally" yaml:"SaveImagesLocally"` // Settings for saving images locally alongside content.
+	CacheImages       Config_CacheImages       `json:"cache_images" yaml:"CacheImages"`                 // Settings for caching images.
+	SaveImagesLocally Config_SaveImagesLocally `json:"save_images_locally" yaml:"SaveImagesLocally"`    // Settings for saving images locally alongside content.
+	LocalArtwork      Config_LocalArtwork      `json:"local_artwork" yaml:"LocalArtwork,omitempty"`     // Settings for using a local artwork folder as an image source.
+	ArtworkHistory    Config_ArtworkHistory    `json:"artwork_history" yaml:"ArtworkHistory,omitempty"` // Settings for keeping the artwork that AURA replaces.
+	DriftDetection    Config_DriftDetection    `json:"drift_detection" yaml:"DriftDetection,omitempty"` // Settings for detecting artwork that was changed outside of AURA.
+	VerifyApply       Config_VerifyApply       `json:"verify_apply" yaml:"VerifyApply,omitempty"`       // Settings for checking that the media server shows an applied image.
 }
 
 type Config_CacheImages struct {
@@ -118,8 +178,30 @@ type Config_SaveImagesLocally struct {
 	RunningOnWindows        bool   `json:"running_on_windows,omitempty" yaml:"RunningOnWindows,omitempty"`               // Whether the application is running on Windows. This affects path formatting.
 }
 
+type Config_LocalArtwork struct {
+	Enabled bool   `json:"enabled" yaml:"Enabled"`               // Whether to index the local artwork folder as an image source.
+	Path    string `json:"path,omitempty" yaml:"Path,omitempty"` // Root folder containing one sub folder per item, named by TMDB ID or "Title (Year)".
+}
+
+type Config_ArtworkHistory struct {
+	Enabled     bool `json:"enabled" yaml:"Enabled"`                              // Whether to save the current media server artwork before AURA replaces it.
+	MaxVersions int  `json:"max_versions,omitempty" yaml:"MaxVersions,omitempty"` // Number of versions kept per image (e.g. per poster or titlecard). Defaults to 5.
+}
+
+type Config_DriftDetection struct {
+	Enabled bool   `json:"enabled" yaml:"Enabled"`               // Whether to record a fingerprint of applied images and check them on a schedule.
+	Cron    string `json:"cron,omitempty" yaml:"Cron,omitempty"` // Cron expression for the drift check. Defaults to every day at 3 AM.
+	Reapply bool   `json:"reapply" yaml:"Reapply,omitempty"`     // Whether to re-apply images that were changed outside of AURA.
+}
+
+type Config_VerifyApply struct {
+	Enabled bool `json:"enabled" yaml:"Enabled"` // Whether to fetch every applied image back from the media server and compare it with the uploaded image.
+}
+
 type Config_TMDB struct {
-	ApiToken string `json:"-" yaml:"ApiToken"` // API token for accessing TMDB (The Movie Database) services.
+	ApiToken       string   `json:"api_token" yaml:"ApiToken"`                                  // API token for accessing TMDB (The Movie Database) services. Used as a fallback image source when MediUX has no sets for an item.
+	Languages      []string `json:"languages,omitempty" yaml:"Languages,omitempty"`             // ISO 639-1 codes of the TMDB images to offer. Use "none" for textless images. Empty means all languages.
+	MinVoteAverage float64  `json:"min_vote_average,omitempty" yaml:"MinVoteAverage,omitempty"` // Minimum TMDB vote average (0-10) of the TMDB posters and backdrops to offer. Episode stills are not filtered.
 }
 
 type Config_LabelsAndTags struct {
@@ -188,6 +270,8 @@ type Config_NotificationTemplate struct {
 	NewSetsAvailableForIgnoredItems Config_CustomNotification `json:"new_sets_available_for_ignored_items" yaml:"NewSetsAvailableForIgnoredItems,omitempty"` // Custom notification settings for when new sets become available for ignored items.
 	CheckForMediaItemChangesJob     Config_CustomNotification `json:"check_for_media_item_changes_job" yaml:"CheckForMediaItemChangesJob,omitempty"`         // Custom notification settings for the media item changes job.
 	SonarrNotification              Config_CustomNotification `json:"sonarr_notification" yaml:"SonarrNotification,omitempty"`                               // Custom notification settings for Sonarr events.
+	WantedItemAvailable             Config_CustomNotification `json:"wanted_item_available" yaml:"WantedItemAvailable,omitempty"`                            // Custom notification settings for when a wanted item lands in the library.
+	IgnoredItemExpired              Config_CustomNotification `json:"ignored_item_expired" yaml:"IgnoredItemExpired,omitempty"`                              // Custom notification settings for when an item ignored until a date is no longer ignored.
 }
 
 type Config_CustomNotification struct {
@@ -208,6 +292,10 @@ type Config_SonarrRadarrApp struct {
 	ApiToken string `json:"api_token,omitempty" yaml:"ApiToken,omitempty"` // API key for accessing the Sonarr/Radarr server.
 }
 
+type Config_Health struct {
+	Criticality map[string]string `json:"criticality,omitempty" yaml:"Criticality,omitempty"` // How much a failing check matters, keyed by check name: critical, optional or ignore.
+}
+
 type Config_Database struct {
 	Type     string `json:"type,omitempty" yaml:"Type,omitempty"`         // Type of database (e.g., "sqlite", "mysql", "postgresql").
 	Path     string `json:"path,omitempty" yaml:"Path,omitempty"`         // File path for the database (if applicable, e.g., for SQLite).
@@ -1,5 +1,7 @@
 package config
 
+import "aura/logging"
+
 func DefaultNotificationTemplates() Config_NotificationTemplate {
 	return Config_NotificationTemplate{
 		AppStartup: Config_CustomNotification{
@@ -42,6 +44,51 @@ func DefaultNotificationTemplates() Config_NotificationTemplate {
 			Message:      "{{MediaItemTitle}}{{NewLine}}{{ImageName}}{{NewLine}}Set ID: {{SetID}}{{NewLine}}Reason:{{NewLine}}{{Reason}}{{NewLine}}{{Result}}",
 			IncludeImage: true,
 		},
+		WantedItemAvailable: Config_CustomNotification{
+			Enabled:      true,
+			Title:        "Wanted Item Available",
+			Message:      "{{MediaItemTitle}} ({{MediaItemYear}}) is now in {{MediaItemLibraryTitle}}.{{NewLine}}The set '{{SetTitle}}' by {{SetCreator}} has been saved and added to the download queue.",
+			IncludeImage: true,
+		},
+		IgnoredItemExpired: Config_CustomNotification{
+			Enabled:      true,
+			Title:        "Ignored Item Expired",
+			Message:      "{{MediaItemTitle}} ({{MediaItemLibraryTitle}}) was ignored until {{IgnoredUntil}} and will no longer be ignored in {{AppName}}.",
+			IncludeImage: true,
+		},
+	}
+}
+
+// Readiness checks reported by /api/health/ready
+const (
+	HealthCheckDatabase       = "database"
+	HealthCheckMediaServer    = "media_server"
+	HealthCheckMediux         = "mediux"
+	HealthCheckSonarrRadarr   = "sonarr_radarr"
+	HealthCheckEventListener  = "event_listener"
+	HealthCheckMediuxListener = "mediux_listener"
+	HealthCheckCacheWarmup    = "cache_warmup"
+	HealthCheckDownloadQueue  = "download_queue"
+)
+
+// How much a failing readiness check matters
+const (
+	HealthCritical = "critical" // The app is unhealthy
+	HealthOptional = "optional" // The app is degraded
+	HealthIgnore   = "ignore"   // The check is not run
+)
+
+// DefaultHealthCriticality is used for the checks that Health.Criticality doesn't list
+func DefaultHealthCriticality() map[string]string {
+	return map[string]string{
+		HealthCheckDatabase:       HealthCritical,
+		HealthCheckMediaServer:    HealthCritical,
+		HealthCheckMediux:         HealthOptional,
+		HealthCheckSonarrRadarr:   HealthOptional,
+		HealthCheckEventListener:  HealthOptional,
+		HealthCheckMediuxListener: HealthOptional,
+		HealthCheckCacheWarmup:    HealthCritical,
+		HealthCheckDownloadQueue:  HealthOptional,
 	}
 }
 
@@ -52,7 +99,11 @@ func DefaultConfig() Config {
 			SessionCookieSecure: "auto",
 		},
 		Logging: Config_Logging{
-			Level: "INFO",
+			Level:      "INFO",
+			MaxSizeMB:  logging.DefaultLogMaxSizeMB,
+			MaxBackups: logging.DefaultLogMaxBackups,
+			MaxAgeDays: logging.DefaultLogMaxAgeDays,
+			Compress:   true,
 		},
 		Mediux: Config_Mediux{
 			DownloadQuality: "optimized",
@@ -60,6 +111,19 @@ func DefaultConfig() Config {
 		AutoDownload: Config_AutoDownload{
 			Enabled: false,
 			Cron:    "0 0 * * *",
+			AutoApply: Config_AutoDownload_AutoApply{
+				Enabled:               false,
+				Creators:              []Config_AutoDownload_AutoApplyCreator{},
+				FallbackToMostPopular: false,
+				SelectedTypes: Config_AutoDownload_AutoApplyTypes{
+					Poster:              true,
+					Backdrop:            true,
+					SeasonPoster:        true,
+					SpecialSeasonPoster: true,
+					Titlecard:           true,
+				},
+				AutoDownload: true,
+			},
 		},
 		Images: Config_Images{
 			CacheImages: Config_CacheImages{
@@ -68,6 +132,21 @@ func DefaultConfig() Config {
 			SaveImagesLocally: Config_SaveImagesLocally{
 				Enabled: false,
 			},
+			LocalArtwork: Config_LocalArtwork{
+				Enabled: false,
+			},
+			ArtworkHistory: Config_ArtworkHistory{
+				Enabled:     false,
+				MaxVersions: 5,
+			},
+			DriftDetection: Config_DriftDetection{
+				Enabled: false,
+				Cron:    "0 3 * * *",
+				Reapply: false,
+			},
+			VerifyApply: Config_VerifyApply{
+				Enabled: false,
+			},
 		},
 		Notifications: Config_Notifications{
 			Enabled:              false,
@@ -0,0 +1,502 @@
+package config
+
+import (
+	"aura/logging"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of every environment variable that overrides a config key.
+// The rest of the name is the YAML path of the key in upper case, joined by underscores.
+// For example, MediaServer.ApiToken is set with AURA_MEDIASERVER_APITOKEN.
+// List entries use their index (AURA_NOTIFICATIONS_PROVIDERS_0_PROVIDER) and every
+// variable also has a _FILE variant that reads the value from a file (Docker/Kubernetes secrets).
+const EnvPrefix = "AURA"
+
+// maxEnvListIndex caps the list index read from environment variables
+const maxEnvListIndex = 99
+
+// ConfigSource describes where an effective config value came from
+type ConfigSource struct {
+	Source   string `json:"source"`   // "env" for an environment variable or "file" for a _FILE secret
+	Variable string `json:"variable"` // Name of the environment variable that set the value
+}
+
+type envOverrideKind int
+
+const (
+	envOverrideValue     envOverrideKind = iota // A single config value
+	envOverridePointer                          // A nested section that only exists because of an override
+	envOverrideListGrown                        // A list that got new entries from overrides
+)
+
+type envOverride struct {
+	kind     envOverrideKind
+	steps    []int // Field and list indexes from the root Config
+	jsonPath string
+	source   ConfigSource
+}
+
+var (
+	envOverridesMu sync.RWMutex
+	envOverrides   []envOverride
+	envFileConfig  Config // The config as it is in config.yaml, without any overrides
+)
+
+// ApplyEnvOverrides sets every config key that has an environment variable (or _FILE secret) to that value.
+// The overrides are remembered so that Save never writes them to config.yaml.
+func ApplyEnvOverrides(ctx context.Context, config *Config) {
+	overrides := applyEnvOverrides(ctx, config)
+
+	envOverridesMu.Lock()
+	envOverrides = overrides
+	envOverridesMu.Unlock()
+}
+
+// applyEnvOverrides sets the overridden config keys without remembering them
+func applyEnvOverrides(ctx context.Context, config *Config) []envOverride {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Applying Environment Overrides", logging.LevelDebug)
+	defer logAction.Complete()
+
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(key, EnvPrefix+"_") {
+			env[key] = value
+		}
+	}
+
+	applier := envApplier{env: env, logAction: logAction}
+	if len(env) > 0 && config != nil {
+		applier.walk(reflect.ValueOf(config).Elem(), EnvPrefix, "", nil)
+	}
+
+	values := 0
+	for _, override := range applier.overrides {
+		if override.kind == envOverrideValue {
+			values++
+		}
+	}
+	logAction.AppendResult("overrides", values)
+	return applier.overrides
+}
+
+// ConfigSources returns the source of every config value that was set by an environment variable or _FILE secret.
+// The keys are the JSON paths of the values (e.g. "media_server.api_token").
+func ConfigSources() map[string]ConfigSource {
+	envOverridesMu.RLock()
+	defer envOverridesMu.RUnlock()
+
+	sources := map[string]ConfigSource{}
+	for _, override := range envOverrides {
+		if override.kind == envOverrideValue {
+			sources[override.jsonPath] = override.source
+		}
+	}
+	return sources
+}
+
+// EnvOverriddenListChanged returns the JSON path of an environment override whose list the updated config
+// adds, removes or moves entries in. Overrides in lists are matched by their index, so a list that environment
+// variables set values in or added entries to can only be edited in place.
+func EnvOverriddenListChanged(current, updated *Config) (jsonPath string, changed bool) {
+	envOverridesMu.RLock()
+	defer envOverridesMu.RUnlock()
+
+	cur := reflect.ValueOf(current).Elem()
+	upd := reflect.ValueOf(updated).Elem()
+	for _, override := range envOverrides {
+		lists := listPaths(cur, override.steps)
+		if override.kind == envOverrideListGrown {
+			lists = append(lists, override.steps)
+		}
+		for _, listSteps := range lists {
+			currentList := resolveConfigPath(cur, listSteps)
+			updatedList := resolveConfigPath(upd, listSteps)
+			if !updatedList.IsValid() || currentList.Len() != updatedList.Len() {
+				return override.jsonPath, true
+			}
+		}
+		if override.kind != envOverrideValue || len(lists) == 0 {
+			continue
+		}
+
+		// An entry that moved shows up as a different value at the overridden index.
+		// Masked secrets and empty values are skipped, the override sets them again anyway.
+		updatedValue := resolveConfigPath(upd, override.steps)
+		if !updatedValue.IsValid() {
+			return override.jsonPath, true
+		}
+		if str, ok := updatedValue.Interface().(string); ok && (str == "" || IsMaskedField(str) || IsMaskedWebhook(str)) {
+			continue
+		}
+		if !reflect.DeepEqual(updatedValue.Interface(), resolveConfigPath(cur, override.steps).Interface()) {
+			return override.jsonPath, true
+		}
+	}
+	return "", false
+}
+
+// listPaths returns the steps of every list the path goes through
+func listPaths(v reflect.Value, steps []int) (lists [][]int) {
+	for i := range steps {
+		list := resolveConfigPath(v, steps[:i])
+		if list.Kind() == reflect.Pointer && !list.IsNil() {
+			list = list.Elem()
+		}
+		if list.Kind() == reflect.Slice {
+			lists = append(lists, steps[:i])
+		}
+	}
+	return lists
+}
+
+// rememberFileConfig keeps a copy of the config as it is in config.yaml
+func rememberFileConfig(config Config) {
+	envOverridesMu.Lock()
+	envFileConfig = cloneConfig(config)
+	envOverridesMu.Unlock()
+}
+
+// withoutEnvOverrides returns a copy of the config with every overridden value set back to the value in config.yaml
+func (config *Config) withoutEnvOverrides() Config {
+	envOverridesMu.RLock()
+	defer envOverridesMu.RUnlock()
+
+	if len(envOverrides) == 0 {
+		return *config
+	}
+
+	c := cloneConfig(*config)
+	dst := reflect.ValueOf(&c).Elem()
+	src := reflect.ValueOf(&envFileConfig).Elem()
+
+	// Restore the values first, then drop the sections and list entries that only exist because of overrides
+	for _, override := range envOverrides {
+		if override.kind != envOverrideValue {
+			continue
+		}
+		target := resolveConfigPath(dst, override.steps)
+		if !target.IsValid() {
+			continue
+		}
+		if original := resolveConfigPath(src, override.steps); original.IsValid() {
+			target.Set(original)
+		} else {
+			target.Set(reflect.Zero(target.Type()))
+		}
+	}
+	for i := len(envOverrides) - 1; i >= 0; i-- {
+		override := envOverrides[i]
+		target := resolveConfigPath(dst, override.steps)
+		if !target.IsValid() {
+			continue
+		}
+		original := resolveConfigPath(src, override.steps)
+		switch override.kind {
+		case envOverridePointer:
+			if !original.IsValid() || original.IsNil() {
+				target.Set(reflect.Zero(target.Type()))
+			}
+		case envOverrideListGrown:
+			fileLen := 0
+			if original.IsValid() {
+				fileLen = original.Len()
+			}
+			if target.Len() > fileLen {
+				target.Set(target.Slice(0, fileLen))
+			}
+		}
+	}
+	return c
+}
+
+// cloneConfig makes a deep copy of the config
+func cloneConfig(config Config) Config {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return config
+	}
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return config
+	}
+	c.MediaServer.UserID = config.MediaServer.UserID
+	c.Status = config.Status
+	return c
+}
+
+// resolveConfigPath follows field and list indexes from v.
+// An invalid value is returned when a pointer on the way is nil or a list is too short.
+func resolveConfigPath(v reflect.Value, steps []int) reflect.Value {
+	for _, step := range steps {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			v = v.Field(step)
+		case reflect.Slice:
+			if step >= v.Len() {
+				return reflect.Value{}
+			}
+			v = v.Index(step)
+		default:
+			return reflect.Value{}
+		}
+	}
+	return v
+}
+
+type envApplier struct {
+	env       map[string]string
+	logAction *logging.LogAction
+	overrides []envOverride
+}
+
+// hasPrefix reports whether any environment variable is named name or starts with name_
+func (a *envApplier) hasPrefix(name string) bool {
+	for key := range a.env {
+		if key == name || strings.HasPrefix(key, name+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *envApplier) walk(v reflect.Value, envName, jsonPath string, steps []int) (changed bool) {
+	if !a.hasPrefix(envName) {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			yamlName, yamlOpts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if yamlName == "-" {
+				continue
+			}
+			fieldSteps := append(append([]int{}, steps...), i)
+			if field.Anonymous && strings.Contains(yamlOpts, "inline") {
+				if a.walk(v.Field(i), envName, jsonPath, fieldSteps) {
+					changed = true
+				}
+				continue
+			}
+			if yamlName == "" {
+				yamlName = field.Name
+			}
+			jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if jsonName == "" || jsonName == "-" {
+				jsonName = strings.ToLower(yamlName)
+			}
+			if a.walk(v.Field(i), envName+"_"+strings.ToUpper(yamlName), joinJSONPath(jsonPath, jsonName), fieldSteps) {
+				changed = true
+			}
+		}
+		return changed
+
+	case reflect.Pointer:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			return a.setValue(v, envName, jsonPath, steps)
+		}
+		if !v.IsNil() {
+			return a.walk(v.Elem(), envName, jsonPath, steps)
+		}
+		// Only create the section if one of its values is set
+		section := reflect.New(v.Type().Elem())
+		if !a.walk(section.Elem(), envName, jsonPath, steps) {
+			return false
+		}
+		v.Set(section)
+		a.overrides = append(a.overrides, envOverride{kind: envOverridePointer, steps: steps, jsonPath: jsonPath})
+		return true
+
+	case reflect.Slice:
+		elemType := v.Type().Elem()
+		if elemType.Kind() != reflect.Struct && !(elemType.Kind() == reflect.Pointer && elemType.Elem().Kind() == reflect.Struct) {
+			return a.setValue(v, envName, jsonPath, steps)
+		}
+
+		maxIndex := a.maxListIndex(envName)
+		if maxIndex < 0 {
+			return false
+		}
+		if maxIndex >= v.Len() {
+			grown := reflect.MakeSlice(v.Type(), maxIndex+1, maxIndex+1)
+			reflect.Copy(grown, v)
+			v.Set(grown)
+			a.overrides = append(a.overrides, envOverride{kind: envOverrideListGrown, steps: steps, jsonPath: jsonPath})
+			changed = true
+		}
+		for i := 0; i < v.Len(); i++ {
+			elemSteps := append(append([]int{}, steps...), i)
+			if a.walk(v.Index(i), fmt.Sprintf("%s_%d", envName, i), fmt.Sprintf("%s.%d", jsonPath, i), elemSteps) {
+				changed = true
+			}
+		}
+		return changed
+
+	default:
+		return a.setValue(v, envName, jsonPath, steps)
+	}
+}
+
+// maxListIndex returns the highest list index used by environment variables under envName, or -1 if there is none
+func (a *envApplier) maxListIndex(envName string) int {
+	maxIndex := -1
+	for key := range a.env {
+		rest, found := strings.CutPrefix(key, envName+"_")
+		if !found {
+			continue
+		}
+		indexStr, _, _ := strings.Cut(rest, "_")
+		index, err := strconv.Atoi(indexStr)
+		if err != nil || index < 0 {
+			continue
+		}
+		if index > maxEnvListIndex {
+			a.logAction.AppendWarning(key, fmt.Sprintf("List index is above %d and was ignored", maxEnvListIndex))
+			continue
+		}
+		maxIndex = max(maxIndex, index)
+	}
+	return maxIndex
+}
+
+// setValue sets a single config value from envName or envName_FILE
+func (a *envApplier) setValue(v reflect.Value, envName, jsonPath string, steps []int) bool {
+	raw, source, found := a.lookup(envName)
+	if !found {
+		return false
+	}
+
+	if err := parseEnvValue(v, raw); err != nil {
+		a.logAction.AppendWarning(source.Variable, fmt.Sprintf("Invalid value for %s: %s", jsonPath, err.Error()))
+		return false
+	}
+
+	logging.LOGGER.Debug().Timestamp().
+		Str("key", jsonPath).
+		Str("variable", source.Variable).
+		Str("source", source.Source).
+		Msg("Config value set from environment")
+	a.overrides = append(a.overrides, envOverride{kind: envOverrideValue, steps: steps, jsonPath: jsonPath, source: source})
+	return true
+}
+
+// lookup returns the value of envName, or the contents of the file named by envName_FILE
+func (a *envApplier) lookup(envName string) (value string, source ConfigSource, found bool) {
+	if value, ok := a.env[envName]; ok {
+		if _, fileSet := a.env[envName+"_FILE"]; fileSet {
+			a.logAction.AppendWarning(envName, fmt.Sprintf("Both %s and %s_FILE are set, using %s", envName, envName, envName))
+		}
+		return value, ConfigSource{Source: "env", Variable: envName}, true
+	}
+
+	filePath, ok := a.env[envName+"_FILE"]
+	if !ok {
+		return "", ConfigSource{}, false
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		a.logAction.AppendWarning(envName+"_FILE", fmt.Sprintf("Failed to read secret file: %s", err.Error()))
+		return "", ConfigSource{}, false
+	}
+	return strings.TrimRight(string(data), "\r\n"), ConfigSource{Source: "file", Variable: envName + "_FILE"}, true
+}
+
+// parseEnvValue converts the string value of an environment variable into v.
+// Lists are comma separated and maps use key=value pairs separated by commas.
+func parseEnvValue(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("expected true or false")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected a whole number")
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected a number")
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		list := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, part := range splitEnvList(raw) {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := parseEnvValue(elem, part); err != nil {
+				return err
+			}
+			list = reflect.Append(list, elem)
+		}
+		v.Set(list)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", v.Type())
+		}
+		m := reflect.MakeMap(v.Type())
+		for _, part := range splitEnvList(raw) {
+			key, value, found := strings.Cut(part, "=")
+			if !found {
+				return fmt.Errorf("expected key=value, got '%s'", part)
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := parseEnvValue(elem, strings.TrimSpace(value)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)), elem)
+		}
+		v.Set(m)
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := parseEnvValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(elem)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func splitEnvList(raw string) []string {
+	parts := []string{}
+	for part := range strings.SplitSeq(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func joinJSONPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -77,8 +77,13 @@ func LoadYAML(ctx context.Context) {
 	}
 	actionParse.Complete()
 
-	Current = config
-	Loaded = true
+	// Environment variables and _FILE secrets take precedence over config.yaml
+	rememberFileConfig(config)
+	ApplyEnvOverrides(ctx, &config)
+
+	config.Status = Latest().Status
+	config.Status.Loaded = true
+	Set(ctx, config)
 }
 
 func createBaseConfig(ctx context.Context) (success bool) {
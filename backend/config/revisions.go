@@ -0,0 +1,387 @@
+package config
+
+import (
+	"aura/logging"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxConfigRevisions is the number of config revisions kept on disk
+const MaxConfigRevisions = 50
+
+const revisionIDFormat = "20060102-150405.000000"
+
+var (
+	revisionsMu       sync.Mutex
+	revisionIDPattern = regexp.MustCompile(`^\d{8}-\d{6}\.\d{6}$`)
+)
+
+// ConfigRevision is a saved version of config.yaml.
+// The full file is kept next to it so that it can be restored, only the changes are masked.
+type ConfigRevision struct {
+	ID           string         `json:"id"`                      // Timestamp based ID of the revision
+	CreatedAt    time.Time      `json:"created_at"`              // When the config was saved
+	RestoredFrom string         `json:"restored_from,omitempty"` // ID of the revision this one was rolled back to, if any
+	Changes      []ConfigChange `json:"changes"`                 // Changes from the previous revision with secrets masked
+}
+
+// ConfigChange is a single changed value between two configs
+type ConfigChange struct {
+	Path string `json:"path"`          // YAML path of the value (e.g. "MediaServer.Libraries[0].Title")
+	Type string `json:"type"`          // "added", "removed" or "changed"
+	Old  any    `json:"old,omitempty"` // Old value (masked if it is a secret)
+	New  any    `json:"new,omitempty"` // New value (masked if it is a secret)
+}
+
+func revisionsDir() string {
+	return path.Join(ConfigPath, "revisions")
+}
+
+// saveRevision keeps data as a new revision.
+// previous is the config.yaml that data replaced. It is kept as the first revision when there is none yet.
+func saveRevision(ctx context.Context, previous, data []byte, restoredFrom string) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Saving Config Revision", logging.LevelDebug)
+	defer logAction.Complete()
+
+	revisionsMu.Lock()
+	defer revisionsMu.Unlock()
+
+	if err := os.MkdirAll(revisionsDir(), 0700); err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to create revisions folder: %s", err.Error()))
+		return
+	}
+
+	ids := revisionIDs()
+	if len(ids) == 0 {
+		if len(previous) > 0 && !bytes.Equal(previous, data) {
+			writeRevision(ctx, previous, ConfigRevision{})
+		}
+	} else {
+		latest, err := os.ReadFile(path.Join(revisionsDir(), ids[len(ids)-1]+".yaml"))
+		if err == nil {
+			previous = latest
+		}
+		if bytes.Equal(previous, data) && restoredFrom == "" {
+			logAction.AppendResult("skipped", "No changes since the last revision")
+			return
+		}
+	}
+
+	revision := ConfigRevision{RestoredFrom: restoredFrom}
+	if len(previous) > 0 {
+		revision.Changes = diffConfigYAML(ctx, previous, data)
+	}
+	revision = writeRevision(ctx, data, revision)
+	logAction.AppendResult("revision", revision.ID)
+	logAction.AppendResult("changes", len(revision.Changes))
+
+	// Only keep the latest revisions
+	ids = revisionIDs()
+	for len(ids) > MaxConfigRevisions {
+		os.Remove(path.Join(revisionsDir(), ids[0]+".yaml"))
+		os.Remove(path.Join(revisionsDir(), ids[0]+".json"))
+		ids = ids[1:]
+	}
+}
+
+// writeRevision writes the config file and the revision details with a new ID
+func writeRevision(ctx context.Context, data []byte, revision ConfigRevision) ConfigRevision {
+	_, logAction := logging.AddSubActionToContext(ctx, "Writing Config Revision", logging.LevelTrace)
+	defer logAction.Complete()
+
+	// IDs are timestamps, make sure two saves in the same microsecond don't collide
+	now := time.Now().UTC()
+	revision.ID = now.Format(revisionIDFormat)
+	for {
+		if _, err := os.Stat(path.Join(revisionsDir(), revision.ID+".yaml")); os.IsNotExist(err) {
+			break
+		}
+		now = now.Add(time.Microsecond)
+		revision.ID = now.Format(revisionIDFormat)
+	}
+	revision.CreatedAt = now
+	if revision.Changes == nil {
+		revision.Changes = []ConfigChange{}
+	}
+
+	details, err := json.MarshalIndent(revision, "", "  ")
+	if err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to marshal revision details: %s", err.Error()))
+		return revision
+	}
+	// Revisions hold the full config (including secrets), so only the app can read them
+	if err := os.WriteFile(path.Join(revisionsDir(), revision.ID+".yaml"), data, 0600); err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to write revision: %s", err.Error()))
+		return revision
+	}
+	if err := os.WriteFile(path.Join(revisionsDir(), revision.ID+".json"), details, 0600); err != nil {
+		logAction.AppendWarning("message", fmt.Sprintf("Failed to write revision details: %s", err.Error()))
+	}
+	return revision
+}
+
+// revisionIDs returns the IDs of all revisions, oldest first
+func revisionIDs() []string {
+	entries, err := os.ReadDir(revisionsDir())
+	if err != nil {
+		return []string{}
+	}
+	ids := []string{}
+	for _, entry := range entries {
+		id, found := strings.CutSuffix(entry.Name(), ".yaml")
+		if found && revisionIDPattern.MatchString(id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// ListRevisions returns every saved config revision, newest first
+func ListRevisions(ctx context.Context) (revisions []ConfigRevision, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Listing Config Revisions", logging.LevelDebug)
+	defer logAction.Complete()
+
+	revisionsMu.Lock()
+	defer revisionsMu.Unlock()
+
+	revisions = []ConfigRevision{}
+	ids := revisionIDs()
+	for _, id := range slices.Backward(ids) {
+		revision, Err := readRevisionDetails(ctx, id)
+		if Err.Message != "" {
+			logAction.AppendWarning(id, Err.Message)
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+
+	logAction.AppendResult("revisions", len(revisions))
+	return revisions, logging.LogErrorInfo{}
+}
+
+func readRevisionDetails(ctx context.Context, id string) (revision ConfigRevision, Err logging.LogErrorInfo) {
+	_, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Reading Config Revision %s", id), logging.LevelTrace)
+	defer logAction.Complete()
+
+	details, err := os.ReadFile(path.Join(revisionsDir(), id+".json"))
+	if err != nil {
+		// The details are only a convenience, the revision itself is the config file
+		return ConfigRevision{ID: id, Changes: []ConfigChange{}}, logging.LogErrorInfo{}
+	}
+	if err := json.Unmarshal(details, &revision); err != nil {
+		logAction.SetError("Failed to parse revision details", err.Error(), map[string]any{"id": id})
+		return revision, *logAction.Error
+	}
+	return revision, logging.LogErrorInfo{}
+}
+
+// readRevisionFile returns the config file of a revision
+func readRevisionFile(ctx context.Context, id string) (data []byte, Err logging.LogErrorInfo) {
+	_, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Reading Config Revision File %s", id), logging.LevelTrace)
+	defer logAction.Complete()
+
+	if !revisionIDPattern.MatchString(id) {
+		logAction.SetError("Invalid revision ID", "Use an ID from the revision list", map[string]any{"id": id})
+		return nil, *logAction.Error
+	}
+	data, err := os.ReadFile(path.Join(revisionsDir(), id+".yaml"))
+	if err != nil {
+		logAction.SetError("Config revision not found", err.Error(), map[string]any{"id": id})
+		return nil, *logAction.Error
+	}
+	return data, logging.LogErrorInfo{}
+}
+
+// DiffRevisions returns the changes between two revisions with secrets masked.
+// When toID is empty, the latest revision is used.
+func DiffRevisions(ctx context.Context, fromID, toID string) (changes []ConfigChange, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Comparing Config Revisions", logging.LevelDebug)
+	defer logAction.Complete()
+
+	revisionsMu.Lock()
+	defer revisionsMu.Unlock()
+
+	if toID == "" {
+		ids := revisionIDs()
+		if len(ids) == 0 {
+			logAction.SetError("No config revisions found", "Save the config to create the first revision", nil)
+			return nil, *logAction.Error
+		}
+		toID = ids[len(ids)-1]
+	}
+
+	fromData, Err := readRevisionFile(ctx, fromID)
+	if Err.Message != "" {
+		return nil, Err
+	}
+	toData, Err := readRevisionFile(ctx, toID)
+	if Err.Message != "" {
+		return nil, Err
+	}
+
+	changes = diffConfigYAML(ctx, fromData, toData)
+	logAction.AppendResult("changes", len(changes))
+	return changes, logging.LogErrorInfo{}
+}
+
+// RollbackToRevision validates a revision and writes it to config.yaml.
+// The rollback itself is kept as a new revision. Reload the config afterwards to use it.
+func RollbackToRevision(ctx context.Context, id string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Rolling Back Config to Revision %s", id), logging.LevelInfo)
+	defer logAction.Complete()
+
+	revisionsMu.Lock()
+	data, Err := readRevisionFile(ctx, id)
+	revisionsMu.Unlock()
+	if Err.Message != "" {
+		return Err
+	}
+
+	var revisionConfig Config
+	if err := yaml.Unmarshal(data, &revisionConfig); err != nil {
+		logAction.SetError("Failed to parse config revision", err.Error(), map[string]any{"id": id})
+		return *logAction.Error
+	}
+
+	// Validate the config the way it will run, with environment overrides applied.
+	applyEnvOverrides(ctx, &revisionConfig)
+	revisionConfig.Validate(ctx)
+	if !revisionConfig.Status.Valid {
+		logAction.SetError("Config revision is not valid", "The current config was kept. Check the validation results for details.", map[string]any{"id": id})
+		return *logAction.Error
+	}
+
+	configFile := path.Join(ConfigPath, "config.yaml")
+	previous, _ := os.ReadFile(configFile)
+	if err := os.WriteFile(configFile, data, 0644); err != nil {
+		logAction.SetError("Failed to write config file", err.Error(), nil)
+		return *logAction.Error
+	}
+	saveRevision(ctx, previous, data, id)
+
+	return logging.LogErrorInfo{}
+}
+
+// diffConfigYAML compares two config files value by value.
+// Changes are found on the real values, but the masked values are returned.
+func diffConfigYAML(ctx context.Context, oldData, newData []byte) []ConfigChange {
+	var oldConfig, newConfig Config
+	if err := yaml.Unmarshal(oldData, &oldConfig); err != nil {
+		return []ConfigChange{}
+	}
+	if err := yaml.Unmarshal(newData, &newConfig); err != nil {
+		return []ConfigChange{}
+	}
+
+	oldValues := flattenConfig(oldConfig)
+	newValues := flattenConfig(newConfig)
+	oldMasked := flattenConfig(maskRevisionConfig(ctx, oldConfig))
+	newMasked := flattenConfig(maskRevisionConfig(ctx, newConfig))
+
+	paths := slices.Sorted(maps.Keys(oldValues))
+	for p := range newValues {
+		if _, found := oldValues[p]; !found {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+
+	changes := []ConfigChange{}
+	for _, p := range paths {
+		oldValue, inOld := oldValues[p]
+		newValue, inNew := newValues[p]
+		switch {
+		case !inOld:
+			changes = append(changes, ConfigChange{Path: p, Type: "added", New: newMasked[p]})
+		case !inNew:
+			changes = append(changes, ConfigChange{Path: p, Type: "removed", Old: oldMasked[p]})
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(changes, ConfigChange{Path: p, Type: "changed", Old: oldMasked[p], New: newMasked[p]})
+		}
+	}
+	return changes
+}
+
+// maskRevisionConfig masks every secret in the config, including the ones the UI never gets back
+func maskRevisionConfig(ctx context.Context, config Config) Config {
+	c := *config.SanitizeConfig(ctx)
+	c.Auth.Password = MaskToken(c.Auth.Password)
+	c.Auth.APIKeyHash = MaskToken(c.Auth.APIKeyHash)
+	c.Database.Password = MaskToken(c.Database.Password)
+	c.Database.DSN = MaskToken(c.Database.DSN)
+	if len(c.Logging.Tracing.Headers) > 0 {
+		headers := map[string]string{}
+		for key, value := range c.Logging.Tracing.Headers {
+			headers[key] = MaskToken(value)
+		}
+		c.Logging.Tracing.Headers = headers
+	}
+	for i, provider := range c.Notifications.Providers {
+		if provider.Webhook == nil {
+			continue
+		}
+		webhook := &Config_Notification_Webhook{URL: provider.Webhook.URL, Headers: map[string]string{}}
+		for key, value := range provider.Webhook.Headers {
+			webhook.Headers[key] = MaskToken(value)
+		}
+		c.Notifications.Providers[i].Webhook = webhook
+	}
+	return c
+}
+
+// flattenConfig returns every value of the config keyed by its YAML path
+func flattenConfig(config Config) map[string]any {
+	values := map[string]any{}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return values
+	}
+	var tree any
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return values
+	}
+	flattenValue(tree, "", values)
+	return values
+}
+
+func flattenValue(v any, prefix string, values map[string]any) {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 && prefix != "" {
+			values[prefix] = v
+		}
+		for key, child := range v {
+			flattenValue(child, joinYAMLPath(prefix, key), values)
+		}
+	case []any:
+		if len(v) == 0 {
+			values[prefix] = v
+		}
+		for i, child := range v {
+			flattenValue(child, fmt.Sprintf("%s[%d]", prefix, i), values)
+		}
+	default:
+		values[prefix] = v
+	}
+}
+
+func joinYAMLPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
@@ -23,6 +23,14 @@ func (config *Config) SanitizeConfig(ctx context.Context) *Config {
 	c.MediaServer.ApiToken = MaskToken(c.MediaServer.ApiToken)
 	c.Auth.OIDC.ClientSecret = MaskToken(c.Auth.OIDC.ClientSecret)
 
+	// Tracing headers usually hold a collector token, copy the map so the original keeps its values
+	if len(config.Logging.Tracing.Headers) > 0 {
+		c.Logging.Tracing.Headers = make(map[string]string, len(config.Logging.Tracing.Headers))
+		for key, value := range config.Logging.Tracing.Headers {
+			c.Logging.Tracing.Headers[key] = MaskToken(value)
+		}
+	}
+
 	// Deep copy notifications.providers slice and nested pointer
 	if len(config.Notifications.Providers) > 0 {
 		c.Notifications.Providers = make([]Config_Notification_Provider, len(config.Notifications.Providers))
@@ -13,13 +13,16 @@ func (config *Config) Save(ctx context.Context) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Saving Config to File", logging.LevelDebug)
 	defer logAction.Complete()
 
+	// Values set by environment variables or _FILE secrets are never written to the file
+	fileConfig := config.withoutEnvOverrides()
+
 	// Clear the User ID before saving
 	// This is done so that it is loaded on startup
-	config.MediaServer.UserID = ""
+	fileConfig.MediaServer.UserID = ""
 
 	// Sub-action: Marshal config to YAML
 	subActionMarshal := logAction.AddSubAction("Marshal Config to YAML", logging.LevelTrace)
-	data, marshalErr := yaml.Marshal(config)
+	data, marshalErr := yaml.Marshal(fileConfig)
 	if marshalErr != nil {
 		subActionMarshal.SetError("Failed to marshal config to YAML", marshalErr.Error(), nil)
 		logAction.Status = logging.StatusError
@@ -29,12 +32,18 @@ func (config *Config) Save(ctx context.Context) (Err logging.LogErrorInfo) {
 
 	// Sub-action: Write config to file
 	subActionWrite := logAction.AddSubAction("Write Config to File", logging.LevelTrace)
-	if writeErr := os.WriteFile(path.Join(ConfigPath, "config.yaml"), data, 0644); writeErr != nil {
+	configFile := path.Join(ConfigPath, "config.yaml")
+	previous, _ := os.ReadFile(configFile)
+	if writeErr := os.WriteFile(configFile, data, 0644); writeErr != nil {
 		subActionWrite.SetError("Failed to write config to file", writeErr.Error(), nil)
 		logAction.Status = logging.StatusError
 		return *subActionWrite.Error
 	}
 	subActionWrite.Complete()
+	rememberFileConfig(fileConfig)
+
+	// Keep every saved config so that it can be rolled back
+	saveRevision(ctx, previous, data, "")
 
 	return logging.LogErrorInfo{}
 }
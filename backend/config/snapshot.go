@@ -0,0 +1,138 @@
+package config
+
+import (
+	"aura/logging"
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Order in which change subscribers are notified. Lower runs first.
+const (
+	SubscriberOrderLogging   = 10 // Log level, so the rest logs at the new level
+	SubscriberOrderAuth      = 20 // OIDC and other auth settings
+	SubscriberOrderJobs      = 30 // Cron jobs
+	SubscriberOrderListeners = 40 // Media server event listeners
+)
+
+// ChangeFunc is called with the previous and the new config snapshot after a change.
+// Both snapshots are read only. A ChangeFunc must not call Set or Update.
+type ChangeFunc func(ctx context.Context, previous, current *Config)
+
+type subscriber struct {
+	name  string
+	order int
+	fn    ChangeFunc
+}
+
+type snapshotContextKey struct{}
+
+var (
+	// The config snapshot in use. Snapshots are never changed after they are stored,
+	// a change stores a new one.
+	snapshot atomic.Pointer[Config]
+
+	// setMu makes sure changes are stored and announced one at a time, in the order they were made
+	setMu       sync.Mutex
+	subscribers []subscriber
+)
+
+func init() {
+	// The connections count as valid until they are checked
+	snapshot.Store(&Config{Status: Status{MediaServerValid: true, MediuxValid: true}})
+}
+
+// Latest returns the config snapshot in use right now.
+// The snapshot is shared, so it must never be changed. Use Update to change the config.
+func Latest() *Config {
+	return snapshot.Load()
+}
+
+// Current returns the config snapshot captured in ctx by WithSnapshot, or the latest one if there is none.
+// Long-running operations capture a snapshot at the start, so every step of the run sees the same config.
+// The snapshot is shared, so it must never be changed.
+func Current(ctx context.Context) *Config {
+	if ctx != nil {
+		if cfg, ok := ctx.Value(snapshotContextKey{}).(*Config); ok && cfg != nil {
+			return cfg
+		}
+	}
+	return Latest()
+}
+
+// WithSnapshot captures cfg in ctx, so Current(ctx) returns it for the rest of the operation.
+// Pass Latest() at the start of a run, or Current(ctx) to hand the run's snapshot to a new context.
+func WithSnapshot(ctx context.Context, cfg *Config) context.Context {
+	if cfg == nil {
+		cfg = Latest()
+	}
+	return context.WithValue(ctx, snapshotContextKey{}, cfg)
+}
+
+// Set stores a copy of cfg as the new config snapshot and notifies the subscribers in order
+func Set(ctx context.Context, cfg Config) {
+	setMu.Lock()
+	defer setMu.Unlock()
+
+	next := cloneConfig(cfg)
+	storeAndNotify(ctx, &next)
+}
+
+// Update stores a new config snapshot with the changes made by fn to a copy of the latest one
+func Update(ctx context.Context, fn func(cfg *Config)) {
+	setMu.Lock()
+	defer setMu.Unlock()
+
+	next := cloneConfig(*Latest())
+	fn(&next)
+	storeAndNotify(ctx, &next)
+}
+
+func storeAndNotify(ctx context.Context, next *Config) {
+	previous := snapshot.Swap(next)
+
+	if len(subscribers) == 0 {
+		return
+	}
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Notifying Config Subscribers", logging.LevelDebug)
+	if logAction != nil {
+		defer logAction.Complete()
+	}
+	for _, sub := range subscribers {
+		sub.fn(ctx, previous, next)
+		if logAction != nil {
+			logAction.AppendResult(sub.name, "notified")
+		}
+	}
+}
+
+// Subscribe registers fn to be called after every config change.
+// Subscribers are called by order, then in the order they subscribed.
+func Subscribe(name string, order int, fn ChangeFunc) {
+	setMu.Lock()
+	defer setMu.Unlock()
+
+	subscribers = append(subscribers, subscriber{name: name, order: order, fn: fn})
+	slices.SortStableFunc(subscribers, func(a, b subscriber) int {
+		return a.order - b.order
+	})
+}
+
+// AtomicString is a string that can be read and written from different goroutines
+type AtomicString struct {
+	v atomic.Pointer[string]
+}
+
+// Load returns the stored string
+func (s *AtomicString) Load() string {
+	if p := s.v.Load(); p != nil {
+		return *p
+	}
+	return ""
+}
+
+// Store replaces the stored string
+func (s *AtomicString) Store(value string) {
+	s.v.Store(&value)
+}
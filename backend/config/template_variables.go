@@ -8,6 +8,8 @@ const (
 	TemplateTypeNewSetsAvailableForIgnoredItems = "new_sets_available_for_ignored_items"
 	TemplateTypeCheckForMediaItemChangesJob     = "check_for_media_item_changes_job"
 	TemplateTypeSonarrNotification              = "sonarr_notification"
+	TemplateTypeWantedItemAvailable             = "wanted_item_available"
+	TemplateTypeIgnoredItemExpired              = "ignored_item_expired"
 )
 
 var BaseTemplateVariables = []string{
@@ -103,6 +105,20 @@ func AllowedTemplateVariables(templateType string) []string {
 				"{{Result}}",
 			},
 		)
+	case TemplateTypeWantedItemAvailable:
+		return mergeTemplateVariableGroups(
+			BaseTemplateVariables,
+			MediaItemVariables,
+			SetItemVariables,
+		)
+	case TemplateTypeIgnoredItemExpired:
+		return mergeTemplateVariableGroups(
+			BaseTemplateVariables,
+			MediaItemVariables,
+			[]string{
+				"{{IgnoredUntil}}",
+			},
+		)
 	default:
 		return []string{}
 	}
@@ -118,6 +134,8 @@ func GetNotificationTemplateVariableCatalog() NotificationTemplateVariableCatalo
 			TemplateTypeNewSetsAvailableForIgnoredItems: AllowedTemplateVariables(TemplateTypeNewSetsAvailableForIgnoredItems),
 			TemplateTypeCheckForMediaItemChangesJob:     AllowedTemplateVariables(TemplateTypeCheckForMediaItemChangesJob),
 			TemplateTypeSonarrNotification:              AllowedTemplateVariables(TemplateTypeSonarrNotification),
+			TemplateTypeWantedItemAvailable:             AllowedTemplateVariables(TemplateTypeWantedItemAvailable),
+			TemplateTypeIgnoredItemExpired:              AllowedTemplateVariables(TemplateTypeIgnoredItemExpired),
 		},
 	}
 }
@@ -2,9 +2,11 @@ package config
 
 import (
 	"aura/logging"
+	"aura/models"
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"slices"
 	"strings"
 
@@ -37,6 +39,9 @@ func (config *Config) Validate(ctx context.Context) {
 	// Sub-action: Images Config
 	isImagesValid := ValidateImages(ctx, &config.Images, config.MediaServer)
 
+	// Sub-action: TMDB Config
+	isTMDBValid := ValidateTMDB(ctx, &config.TMDB)
+
 	// Sub-action: Notifications Config
 	isNotificationsValid := ValidateNotifications(ctx, &config.Notifications)
 
@@ -49,15 +54,20 @@ func (config *Config) Validate(ctx context.Context) {
 	// Sub-action: LabelsAndTags Config
 	isLabelsAndTagsValid := ValidateLabelsAndTags(ctx, &config.LabelsAndTags)
 
+	// Sub-action: Health Config
+	isHealthValid := ValidateHealth(ctx, &config.Health)
+
 	// If any validation failed, set status to error
 	if !isAuthValid || !isLoggingValid || !isMediaServerValid ||
-		!isMediuxValid || !isAutoDownloadValid ||
-		!isImagesValid || !isNotificationsValid || !isSonarrRadarrValid || !isDatabaseValid || !isLabelsAndTagsValid {
+		!isMediuxValid || !isAutoDownloadValid || !isTMDBValid ||
+		!isImagesValid || !isNotificationsValid || !isSonarrRadarrValid || !isDatabaseValid || !isLabelsAndTagsValid || !isHealthValid {
 		logAction.SetError("Config validation failed", "One or more config sections are invalid", nil)
-		Valid = false
+		config.Status.Valid = false
 	} else {
 		logging.SetLogLevel(config.Logging.Level)
-		Valid = true
+		logging.SetLogRotation(config.Logging.Rotation())
+		logging.SetTracing(config.Logging.Tracing.TraceConfig())
+		config.Status.Valid = true
 	}
 
 }
@@ -130,9 +140,85 @@ func ValidateLogging(ctx context.Context, Logging *Config_Logging) bool {
 		logging.SetLogLevel(Logging.Level)
 	}
 
+	// Rotation settings can't be negative (0 uses the default)
+	if Logging.MaxSizeMB < 0 || Logging.MaxBackups < 0 || Logging.MaxAgeDays < 0 {
+		logAction.SetError("Logging rotation settings are invalid", "MaxSizeMB, MaxBackups and MaxAgeDays must be 0 or greater", map[string]any{
+			"max_size_mb":  Logging.MaxSizeMB,
+			"max_backups":  Logging.MaxBackups,
+			"max_age_days": Logging.MaxAgeDays,
+		})
+		isValid = false
+	}
+
+	if Logging.Tracing.Enabled {
+		if Logging.Tracing.ServiceName == "" {
+			Logging.Tracing.ServiceName = "aura"
+		}
+		endpoint, err := url.ParseRequestURI(Logging.Tracing.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			logAction.SetError("Logging.Tracing.Endpoint is not a valid URL", "Set it to the OTLP/HTTP endpoint of your collector (e.g. http://tempo:4318)", map[string]any{
+				"endpoint": Logging.Tracing.Endpoint,
+			})
+			isValid = false
+		}
+	}
+
 	return isValid
 }
 
+// Rotation returns the log rotation settings to hand to the logger
+func (l Config_Logging) Rotation() logging.LogRotation {
+	return logging.LogRotation{
+		MaxSizeMB:  l.MaxSizeMB,
+		MaxBackups: l.MaxBackups,
+		MaxAgeDays: l.MaxAgeDays,
+		Compress:   l.Compress,
+	}
+}
+
+// TraceConfig returns the trace export settings to hand to the logger
+func (t Config_Logging_Tracing) TraceConfig() logging.TraceConfig {
+	return logging.TraceConfig{
+		Enabled:     t.Enabled,
+		Endpoint:    t.Endpoint,
+		ServiceName: t.ServiceName,
+		Headers:     t.Headers,
+	}
+}
+
+func ValidateTMDB(ctx context.Context, TMDB *Config_TMDB) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating TMDB Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if TMDB.MinVoteAverage < 0 || TMDB.MinVoteAverage > 10 {
+		logAction.SetError("TMDB.MinVoteAverage is not valid", "TMDB.MinVoteAverage must be between 0 and 10", map[string]any{
+			"min_vote_average": TMDB.MinVoteAverage,
+		})
+		isValid = false
+	}
+
+	languages := []string{}
+	for _, lang := range TMDB.Languages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang != "" && !slices.Contains(languages, lang) {
+			languages = append(languages, lang)
+		}
+	}
+	TMDB.Languages = languages
+
+	return isValid
+}
+
+// ImageFilter returns the configured filter for TMDB images
+func (t Config_TMDB) ImageFilter() models.ImageFilter {
+	return models.ImageFilter{
+		Languages:      slices.Clone(t.Languages),
+		MinVoteAverage: t.MinVoteAverage,
+	}
+}
+
 func ValidateMediaServer(ctx context.Context, MediaServer *Config_MediaServer) bool {
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating MediaServer Config", logging.LevelTrace)
 	defer logAction.Complete()
@@ -216,6 +302,11 @@ func ValidateAutoDownload(ctx context.Context, AutoDownload *Config_AutoDownload
 
 	isValid := true
 
+	// AutoApply works on its own, so it is validated even when AutoDownload is disabled
+	if !validateAutoApply(ctx, &AutoDownload.AutoApply) {
+		isValid = false
+	}
+
 	// Check if AutoDownload is enabled
 	if !AutoDownload.Enabled {
 		return isValid
@@ -237,6 +328,45 @@ func ValidateAutoDownload(ctx context.Context, AutoDownload *Config_AutoDownload
 	return isValid
 }
 
+func validateAutoApply(ctx context.Context, AutoApply *Config_AutoDownload_AutoApply) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating AutoDownload.AutoApply Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	if !AutoApply.Enabled {
+		return isValid
+	}
+
+	if len(AutoApply.Creators) == 0 && !AutoApply.FallbackToMostPopular {
+		logAction.SetError("AutoDownload.AutoApply.Creators is empty", "Add at least one creator or enable FallbackToMostPopular", nil)
+		isValid = false
+	}
+
+	types := AutoApply.SelectedTypes
+	if !types.Poster && !types.Backdrop && !types.SeasonPoster && !types.SpecialSeasonPoster && !types.Titlecard {
+		logAction.SetError("AutoDownload.AutoApply.SelectedTypes has no types selected", "Select at least one image type to apply", nil)
+		isValid = false
+	}
+
+	for i := range AutoApply.Creators {
+		creator := &AutoApply.Creators[i]
+		creator.Username = strings.TrimSpace(creator.Username)
+		if creator.Username == "" {
+			logAction.SetError(fmt.Sprintf("AutoDownload.AutoApply.Creators[%d].Username is not set", i), "Each creator must have a MediUX username", nil)
+			isValid = false
+		}
+		for _, itemType := range creator.Types {
+			if itemType != "movie" && itemType != "show" {
+				logAction.SetError(fmt.Sprintf("AutoDownload.AutoApply.Creators[%d].Types: '%s' is not a valid type", i, itemType), "Types must be 'movie' or 'show'", nil)
+				isValid = false
+			}
+		}
+	}
+
+	return isValid
+}
+
 func ValidateCron(cronExpression string) bool {
 	_, err := cron.ParseStandard(cronExpression)
 	return err == nil
@@ -248,6 +378,39 @@ func ValidateImages(ctx context.Context, Images *Config_Images, msConfig Config_
 
 	isValid := true
 
+	// If Images.LocalArtwork.Enabled is true, the Path must be an existing folder
+	if Images.LocalArtwork.Enabled {
+		if Images.LocalArtwork.Path == "" {
+			logAction.SetError("Images.LocalArtwork.Path is not set", "Path must be set when the local artwork folder is enabled", nil)
+			isValid = false
+		} else if info, err := os.Stat(Images.LocalArtwork.Path); err != nil || !info.IsDir() {
+			logAction.SetError("Images.LocalArtwork.Path is not a folder", "Ensure the path exists and is readable by AURA", map[string]any{
+				"path": Images.LocalArtwork.Path,
+			})
+			isValid = false
+		}
+	}
+
+	// Images.ArtworkHistory.MaxVersions can't be negative (0 uses the default)
+	if Images.ArtworkHistory.MaxVersions < 0 {
+		logAction.SetError("Images.ArtworkHistory.MaxVersions is invalid", "MaxVersions must be 0 or greater", map[string]any{
+			"max_versions": Images.ArtworkHistory.MaxVersions,
+		})
+		isValid = false
+	}
+
+	// Images.DriftDetection.Cron has to be valid when drift detection is enabled
+	if Images.DriftDetection.Enabled {
+		if Images.DriftDetection.Cron == "" {
+			Images.DriftDetection.Cron = "0 3 * * *"
+			logAction.AppendWarning("message", "Images.DriftDetection.Cron not set, defaulting to '0 3 * * *' (every day at 3 AM)")
+		}
+		if !ValidateCron(Images.DriftDetection.Cron) {
+			logAction.SetError(fmt.Sprintf("Images.DriftDetection.Cron: '%s' is not a valid cron expression", Images.DriftDetection.Cron), "Please provide a valid cron expression", nil)
+			isValid = false
+		}
+	}
+
 	// If Images.SaveImagesLocally.Enabled is true, validate the EpisodeNamingConvention
 	if Images.SaveImagesLocally.Enabled {
 		if msConfig.Type != "Plex" {
@@ -419,6 +582,42 @@ func ValidateNotifications(ctx context.Context, Notifications *Config_Notificati
 		}
 	}
 
+	if Notifications.NotificationTemplate.WantedItemAvailable == (Config_CustomNotification{}) {
+		Notifications.NotificationTemplate.WantedItemAvailable = defaults.WantedItemAvailable
+		logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.WantedItemAvailable not set, defaulting to built-in template")
+		logAction.AppendWarning("message", "Notifications.NotificationTemplate.WantedItemAvailable not set, defaulting to built-in template")
+	} else {
+		validWantedItemAvailableVariables := AllowedTemplateVariables(TemplateTypeWantedItemAvailable)
+		if !validateTemplateVariables(Notifications.NotificationTemplate.WantedItemAvailable.Title, validWantedItemAvailableVariables) {
+			logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.WantedItemAvailable.Title contains invalid variables, please check the config documentation for valid variables")
+			logAction.AppendWarning("message", "Notifications.NotificationTemplate.WantedItemAvailable.Title contains invalid variables, please check the config documentation for valid variables")
+			Notifications.NotificationTemplate.WantedItemAvailable.Title = defaults.WantedItemAvailable.Title
+		}
+		if !validateTemplateVariables(Notifications.NotificationTemplate.WantedItemAvailable.Message, validWantedItemAvailableVariables) {
+			logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.WantedItemAvailable.Message contains invalid variables, please check the config documentation for valid variables")
+			logAction.AppendWarning("message", "Notifications.NotificationTemplate.WantedItemAvailable.Message contains invalid variables, please check the config documentation for valid variables")
+			Notifications.NotificationTemplate.WantedItemAvailable.Message = defaults.WantedItemAvailable.Message
+		}
+	}
+
+	if Notifications.NotificationTemplate.IgnoredItemExpired == (Config_CustomNotification{}) {
+		Notifications.NotificationTemplate.IgnoredItemExpired = defaults.IgnoredItemExpired
+		logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.IgnoredItemExpired not set, defaulting to built-in template")
+		logAction.AppendWarning("message", "Notifications.NotificationTemplate.IgnoredItemExpired not set, defaulting to built-in template")
+	} else {
+		validIgnoredItemExpiredVariables := AllowedTemplateVariables(TemplateTypeIgnoredItemExpired)
+		if !validateTemplateVariables(Notifications.NotificationTemplate.IgnoredItemExpired.Title, validIgnoredItemExpiredVariables) {
+			logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.IgnoredItemExpired.Title contains invalid variables, please check the config documentation for valid variables")
+			logAction.AppendWarning("message", "Notifications.NotificationTemplate.IgnoredItemExpired.Title contains invalid variables, please check the config documentation for valid variables")
+			Notifications.NotificationTemplate.IgnoredItemExpired.Title = defaults.IgnoredItemExpired.Title
+		}
+		if !validateTemplateVariables(Notifications.NotificationTemplate.IgnoredItemExpired.Message, validIgnoredItemExpiredVariables) {
+			logging.LOGGER.Warn().Timestamp().Msg("Notifications.NotificationTemplate.IgnoredItemExpired.Message contains invalid variables, please check the config documentation for valid variables")
+			logAction.AppendWarning("message", "Notifications.NotificationTemplate.IgnoredItemExpired.Message contains invalid variables, please check the config documentation for valid variables")
+			Notifications.NotificationTemplate.IgnoredItemExpired.Message = defaults.IgnoredItemExpired.Message
+		}
+	}
+
 	return isValid
 }
 
@@ -617,3 +816,39 @@ func ValidateLabelsAndTags(ctx context.Context, LabelsAndTags *Config_LabelsAndT
 func stringSliceContains(slice []string, item string) bool {
 	return slices.Contains(slice, item)
 }
+
+func ValidateHealth(ctx context.Context, Health *Config_Health) bool {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Validating Health Config", logging.LevelTrace)
+	defer logAction.Complete()
+
+	isValid := true
+
+	defaults := DefaultHealthCriticality()
+	for check, criticality := range Health.Criticality {
+		if _, known := defaults[check]; !known {
+			logAction.SetError(fmt.Sprintf("Health.Criticality has an unknown check: %s", check),
+				"Valid checks are database, media_server, mediux, sonarr_radarr, event_listener, mediux_listener, cache_warmup and download_queue", nil)
+			isValid = false
+			continue
+		}
+		criticality = strings.ToLower(strings.TrimSpace(criticality))
+		if criticality != HealthCritical && criticality != HealthOptional && criticality != HealthIgnore {
+			logAction.SetError(fmt.Sprintf("Health.Criticality.%s is not valid", check), "Valid values are critical, optional and ignore", map[string]any{
+				"criticality": criticality,
+			})
+			isValid = false
+			continue
+		}
+		Health.Criticality[check] = criticality
+	}
+
+	return isValid
+}
+
+// CriticalityOf returns how much a failing readiness check matters
+func (h Config_Health) CriticalityOf(check string) string {
+	if criticality, ok := h.Criticality[check]; ok {
+		return criticality
+	}
+	return DefaultHealthCriticality()[check]
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"aura/config"
+	autodownload "aura/download/auto"
+	"aura/jobs"
+	"aura/logging"
+	routes_auth "aura/routing/auth"
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var subscribeOnce sync.Once
+
+// subscribeToConfigChanges restarts whatever uses a setting when that setting changes.
+// The subscribers run in this order: logging, OIDC, cron jobs, media server and MediUX listeners.
+func subscribeToConfigChanges() {
+	subscribeOnce.Do(func() {
+		config.Subscribe("Logging", config.SubscriberOrderLogging, func(ctx context.Context, previous, current *config.Config) {
+			if previous.Logging.Level != current.Logging.Level {
+				logging.SetLogLevel(current.Logging.Level)
+			}
+			if previous.Logging.Rotation() != current.Logging.Rotation() {
+				logging.SetLogRotation(current.Logging.Rotation())
+			}
+			if !reflect.DeepEqual(previous.Logging.Tracing, current.Logging.Tracing) {
+				logging.SetTracing(current.Logging.Tracing.TraceConfig())
+			}
+		})
+
+		config.Subscribe("OIDC", config.SubscriberOrderAuth, func(ctx context.Context, previous, current *config.Config) {
+			if reflect.DeepEqual(previous.Auth.OIDC, current.Auth.OIDC) {
+				return
+			}
+			// Bounded timeout so a slow/unreachable IdP can't hold up the config change
+			oidcCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			routes_auth.InitOIDC(config.WithSnapshot(oidcCtx, current))
+		})
+
+		config.Subscribe("AutoDownload Job", config.SubscriberOrderJobs, func(ctx context.Context, previous, current *config.Config) {
+			if !reflect.DeepEqual(previous.AutoDownload, current.AutoDownload) {
+				jobs.StartAutoDownloadJob()
+			}
+		})
+
+		config.Subscribe("Artwork Drift Detection Job", config.SubscriberOrderJobs, func(ctx context.Context, previous, current *config.Config) {
+			if previous.Images.DriftDetection != current.Images.DriftDetection {
+				jobs.StartArtworkDriftJob()
+			}
+		})
+
+		// The Download Queue and MediUX site link jobs read no settings, they pick up changes on their next run
+		config.Subscribe("Media Server Jobs", config.SubscriberOrderJobs, func(ctx context.Context, previous, current *config.Config) {
+			prevMS, currMS := previous.MediaServer, current.MediaServer
+			serverChanged := prevMS.Type != currMS.Type || prevMS.URL != currMS.URL || prevMS.ApiToken != currMS.ApiToken
+			if !serverChanged && reflect.DeepEqual(prevMS.Libraries, currMS.Libraries) &&
+				prevMS.EnableSortByEpisodeAddedDate == currMS.EnableSortByEpisodeAddedDate {
+				return
+			}
+			jobs.StartRefreshMediaItemsAndCollectionsJob()
+			jobs.StartCheckForMediaItemChangesJob()
+			jobs.StartHandleTempIgnoredItemsJob()
+			// When the server changed the cached items belong to the old one, so every library is loaded again
+			jobs.RunRefreshMediaItemsAndCollectionsJobNow(serverChanged)
+		})
+
+		config.Subscribe("MediUX Jobs", config.SubscriberOrderJobs, func(ctx context.Context, previous, current *config.Config) {
+			if previous.Mediux.ApiToken == current.Mediux.ApiToken {
+				return
+			}
+			jobs.StartRefreshMediuxUsersJob()
+			jobs.RunRefreshMediuxUsersJobNow()
+		})
+
+		config.Subscribe("Media Server Listeners", config.SubscriberOrderListeners, func(ctx context.Context, previous, current *config.Config) {
+			prevMS, currMS := previous.MediaServer, current.MediaServer
+			if prevMS.Type == currMS.Type && prevMS.URL == currMS.URL && prevMS.ApiToken == currMS.ApiToken &&
+				prevMS.EnablePlexEventListener == currMS.EnablePlexEventListener &&
+				prevMS.EnableEmbyJellyfinEventListener == currMS.EnableEmbyJellyfinEventListener {
+				return
+			}
+			autodownload.StartOrRestartPlexWebSocketClient()
+			autodownload.StartOrRestartEJWebSocketClient()
+		})
+
+		config.Subscribe("MediUX Listener", config.SubscriberOrderListeners, func(ctx context.Context, previous, current *config.Config) {
+			if previous.Mediux.ApiToken == current.Mediux.ApiToken &&
+				previous.Mediux.EnableEventListener == current.Mediux.EnableEventListener {
+				return
+			}
+			autodownload.StartOrRestartMediuxWebSocketClient()
+		})
+	})
+}
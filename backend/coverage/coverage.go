@@ -0,0 +1,254 @@
+package coverage
+
+import (
+	"aura/cache"
+	"aura/database"
+	"aura/localartwork"
+	"aura/logging"
+	"aura/models"
+	"aura/tmdb"
+	"context"
+	"slices"
+	"sort"
+	"time"
+)
+
+type ItemStatus string
+
+const (
+	STATUS_SAVED            ItemStatus = "saved"            // At least one set is saved for the item
+	STATUS_MEDIUX_AVAILABLE ItemStatus = "mediux_available" // MediUX has sets for the item, but none is saved
+	STATUS_LOCAL_AVAILABLE  ItemStatus = "local_available"  // MediUX has no sets, but the local artwork folder has a folder for the item
+	STATUS_NO_SETS          ItemStatus = "no_sets"          // No image source has sets for the item
+	STATUS_IGNORED          ItemStatus = "ignored"          // The item is ignored
+)
+
+// ItemStatuses lists every item status
+var ItemStatuses = []ItemStatus{STATUS_SAVED, STATUS_MEDIUX_AVAILABLE, STATUS_LOCAL_AVAILABLE, STATUS_NO_SETS, STATUS_IGNORED}
+
+// Gap is an image of the newest season or episode of a show that the saved sets select but don't have
+type Gap struct {
+	ImageType     string `json:"image_type"` // season_poster or titlecard
+	SeasonNumber  int    `json:"season_number"`
+	EpisodeNumber *int   `json:"episode_number,omitempty"`
+}
+
+// Item is the coverage of a single library item
+type Item struct {
+	TMDB_ID      string     `json:"tmdb_id"`
+	LibraryTitle string     `json:"library_title"`
+	Edition      string     `json:"edition,omitempty"`
+	RatingKey    string     `json:"rating_key"`
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Year         int        `json:"year"`
+	Status       ItemStatus `json:"status"`
+	SetIDs       []string   `json:"set_ids,omitempty"`
+	Gaps         []Gap      `json:"gaps,omitempty"`
+	TMDBFallback bool       `json:"tmdb_fallback,omitempty"` // No sets, but TMDB is asked as a fallback. Whether it has images is not checked, that takes a request per item.
+}
+
+// Library is the summary of a library section
+type Library struct {
+	LibraryTitle    string `json:"library_title"`
+	Type            string `json:"type"`
+	Total           int    `json:"total"`
+	Saved           int    `json:"saved"`
+	MediuxAvailable int    `json:"mediux_available"`
+	LocalAvailable  int    `json:"local_available"`
+	NoSets          int    `json:"no_sets"`
+	TMDBFallback    int    `json:"tmdb_fallback"` // Items with no sets that TMDB is asked for images for
+	Ignored         int    `json:"ignored"`
+	WithGaps        int    `json:"with_gaps"`
+}
+
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Libraries   []Library `json:"libraries"`
+	Items       []Item    `json:"items"`
+}
+
+// Filter limits the items in the report. Library counts only use the library and type filters,
+// so they always describe the whole library.
+type Filter struct {
+	LibraryTitles []string     // Only these libraries
+	Type          string       // movie or show
+	Statuses      []ItemStatus // Only items with one of these statuses
+	GapsOnly      bool         // Only shows with gaps
+}
+
+// BuildReport joins the media server library cache, the image sources and the saved sets
+// to report how much of every library has artwork.
+func BuildReport(ctx context.Context, filter Filter) (report Report, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Building Library Coverage Report", logging.LevelInfo)
+	defer logAction.Complete()
+
+	report = Report{GeneratedAt: time.Now(), Libraries: []Library{}, Items: []Item{}}
+
+	if cache.LibraryStore.IsEmpty() {
+		logAction.SetError("Library cache is empty", "Wait for the media server libraries to be loaded and try again", nil)
+		return report, *logAction.Error
+	}
+
+	savedItems, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
+	if Err.Message != "" {
+		return report, Err
+	}
+	savedByKey := make(map[string]models.DBSavedItem, len(savedItems.Items))
+	for _, savedItem := range savedItems.Items {
+		savedByKey[itemKey(savedItem.MediaItem.TMDB_ID, savedItem.MediaItem.LibraryTitle, savedItem.MediaItem.Edition)] = savedItem
+	}
+
+	flaggedItems, Err := database.GetAllMediaItemsWithFlags(ctx)
+	if Err.Message != "" {
+		return report, Err
+	}
+	ignored := map[string]bool{}
+	for _, flagged := range flaggedItems {
+		if flagged.IsIgnored {
+			ignored[itemKey(flagged.TMDB_ID, flagged.LibraryTitle, flagged.Edition)] = true
+		}
+	}
+
+	localFolders := localartwork.GetItemFolders(ctx)
+	tmdbEnabled := tmdb.IsEnabled(ctx)
+
+	for _, section := range cache.LibraryStore.GetAllSectionsSortedByTitle() {
+		if len(filter.LibraryTitles) > 0 && !slices.Contains(filter.LibraryTitles, section.Title) {
+			continue
+		}
+		if filter.Type != "" && section.Type != filter.Type {
+			continue
+		}
+
+		library := Library{LibraryTitle: section.Title, Type: section.Type}
+		for _, mediaItem := range section.MediaItems {
+			key := itemKey(mediaItem.TMDB_ID, mediaItem.LibraryTitle, mediaItem.Edition)
+			item := Item{
+				TMDB_ID:      mediaItem.TMDB_ID,
+				LibraryTitle: mediaItem.LibraryTitle,
+				Edition:      mediaItem.Edition,
+				RatingKey:    mediaItem.RatingKey,
+				Type:         mediaItem.Type,
+				Title:        mediaItem.Title,
+				Year:         mediaItem.Year,
+			}
+
+			savedItem, saved := savedByKey[key]
+			switch {
+			case ignored[key] || mediaItem.IgnoredInDB:
+				item.Status = STATUS_IGNORED
+				library.Ignored++
+			case saved:
+				item.Status = STATUS_SAVED
+				for _, posterSet := range savedItem.PosterSets {
+					item.SetIDs = append(item.SetIDs, posterSet.ID)
+				}
+				item.Gaps = findGaps(savedItem)
+				library.Saved++
+				if len(item.Gaps) > 0 {
+					library.WithGaps++
+				}
+			case mediaItem.HasMediuxSets || cache.MediuxItems.CheckItemExists(mediaItem.Type, mediaItem.TMDB_ID):
+				item.Status = STATUS_MEDIUX_AVAILABLE
+				library.MediuxAvailable++
+			case localFolders.Has(mediaItem.TMDB_ID, mediaItem.Title, mediaItem.Year):
+				item.Status = STATUS_LOCAL_AVAILABLE
+				library.LocalAvailable++
+			default:
+				item.Status = STATUS_NO_SETS
+				library.NoSets++
+				if tmdbEnabled && mediaItem.TMDB_ID != "" {
+					item.TMDBFallback = true
+					library.TMDBFallback++
+				}
+			}
+			library.Total++
+
+			if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, item.Status) {
+				continue
+			}
+			if filter.GapsOnly && len(item.Gaps) == 0 {
+				continue
+			}
+			report.Items = append(report.Items, item)
+		}
+		report.Libraries = append(report.Libraries, library)
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		if report.Items[i].LibraryTitle != report.Items[j].LibraryTitle {
+			return report.Items[i].LibraryTitle < report.Items[j].LibraryTitle
+		}
+		return report.Items[i].Title < report.Items[j].Title
+	})
+
+	logAction.AppendResult("libraries", len(report.Libraries))
+	logAction.AppendResult("items", len(report.Items))
+	return report, logging.LogErrorInfo{}
+}
+
+// findGaps checks the newest season and episode of a saved show.
+// A gap is reported when a saved set selects season posters or titlecards, but no saved set has one for them.
+func findGaps(savedItem models.DBSavedItem) []Gap {
+	series := savedItem.MediaItem.Series
+	if savedItem.MediaItem.Type != "show" || series == nil {
+		return nil
+	}
+
+	var newestSeason *models.MediaItemSeason
+	for i := range series.Seasons {
+		season := &series.Seasons[i]
+		if season.SeasonNumber == 0 {
+			continue
+		}
+		if newestSeason == nil || season.SeasonNumber > newestSeason.SeasonNumber {
+			newestSeason = season
+		}
+	}
+	if newestSeason == nil {
+		return nil
+	}
+
+	var newestEpisode *models.MediaItemEpisode
+	for i := range newestSeason.Episodes {
+		episode := &newestSeason.Episodes[i]
+		if newestEpisode == nil || episode.EpisodeNumber > newestEpisode.EpisodeNumber {
+			newestEpisode = episode
+		}
+	}
+
+	wantsSeasonPoster, hasSeasonPoster := false, false
+	wantsTitlecard, hasTitlecard := false, false
+	for _, posterSet := range savedItem.PosterSets {
+		wantsSeasonPoster = wantsSeasonPoster || posterSet.SelectedTypes.SeasonPoster
+		wantsTitlecard = wantsTitlecard || posterSet.SelectedTypes.Titlecard
+		for _, image := range posterSet.Images {
+			if image.SeasonNumber == nil || *image.SeasonNumber != newestSeason.SeasonNumber {
+				continue
+			}
+			switch image.Type {
+			case "season_poster":
+				hasSeasonPoster = hasSeasonPoster || posterSet.SelectedTypes.SeasonPoster
+			case "titlecard":
+				if newestEpisode != nil && image.EpisodeNumber != nil && *image.EpisodeNumber == newestEpisode.EpisodeNumber {
+					hasTitlecard = hasTitlecard || posterSet.SelectedTypes.Titlecard
+				}
+			}
+		}
+	}
+
+	gaps := []Gap{}
+	if wantsSeasonPoster && !hasSeasonPoster {
+		gaps = append(gaps, Gap{ImageType: "season_poster", SeasonNumber: newestSeason.SeasonNumber})
+	}
+	if wantsTitlecard && newestEpisode != nil && !hasTitlecard {
+		episodeNumber := newestEpisode.EpisodeNumber
+		gaps = append(gaps, Gap{ImageType: "titlecard", SeasonNumber: newestSeason.SeasonNumber, EpisodeNumber: &episodeNumber})
+	}
+	return gaps
+}
+
+func itemKey(tmdbID, libraryTitle, edition string) string {
+	return libraryTitle + "|" + tmdbID + "|" + edition
+}
@@ -0,0 +1,52 @@
+package coverage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{"library_title", "type", "tmdb_id", "edition", "title", "year", "rating_key", "status", "tmdb_fallback", "set_ids", "gaps"}
+
+// WriteCSV writes one row per item of the report
+func WriteCSV(w io.Writer, report Report) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, item := range report.Items {
+		row := []string{
+			item.LibraryTitle,
+			item.Type,
+			item.TMDB_ID,
+			item.Edition,
+			item.Title,
+			strconv.Itoa(item.Year),
+			item.RatingKey,
+			string(item.Status),
+			strconv.FormatBool(item.TMDBFallback),
+			strings.Join(item.SetIDs, ";"),
+			formatGaps(item.Gaps),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatGaps lists the gaps as e.g. "S03 season_poster;S03E08 titlecard"
+func formatGaps(gaps []Gap) string {
+	parts := make([]string, 0, len(gaps))
+	for _, gap := range gaps {
+		if gap.EpisodeNumber != nil {
+			parts = append(parts, fmt.Sprintf("S%02dE%02d %s", gap.SeasonNumber, *gap.EpisodeNumber, gap.ImageType))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("S%02d %s", gap.SeasonNumber, gap.ImageType))
+	}
+	return strings.Join(parts, ";")
+}
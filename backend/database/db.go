@@ -7,9 +7,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 )
 
-const LATEST_DB_VERSION = 6
+const LATEST_DB_VERSION = 11
 
 var Client DB
 
@@ -27,6 +28,8 @@ type DB interface {
 
 	// Get Database Type
 	GetConfig() (config config.Config_Database)
+	// Check that the database connection is usable
+	Ping(ctx context.Context) (Err logging.LogErrorInfo)
 
 	// Create Main Tables
 	CreateTables(ctx context.Context) (Err logging.LogErrorInfo)
@@ -53,7 +56,7 @@ type DB interface {
 	Backup(ctx context.Context, currentVersion, newVersion int) (Err logging.LogErrorInfo)
 
 	// Upsert Converted Saved Item
-	UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) (Err logging.LogErrorInfo)
+	UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) (autoDownloadSetsChanged bool, Err logging.LogErrorInfo)
 
 	// Check Media Item Exists
 	CheckIfMediaItemExists(ctx context.Context, TMDB_ID, libraryTitle, edition string) (ignored bool, ignoredMode string, sets []models.DBSavedSet, logErr logging.LogErrorInfo)
@@ -86,7 +89,7 @@ type DB interface {
 	DeleteAllPosterSetsForMediaItem(ctx context.Context, tmdbID, libraryTitle, edition string) (Err logging.LogErrorInfo)
 
 	// Ignore Media Item
-	IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, edition, mode, currentSets string) (Err logging.LogErrorInfo)
+	IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, edition, mode, currentSets string, ignoreUntil time.Time) (Err logging.LogErrorInfo)
 
 	// Stop Ignoring Media Item
 	StopIgnoringMediaItem(ctx context.Context, TMDB_ID, libraryTitle, edition string) (Err logging.LogErrorInfo)
@@ -94,15 +97,45 @@ type DB interface {
 	// Get Temp Ignored Items
 	GetTempIgnoredItems(ctx context.Context) (items []models.MediaItem, Err logging.LogErrorInfo)
 
+	// Stop ignoring items whose 'until-date' ignore has expired
+	ExpireIgnoredItems(ctx context.Context, now time.Time) (expired []models.MediaItem, Err logging.LogErrorInfo)
+
 	// Update Media Item on_server flag
 	UpdateMediaItemOnServer(ctx context.Context, tmdbID string, libraryTitle string, edition string, onServer bool) (logErr logging.LogErrorInfo)
 
 	// Reconcile a Media Item whose Edition changed
 	ReconcileMediaItemEdition(ctx context.Context, tmdbID, libraryTitle, oldEdition string, updatedItem models.MediaItem) (Err logging.LogErrorInfo)
+
+	// Upsert the collection set applied to a media server collection
+	UpsertSavedCollection(ctx context.Context, saved models.DBSavedCollection) (Err logging.LogErrorInfo)
+
+	// Get All Saved Collections
+	GetAllSavedCollections(ctx context.Context) (collections []models.DBSavedCollection, Err logging.LogErrorInfo)
+
+	// Delete Saved Collection by Library Title and Rating Key
+	DeleteSavedCollection(ctx context.Context, libraryTitle, ratingKey string) (Err logging.LogErrorInfo)
+
+	// Upsert the fingerprint of an applied image
+	UpsertImageFingerprint(ctx context.Context, fingerprint models.DBImageFingerprint) (Err logging.LogErrorInfo)
+
+	// Get All Image Fingerprints
+	GetAllImageFingerprints(ctx context.Context) (fingerprints []models.DBImageFingerprint, Err logging.LogErrorInfo)
+
+	// Delete Image Fingerprints of a media item (all of them when slot is empty)
+	DeleteImageFingerprints(ctx context.Context, tmdbID, libraryTitle, edition, slot string) (Err logging.LogErrorInfo)
+
+	// Upsert an item on the wanted list
+	UpsertWantedItem(ctx context.Context, wanted models.DBWantedItem) (Err logging.LogErrorInfo)
+
+	// Get All Wanted Items
+	GetAllWantedItems(ctx context.Context) (items []models.DBWantedItem, Err logging.LogErrorInfo)
+
+	// Delete an item from the wanted list by TMDB ID and Type
+	DeleteWantedItem(ctx context.Context, tmdbID, itemType string) (Err logging.LogErrorInfo)
 }
 
 func NewDatabaseClient() (DB, logging.LogErrorInfo) {
-	dbConfig := config.Current.Database
+	dbConfig := config.Latest().Database
 	switch dbConfig.Type {
 	case "sqlite3":
 		return &SQliteDB{Config: dbConfig}, logging.LogErrorInfo{}
@@ -114,7 +147,7 @@ func NewDatabaseClient() (DB, logging.LogErrorInfo) {
 }
 
 func BuildDSN() (string, logging.LogErrorInfo) {
-	dbConfig := config.Current.Database
+	dbConfig := config.Latest().Database
 	switch dbConfig.Type {
 	case "sqlite3":
 		return dbConfig.Path, logging.LogErrorInfo{}
@@ -149,11 +182,18 @@ func Init(ctx context.Context) (newDB bool, Err logging.LogErrorInfo) {
 
 func GetConfig() (cfg config.Config_Database) {
 	if Client == nil {
-		return config.Current.Database
+		return config.Latest().Database
 	}
 	return Client.GetConfig()
 }
 
+func Ping(ctx context.Context) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	return Client.Ping(ctx)
+}
+
 func CreateVersionTable(ctx context.Context) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
@@ -214,7 +254,12 @@ func UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) (Err loggi
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.UpsertSavedItem(ctx, newItem)
+	// Most upserts only record a new download, the watched sets only change with the AutoDownload sets
+	autoDownloadSetsChanged, Err := Client.UpsertSavedItem(ctx, newItem)
+	if Err.Message == "" && autoDownloadSetsChanged {
+		notifySavedSetsChanged()
+	}
+	return Err
 }
 
 func CheckIfMediaItemExists(ctx context.Context, TMDB_ID, libraryTitle, edition string) (ignored bool, ignoreMode string, sets []models.DBSavedSet, logErr logging.LogErrorInfo) {
@@ -249,7 +294,11 @@ func DeleteMediaItemAndIgnoredStatus(ctx context.Context, TMDB_ID, libraryTitle,
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.DeleteMediaItemAndIgnoredStatus(ctx, TMDB_ID, libraryTitle, edition)
+	Err = Client.DeleteMediaItemAndIgnoredStatus(ctx, TMDB_ID, libraryTitle, edition)
+	if Err.Message == "" {
+		notifySavedSetsChanged()
+	}
+	return Err
 }
 
 func GetAllSavedSets(ctx context.Context, dbFilter models.DBFilter) (out PagedSavedItems, logErr logging.LogErrorInfo) {
@@ -277,21 +326,29 @@ func DeletePosterSetForMediaItem(ctx context.Context, tmdbID, libraryTitle, edit
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.DeletePosterSetForMediaItem(ctx, tmdbID, libraryTitle, edition, setID)
+	Err = Client.DeletePosterSetForMediaItem(ctx, tmdbID, libraryTitle, edition, setID)
+	if Err.Message == "" {
+		notifySavedSetsChanged()
+	}
+	return Err
 }
 
 func DeleteAllPosterSetsForMediaItem(ctx context.Context, tmdbID, libraryTitle, edition string) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.DeleteAllPosterSetsForMediaItem(ctx, tmdbID, libraryTitle, edition)
+	Err = Client.DeleteAllPosterSetsForMediaItem(ctx, tmdbID, libraryTitle, edition)
+	if Err.Message == "" {
+		notifySavedSetsChanged()
+	}
+	return Err
 }
 
-func IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, edition, mode, currentSets string) (Err logging.LogErrorInfo) {
+func IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, edition, mode, currentSets string, ignoreUntil time.Time) (Err logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
 	}
-	return Client.IgnoreMediaItem(ctx, tmdbID, libraryTitle, edition, mode, currentSets)
+	return Client.IgnoreMediaItem(ctx, tmdbID, libraryTitle, edition, mode, currentSets, ignoreUntil)
 }
 
 func StopIgnoringMediaItem(ctx context.Context, TMDB_ID, libraryTitle, edition string) (Err logging.LogErrorInfo) {
@@ -308,6 +365,13 @@ func GetTempIgnoredItems(ctx context.Context) (items []models.MediaItem, Err log
 	return Client.GetTempIgnoredItems(ctx)
 }
 
+func ExpireIgnoredItems(ctx context.Context, now time.Time) (expired []models.MediaItem, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return []models.MediaItem{}, logging.Error_DBClientNotInitialized()
+	}
+	return Client.ExpireIgnoredItems(ctx, now)
+}
+
 func UpdateMediaItemOnServer(ctx context.Context, tmdbID string, libraryTitle string, edition string, onServer bool) (logErr logging.LogErrorInfo) {
 	if Client == nil {
 		return logging.Error_DBClientNotInitialized()
@@ -321,3 +385,66 @@ func ReconcileMediaItemEdition(ctx context.Context, tmdbID, libraryTitle, oldEdi
 	}
 	return Client.ReconcileMediaItemEdition(ctx, tmdbID, libraryTitle, oldEdition, updatedItem)
 }
+
+func UpsertSavedCollection(ctx context.Context, saved models.DBSavedCollection) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	return Client.UpsertSavedCollection(ctx, saved)
+}
+
+func GetAllSavedCollections(ctx context.Context) (collections []models.DBSavedCollection, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return []models.DBSavedCollection{}, logging.Error_DBClientNotInitialized()
+	}
+	return Client.GetAllSavedCollections(ctx)
+}
+
+func DeleteSavedCollection(ctx context.Context, libraryTitle, ratingKey string) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	return Client.DeleteSavedCollection(ctx, libraryTitle, ratingKey)
+}
+
+func UpsertImageFingerprint(ctx context.Context, fingerprint models.DBImageFingerprint) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	return Client.UpsertImageFingerprint(ctx, fingerprint)
+}
+
+func GetAllImageFingerprints(ctx context.Context) (fingerprints []models.DBImageFingerprint, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return []models.DBImageFingerprint{}, logging.Error_DBClientNotInitialized()
+	}
+	return Client.GetAllImageFingerprints(ctx)
+}
+
+func DeleteImageFingerprints(ctx context.Context, tmdbID, libraryTitle, edition, slot string) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	return Client.DeleteImageFingerprints(ctx, tmdbID, libraryTitle, edition, slot)
+}
+
+func UpsertWantedItem(ctx context.Context, wanted models.DBWantedItem) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	return Client.UpsertWantedItem(ctx, wanted)
+}
+
+func GetAllWantedItems(ctx context.Context) (items []models.DBWantedItem, Err logging.LogErrorInfo) {
+	if Client == nil {
+		return []models.DBWantedItem{}, logging.Error_DBClientNotInitialized()
+	}
+	return Client.GetAllWantedItems(ctx)
+}
+
+func DeleteWantedItem(ctx context.Context, tmdbID, itemType string) (Err logging.LogErrorInfo) {
+	if Client == nil {
+		return logging.Error_DBClientNotInitialized()
+	}
+	return Client.DeleteWantedItem(ctx, tmdbID, itemType)
+}
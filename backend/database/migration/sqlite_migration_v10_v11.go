@@ -0,0 +1,91 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_10_to_11 adds the 'until-date' ignore mode and the ignore_until column to IgnoredItems.
+// SQLite can't change a CHECK constraint in place, so the table is rebuilt.
+func migrate_10_to_11(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v10 to v11", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 10).Int("To Version", 11).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 10, 11)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	// Get DB connection
+	conn, _, getDBConnErr := database.GetDBConnection(ctx)
+	if getDBConnErr.Message != "" {
+		return getDBConnErr
+	}
+
+	ignoreUntilColumnExists, checkColumnErr := checkColumnExists(ctx, "IgnoredItems", "ignore_until")
+	if checkColumnErr.Message != "" {
+		return checkColumnErr
+	}
+
+	if !ignoreUntilColumnExists {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			logAction.SetError("Failed to begin transaction for adding ignore_until column", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+
+		if _, err = tx.ExecContext(ctx, `ALTER TABLE IgnoredItems RENAME TO IgnoredItems_old;`); err != nil {
+			tx.Rollback()
+			logAction.SetError("Failed to rename IgnoredItems table", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+		if _, err = tx.ExecContext(ctx, `
+			CREATE TABLE IgnoredItems (
+				tmdb_id TEXT NOT NULL,
+				library_title TEXT NOT NULL,
+				edition TEXT NOT NULL DEFAULT '',
+				mode TEXT NOT NULL CHECK (mode IN ('always','until-set-available','until-new-set-available','until-date')),
+				current_sets TEXT NOT NULL DEFAULT '[]',
+				ignore_until DATETIME,
+				PRIMARY KEY (tmdb_id, library_title, edition)
+			) WITHOUT ROWID;
+		`); err != nil {
+			tx.Rollback()
+			logAction.SetError("Failed to create new IgnoredItems table with ignore_until column", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+		if _, err = tx.ExecContext(ctx, `
+			INSERT INTO IgnoredItems (tmdb_id, library_title, edition, mode, current_sets)
+			SELECT tmdb_id, library_title, edition, mode, current_sets
+			FROM IgnoredItems_old;
+		`); err != nil {
+			tx.Rollback()
+			logAction.SetError("Failed to copy data into new IgnoredItems table", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+		if _, err = tx.ExecContext(ctx, `DROP TABLE IgnoredItems_old;`); err != nil {
+			tx.Rollback()
+			logAction.SetError("Failed to drop old IgnoredItems table", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+		// Dropping the old table dropped its index too
+		if _, err = tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_ignoreditems_mode ON IgnoredItems(mode);`); err != nil {
+			tx.Rollback()
+			logAction.SetError("Failed to recreate IgnoredItems mode index", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+
+		if err = tx.Commit(); err != nil {
+			logAction.SetError("Failed to commit transaction for adding ignore_until column", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v10.0 to v11.0 completed successfully")
+	return Err
+}
@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_6_to_7 adds a "source" column to PosterSets so that each saved set
+// records which image source (mediux, tmdb) it came from. Existing rows default
+// to 'mediux', except TMDB fallback sets which are identified by their set ID prefix.
+func migrate_6_to_7(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v6 to v7", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 6).Int("To Version", 7).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 6, 7)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	// Get DB connection
+	conn, _, getDBConnErr := database.GetDBConnection(ctx)
+	if getDBConnErr.Message != "" {
+		return getDBConnErr
+	}
+
+	// Check if the "source" column already exists to avoid duplicate column error
+	sourceColumnExists, checkColumnErr := checkColumnExists(ctx, "PosterSets", "source")
+	if checkColumnErr.Message != "" {
+		return checkColumnErr
+	}
+
+	if !sourceColumnExists {
+		// Add a new column "source" to the PosterSets table with a default value of 'mediux'
+		alterTableQuery := `ALTER TABLE PosterSets ADD COLUMN source TEXT NOT NULL DEFAULT 'mediux';`
+		_, err := conn.ExecContext(ctx, alterTableQuery)
+		if err != nil {
+			logAction.SetError("Failed to alter PosterSets table to add source column", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+
+		// TMDB fallback sets were saved before the source was tracked
+		backfillQuery := `UPDATE PosterSets SET source = 'tmdb' WHERE set_id LIKE 'tmdb\_%' ESCAPE '\';`
+		_, err = conn.ExecContext(ctx, backfillQuery)
+		if err != nil {
+			logAction.SetError("Failed to backfill source column for TMDB sets", "", map[string]any{"error": err.Error()})
+			return *logAction.Error
+		}
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v6.0 to v7.0 completed successfully")
+	return Err
+}
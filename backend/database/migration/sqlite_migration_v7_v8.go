@@ -0,0 +1,38 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_7_to_8 adds the SavedCollections table, which records the collection set
+// applied to a media server collection so that AutoDownload can keep it up to date.
+func migrate_7_to_8(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v7 to v8", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 7).Int("To Version", 8).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 7, 8)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	// Get DB connection
+	conn, _, getDBConnErr := database.GetDBConnection(ctx)
+	if getDBConnErr.Message != "" {
+		return getDBConnErr
+	}
+
+	_, err := conn.ExecContext(ctx, database.CreateSavedCollectionsTableQuery)
+	if err != nil {
+		logAction.SetError("Failed to create SavedCollections table", "", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v7.0 to v8.0 completed successfully")
+	return Err
+}
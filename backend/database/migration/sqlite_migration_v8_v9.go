@@ -0,0 +1,38 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_8_to_9 adds the ImageFingerprints table, which records a fingerprint of every applied image
+// so that images changed outside of AURA can be detected.
+func migrate_8_to_9(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v8 to v9", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 8).Int("To Version", 9).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 8, 9)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	// Get DB connection
+	conn, _, getDBConnErr := database.GetDBConnection(ctx)
+	if getDBConnErr.Message != "" {
+		return getDBConnErr
+	}
+
+	_, err := conn.ExecContext(ctx, database.CreateImageFingerprintsTableQuery)
+	if err != nil {
+		logAction.SetError("Failed to create ImageFingerprints table", "", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v8.0 to v9.0 completed successfully")
+	return Err
+}
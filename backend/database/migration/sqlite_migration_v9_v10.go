@@ -0,0 +1,38 @@
+package migration
+
+import (
+	"aura/database"
+	"aura/logging"
+	"context"
+)
+
+// migrate_9_to_10 adds the WantedItems table, which keeps the sets chosen for items
+// that are not in the library yet.
+func migrate_9_to_10(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Migrating Database from v9 to v10", logging.LevelInfo)
+	defer logAction.Complete()
+	logging.LOGGER.Info().Timestamp().Int("From Version", 9).Int("To Version", 10).Msg("Starting database migration")
+
+	Err = logging.LogErrorInfo{}
+
+	// Create a backup of the current database
+	backupErr := database.Backup(ctx, 9, 10)
+	if backupErr.Message != "" {
+		return backupErr
+	}
+
+	// Get DB connection
+	conn, _, getDBConnErr := database.GetDBConnection(ctx)
+	if getDBConnErr.Message != "" {
+		return getDBConnErr
+	}
+
+	_, err := conn.ExecContext(ctx, database.CreateWantedItemsTableQuery)
+	if err != nil {
+		logAction.SetError("Failed to create WantedItems table", "", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	logging.LOGGER.Info().Timestamp().Msg("Database migration v9.0 to v10.0 completed successfully")
+	return Err
+}
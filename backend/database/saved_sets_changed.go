@@ -0,0 +1,26 @@
+package database
+
+import "sync"
+
+var savedSetsChanged = struct {
+	mu        sync.Mutex
+	listeners []func()
+}{}
+
+// OnSavedSetsChanged registers fn to be called after the saved sets with AutoDownload on may have changed.
+// Deletes always call it, upserts only when they add or remove an AutoDownload set.
+// fn is called on the goroutine that changed the saved sets, so it must not block.
+func OnSavedSetsChanged(fn func()) {
+	savedSetsChanged.mu.Lock()
+	defer savedSetsChanged.mu.Unlock()
+	savedSetsChanged.listeners = append(savedSetsChanged.listeners, fn)
+}
+
+func notifySavedSetsChanged() {
+	savedSetsChanged.mu.Lock()
+	listeners := savedSetsChanged.listeners
+	savedSetsChanged.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
@@ -78,6 +78,7 @@ func (s *SQliteDB) CheckIfMediaItemExists(ctx context.Context, TMDB_ID, libraryT
 	query := `
         SELECT DISTINCT
             ps.set_id,
+            ps.source,
             ps.user,
             si.poster_selected,
             si.backdrop_selected,
@@ -107,7 +108,7 @@ func (s *SQliteDB) CheckIfMediaItemExists(ctx context.Context, TMDB_ID, libraryT
 	for rows.Next() {
 		var set models.DBSavedSet
 		var posterSelected, backdropSelected, seasonPosterSelected, specialSeasonPosterSelected, titlecardSelected int
-		if err := rows.Scan(&set.ID, &set.UserCreated, &posterSelected, &backdropSelected, &seasonPosterSelected, &specialSeasonPosterSelected, &titlecardSelected); err != nil {
+		if err := rows.Scan(&set.ID, &set.Source, &set.UserCreated, &posterSelected, &backdropSelected, &seasonPosterSelected, &specialSeasonPosterSelected, &titlecardSelected); err != nil {
 			_, logAction := logging.AddSubActionToContext(ctx, "Scanning media item row", logging.LevelError)
 			defer logAction.Complete()
 			logAction.SetError("Failed to scan media item row", err.Error(), map[string]any{
@@ -23,6 +23,9 @@ func (s *SQliteDB) CreateTables(ctx context.Context) (Err logging.LogErrorInfo)
 		v2_CreateSavedItemsTable,
 		v2_CreateIgnoredItemsTable,
 		v2_AddIndexesToNewTables,
+		v8_CreateSavedCollectionsTable,
+		v9_CreateImageFingerprintsTable,
+		v10_CreateWantedItemsTable,
 	}
 
 	for _, step := range steps {
@@ -200,7 +203,8 @@ CREATE TABLE PosterSets (
     title TEXT NOT NULL,
     user TEXT NOT NULL,
     date_created DATETIME,
-    date_updated DATETIME
+    date_updated DATETIME,
+    source TEXT NOT NULL DEFAULT 'mediux'
 );
 `
 	_, err := conn.ExecContext(ctx, query)
@@ -314,11 +318,15 @@ CREATE TABLE IgnoredItems (
     -- 'always' = persist until user un-ignores
     -- 'until-set-available'   = cleared by cron job when a set becomes available
 	-- 'until-new-set-available' = never cleared by cron job, but user notified when a new set becomes available and given the option to clear the ignore manually
-    mode TEXT NOT NULL CHECK (mode IN ('always','until-set-available','until-new-set-available')),
+	-- 'until-date' = cleared by cron job once ignore_until has passed
+    mode TEXT NOT NULL CHECK (mode IN ('always','until-set-available','until-new-set-available','until-date')),
 
 	-- Sets that currently available for this item (array stored as JSON string)
 	current_sets TEXT NOT NULL DEFAULT '[]',
 
+	-- When the ignore expires (only used for 'until-date' mode)
+	ignore_until DATETIME,
+
     PRIMARY KEY (tmdb_id, library_title, edition)
 ) WITHOUT ROWID;
 `
@@ -364,3 +372,125 @@ CREATE INDEX idx_ignoreditems_mode ON IgnoredItems(mode);
 
 	return Err
 }
+
+func v8_CreateSavedCollectionsTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating SavedCollections Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	_, err := conn.ExecContext(ctx, CreateSavedCollectionsTableQuery)
+	if err != nil {
+		logAction.SetError("Failed to create SavedCollections table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": CreateSavedCollectionsTableQuery,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+// CreateSavedCollectionsTableQuery is shared with the v7 to v8 migration
+const CreateSavedCollectionsTableQuery = `
+CREATE TABLE IF NOT EXISTS SavedCollections (
+    library_title TEXT NOT NULL,
+    rating_key TEXT NOT NULL,
+    collection_tmdb_id TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+
+    -- The collection set that was applied
+    set_id TEXT NOT NULL,
+    set_title TEXT NOT NULL DEFAULT '',
+    user TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT 'mediux',
+    date_updated DATETIME,
+
+    -- Collection images of the set at the last download (array stored as JSON string)
+    images TEXT NOT NULL DEFAULT '[]',
+
+    poster_selected INTEGER NOT NULL DEFAULT 0 CHECK (poster_selected IN (0,1)),
+    backdrop_selected INTEGER NOT NULL DEFAULT 0 CHECK (backdrop_selected IN (0,1)),
+    autodownload INTEGER NOT NULL DEFAULT 0 CHECK (autodownload IN (0,1)),
+    last_downloaded DATETIME NOT NULL,
+
+    PRIMARY KEY (library_title, rating_key)
+) WITHOUT ROWID;
+`
+
+func v9_CreateImageFingerprintsTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating ImageFingerprints Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	_, err := conn.ExecContext(ctx, CreateImageFingerprintsTableQuery)
+	if err != nil {
+		logAction.SetError("Failed to create ImageFingerprints table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": CreateImageFingerprintsTableQuery,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+// CreateImageFingerprintsTableQuery is shared with the v8 to v9 migration
+const CreateImageFingerprintsTableQuery = `
+CREATE TABLE IF NOT EXISTS ImageFingerprints (
+    tmdb_id TEXT NOT NULL,
+    library_title TEXT NOT NULL,
+    edition TEXT NOT NULL DEFAULT '',
+    slot TEXT NOT NULL,
+
+    -- The image that was applied (stored as JSON string) and the set it came from
+    image_file TEXT NOT NULL DEFAULT '{}',
+    set_id TEXT NOT NULL DEFAULT '',
+
+    -- SHA-256 and perceptual hash of the source image that AURA applied (not of the media server's copy).
+    -- The media server may re-encode the image, so drift checks compare the perceptual hash.
+    hash TEXT NOT NULL,
+    perceptual_hash TEXT NOT NULL DEFAULT '',
+
+    applied_at DATETIME NOT NULL,
+    last_checked_at DATETIME,
+    drifted INTEGER NOT NULL DEFAULT 0 CHECK (drifted IN (0,1)),
+
+    PRIMARY KEY (tmdb_id, library_title, edition, slot)
+) WITHOUT ROWID;
+`
+
+func v10_CreateWantedItemsTable(ctx context.Context, conn *sql.DB) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Creating WantedItems Table", logging.LevelTrace)
+	defer logAction.Complete()
+	Err = logging.LogErrorInfo{}
+
+	_, err := conn.ExecContext(ctx, CreateWantedItemsTableQuery)
+	if err != nil {
+		logAction.SetError("Failed to create WantedItems table", err.Error(), map[string]any{
+			"error": err.Error(),
+			"query": CreateWantedItemsTableQuery,
+		})
+		return *logAction.Error
+	}
+
+	return Err
+}
+
+// CreateWantedItemsTableQuery is shared with the v9 to v10 migration
+const CreateWantedItemsTableQuery = `
+CREATE TABLE IF NOT EXISTS WantedItems (
+    tmdb_id TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('movie','show')),
+    title TEXT NOT NULL DEFAULT '',
+    year INTEGER NOT NULL DEFAULT 0,
+    library_title TEXT NOT NULL,
+
+    -- The chosen set (stored as JSON string), its images are fetched when the item lands
+    set_info TEXT NOT NULL DEFAULT '{}',
+    selected_types TEXT NOT NULL DEFAULT '{}',
+    autodownload INTEGER NOT NULL DEFAULT 0 CHECK (autodownload IN (0,1)),
+    created_at DATETIME NOT NULL,
+
+    PRIMARY KEY (tmdb_id, type)
+) WITHOUT ROWID;
+`
@@ -202,6 +202,7 @@ SELECT
           'title', ps.title,
           'type', ps.type,
           'user_created', ps.user,
+          'source', ps.source,
 
           'date_created', replace(ps.date_created, ' ', 'T'),
           'date_updated', replace(ps.date_updated, ' ', 'T'),
@@ -226,6 +227,7 @@ SELECT
                 json_object(
                   'id', im.image_id,
                   'type', im.image_type,
+                  'source', ps.source,
                   'modified', replace(im.image_last_updated, ' ', 'T'),
                   'season_number', im.image_season_number,
                   'episode_number', im.image_episode_number
@@ -5,7 +5,9 @@ import (
 	"aura/logging"
 	"aura/models"
 	"context"
+	"database/sql"
 	"strings"
+	"time"
 )
 
 func (s *SQliteDB) GetTempIgnoredItems(ctx context.Context) (items []models.MediaItem, Err logging.LogErrorInfo) {
@@ -16,7 +18,7 @@ func (s *SQliteDB) GetTempIgnoredItems(ctx context.Context) (items []models.Medi
 
 	// Query the database for temp ignored items
 	rows, err := s.conn.QueryContext(ctx, `
-        SELECT tmdb_id, library_title, edition, mode, current_sets
+        SELECT tmdb_id, library_title, edition, mode, current_sets, ignore_until
         FROM IgnoredItems
         WHERE mode = 'until-set-available' OR mode = 'until-new-set-available';
     `)
@@ -33,8 +35,9 @@ func (s *SQliteDB) GetTempIgnoredItems(ctx context.Context) (items []models.Medi
 	var edition string
 	var mode string
 	var currentSets string
+	var ignoreUntil sql.NullTime
 	for rows.Next() {
-		if err := rows.Scan(&tmdbID, &libraryTitle, &edition, &mode, &currentSets); err != nil {
+		if err := rows.Scan(&tmdbID, &libraryTitle, &edition, &mode, &currentSets, &ignoreUntil); err != nil {
 			return nil, logging.LogErrorInfo{
 				Message: "Failed to scan temp ignored item",
 				Detail:  map[string]any{"error": err.Error()},
@@ -52,13 +55,101 @@ func (s *SQliteDB) GetTempIgnoredItems(ctx context.Context) (items []models.Medi
 		}
 		cachedItem.IgnoredMode = mode
 		cachedItem.IgnoredSets = strings.Split(currentSets, ",")
+		cachedItem.IgnoredUntil = nil
+		if ignoreUntil.Valid {
+			until := ignoreUntil.Time
+			cachedItem.IgnoredUntil = &until
+		}
 		items = append(items, *cachedItem)
 	}
 
 	return items, Err
 }
 
-func (s *SQliteDB) IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, edition, mode, currentSets string) (Err logging.LogErrorInfo) {
+// ExpireIgnoredItems removes the 'until-date' ignores that expired at or before now and returns the expired items.
+// Items that are not in the library cache are still expired, they are returned with only their TMDB ID, library and edition.
+func (s *SQliteDB) ExpireIgnoredItems(ctx context.Context, now time.Time) (expired []models.MediaItem, Err logging.LogErrorInfo) {
+	Err = logging.LogErrorInfo{}
+	if s == nil || s.conn == nil {
+		return nil, logging.LogErrorInfo{Message: "Database connection is nil"}
+	}
+
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, logging.LogErrorInfo{
+			Message: "Failed to begin transaction for expiring ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	defer tx.Rollback()
+
+	// ignore_until is stored in UTC, so comparing with a UTC time works on the stored text
+	now = now.UTC()
+	rows, err := tx.QueryContext(ctx, `
+        SELECT tmdb_id, library_title, edition, ignore_until
+        FROM IgnoredItems
+        WHERE mode = 'until-date' AND ignore_until IS NOT NULL AND ignore_until <= ?;
+    `, now)
+	if err != nil {
+		return nil, logging.LogErrorInfo{
+			Message: "Failed to get expired ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	for rows.Next() {
+		var tmdbID, libraryTitle, edition string
+		var ignoreUntil time.Time
+		if err := rows.Scan(&tmdbID, &libraryTitle, &edition, &ignoreUntil); err != nil {
+			rows.Close()
+			return nil, logging.LogErrorInfo{
+				Message: "Failed to scan expired ignored item",
+				Detail:  map[string]any{"error": err.Error()},
+			}
+		}
+		item := models.MediaItem{TMDB_ID: tmdbID, LibraryTitle: libraryTitle, Edition: edition}
+		if cachedItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBIDAndEdition(libraryTitle, tmdbID, edition); found {
+			item = *cachedItem
+		}
+		item.IgnoredMode = "until-date"
+		item.IgnoredUntil = &ignoreUntil
+		expired = append(expired, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, logging.LogErrorInfo{
+			Message: "Failed to read expired ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	if len(expired) == 0 {
+		return expired, Err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        DELETE FROM IgnoredItems
+        WHERE mode = 'until-date' AND ignore_until IS NOT NULL AND ignore_until <= ?;
+    `, now); err != nil {
+		return nil, logging.LogErrorInfo{
+			Message: "Failed to delete expired ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, logging.LogErrorInfo{
+			Message: "Failed to commit expired ignored items",
+			Detail:  map[string]any{"error": err.Error()},
+		}
+	}
+
+	logging.LOGGER.Debug().Timestamp().
+		Str("op", "DELETE").
+		Str("table", "IgnoredItems").
+		Int("count", len(expired)).
+		Msg("Stopped ignoring expired media items")
+	return expired, Err
+}
+
+func (s *SQliteDB) IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, edition, mode, currentSets string, ignoreUntil time.Time) (Err logging.LogErrorInfo) {
 	Err = logging.LogErrorInfo{}
 
 	if s == nil || s.conn == nil {
@@ -77,16 +168,27 @@ func (s *SQliteDB) IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, ed
 		}
 	}
 
-	if mode != "always" && mode != "until-set-available" && mode != "until-new-set-available" {
+	if mode != "always" && mode != "until-set-available" && mode != "until-new-set-available" && mode != "until-date" {
 		return logging.LogErrorInfo{
 			Message: "Invalid ignore mode",
-			Detail:  map[string]any{"mode": mode, "valid_modes": []string{"always", "until-set-available", "until-new-set-available"}},
+			Detail:  map[string]any{"mode": mode, "valid_modes": []string{"always", "until-set-available", "until-new-set-available", "until-date"}},
 		}
 	} else if mode == "until-new-set-available" && currentSets == "" {
 		return logging.LogErrorInfo{
 			Message: "current_sets is required for 'until-new-set-available' mode",
 			Detail:  map[string]any{"mode": mode, "current_sets": currentSets},
 		}
+	} else if mode == "until-date" && ignoreUntil.IsZero() {
+		return logging.LogErrorInfo{
+			Message: "ignore_until is required for 'until-date' mode",
+			Detail:  map[string]any{"mode": mode},
+		}
+	}
+
+	// The expiry only applies to 'until-date' mode
+	var until sql.NullTime
+	if mode == "until-date" {
+		until = sql.NullTime{Time: ignoreUntil.UTC(), Valid: true}
 	}
 
 	// Determine insert vs update for logging
@@ -103,12 +205,13 @@ func (s *SQliteDB) IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, ed
 	}
 
 	_, err := s.conn.ExecContext(ctx, `
-        INSERT INTO IgnoredItems (tmdb_id, library_title, edition, mode, current_sets)
-        VALUES (?, ?, ?, ?, ?)
+        INSERT INTO IgnoredItems (tmdb_id, library_title, edition, mode, current_sets, ignore_until)
+        VALUES (?, ?, ?, ?, ?, ?)
         ON CONFLICT(tmdb_id, library_title, edition) DO UPDATE SET
             mode = excluded.mode,
-            current_sets = excluded.current_sets;
-    `, tmdbID, libraryTitle, edition, mode, currentSets)
+            current_sets = excluded.current_sets,
+            ignore_until = excluded.ignore_until;
+    `, tmdbID, libraryTitle, edition, mode, currentSets, until)
 	if err != nil {
 		return logging.LogErrorInfo{
 			Message: "Failed to ignore media item",
@@ -124,6 +227,7 @@ func (s *SQliteDB) IgnoreMediaItem(ctx context.Context, tmdbID, libraryTitle, ed
 		Str("edition", edition).
 		Str("mode", mode).
 		Str("current_sets", currentSets).
+		Time("ignore_until", until.Time).
 		Msg("Ignored media item")
 
 	return Err
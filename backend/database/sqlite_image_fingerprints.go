@@ -0,0 +1,166 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+func (s *SQliteDB) UpsertImageFingerprint(ctx context.Context, fingerprint models.DBImageFingerprint) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Upserting Image Fingerprint %s | %s | %s", fingerprint.LibraryTitle, fingerprint.TMDB_ID, fingerprint.Slot), logging.LevelTrace)
+	defer logAction.Complete()
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	if fingerprint.TMDB_ID == "" || fingerprint.LibraryTitle == "" || fingerprint.Slot == "" {
+		logAction.SetError("tmdb_id, library_title and slot are required", "", map[string]any{
+			"tmdb_id":       fingerprint.TMDB_ID,
+			"library_title": fingerprint.LibraryTitle,
+			"slot":          fingerprint.Slot,
+		})
+		return *logAction.Error
+	}
+
+	imageFile, err := json.Marshal(fingerprint.ImageFile)
+	if err != nil {
+		logAction.SetError("Failed to marshal image file", err.Error(), nil)
+		return *logAction.Error
+	}
+
+	var lastChecked sql.NullTime
+	if !fingerprint.LastCheckedAt.IsZero() {
+		lastChecked = sql.NullTime{Time: fingerprint.LastCheckedAt, Valid: true}
+	}
+
+	_, err = s.conn.ExecContext(ctx, `
+INSERT INTO ImageFingerprints (
+  tmdb_id, library_title, edition, slot,
+  image_file, set_id, hash, perceptual_hash,
+  applied_at, last_checked_at, drifted
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(tmdb_id, library_title, edition, slot) DO UPDATE SET
+  image_file      = excluded.image_file,
+  set_id          = excluded.set_id,
+  hash            = excluded.hash,
+  perceptual_hash = excluded.perceptual_hash,
+  applied_at      = excluded.applied_at,
+  last_checked_at = excluded.last_checked_at,
+  drifted         = excluded.drifted;
+`,
+		fingerprint.TMDB_ID,
+		fingerprint.LibraryTitle,
+		fingerprint.Edition,
+		fingerprint.Slot,
+		string(imageFile),
+		fingerprint.SetID,
+		fingerprint.Hash,
+		fingerprint.PerceptualHash,
+		fingerprint.AppliedAt,
+		lastChecked,
+		boolToInt(fingerprint.Drifted),
+	)
+	if err != nil {
+		logAction.SetError("DB: UPSERT ImageFingerprints failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+func (s *SQliteDB) GetAllImageFingerprints(ctx context.Context) (fingerprints []models.DBImageFingerprint, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting All Image Fingerprints", logging.LevelDebug)
+	defer logAction.Complete()
+
+	fingerprints = []models.DBImageFingerprint{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return fingerprints, *logAction.Error
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+SELECT tmdb_id, library_title, edition, slot,
+       image_file, set_id, hash, perceptual_hash,
+       applied_at, last_checked_at, drifted
+FROM ImageFingerprints
+ORDER BY library_title, tmdb_id, edition, slot;
+`)
+	if err != nil {
+		logAction.SetError("Failed to query image fingerprints", err.Error(), map[string]any{"error": err.Error()})
+		return fingerprints, *logAction.Error
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			fingerprint models.DBImageFingerprint
+			imageFile   string
+			lastChecked sql.NullTime
+			drifted     int
+		)
+		if err := rows.Scan(
+			&fingerprint.TMDB_ID,
+			&fingerprint.LibraryTitle,
+			&fingerprint.Edition,
+			&fingerprint.Slot,
+			&imageFile,
+			&fingerprint.SetID,
+			&fingerprint.Hash,
+			&fingerprint.PerceptualHash,
+			&fingerprint.AppliedAt,
+			&lastChecked,
+			&drifted,
+		); err != nil {
+			logAction.SetError("Failed to scan image fingerprint", err.Error(), map[string]any{"error": err.Error()})
+			return fingerprints, *logAction.Error
+		}
+
+		if err := json.Unmarshal([]byte(imageFile), &fingerprint.ImageFile); err != nil {
+			logAction.AppendWarning("image_file_decode_error", fmt.Sprintf("%s | %s: %s", fingerprint.TMDB_ID, fingerprint.Slot, err.Error()))
+		}
+		if lastChecked.Valid {
+			fingerprint.LastCheckedAt = lastChecked.Time
+		}
+		fingerprint.Drifted = drifted == 1
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	if err := rows.Err(); err != nil {
+		logAction.SetError("Failed to read image fingerprints", err.Error(), map[string]any{"error": err.Error()})
+		return fingerprints, *logAction.Error
+	}
+
+	logAction.AppendResult("fingerprints", len(fingerprints))
+	return fingerprints, logging.LogErrorInfo{}
+}
+
+func (s *SQliteDB) DeleteImageFingerprints(ctx context.Context, tmdbID, libraryTitle, edition, slot string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Deleting Image Fingerprints %s | %s | %s", libraryTitle, tmdbID, slot), logging.LevelTrace)
+	defer logAction.Complete()
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	res, err := s.conn.ExecContext(ctx, `
+DELETE FROM ImageFingerprints
+WHERE tmdb_id = ?
+  AND library_title = ?
+  AND edition = ?
+  AND (? = '' OR slot = ?);
+`, tmdbID, libraryTitle, edition, slot, slot)
+	if err != nil {
+		logAction.SetError("Failed to delete image fingerprints", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	deleted, _ := res.RowsAffected()
+	logAction.AppendResult("deleted", deleted)
+
+	return logging.LogErrorInfo{}
+}
@@ -0,0 +1,25 @@
+package database
+
+import (
+	"aura/logging"
+	"context"
+)
+
+func (s *SQliteDB) Ping(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Pinging Database", logging.LevelTrace)
+	defer logAction.Complete()
+
+	if s.conn == nil {
+		logAction.SetError("Database connection is not open", "Check the logs for database initialization errors", nil)
+		return *logAction.Error
+	}
+
+	if err := s.conn.PingContext(ctx); err != nil {
+		logAction.SetError("Failed to ping database", "Ensure the database file is reachable and not locked", map[string]any{
+			"error": err.Error(),
+		})
+		return *logAction.Error
+	}
+
+	return logging.LogErrorInfo{}
+}
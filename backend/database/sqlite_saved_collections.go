@@ -0,0 +1,185 @@
+package database
+
+import (
+	"aura/imagesource"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (s *SQliteDB) UpsertSavedCollection(ctx context.Context, saved models.DBSavedCollection) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Upserting Saved Collection %s", utils.CollectionItemInfo(saved.CollectionItem)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	libraryTitle := strings.TrimSpace(saved.CollectionItem.LibraryTitle)
+	ratingKey := strings.TrimSpace(saved.CollectionItem.RatingKey)
+	if libraryTitle == "" || ratingKey == "" || saved.PosterSet.ID == "" {
+		logAction.SetError("library_title, rating_key and set_id are required", "", map[string]any{
+			"library_title": libraryTitle,
+			"rating_key":    ratingKey,
+			"set_id":        saved.PosterSet.ID,
+		})
+		return *logAction.Error
+	}
+
+	images, err := json.Marshal(saved.PosterSet.Images)
+	if err != nil {
+		logAction.SetError("Failed to marshal collection images", err.Error(), nil)
+		return *logAction.Error
+	}
+
+	q := `
+INSERT INTO SavedCollections (
+  library_title, rating_key, collection_tmdb_id, title,
+  set_id, set_title, user, source, date_updated, images,
+  poster_selected, backdrop_selected, autodownload, last_downloaded
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(library_title, rating_key) DO UPDATE SET
+  collection_tmdb_id = excluded.collection_tmdb_id,
+  title              = excluded.title,
+  set_id             = excluded.set_id,
+  set_title          = excluded.set_title,
+  user               = excluded.user,
+  source             = excluded.source,
+  date_updated       = excluded.date_updated,
+  images             = excluded.images,
+  poster_selected    = excluded.poster_selected,
+  backdrop_selected  = excluded.backdrop_selected,
+  autodownload       = excluded.autodownload,
+  last_downloaded    = excluded.last_downloaded;
+`
+	_, err = s.conn.ExecContext(ctx, q,
+		libraryTitle,
+		ratingKey,
+		saved.CollectionItem.TMDB_ID,
+		saved.CollectionItem.Title,
+		saved.PosterSet.ID,
+		saved.PosterSet.Title,
+		saved.PosterSet.UserCreated,
+		imagesource.GetSetSource(saved.PosterSet.BaseSetInfo),
+		saved.PosterSet.DateUpdated,
+		string(images),
+		boolToInt(saved.SelectedTypes.Poster),
+		boolToInt(saved.SelectedTypes.Backdrop),
+		boolToInt(saved.AutoDownload),
+		saved.LastDownloaded,
+	)
+	if err != nil {
+		logAction.SetError("DB: UPSERT SavedCollections failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+func (s *SQliteDB) GetAllSavedCollections(ctx context.Context) (collections []models.DBSavedCollection, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting All Saved Collections", logging.LevelDebug)
+	defer logAction.Complete()
+
+	collections = []models.DBSavedCollection{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return collections, *logAction.Error
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+SELECT library_title, rating_key, collection_tmdb_id, title,
+       set_id, set_title, user, source, date_updated, images,
+       poster_selected, backdrop_selected, autodownload, last_downloaded
+FROM SavedCollections
+ORDER BY library_title, title;
+`)
+	if err != nil {
+		logAction.SetError("Failed to query saved collections", err.Error(), map[string]any{"error": err.Error()})
+		return collections, *logAction.Error
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			saved          models.DBSavedCollection
+			dateUpdated    sql.NullTime
+			images         string
+			posterSelected int
+			backdropSel    int
+			autodownload   int
+			lastDownloaded time.Time
+		)
+		if err := rows.Scan(
+			&saved.CollectionItem.LibraryTitle,
+			&saved.CollectionItem.RatingKey,
+			&saved.CollectionItem.TMDB_ID,
+			&saved.CollectionItem.Title,
+			&saved.PosterSet.ID,
+			&saved.PosterSet.Title,
+			&saved.PosterSet.UserCreated,
+			&saved.PosterSet.Source,
+			&dateUpdated,
+			&images,
+			&posterSelected,
+			&backdropSel,
+			&autodownload,
+			&lastDownloaded,
+		); err != nil {
+			logAction.SetError("Failed to scan saved collection", err.Error(), map[string]any{"error": err.Error()})
+			return collections, *logAction.Error
+		}
+
+		saved.PosterSet.Type = "collection"
+		if dateUpdated.Valid {
+			saved.PosterSet.DateUpdated = dateUpdated.Time
+		}
+		saved.PosterSet.Images = []models.ImageFile{}
+		if err := json.Unmarshal([]byte(images), &saved.PosterSet.Images); err != nil {
+			logAction.AppendWarning("images_decode_error", fmt.Sprintf("%s: %s", saved.CollectionItem.RatingKey, err.Error()))
+		}
+		saved.SelectedTypes.Poster = posterSelected == 1
+		saved.SelectedTypes.Backdrop = backdropSel == 1
+		saved.AutoDownload = autodownload == 1
+		saved.LastDownloaded = lastDownloaded
+		collections = append(collections, saved)
+	}
+	if err := rows.Err(); err != nil {
+		logAction.SetError("Failed to read saved collections", err.Error(), map[string]any{"error": err.Error()})
+		return collections, *logAction.Error
+	}
+
+	logAction.AppendResult("collections", len(collections))
+	return collections, logging.LogErrorInfo{}
+}
+
+func (s *SQliteDB) DeleteSavedCollection(ctx context.Context, libraryTitle, ratingKey string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Deleting Saved Collection %s | %s", libraryTitle, ratingKey), logging.LevelDebug)
+	defer logAction.Complete()
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	res, err := s.conn.ExecContext(ctx, `
+DELETE FROM SavedCollections
+WHERE library_title = ?
+  AND rating_key = ?;
+`, libraryTitle, ratingKey)
+	if err != nil {
+		logAction.SetError("Failed to delete saved collection", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	deleted, _ := res.RowsAffected()
+	logAction.AppendResult("deleted", deleted)
+
+	return logging.LogErrorInfo{}
+}
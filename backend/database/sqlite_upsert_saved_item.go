@@ -1,16 +1,18 @@
 package database
 
 import (
+	"aura/imagesource"
 	"aura/logging"
 	"aura/models"
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
 
-func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) logging.LogErrorInfo {
+func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedItem) (autoDownloadSetsChanged bool, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(
 		ctx,
 		fmt.Sprintf(
@@ -26,13 +28,13 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 
 	if s == nil || s.conn == nil {
 		logAction.SetError("DB: connection is nil", "", map[string]any{})
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 
 	tx, err := s.conn.BeginTx(ctx, nil)
 	if err != nil {
 		logAction.SetError("DB: TX BEGIN failed", err.Error(), map[string]any{"error": err.Error()})
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 	defer func() { _ = tx.Rollback() }()
 
@@ -44,37 +46,44 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 		emptySavedItemsDeleted int64
 	)
 
+	// The AutoDownload sets of the item before the upsert, to tell if the ones watched for updates changed
+	previousAutoDownloadSetIDs, errInfo := getAutoDownloadSetIDsForItem(ctx, tx, newItem.MediaItem)
+	if errInfo.Message != "" {
+		logAction.SetError(errInfo.Message, "", errInfo.Detail)
+		return false, *logAction.Error
+	}
+
 	// 1) Delete Ignore entry for this Media Item if it exists
 	if errInfo := deleteIgnoredEntryForMediaItemIfExists(ctx, tx, newItem.MediaItem); errInfo.Message != "" {
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 
 	mediaItemRowID, errInfo := upsertMediaItem(ctx, tx, newItem.MediaItem)
 	if errInfo.Message != "" {
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 
 	// Upsert per-type details
 	switch newItem.MediaItem.Type {
 	case "movie":
 		if errInfo := upsertMovie(ctx, tx, newItem.MediaItem, mediaItemRowID); errInfo.Message != "" {
-			return *logAction.Error
+			return false, *logAction.Error
 		}
 		logAction.AppendResult("action", "upsert_movie")
 	case "show":
 		seriesRowID, errInfo := upsertSeries(ctx, tx, newItem.MediaItem, mediaItemRowID)
 		if errInfo.Message != "" {
-			return *logAction.Error
+			return false, *logAction.Error
 		}
 		if errInfo := reconcileSeasonsAndEpisodes(ctx, tx, newItem.MediaItem, seriesRowID); errInfo.Message != "" {
-			return *logAction.Error
+			return false, *logAction.Error
 		}
 		logAction.AppendResult("action", "upsert_show_reconcile")
 	default:
 		logAction.SetError("DB: unsupported media item type", newItem.MediaItem.Type, map[string]any{
 			"type": newItem.MediaItem.Type,
 		})
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 
 	// Enforce uniqueness of SelectedTypes across sets for this item:
@@ -109,7 +118,7 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 		deletedLinks, errInfo := deleteSavedItemLinkAndImages(ctx, tx, newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle, newItem.MediaItem.Edition, ps.ID)
 		if errInfo.Message != "" {
 			logAction.SetError(errInfo.Message, "", errInfo.Detail)
-			return *logAction.Error
+			return false, *logAction.Error
 		}
 		posterSetsDeleted += deletedLinks
 	}
@@ -128,21 +137,21 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 		posterSetRowID, errInfo := upsertPosterSet(ctx, tx, ps)
 		if errInfo.Message != "" {
 			logAction.SetError(errInfo.Message, "", errInfo.Detail)
-			return *logAction.Error
+			return false, *logAction.Error
 		}
 		posterSetsUpserted++
 
 		// Upsert item+set link (SavedItems)
 		if errInfo := upsertSavedItemEntry(ctx, tx, newItem.MediaItem, ps, posterSetRowID); errInfo.Message != "" {
 			logAction.SetError(errInfo.Message, "", errInfo.Detail)
-			return *logAction.Error
+			return false, *logAction.Error
 		}
 
 		// Upsert images for this set, scoped to this item
 		imagesUpserted += len(ps.Images)
 		if errInfo := upsertImageFiles(ctx, tx, ps, posterSetRowID, newItem.MediaItem.TMDB_ID); errInfo.Message != "" {
 			logAction.SetError(errInfo.Message, "", errInfo.Detail)
-			return *logAction.Error
+			return false, *logAction.Error
 		}
 	}
 
@@ -152,7 +161,7 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 	// Apply SelectedTypes uniqueness across ALL sets for this media item
 	if errInfo := clearSelectedTypesOnOtherSets(ctx, tx, newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle, newItem.MediaItem.Edition, typeOwnerSetID); errInfo.Message != "" {
 		logAction.SetError(errInfo.Message, "", errInfo.Detail)
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 	logAction.AppendResult("selected_types_uniqueness", "applied")
 
@@ -160,7 +169,7 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 	deletedEmpty, errInfo := deleteEmptySavedItemLinks(ctx, tx, newItem.MediaItem.TMDB_ID, newItem.MediaItem.LibraryTitle, newItem.MediaItem.Edition)
 	if errInfo.Message != "" {
 		logAction.SetError(errInfo.Message, "", errInfo.Detail)
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 	emptySavedItemsDeleted += deletedEmpty
 	if emptySavedItemsDeleted > 0 {
@@ -171,7 +180,7 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 	orphanSetsDeleted, orphanImagesDeleted, errInfo := deleteOrphanPosterSetsAndImages(ctx, tx)
 	if errInfo.Message != "" {
 		logAction.SetError(errInfo.Message, "", errInfo.Detail)
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 	if orphanSetsDeleted > 0 {
 		logAction.AppendResult("orphan_poster_sets_deleted", orphanSetsDeleted)
@@ -180,10 +189,17 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 		logAction.AppendResult("orphan_images_deleted", orphanImagesDeleted)
 	}
 
+	autoDownloadSetIDs, errInfo := getAutoDownloadSetIDsForItem(ctx, tx, newItem.MediaItem)
+	if errInfo.Message != "" {
+		logAction.SetError(errInfo.Message, "", errInfo.Detail)
+		return false, *logAction.Error
+	}
+	autoDownloadSetsChanged = !slices.Equal(previousAutoDownloadSetIDs, autoDownloadSetIDs)
+
 	// Commit the transaction
 	if err := tx.Commit(); err != nil {
 		logAction.SetError("DB: TX COMMIT failed", err.Error(), map[string]any{"error": err.Error()})
-		return *logAction.Error
+		return false, *logAction.Error
 	}
 
 	logging.LOGGER.Info().Timestamp().
@@ -191,7 +207,35 @@ func (s *SQliteDB) UpsertSavedItem(ctx context.Context, newItem models.DBSavedIt
 		Str("library_title", newItem.MediaItem.LibraryTitle).
 		Msg("Upserted SavedItem entry for MediaItem")
 
-	return logging.LogErrorInfo{}
+	return autoDownloadSetsChanged, logging.LogErrorInfo{}
+}
+
+// getAutoDownloadSetIDsForItem returns the sorted IDs of the saved sets of a media item that have AutoDownload on
+func getAutoDownloadSetIDsForItem(ctx context.Context, tx *sql.Tx, mediaItem models.MediaItem) (setIDs []string, Err logging.LogErrorInfo) {
+	rows, err := tx.QueryContext(ctx, `
+SELECT ps.set_id
+FROM SavedItems si
+JOIN PosterSets ps ON ps.id = si.poster_set_id
+WHERE si.tmdb_id = ? AND si.library_title = ? AND si.edition = ? AND si.autodownload = 1
+ORDER BY ps.set_id;
+`, mediaItem.TMDB_ID, mediaItem.LibraryTitle, mediaItem.Edition)
+	if err != nil {
+		return nil, logging.LogErrorInfo{Message: "DB: query AutoDownload sets failed", Detail: map[string]any{"error": err.Error()}}
+	}
+	defer rows.Close()
+
+	setIDs = []string{}
+	for rows.Next() {
+		var setID string
+		if err := rows.Scan(&setID); err != nil {
+			return nil, logging.LogErrorInfo{Message: "DB: scan AutoDownload sets failed", Detail: map[string]any{"error": err.Error()}}
+		}
+		setIDs = append(setIDs, setID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, logging.LogErrorInfo{Message: "DB: read AutoDownload sets failed", Detail: map[string]any{"error": err.Error()}}
+	}
+	return setIDs, logging.LogErrorInfo{}
 }
 
 func deleteIgnoredEntryForMediaItemIfExists(ctx context.Context, tx *sql.Tx, mediaItem models.MediaItem) logging.LogErrorInfo {
@@ -469,12 +513,13 @@ func reconcileSeasonsAndEpisodes(ctx context.Context, tx *sql.Tx, mediaItem mode
 
 func upsertPosterSet(ctx context.Context, tx *sql.Tx, ps models.DBPosterSetDetail) (posterSetRowID int64, Err logging.LogErrorInfo) {
 	q := `
-INSERT INTO PosterSets (set_id, type, title, user, date_created, date_updated)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO PosterSets (set_id, type, title, user, date_created, date_updated, source)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(set_id) DO UPDATE SET
   type        = excluded.type,
   title       = excluded.title,
   user        = excluded.user,
+  source      = excluded.source,
   -- preserve original creation time
   date_created = PosterSets.date_created,
   date_updated = excluded.date_updated
@@ -487,6 +532,7 @@ RETURNING id;
 		ps.UserCreated,
 		ps.DateCreated,
 		ps.DateUpdated,
+		imagesource.GetSetSource(ps.BaseSetInfo),
 	).Scan(&posterSetRowID)
 	if err != nil {
 		return 0, logging.LogErrorInfo{Message: "DB: UPSERT PosterSets failed", Detail: map[string]any{"error": err.Error(), "set_id": ps.ID}}
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func (s *SQliteDB) UpsertWantedItem(ctx context.Context, wanted models.DBWantedItem) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Upserting Wanted Item %s | %s", wanted.Type, wanted.TMDB_ID), logging.LevelTrace)
+	defer logAction.Complete()
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	if wanted.TMDB_ID == "" || wanted.LibraryTitle == "" || wanted.Set.ID == "" {
+		logAction.SetError("tmdb_id, library_title and set.id are required", "", map[string]any{
+			"tmdb_id":       wanted.TMDB_ID,
+			"library_title": wanted.LibraryTitle,
+			"set_id":        wanted.Set.ID,
+		})
+		return *logAction.Error
+	}
+	if wanted.Type != "movie" && wanted.Type != "show" {
+		logAction.SetError("Invalid wanted item type", "Type must be 'movie' or 'show'", map[string]any{"type": wanted.Type})
+		return *logAction.Error
+	}
+
+	setInfo, err := json.Marshal(wanted.Set)
+	if err != nil {
+		logAction.SetError("Failed to marshal set info", err.Error(), nil)
+		return *logAction.Error
+	}
+	selectedTypes, err := json.Marshal(wanted.SelectedTypes)
+	if err != nil {
+		logAction.SetError("Failed to marshal selected types", err.Error(), nil)
+		return *logAction.Error
+	}
+
+	if wanted.CreatedAt.IsZero() {
+		wanted.CreatedAt = time.Now().UTC()
+	}
+
+	_, err = s.conn.ExecContext(ctx, `
+INSERT INTO WantedItems (
+  tmdb_id, type, title, year, library_title,
+  set_info, selected_types, autodownload, created_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(tmdb_id, type) DO UPDATE SET
+  title          = excluded.title,
+  year           = excluded.year,
+  library_title  = excluded.library_title,
+  set_info       = excluded.set_info,
+  selected_types = excluded.selected_types,
+  autodownload   = excluded.autodownload;
+`,
+		wanted.TMDB_ID,
+		wanted.Type,
+		wanted.Title,
+		wanted.Year,
+		wanted.LibraryTitle,
+		string(setInfo),
+		string(selectedTypes),
+		boolToInt(wanted.AutoDownload),
+		wanted.CreatedAt,
+	)
+	if err != nil {
+		logAction.SetError("DB: UPSERT WantedItems failed", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+
+	return logging.LogErrorInfo{}
+}
+
+func (s *SQliteDB) GetAllWantedItems(ctx context.Context) (items []models.DBWantedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Getting All Wanted Items", logging.LevelDebug)
+	defer logAction.Complete()
+
+	items = []models.DBWantedItem{}
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return items, *logAction.Error
+	}
+
+	rows, err := s.conn.QueryContext(ctx, `
+SELECT tmdb_id, type, title, year, library_title,
+       set_info, selected_types, autodownload, created_at
+FROM WantedItems
+ORDER BY created_at, tmdb_id;
+`)
+	if err != nil {
+		logAction.SetError("Failed to query wanted items", err.Error(), map[string]any{"error": err.Error()})
+		return items, *logAction.Error
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item          models.DBWantedItem
+			setInfo       string
+			selectedTypes string
+			autoDownload  int
+		)
+		if err := rows.Scan(
+			&item.TMDB_ID,
+			&item.Type,
+			&item.Title,
+			&item.Year,
+			&item.LibraryTitle,
+			&setInfo,
+			&selectedTypes,
+			&autoDownload,
+			&item.CreatedAt,
+		); err != nil {
+			logAction.SetError("Failed to scan wanted item", err.Error(), map[string]any{"error": err.Error()})
+			return items, *logAction.Error
+		}
+
+		if err := json.Unmarshal([]byte(setInfo), &item.Set); err != nil {
+			logAction.AppendWarning("set_info_decode_error", fmt.Sprintf("%s | %s: %s", item.Type, item.TMDB_ID, err.Error()))
+		}
+		if err := json.Unmarshal([]byte(selectedTypes), &item.SelectedTypes); err != nil {
+			logAction.AppendWarning("selected_types_decode_error", fmt.Sprintf("%s | %s: %s", item.Type, item.TMDB_ID, err.Error()))
+		}
+		item.AutoDownload = autoDownload == 1
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		logAction.SetError("Failed to read wanted items", err.Error(), map[string]any{"error": err.Error()})
+		return items, *logAction.Error
+	}
+
+	logAction.AppendResult("wanted_items", len(items))
+	return items, logging.LogErrorInfo{}
+}
+
+func (s *SQliteDB) DeleteWantedItem(ctx context.Context, tmdbID, itemType string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Deleting Wanted Item %s | %s", itemType, tmdbID), logging.LevelTrace)
+	defer logAction.Complete()
+
+	if s == nil || s.conn == nil {
+		logAction.SetError("DB: connection is nil", "", map[string]any{})
+		return *logAction.Error
+	}
+
+	res, err := s.conn.ExecContext(ctx, `
+DELETE FROM WantedItems
+WHERE tmdb_id = ?
+  AND type = ?;
+`, tmdbID, itemType)
+	if err != nil {
+		logAction.SetError("Failed to delete wanted item", err.Error(), map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	deleted, _ := res.RowsAffected()
+	logAction.AppendResult("deleted", deleted)
+
+	return logging.LogErrorInfo{}
+}
@@ -0,0 +1,275 @@
+package autodownload
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	downloadqueue "aura/download/queue"
+	"aura/imagesource"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AutoApplyResult struct {
+	Item         string `json:"item"`
+	TMDB_ID      string `json:"tmdb_id"`
+	LibraryTitle string `json:"library_title"`
+	Edition      string `json:"edition,omitempty"`
+	SetID        string `json:"set_id,omitempty"`
+	SetTitle     string `json:"set_title,omitempty"`
+	UserCreated  string `json:"user_created,omitempty"`
+	Result       string `json:"result"` // queued, would-queue, skipped, error
+	Reason       string `json:"reason"`
+}
+
+// IsAutoApplyEnabled returns true when sets should be applied to newly added items
+func IsAutoApplyEnabled() bool {
+	return config.Latest().AutoDownload.AutoApply.Enabled
+}
+
+// GetLibraryItemKeys returns a key for every media item currently in the library cache.
+// Pass the result to GetNewLibraryItems after a refresh to find out which items were added.
+func GetLibraryItemKeys() map[string]bool {
+	keys := map[string]bool{}
+	for _, item := range cache.LibraryStore.GetAllMediaItems() {
+		keys[libraryItemKey(item)] = true
+	}
+	return keys
+}
+
+// GetNewLibraryItems returns the media items in the library cache that are not in the given keys.
+// If the keys are empty (the cache was not loaded yet), nothing is returned so that a fresh start does not treat the whole library as new.
+func GetNewLibraryItems(previousKeys map[string]bool) []models.MediaItem {
+	newItems := []models.MediaItem{}
+	if len(previousKeys) == 0 {
+		return newItems
+	}
+	for _, item := range cache.LibraryStore.GetAllMediaItems() {
+		if !previousKeys[libraryItemKey(item)] {
+			newItems = append(newItems, item)
+		}
+	}
+	return newItems
+}
+
+func libraryItemKey(item models.MediaItem) string {
+	return item.LibraryTitle + "|" + item.TMDB_ID + "|" + item.Edition
+}
+
+// AutoApplyToNewItems picks a set for each item that has nothing saved in the database and queues it.
+// When dryRun is true, nothing is queued and the results only show which set would be picked.
+func AutoApplyToNewItems(ctx context.Context, items []models.MediaItem, dryRun bool) (results []AutoApplyResult) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Auto Apply: Checking %d Items", len(items)), logging.LevelInfo)
+	defer logAction.Complete()
+
+	results = []AutoApplyResult{}
+	queued := 0
+	for _, item := range items {
+		result := autoApplyToItem(ctx, item, dryRun)
+		if result.Result == "queued" {
+			queued++
+		}
+		results = append(results, result)
+	}
+
+	logAction.AppendResult("items_checked", len(items))
+	logAction.AppendResult("items_queued", queued)
+	logAction.AppendResult("dry_run", dryRun)
+	return results
+}
+
+// isConfiguredLibrary returns true if the library is one of the configured MediaServer.Libraries
+func isConfiguredLibrary(ctx context.Context, libraryTitle string) bool {
+	return slices.ContainsFunc(config.Current(ctx).MediaServer.Libraries, func(library models.LibrarySection) bool {
+		return library.Title == libraryTitle
+	})
+}
+
+func autoApplyToItem(ctx context.Context, item models.MediaItem, dryRun bool) (result AutoApplyResult) {
+	result = AutoApplyResult{
+		Item:         utils.MediaItemInfo(item),
+		TMDB_ID:      item.TMDB_ID,
+		LibraryTitle: item.LibraryTitle,
+		Edition:      item.Edition,
+	}
+
+	if item.TMDB_ID == "" {
+		result.Result = "skipped"
+		result.Reason = "Item has no TMDB ID"
+		return result
+	}
+	if item.Type != "movie" && item.Type != "show" {
+		result.Result = "skipped"
+		result.Reason = fmt.Sprintf("Item type '%s' is not supported", item.Type)
+		return result
+	}
+	if !isConfiguredLibrary(ctx, item.LibraryTitle) {
+		result.Result = "skipped"
+		result.Reason = fmt.Sprintf("Library '%s' is not one of the configured libraries", item.LibraryTitle)
+		return result
+	}
+
+	ignored, _, savedSets, Err := database.CheckIfMediaItemExists(ctx, item.TMDB_ID, item.LibraryTitle, item.Edition)
+	if Err.Message != "" {
+		result.Result = "error"
+		result.Reason = fmt.Sprintf("Failed to check the database for the item: %s", Err.Message)
+		return result
+	}
+	if ignored {
+		result.Result = "skipped"
+		result.Reason = "Item is ignored"
+		return result
+	}
+	if len(savedSets) > 0 {
+		result.Result = "skipped"
+		result.Reason = "Item already has a saved set"
+		return result
+	}
+
+	pickedSet, reason, Err := PickAutoApplySet(ctx, item)
+	if Err.Message != "" {
+		result.Result = "error"
+		result.Reason = fmt.Sprintf("Failed to get sets for the item: %s", Err.Message)
+		return result
+	}
+	if pickedSet.ID == "" {
+		result.Result = "skipped"
+		result.Reason = reason
+		return result
+	}
+
+	result.SetID = pickedSet.ID
+	result.SetTitle = pickedSet.Title
+	result.UserCreated = pickedSet.UserCreated
+	result.Reason = reason
+
+	if dryRun {
+		result.Result = "would-queue"
+		return result
+	}
+
+	selectedTypes := config.Current(ctx).AutoDownload.AutoApply.SelectedTypes
+	queueItem := models.DBSavedItem{
+		MediaItem: item,
+		PosterSets: []models.DBPosterSetDetail{
+			{
+				PosterSet:      pickedSet.PosterSet,
+				LastDownloaded: time.Now(),
+				SelectedTypes: models.SelectedTypes{
+					Poster:              selectedTypes.Poster,
+					Backdrop:            selectedTypes.Backdrop,
+					SeasonPoster:        selectedTypes.SeasonPoster,
+					SpecialSeasonPoster: selectedTypes.SpecialSeasonPoster,
+					Titlecard:           selectedTypes.Titlecard,
+				},
+				AutoDownload: config.Current(ctx).AutoDownload.AutoApply.AutoDownload,
+			},
+		},
+	}
+	Err = downloadqueue.AddToQueue(ctx, queueItem)
+	if Err.Message != "" {
+		result.Result = "error"
+		result.Reason = fmt.Sprintf("Failed to queue set '%s': %s", pickedSet.ID, Err.Message)
+		return result
+	}
+
+	logging.LOGGER.Info().Timestamp().
+		Str("item", utils.MediaItemInfo(item)).
+		Str("set_id", pickedSet.ID).
+		Str("user_created", pickedSet.UserCreated).
+		Msg("Auto Apply: Queued set for new item")
+	result.Result = "queued"
+	return result
+}
+
+// PickAutoApplySet picks the set to apply to an item using the AutoApply creator preferences.
+// The creators are checked in order and the most popular matching set of the first creator with one is used.
+// If no preferred creator has a set, the most popular set is used when FallbackToMostPopular is enabled.
+// An empty set is returned with the reason when nothing should be applied.
+func PickAutoApplySet(ctx context.Context, item models.MediaItem) (pickedSet models.SetRef, reason string, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Auto Apply: Picking Set for %s", utils.MediaItemInfo(item)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	autoApply := config.Current(ctx).AutoDownload.AutoApply
+
+	sets, _, Err := imagesource.GetItemSets(ctx, item.TMDB_ID, item.Type, item.LibraryTitle, item.Edition, config.Current(ctx).TMDB.ImageFilter())
+	if Err.Message != "" {
+		return pickedSet, "", Err
+	}
+
+	// Collection sets also cover other movies, so only sets made for this item type are considered
+	itemSets := []models.SetRef{}
+	for _, set := range sets {
+		if set.Type == item.Type && len(set.Images) > 0 {
+			itemSets = append(itemSets, set)
+		}
+	}
+	logAction.AppendResult("sets_found", len(itemSets))
+	if len(itemSets) == 0 {
+		return pickedSet, "No sets available for this item", logging.LogErrorInfo{}
+	}
+
+	for rank, creator := range autoApply.Creators {
+		if !creatorAppliesToItem(creator, item) {
+			continue
+		}
+		for _, set := range itemSets {
+			if !strings.EqualFold(set.UserCreated, creator.Username) || !setMatchesLanguages(set, creator.Languages) {
+				continue
+			}
+			if pickedSet.ID == "" || set.Popularity > pickedSet.Popularity {
+				pickedSet = set
+			}
+		}
+		if pickedSet.ID != "" {
+			logAction.AppendResult("picked_set_id", pickedSet.ID)
+			return pickedSet, fmt.Sprintf("Set by preferred creator #%d (%s)", rank+1, creator.Username), logging.LogErrorInfo{}
+		}
+	}
+
+	if !autoApply.FallbackToMostPopular {
+		return pickedSet, "None of the preferred creators has a set for this item", logging.LogErrorInfo{}
+	}
+
+	for _, set := range itemSets {
+		if pickedSet.ID == "" || set.Popularity > pickedSet.Popularity {
+			pickedSet = set
+		}
+	}
+	logAction.AppendResult("picked_set_id", pickedSet.ID)
+	return pickedSet, "None of the preferred creators has a set for this item, using the most popular set", logging.LogErrorInfo{}
+}
+
+// creatorAppliesToItem checks the library and type restrictions of a creator preference
+func creatorAppliesToItem(creator config.Config_AutoDownload_AutoApplyCreator, item models.MediaItem) bool {
+	if len(creator.Libraries) > 0 && !slices.ContainsFunc(creator.Libraries, func(library string) bool {
+		return strings.EqualFold(library, item.LibraryTitle)
+	}) {
+		return false
+	}
+	if len(creator.Types) > 0 && !slices.Contains(creator.Types, item.Type) {
+		return false
+	}
+	return true
+}
+
+// setMatchesLanguages returns true if any image in the set is in one of the languages
+func setMatchesLanguages(set models.SetRef, languages []string) bool {
+	if len(languages) == 0 {
+		return true
+	}
+	for _, image := range set.Images {
+		for _, language := range languages {
+			if strings.EqualFold(image.Language, language) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+package autodownload
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// checkAllCollections re-applies the collection images of every saved collection whose set has changed since it was last downloaded
+func checkAllCollections(ctx context.Context) (Err logging.LogErrorInfo) {
+	ctx, getAllAction := logging.AddSubActionToContext(ctx, "Getting all saved collections for AutoDownload Check", logging.LevelInfo)
+	collections, Err := database.GetAllSavedCollections(ctx)
+	getAllAction.Complete()
+	if Err.Message != "" {
+		return Err
+	}
+
+	errorCount := 0
+	warningCount := 0
+	successCount := 0
+	skippedCount := 0
+	for _, saved := range collections {
+		itemCtx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Current(ctx)), "AutoDownload - Check For Collection Updates")
+		itemAction := ld.AddAction(fmt.Sprintf("Checking Collection %s", utils.CollectionItemInfo(saved.CollectionItem)), logging.LevelInfo)
+		itemCtx = logging.WithCurrentAction(itemCtx, itemAction)
+		result := CheckCollection(itemCtx, saved)
+		switch result.OverallResult {
+		case "error":
+			errorCount++
+		case "warning":
+			warningCount++
+		case "success":
+			successCount++
+		case "skipped":
+			skippedCount++
+		}
+		itemAction.AppendResult("outcomes", result)
+		ld.Log()
+	}
+
+	logging.LOGGER.Info().Timestamp().Int("error_count", errorCount).
+		Int("warning_count", warningCount).
+		Int("success_count", successCount).
+		Int("skipped_count", skippedCount).
+		Msg("Completed AutoDownload Check for all collections")
+	return logging.LogErrorInfo{}
+}
+
+// CheckCollection compares the saved collection set against the latest version of the set and re-applies any new or updated images
+func CheckCollection(ctx context.Context, saved models.DBSavedCollection) (result AutoDownloadResult) {
+	result = AutoDownloadResult{}
+	result.Item = utils.CollectionItemInfo(saved.CollectionItem)
+
+	defer func() {
+		if r := recover(); r != nil {
+			logging.LOGGER.Error().
+				Timestamp().
+				Str("collection", utils.CollectionItemInfo(saved.CollectionItem)).
+				Interface("recover", r).
+				Str("stack", string(debug.Stack())).
+				Msg("PANIC: in CheckCollection for AutoDownload Check")
+			result = AutoDownloadResult{
+				Item:           utils.CollectionItemInfo(saved.CollectionItem),
+				OverallResult:  "error",
+				OverallMessage: fmt.Sprintf("Panic occurred: %v", r),
+			}
+		}
+	}()
+
+	setResult := AutoDownloadSetResult{
+		ID:          saved.PosterSet.ID,
+		Title:       saved.PosterSet.Title,
+		UserCreated: saved.PosterSet.UserCreated,
+	}
+	defer func() {
+		if setResult.Result != "" {
+			result.Sets = append(result.Sets, setResult)
+			getOverallResults(&result)
+		}
+	}()
+
+	if !saved.AutoDownload {
+		result.OverallResult = "skipped"
+		result.OverallMessage = "Collection is not set to auto-download"
+		return result
+	}
+	if !saved.SelectedTypes.Poster && !saved.SelectedTypes.Backdrop {
+		result.OverallResult = "skipped"
+		result.OverallMessage = "No image types selected for this collection"
+		return result
+	}
+
+	// Use the cached collection when available so that the media server specific fields (e.g. Index) are set
+	collectionItem := saved.CollectionItem
+	if cachedItem, found := cache.CollectionsStore.GetCollectionByRatingKey(saved.CollectionItem.RatingKey); found && cachedItem != nil {
+		collectionItem = *cachedItem
+		if collectionItem.LibraryTitle == "" {
+			collectionItem.LibraryTitle = saved.CollectionItem.LibraryTitle
+		}
+	}
+
+	latestSets, Err := GetCollectionSets(ctx, &collectionItem)
+	if Err.Message != "" {
+		setResult.Result = "error"
+		setResult.Reason = fmt.Sprintf("Failed to get the latest collection sets: %s", Err.Message)
+		return result
+	}
+
+	var latestSet *models.SetRef
+	for i := range latestSets {
+		if latestSets[i].ID == saved.PosterSet.ID {
+			latestSet = &latestSets[i]
+			break
+		}
+	}
+	if latestSet == nil {
+		setResult.Result = "warning"
+		setResult.Reason = "Set no longer found for this collection"
+		return result
+	}
+
+	oldImageByKey := map[string]models.ImageFile{}
+	for _, image := range saved.PosterSet.Images {
+		oldImageByKey[image.Type+"|"+image.ID] = image
+	}
+
+	imagesToApply := []models.ImageFile{}
+	for _, image := range latestSet.Images {
+		if !collectionImageTypeSelected(image.Type, saved.SelectedTypes) {
+			continue
+		}
+		oldImage, found := oldImageByKey[image.Type+"|"+image.ID]
+		if !found || image.Modified.After(oldImage.Modified) {
+			imagesToApply = append(imagesToApply, image)
+		}
+	}
+	if len(imagesToApply) == 0 {
+		setResult.Result = "skipped"
+		setResult.Reason = "No changes detected in the collection set"
+		return result
+	}
+
+	failedImages := map[string]bool{}
+	for _, image := range imagesToApply {
+		Err = mediaserver.ApplyCollectionImage(ctx, &collectionItem, image)
+		if Err.Message != "" {
+			failedImages[image.Type+"|"+image.ID] = true
+		}
+	}
+	failed := len(failedImages)
+	if failed == len(imagesToApply) {
+		setResult.Result = "error"
+		setResult.Reason = "Failed to apply the updated collection images"
+		return result
+	}
+
+	// Only store the images that were applied, so the next check tries the failed ones again
+	updatedSet := latestSet.PosterSet
+	updatedSet.Images = make([]models.ImageFile, 0, len(latestSet.Images))
+	for _, image := range latestSet.Images {
+		key := image.Type + "|" + image.ID
+		if !failedImages[key] {
+			updatedSet.Images = append(updatedSet.Images, image)
+		} else if oldImage, found := oldImageByKey[key]; found {
+			updatedSet.Images = append(updatedSet.Images, oldImage)
+		}
+	}
+	saved.PosterSet = updatedSet
+	saved.LastDownloaded = time.Now()
+	Err = database.UpsertSavedCollection(ctx, saved)
+	if Err.Message != "" {
+		setResult.Result = "warning"
+		setResult.Reason = fmt.Sprintf("Images applied, but failed to update the saved collection: %s", Err.Message)
+		return result
+	}
+
+	if failed > 0 {
+		setResult.Result = "warning"
+		setResult.Reason = fmt.Sprintf("Applied %d of %d updated collection images", len(imagesToApply)-failed, len(imagesToApply))
+		return result
+	}
+	setResult.Result = "success"
+	setResult.Reason = fmt.Sprintf("Applied %d updated collection images", len(imagesToApply))
+	return result
+}
+
+// GetCollectionSets gets the MediUX collection sets for a media server collection.
+// Plex collections have no TMDB collection ID, so the sets are looked up using the TMDB IDs of the movies in the collection.
+func GetCollectionSets(ctx context.Context, collectionItem *models.CollectionItem) (sets []models.SetRef, Err logging.LogErrorInfo) {
+	switch config.Current(ctx).MediaServer.Type {
+	case "Plex":
+		Err = mediaserver.GetCollectionChildrenItems(ctx, collectionItem)
+		if Err.Message != "" {
+			return nil, Err
+		}
+		movieIDs := make([]string, 0, len(collectionItem.MediaItems))
+		for _, child := range collectionItem.MediaItems {
+			if child.Type == "movie" && child.TMDB_ID != "" {
+				movieIDs = append(movieIDs, child.TMDB_ID)
+			}
+		}
+		return mediux.GetCollectionImagesByMovieTMDBIDs(ctx, movieIDs)
+	case "Emby", "Jellyfin":
+		return mediux.GetCollectionImagesByTMDBID(ctx, collectionItem.TMDB_ID)
+	default:
+		_, logAction := logging.AddSubActionToContext(ctx, "Getting Collection Sets", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Unsupported Media Server Type", "The media server type is not supported for fetching collection sets", map[string]any{
+			"server_type": config.Current(ctx).MediaServer.Type,
+		})
+		return nil, *logAction.Error
+	}
+}
+
+func collectionImageTypeSelected(imageType string, selectedTypes models.CollectionSelectedTypes) bool {
+	switch imageType {
+	case "collection_poster", "poster":
+		return selectedTypes.Poster
+	case "collection_backdrop", "backdrop":
+		return selectedTypes.Backdrop
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package autodownload
 
 import (
 	"aura/cache"
+	"aura/config"
 	"aura/database"
 	"aura/logging"
 	"aura/mediaserver"
@@ -27,6 +28,9 @@ type AutoDownloadSetResult struct {
 	UserCreated string `json:"user_created"`
 	Result      string `json:"result"`
 	Reason      string `json:"reason"`
+
+	Verified   []string `json:"verified,omitempty"`   // Images the media server was checked to show (Images.VerifyApply)
+	Unverified []string `json:"unverified,omitempty"` // Images the media server did not show after applying them (Images.VerifyApply)
 }
 
 type ImageFileWithReason struct {
@@ -36,6 +40,9 @@ type ImageFileWithReason struct {
 }
 
 func CheckAllItems(ctx context.Context) (Err logging.LogErrorInfo) {
+	// Use the same config for the whole run, even if it is changed while the run is going
+	ctx = config.WithSnapshot(ctx, config.Latest())
+
 	ctx, getAllItemAction := logging.AddSubActionToContext(ctx, " Getting all saved sets for AutoDownload Check", logging.LevelInfo)
 	out, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
 	if Err.Message != "" {
@@ -51,7 +58,7 @@ func CheckAllItems(ctx context.Context) (Err logging.LogErrorInfo) {
 	successCount := 0
 	skippedCount := 0
 	for _, item := range out.Items {
-		itemCtx, ld := logging.CreateLoggingContext(context.Background(), "AutoDownload - Check For Updates")
+		itemCtx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Current(ctx)), "AutoDownload - Check For Updates")
 		itemAction := ld.AddAction(fmt.Sprintf("Checking Item %s", utils.MediaItemInfo(item.MediaItem)), logging.LevelInfo)
 		itemCtx = logging.WithCurrentAction(itemCtx, itemAction)
 		result := CheckItem(itemCtx, item)
@@ -74,6 +81,9 @@ func CheckAllItems(ctx context.Context) (Err logging.LogErrorInfo) {
 		Int("success_count", successCount).
 		Int("skipped_count", skippedCount).
 		Msg("Completed AutoDownload Check for all items")
+
+	// Collections are saved separately from media items, so they are checked on their own
+	checkAllCollections(ctx)
 	return logging.LogErrorInfo{}
 }
 
@@ -142,6 +152,14 @@ func CheckItem(ctx context.Context, dbItem models.DBSavedItem) (result AutoDownl
 		return result
 	}
 
+	// TMDB fallback sets are not checked for image updates, instead they are upgraded to a MediUX set once one exists
+	tmdbSetResults, dbItem := handleTMDBSets(ctx, *mediaItem, dbItem)
+	if len(dbItem.PosterSets) == 0 {
+		result.Sets = tmdbSetResults
+		getOverallResults(&result)
+		return result
+	}
+
 	switch dbItem.MediaItem.Type {
 	case "movie":
 		result = handleMovie(ctx, *mediaItem, dbItem)
@@ -150,6 +168,12 @@ func CheckItem(ctx context.Context, dbItem models.DBSavedItem) (result AutoDownl
 	default:
 		result.OverallResult = "error"
 		result.OverallMessage = "Unknown media type"
+		return result
+	}
+
+	if len(tmdbSetResults) > 0 {
+		result.Sets = append(tmdbSetResults, result.Sets...)
+		getOverallResults(&result)
 	}
 	return result
 }
@@ -304,6 +328,32 @@ func insertRedownloadedSetIntoDB(ctx context.Context, newMediaItem models.MediaI
 	return logging.LogErrorInfo{}
 }
 
+// recordVerification adds the verification status of a re-applied image to the set result
+func recordVerification(setResult *AutoDownloadSetResult, imageName, verification string, imageRedownloadResult map[string]any) {
+	if verification == "" {
+		return
+	}
+	if imageRedownloadResult != nil {
+		imageRedownloadResult["verification"] = verification
+	}
+	switch verification {
+	case mediaserver.APPLY_VERIFIED:
+		setResult.Verified = append(setResult.Verified, imageName)
+	case mediaserver.APPLY_UNVERIFIED:
+		setResult.Unverified = append(setResult.Unverified, imageName)
+	}
+}
+
+// finishSetResult marks a set as successful, or as a warning when the media server did not show some of the re-applied images
+func finishSetResult(setResult *AutoDownloadSetResult, redownloadCount int) {
+	setResult.Result = "success"
+	setResult.Reason = fmt.Sprintf("%d images need to be redownloaded", redownloadCount)
+	if len(setResult.Unverified) > 0 {
+		setResult.Result = "warning"
+		setResult.Reason = fmt.Sprintf("%s, %d could not be verified on the media server", setResult.Reason, len(setResult.Unverified))
+	}
+}
+
 func getOverallResults(result *AutoDownloadResult) {
 	if len(result.Sets) == 0 {
 		result.OverallResult = "skipped"
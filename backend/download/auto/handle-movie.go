@@ -1,7 +1,9 @@
 package autodownload
 
 import (
+	"aura/artworkhistory"
 	"aura/database"
+	"aura/imagesource"
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/mediux"
@@ -107,13 +109,13 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 		mediuxSet := models.SetRef{}
 		includedItems := map[string]models.IncludedItem{}
 		Err := logging.LogErrorInfo{}
-		// Get the latest set details from MediUX
+		// Get the latest set details from the image source
 		switch dbSet.Type {
 		case "movie":
-			mediuxSet, _, Err = mediux.GetMovieSetByID(ctx, dbSet.ID, mediaItem.LibraryTitle, mediaItem.Edition)
+			mediuxSet, _, Err = imagesource.GetSetByID(ctx, dbSet.BaseSetInfo, mediaItem.TMDB_ID, mediaItem.LibraryTitle, mediaItem.Edition)
 			if Err.Message != "" {
 				setResult.Result = "error"
-				setResult.Reason = "Failed to get latest set details from MediUX"
+				setResult.Reason = fmt.Sprintf("Failed to get latest set details from %s", imagesource.GetSetSource(dbSet.BaseSetInfo))
 				result.Sets = append(result.Sets, setResult)
 				continue
 			}
@@ -226,6 +228,8 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 			Msgf("Image check results for set %s", dbSet.ID)
 
 		_, imageRedownloadsAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Downloading %d updated images for set %s (ID: %s)", len(imagesToRedownload), dbSet.Title, dbSet.ID), logging.LevelInfo)
+		verifier := mediaserver.NewApplyVerifier()
+		pendingResults := map[string]map[string]any{}
 		for idx, image := range imagesToRedownload {
 			// Redownload the image
 			imageRedownloadResult := make(map[string]any)
@@ -233,7 +237,7 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 			imageRedownloadResult["image_type"] = image.Type
 			imageRedownloadResult["redownload_reason"] = image.Reason
 
-			Err := mediaserver.DownloadApplyImageToMediaItem(ctx, &mediaItem, image.ImageFile)
+			verification, Err := verifier.DownloadApplyAndVerifyImage(mediaserver.WithArtworkChanged(artworkhistory.WithReplacingSet(ctx, dbSet.BaseSetInfo)), &mediaItem, image.ImageFile)
 			if Err.Message != "" {
 				imageRedownloadResult["redownload_result"] = "error"
 				imageRedownloadResult["redownload_error"] = Err.Message
@@ -244,6 +248,12 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 				logging.LOGGER.Error().Timestamp().Str("item", utils.MediaItemInfo(mediaItem)).Str("set_id", dbSet.ID).Str("image", utils.GetFileDownloadName(mediaItem.Title, image.ImageFile)).Str("error", Err.Message).Msg("Failed to redownload image for AutoDownload Check")
 				continue
 			} else {
+				recordVerification(&setResult, utils.GetFileDownloadName(mediaItem.Title, image.ImageFile), verification, imageRedownloadResult)
+				if verification == mediaserver.APPLY_PENDING {
+					pendingResults[utils.GetFileDownloadName(mediaItem.Title, image.ImageFile)] = imageRedownloadResult
+				}
+				imageRedownloadsAction.AppendResult(fmt.Sprintf("image_redownload_%d", idx+1), imageRedownloadResult)
+
 				// Send a notification to all configured notification services
 				// We do this asynchronously and don't wait for the result
 				go func(image ImageFileWithReason) {
@@ -251,6 +261,11 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 				}(image)
 			}
 		}
+		// Images that didn't match right away are checked again together
+		for _, retry := range verifier.RetryPending(ctx) {
+			imageName := utils.GetFileDownloadName(mediaItem.Title, retry.ImageFile)
+			recordVerification(&setResult, imageName, retry.Verification, pendingResults[imageName])
+		}
 		imageRedownloadsAction.Complete()
 
 		// We remove the images that are for other items in the set and then update the set in the database with the new image info and download date so that it is up to date for the next check
@@ -259,8 +274,7 @@ func handleMovie(ctx context.Context, mediaItem models.MediaItem, dbItem models.
 		// Reinsert the set into the DB item with the updated image info and download date so that it is up to date for the next check
 		Err = insertRedownloadedSetIntoDB(ctx, mediaItem, mediuxSet.PosterSet, dbItem, dbSet)
 
-		setResult.Result = "success"
-		setResult.Reason = fmt.Sprintf("%d images need to be redownloaded", len(imagesToRedownload))
+		finishSetResult(&setResult, len(imagesToRedownload))
 		result.Sets = append(result.Sets, setResult)
 	}
 
@@ -363,7 +377,7 @@ func handleCollectionAutoAddNewItems(ctx context.Context, dbSet models.DBPosterS
 		}
 
 		for _, image := range itemImages {
-			downloadErr := mediaserver.DownloadApplyImageToMediaItem(ctx, &item, image)
+			downloadErr := mediaserver.DownloadApplyImageToMediaItem(mediaserver.WithArtworkChanged(artworkhistory.WithReplacingSet(ctx, dbSet.BaseSetInfo)), &item, image)
 			if downloadErr.Message != "" {
 				action.AppendWarning("collection_auto_add_download_failed", map[string]any{
 					"tmdb_id":       item.TMDB_ID,
@@ -1,9 +1,10 @@
 package autodownload
 
 import (
+	"aura/artworkhistory"
+	"aura/imagesource"
 	"aura/logging"
 	"aura/mediaserver"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils"
 	"context"
@@ -172,11 +173,11 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 			continue
 		}
 
-		// Get the latest set details from MediUX
-		mediuxSet, _, Err := mediux.GetShowSetByID(ctx, dbSet.ID, mediaItem.LibraryTitle, mediaItem.Edition)
+		// Get the latest set details from the image source
+		mediuxSet, _, Err := imagesource.GetSetByID(ctx, dbSet.BaseSetInfo, mediaItem.TMDB_ID, mediaItem.LibraryTitle, mediaItem.Edition)
 		if Err.Message != "" {
 			setResult.Result = "error"
-			setResult.Reason = "Failed to get latest set details from MediUX"
+			setResult.Reason = fmt.Sprintf("Failed to get latest set details from %s", imagesource.GetSetSource(dbSet.BaseSetInfo))
 			result.Sets = append(result.Sets, setResult)
 			continue
 		}
@@ -400,6 +401,8 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 			Msgf("Image check results for set %s", dbSet.ID)
 
 		_, imageRedownloadsAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Downloading %d updated images for set %s (ID: %s)", len(imagesToRedownload), dbSet.Title, dbSet.ID), logging.LevelInfo)
+		verifier := mediaserver.NewApplyVerifier()
+		pendingResults := map[string]map[string]any{}
 		for idx, image := range imagesToRedownload {
 			// Redownload the image
 			imageRedownloadResult := make(map[string]any)
@@ -407,7 +410,7 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 			imageRedownloadResult["image_type"] = image.Type
 			imageRedownloadResult["redownload_reason"] = image.Reason
 			Err.Message = ""
-			Err := mediaserver.DownloadApplyImageToMediaItem(ctx, &mediaItem, image.ImageFile)
+			verification, Err := verifier.DownloadApplyAndVerifyImage(mediaserver.WithArtworkChanged(artworkhistory.WithReplacingSet(ctx, dbSet.BaseSetInfo)), &mediaItem, image.ImageFile)
 			if Err.Message != "" {
 				imageRedownloadResult["redownload_result"] = "error"
 				imageRedownloadResult["redownload_error"] = Err.Message
@@ -418,6 +421,12 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 				logging.LOGGER.Error().Timestamp().Str("item", utils.MediaItemInfo(mediaItem)).Str("set_id", dbSet.ID).Str("image", utils.GetFileDownloadName(mediaItem.Title, image.ImageFile)).Str("error", Err.Message).Msg("Failed to redownload image for AutoDownload Check")
 				continue
 			} else {
+				recordVerification(&setResult, utils.GetFileDownloadName(mediaItem.Title, image.ImageFile), verification, imageRedownloadResult)
+				if verification == mediaserver.APPLY_PENDING {
+					pendingResults[utils.GetFileDownloadName(mediaItem.Title, image.ImageFile)] = imageRedownloadResult
+				}
+				imageRedownloadsAction.AppendResult(fmt.Sprintf("image_redownload_%d", idx+1), imageRedownloadResult)
+
 				// Send a notification to all configured notification services
 				// We do this asynchronously and don't wait for the result
 				go func(image ImageFileWithReason) {
@@ -425,13 +434,17 @@ func handleShow(ctx context.Context, mediaItem models.MediaItem, dbItem models.D
 				}(image)
 			}
 		}
+		// Images that didn't match right away are checked again together
+		for _, retry := range verifier.RetryPending(ctx) {
+			imageName := utils.GetFileDownloadName(mediaItem.Title, retry.ImageFile)
+			recordVerification(&setResult, imageName, retry.Verification, pendingResults[imageName])
+		}
 		imageRedownloadsAction.Complete()
 
 		// Reinsert the set into the DB item with the updated image info and download date so that it is up to date for the next check
 		Err = insertRedownloadedSetIntoDB(ctx, mediaItem, mediuxSet.PosterSet, dbItem, dbSet)
 
-		setResult.Result = "success"
-		setResult.Reason = fmt.Sprintf("%d images need to be redownloaded", len(imagesToRedownload))
+		finishSetResult(&setResult, len(imagesToRedownload))
 		result.Sets = append(result.Sets, setResult)
 	}
 
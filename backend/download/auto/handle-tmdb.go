@@ -0,0 +1,123 @@
+package autodownload
+
+import (
+	"aura/cache"
+	downloadqueue "aura/download/queue"
+	"aura/imagesource"
+	"aura/logging"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"time"
+)
+
+// handleTMDBSets checks all TMDB fallback sets for an item.
+// If MediUX now has a set for the item, the most popular MediUX set is queued with the same selected types.
+// Once the queue saves the new set, the TMDB set loses all of its selected types and is removed from the database.
+// The returned DB item only contains the non-TMDB sets, so that they can be checked as normal.
+func handleTMDBSets(ctx context.Context, mediaItem models.MediaItem, dbItem models.DBSavedItem) (setResults []AutoDownloadSetResult, remaining models.DBSavedItem) {
+	setResults = []AutoDownloadSetResult{}
+	remaining = dbItem
+	remaining.PosterSets = []models.DBPosterSetDetail{}
+
+	for _, dbSet := range dbItem.PosterSets {
+		if imagesource.GetSetSource(dbSet.BaseSetInfo) != imagesource.SourceTMDB {
+			remaining.PosterSets = append(remaining.PosterSets, dbSet)
+			continue
+		}
+
+		setResult := AutoDownloadSetResult{
+			ID:          dbSet.ID,
+			Title:       dbSet.Title,
+			UserCreated: dbSet.UserCreated,
+		}
+
+		if !dbSet.AutoDownload {
+			setResult.Result = "skipped"
+			setResult.Reason = "Set is not set to auto-download, skipping check for this set"
+			setResults = append(setResults, setResult)
+			continue
+		}
+
+		if !cache.MediuxItems.CheckItemExists(mediaItem.Type, mediaItem.TMDB_ID) {
+			setResult.Result = "skipped"
+			setResult.Reason = "No MediUX sets available for this item yet, keeping the TMDB set"
+			setResults = append(setResults, setResult)
+			continue
+		}
+
+		bestSet, Err := getMostPopularMediuxSet(ctx, mediaItem)
+		if Err.Message != "" {
+			setResult.Result = "error"
+			setResult.Reason = fmt.Sprintf("Failed to get MediUX sets to replace the TMDB set: %s", Err.Message)
+			setResults = append(setResults, setResult)
+			continue
+		}
+		if bestSet.ID == "" {
+			setResult.Result = "skipped"
+			setResult.Reason = "No MediUX sets available for this item yet, keeping the TMDB set"
+			setResults = append(setResults, setResult)
+			continue
+		}
+
+		queueItem := models.DBSavedItem{
+			MediaItem: mediaItem,
+			PosterSets: []models.DBPosterSetDetail{
+				{
+					PosterSet:      bestSet.PosterSet,
+					LastDownloaded: time.Now(),
+					SelectedTypes:  dbSet.SelectedTypes,
+					AutoDownload:   dbSet.AutoDownload,
+				},
+			},
+		}
+		Err = downloadqueue.AddToQueue(ctx, queueItem)
+		if Err.Message != "" {
+			setResult.Result = "error"
+			setResult.Reason = fmt.Sprintf("Failed to queue MediUX set '%s': %s", bestSet.ID, Err.Message)
+			setResults = append(setResults, setResult)
+			continue
+		}
+
+		logging.LOGGER.Info().Timestamp().
+			Str("item", utils.MediaItemInfo(mediaItem)).
+			Str("tmdb_set_id", dbSet.ID).
+			Str("mediux_set_id", bestSet.ID).
+			Msg("Upgrading TMDB set to MediUX set")
+		setResult.Result = "success"
+		setResult.Reason = fmt.Sprintf("MediUX set '%s' by %s is now available, queued it to replace the TMDB set", bestSet.Title, bestSet.UserCreated)
+		setResults = append(setResults, setResult)
+	}
+
+	return setResults, remaining
+}
+
+// getMostPopularMediuxSet returns the MediUX set with the highest popularity for the item
+// An empty set is returned if MediUX has no sets for the item
+func getMostPopularMediuxSet(ctx context.Context, mediaItem models.MediaItem) (bestSet models.SetRef, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Finding most popular MediUX set for %s", utils.MediaItemInfo(mediaItem)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	sets := []models.SetRef{}
+	switch mediaItem.Type {
+	case "show":
+		sets, _, Err = mediux.GetShowItemSets(ctx, mediaItem.TMDB_ID, mediaItem.LibraryTitle, mediaItem.Edition)
+	case "movie":
+		setItems := map[string]models.IncludedItem{}
+		sets, Err = mediux.GetMovieItemSets(ctx, mediaItem.TMDB_ID, mediaItem.LibraryTitle, mediaItem.Edition, &setItems)
+	}
+	if Err.Message != "" {
+		return bestSet, Err
+	}
+
+	for _, set := range sets {
+		if bestSet.ID == "" || set.Popularity > bestSet.Popularity {
+			bestSet = set
+		}
+	}
+	logAction.AppendResult("sets_found", len(sets))
+	logAction.AppendResult("best_set_id", bestSet.ID)
+	return bestSet, logging.LogErrorInfo{}
+}
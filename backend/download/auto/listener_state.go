@@ -0,0 +1,85 @@
+package autodownload
+
+import (
+	"aura/config"
+	"sync"
+	"time"
+)
+
+// ListenerState is the connection state of a media server event listener
+type ListenerState struct {
+	Name      string    `json:"name"`
+	Enabled   bool      `json:"enabled"`
+	Connected bool      `json:"connected"`
+	Since     time.Time `json:"since,omitzero"`       // When the listener last connected or disconnected
+	LastError string    `json:"last_error,omitempty"` // Why the listener last disconnected
+
+	SubscribedSets int `json:"subscribed_sets,omitempty"` // How many saved sets the MediUX listener watches
+}
+
+type listenerStateStore struct {
+	mu    sync.Mutex
+	state ListenerState
+}
+
+var (
+	plexListenerState   = &listenerStateStore{state: ListenerState{Name: plexListenerName}}
+	ejListenerState     = &listenerStateStore{state: ListenerState{Name: ejListenerName}}
+	mediuxListenerState = &listenerStateStore{state: ListenerState{Name: mediuxListenerName}}
+)
+
+func (s *listenerStateStore) setConnected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.Connected = true
+	s.state.Since = time.Now()
+	s.state.LastError = ""
+}
+
+func (s *listenerStateStore) setDisconnected(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state.Connected || err != nil {
+		s.state.Since = time.Now()
+	}
+	s.state.Connected = false
+	if err != nil {
+		s.state.LastError = err.Error()
+	}
+}
+
+func (s *listenerStateStore) setSubscribedSets(count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.SubscribedSets = count
+}
+
+func (s *listenerStateStore) get() ListenerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// EventListenerState returns the state of the event listener of the configured media server
+func EventListenerState() ListenerState {
+	ms := config.Latest().MediaServer
+	switch ms.Type {
+	case "Plex":
+		state := plexListenerState.get()
+		state.Enabled = ms.EnablePlexEventListener
+		return state
+	case "Emby", "Jellyfin":
+		state := ejListenerState.get()
+		state.Enabled = ms.EnableEmbyJellyfinEventListener
+		return state
+	default:
+		return ListenerState{}
+	}
+}
+
+// MediuxListenerState returns the state of the MediUX event listener
+func MediuxListenerState() ListenerState {
+	state := mediuxListenerState.get()
+	state.Enabled = config.Latest().Mediux.EnableEventListener
+	return state
+}
@@ -12,28 +12,28 @@ import (
 
 func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPosterSetDetail, imageWithReason ImageFileWithReason) {
 	// If notifications are disabled, skip
-	if !config.Current.Notifications.Enabled {
+	if !config.Latest().Notifications.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping app start notification")
 		return
 	}
 
 	// If notification providers are not configured, skip
-	if len(config.Current.Notifications.Providers) == 0 {
+	if len(config.Latest().Notifications.Providers) == 0 {
 		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping app start notification")
 		return
 	}
 
 	// If autodownload notification is disabled, skip
-	if !config.Current.Notifications.NotificationTemplate.Autodownload.Enabled {
+	if !config.Latest().Notifications.NotificationTemplate.Autodownload.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Autodownload notification is disabled, skipping app start notification")
 		return
 	}
 
 	vars := utils.TemplateVars_Autodownload(mediaItem, set, imageWithReason.ImageFile, imageWithReason.ReasonTitle, imageWithReason.Reason)
-	title := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.Autodownload.Title, vars)
-	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.Autodownload.Message, vars)
+	title := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.Autodownload.Title, vars)
+	message := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.Autodownload.Message, vars)
 	imageURL := ""
-	if config.Current.Notifications.NotificationTemplate.Autodownload.IncludeImage {
+	if config.Latest().Notifications.NotificationTemplate.Autodownload.IncludeImage {
 		imageURL = fmt.Sprintf("%s/%s?v=%s&key=jpg",
 			"https://images.mediux.io/assets",
 			imageWithReason.ID,
@@ -48,7 +48,7 @@ func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPoste
 	defer logAction.Complete()
 
 	// Send a notification to all configured providers
-	for _, provider := range config.Current.Notifications.Providers {
+	for _, provider := range config.Current(ctx).Notifications.Providers {
 		if provider.Enabled {
 			switch provider.Provider {
 			case "Discord":
@@ -0,0 +1,223 @@
+package autodownload
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	downloadqueue "aura/download/queue"
+	"aura/imagesource"
+	"aura/logging"
+	"aura/models"
+	"aura/notification"
+	"aura/utils"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// CheckWantedItems looks for wanted items that have landed in the library cache.
+// The chosen set of every landed item is saved and queued, and the item is removed from the wanted list.
+// The library keys of the handled items are returned so they can be left out of AutoApply.
+func CheckWantedItems(ctx context.Context) (handled map[string]bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Checking Wanted Items", logging.LevelDebug)
+	defer logAction.Complete()
+
+	handled = map[string]bool{}
+	if cache.LibraryStore.IsEmpty() {
+		logAction.AppendResult("skipped", "Library cache is empty")
+		return handled
+	}
+
+	wantedItems, Err := database.GetAllWantedItems(ctx)
+	if Err.Message != "" {
+		return handled
+	}
+
+	queued := 0
+	for _, wanted := range wantedItems {
+		cachedItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(wanted.LibraryTitle, wanted.TMDB_ID)
+		if !found || cachedItem == nil || cachedItem.Type != wanted.Type {
+			continue
+		}
+
+		item := *cachedItem
+		handled[libraryItemKey(item)] = true
+		if handleLandedWantedItem(ctx, wanted, item) {
+			queued++
+		}
+	}
+
+	logAction.AppendResult("wanted_items", len(wantedItems))
+	logAction.AppendResult("landed", len(handled))
+	logAction.AppendResult("queued", queued)
+	return handled
+}
+
+// ExcludeLibraryItems returns the items whose library key is not in the given keys
+func ExcludeLibraryItems(items []models.MediaItem, keys map[string]bool) []models.MediaItem {
+	return slices.DeleteFunc(items, func(item models.MediaItem) bool {
+		return keys[libraryItemKey(item)]
+	})
+}
+
+// IsWantedItem returns true if the TMDB ID and type are on the wanted list
+func IsWantedItem(ctx context.Context, tmdbID, itemType string) bool {
+	_, found := GetWantedItem(ctx, tmdbID, itemType)
+	return found
+}
+
+// GetWantedItem returns the wanted list entry for the TMDB ID and type
+func GetWantedItem(ctx context.Context, tmdbID, itemType string) (wanted models.DBWantedItem, found bool) {
+	wantedItems, Err := database.GetAllWantedItems(ctx)
+	if Err.Message != "" {
+		return wanted, false
+	}
+	for _, wanted := range wantedItems {
+		if wanted.TMDB_ID == tmdbID && wanted.Type == itemType {
+			return wanted, true
+		}
+	}
+	return models.DBWantedItem{}, false
+}
+
+// handleLandedWantedItem saves and queues the chosen set for an item that is now in the library.
+// Items that are ignored or already have a saved set are only removed from the wanted list.
+// When the set can't be fetched, the item stays on the list and is tried again on the next check.
+func handleLandedWantedItem(ctx context.Context, wanted models.DBWantedItem, item models.MediaItem) (queued bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Handling Wanted Item %s", utils.MediaItemInfo(item)), logging.LevelInfo)
+	defer logAction.Complete()
+
+	ignored, _, savedSets, Err := database.CheckIfMediaItemExists(ctx, item.TMDB_ID, item.LibraryTitle, item.Edition)
+	if Err.Message != "" {
+		return false
+	}
+	if ignored || len(savedSets) > 0 {
+		reason := "Item already has a saved set"
+		if ignored {
+			reason = "Item is ignored"
+		}
+		logAction.AppendResult("skipped", reason)
+		database.DeleteWantedItem(ctx, wanted.TMDB_ID, wanted.Type)
+		return false
+	}
+
+	// Get the latest set details from the image source the set came from
+	set, _, Err := imagesource.GetSetByID(ctx, wanted.Set, item.TMDB_ID, item.LibraryTitle, item.Edition)
+	if Err.Message != "" {
+		logAction.AppendWarning("message", "Failed to get the wanted set, it will be tried again on the next check")
+		return false
+	}
+	if len(set.Images) == 0 {
+		logAction.AppendWarning("message", fmt.Sprintf("Set '%s' has no images for this item yet, it will be tried again on the next check", wanted.Set.ID))
+		return false
+	}
+
+	queueItem := models.DBSavedItem{
+		MediaItem: item,
+		PosterSets: []models.DBPosterSetDetail{
+			{
+				PosterSet:      set.PosterSet,
+				LastDownloaded: time.Now(),
+				SelectedTypes:  wanted.SelectedTypes,
+				AutoDownload:   wanted.AutoDownload,
+			},
+		},
+	}
+	Err = downloadqueue.AddToQueue(ctx, queueItem)
+	if Err.Message != "" {
+		return false
+	}
+
+	Err = database.DeleteWantedItem(ctx, wanted.TMDB_ID, wanted.Type)
+	if Err.Message != "" {
+		logAction.AppendWarning("message", "Set was queued, but the item could not be removed from the wanted list")
+	}
+
+	logging.LOGGER.Info().Timestamp().
+		Str("item", utils.MediaItemInfo(item)).
+		Str("set_id", set.ID).
+		Str("user_created", set.UserCreated).
+		Msg("Wanted Item: Queued set for item that landed in the library")
+	go sendWantedItemAvailableNotification(item, set)
+	return true
+}
+
+func sendWantedItemAvailableNotification(mediaItem models.MediaItem, set models.SetRef) {
+	// If notifications are disabled, skip
+	if !config.Latest().Notifications.Enabled {
+		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping wanted item notification")
+		return
+	}
+
+	// If notification providers are not configured, skip
+	if len(config.Latest().Notifications.Providers) == 0 {
+		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping wanted item notification")
+		return
+	}
+
+	// If wanted item notification is disabled, skip
+	if !config.Latest().Notifications.NotificationTemplate.WantedItemAvailable.Enabled {
+		logging.LOGGER.Debug().Timestamp().Msg("Wanted item notification is disabled, skipping wanted item notification")
+		return
+	}
+
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "Notification - Send Wanted Item Available Message")
+	logAction := ld.AddAction("Sending Wanted Item Available Notification", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+	defer ld.Log()
+	defer logAction.Complete()
+
+	vars := utils.TemplateVars_WantedItemAvailable(mediaItem, set.BaseSetInfo)
+	title := utils.RenderTemplate(config.Current(ctx).Notifications.NotificationTemplate.WantedItemAvailable.Title, vars)
+	message := utils.RenderTemplate(config.Current(ctx).Notifications.NotificationTemplate.WantedItemAvailable.Message, vars)
+	imageURL := ""
+	if config.Current(ctx).Notifications.NotificationTemplate.WantedItemAvailable.IncludeImage {
+		for _, image := range set.Images {
+			if image.Type == "poster" {
+				imageURL, _ = imagesource.GetImageURL(ctx, image)
+				break
+			}
+		}
+	}
+
+	// Send a notification to all configured providers
+	for _, provider := range config.Current(ctx).Notifications.Providers {
+		if provider.Enabled {
+			switch provider.Provider {
+			case "Discord":
+				notification.SendDiscordMessage(
+					ctx,
+					provider.Discord,
+					message,
+					imageURL,
+					title,
+				)
+			case "Pushover":
+				notification.SendPushoverMessage(
+					ctx,
+					provider.Pushover,
+					message,
+					imageURL,
+					title,
+				)
+			case "Gotify":
+				notification.SendGotifyMessage(
+					ctx,
+					provider.Gotify,
+					message,
+					imageURL,
+					title,
+				)
+			case "Webhook":
+				notification.SendWebhookMessage(
+					ctx,
+					provider.Webhook,
+					message,
+					imageURL,
+					title,
+				)
+			}
+		}
+	}
+}
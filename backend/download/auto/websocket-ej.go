@@ -0,0 +1,411 @@
+package autodownload
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// The Emby/Jellyfin listener uses the same timings as the Plex listener
+const (
+	ejListenerName      = "Emby/Jellyfin Event Listener"
+	ejReconnectDelay    = plexReconnectDelay
+	ejScanCoolDown      = plexScanCoolDown
+	ejDedupWindow       = plexDedupWindow
+	ejItemCacheTTL      = plexItemCacheTTL
+	ejKeepAliveInterval = 30 * time.Second
+)
+
+var ejRefreshEventDeduper = struct {
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+}{
+	lastSeen: make(map[string]time.Time),
+}
+
+// ejReAppliedItems holds the items that had their images re-applied recently.
+// Uploading an image makes Emby/Jellyfin send another ItemsUpdated event for the item,
+// so events for these items are ignored for ejScanCoolDown to avoid re-applying in a loop.
+var ejReAppliedItems = struct {
+	mu        sync.Mutex
+	appliedAt map[string]time.Time
+}{
+	appliedAt: make(map[string]time.Time),
+}
+
+var ejRefreshedItemsCache = struct {
+	mu    sync.Mutex
+	items map[string]cachedPlexItem
+}{
+	items: make(map[string]cachedPlexItem),
+}
+
+var (
+	ejWSControlMu sync.Mutex
+	ejWSStopChan  chan struct{}
+)
+
+// StartOrRestartEJWebSocketClient stops any running Emby/Jellyfin WebSocket goroutine and starts a new one.
+func StartOrRestartEJWebSocketClient() {
+	ejWSControlMu.Lock()
+	defer ejWSControlMu.Unlock()
+
+	// Stop previous goroutine if running
+	if ejWSStopChan != nil {
+		close(ejWSStopChan)
+		ejWSStopChan = nil
+	}
+
+	stopChan := make(chan struct{})
+	ejWSStopChan = stopChan
+
+	go func(stop <-chan struct{}) {
+		for {
+			if (config.Latest().MediaServer.Type != "Emby" && config.Latest().MediaServer.Type != "Jellyfin") ||
+				!config.Latest().MediaServer.EnableEmbyJellyfinEventListener {
+				select {
+				case <-stop:
+					return
+				case <-time.After(ejScanCoolDown):
+				}
+				continue
+			}
+
+			err := connectAndListenEJWithStop(stop)
+			if err != nil {
+				logging.LOGGER.Error().Timestamp().Err(err).Msgf("%s WebSocket connection error", config.Latest().MediaServer.Type)
+			}
+
+			logging.LOGGER.Warn().Timestamp().Msgf("Reconnecting to %s WebSocket in %s...", config.Latest().MediaServer.Type, ejReconnectDelay)
+			select {
+			case <-stop:
+				return
+			case <-time.After(ejReconnectDelay):
+			}
+		}
+	}(stopChan)
+}
+
+// connectAndListenEJWithStop connects to the Emby/Jellyfin WebSocket and handles messages until stop is closed or the connection drops.
+func connectAndListenEJWithStop(stop <-chan struct{}) (err error) {
+	defer func() { ejListenerState.setDisconnected(err) }()
+
+	serverType := config.Latest().MediaServer.Type
+	wsURL, wsURLForLog, err := buildEJWebSocketURL()
+	if err != nil {
+		return err
+	}
+
+	logging.LOGGER.Info().Timestamp().Str("url", wsURLForLog).
+		Msgf("%s: Connecting to %s WebSocket", ejListenerName, serverType)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s WebSocket at %s: %w", serverType, wsURLForLog, err)
+	}
+	defer conn.Close()
+
+	logging.LOGGER.Info().Timestamp().
+		Msgf("%s: Connected — watching for library change events", ejListenerName)
+	ejListenerState.setConnected()
+
+	// The server closes idle sessions, so keep the session alive until this connection is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(ejKeepAliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteJSON(ejWebSocketMessage{MessageType: "KeepAlive"}); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	// ReadMessage blocks until a message arrives, so close the connection to stop it as soon as stop is closed
+	go func() {
+		select {
+		case <-stop:
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(2 * ejKeepAliveInterval))
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+			return fmt.Errorf("error reading from %s WebSocket: %w", serverType, err)
+		}
+		handleEJMessage(message)
+	}
+}
+
+func buildEJWebSocketURL() (wsURL string, wsURLForLog string, err error) {
+	u, err := url.Parse(strings.TrimRight(config.Latest().MediaServer.URL, "/"))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse %s URL: %w", config.Latest().MediaServer.Type, err)
+	}
+
+	// Determine the ws/wss scheme from the http/https URL
+	if u.Scheme == "https" {
+		u.Scheme = "wss"
+	} else {
+		u.Scheme = "ws"
+	}
+
+	// Jellyfin serves the session socket at /socket, Emby at /embywebsocket
+	if config.Latest().MediaServer.Type == "Jellyfin" {
+		u.Path += "/socket"
+	} else {
+		u.Path += "/embywebsocket"
+	}
+
+	token := config.Latest().MediaServer.ApiToken
+	query := url.Values{}
+	query.Set("deviceId", "aura")
+	query.Set("api_key", token)
+	u.RawQuery = query.Encode()
+	wsURL = u.String()
+
+	query.Set("api_key", config.MaskToken(token))
+	u.RawQuery = query.Encode()
+	wsURLForLog = u.String()
+
+	return wsURL, wsURLForLog, nil
+}
+
+func handleEJMessage(message []byte) {
+	var payload ejWebSocketMessage
+	if err := json.Unmarshal(message, &payload); err != nil {
+		return
+	}
+
+	// Other session messages (KeepAlive, Sessions, UserDataChanged...) are ignored
+	if payload.MessageType != "LibraryChanged" || len(payload.Data) == 0 {
+		return
+	}
+
+	var libraryChanged EJLibraryChangedData
+	if err := json.Unmarshal(payload.Data, &libraryChanged); err != nil {
+		return
+	}
+
+	for _, itemID := range libraryChanged.ItemsUpdated {
+		processEJRefreshEvent(itemID)
+	}
+}
+
+func processEJRefreshEvent(itemID string) {
+	if !shouldEmitEJRefreshEvent(itemID) {
+		return
+	}
+
+	refreshedItem, ok := resolveEJUpdatedItemFromCache(itemID)
+	if !ok || refreshedItem.MediaItem.RatingKey == "" {
+		// ItemsUpdated also includes folders, extras and items outside the managed libraries
+		logging.DevMsgf("%s: No matching item found in cache for item ID %s", ejListenerName, itemID)
+		return
+	}
+
+	if cachedMediaItem, found := getEJCachedRefreshedMediaItem(refreshedItem.MediaItem.RatingKey); found {
+		refreshedItem.MediaItem = cachedMediaItem
+		go reApplyEJSavedImages(refreshedItem)
+		return
+	}
+
+	ctx, ld := logging.CreateLoggingContext(context.Background(), ejListenerName)
+	logAction := ld.AddAction("Refresh Item on Metadata Update", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	_, Err := mediaserver.GetMediaItemDetails(ctx, &refreshedItem.MediaItem)
+	if Err.Message != "" {
+		logAction.SetError(
+			"Failed to refresh item details",
+			"Review errors for more details",
+			map[string]any{
+				"title":      refreshedItem.MediaItem.Title,
+				"rating_key": refreshedItem.MediaItem.RatingKey,
+				"error":      Err.Message,
+			},
+		)
+		ld.Log()
+		return
+	}
+
+	// Persist refreshed details back into the cache
+	cache.LibraryStore.UpdateMediaItem(refreshedItem.MediaItem.LibraryTitle, &refreshedItem.MediaItem)
+	setEJCachedRefreshedMediaItem(refreshedItem.MediaItem)
+
+	logging.LOGGER.Info().Timestamp().
+		Str("item_id", itemID).
+		Str("item_title", refreshedItem.MediaItem.Title).
+		Str("item_type", refreshedItem.ItemType).
+		Msgf("%s: Detected metadata refresh for item %s", ejListenerName, utils.MediaItemInfo(refreshedItem.MediaItem))
+
+	go reApplyEJSavedImages(refreshedItem)
+}
+
+// reApplyEJSavedImages re-applies the saved images and starts the cooldown for the item.
+// Uploads to a show also send ItemsUpdated events for its seasons and episodes, so they cool down too.
+func reApplyEJSavedImages(item MediaServerRefreshedItem) {
+	itemIDs := ejItemAndChildIDs(item)
+	markEJItemsReApplied(itemIDs)
+	reApplySavedImages(ejListenerName, item)
+	// Start the cooldown again once the uploads are done, the update events they cause can arrive late
+	markEJItemsReApplied(itemIDs)
+}
+
+// ejItemAndChildIDs returns the ID of the updated item, of its movie or show and of every season and episode of the show
+func ejItemAndChildIDs(item MediaServerRefreshedItem) []string {
+	itemIDs := []string{item.ItemRatingKey, item.MediaItem.RatingKey}
+	if item.MediaItem.Series == nil {
+		return itemIDs
+	}
+	for _, season := range item.MediaItem.Series.Seasons {
+		itemIDs = append(itemIDs, season.RatingKey)
+		for _, episode := range season.Episodes {
+			itemIDs = append(itemIDs, episode.RatingKey)
+		}
+	}
+	return itemIDs
+}
+
+// shouldEmitEJRefreshEvent drops repeated events for the same item within ejDedupWindow
+// and events for items that are cooling down after their images were re-applied
+func shouldEmitEJRefreshEvent(itemID string) bool {
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return false
+	}
+	now := time.Now()
+
+	ejReAppliedItems.mu.Lock()
+	for key, ts := range ejReAppliedItems.appliedAt {
+		if now.Sub(ts) > ejScanCoolDown {
+			delete(ejReAppliedItems.appliedAt, key)
+		}
+	}
+	_, coolingDown := ejReAppliedItems.appliedAt[itemID]
+	ejReAppliedItems.mu.Unlock()
+	if coolingDown {
+		return false
+	}
+
+	ejRefreshEventDeduper.mu.Lock()
+	defer ejRefreshEventDeduper.mu.Unlock()
+
+	for key, ts := range ejRefreshEventDeduper.lastSeen {
+		if now.Sub(ts) > ejDedupWindow {
+			delete(ejRefreshEventDeduper.lastSeen, key)
+		}
+	}
+
+	if lastSeen, exists := ejRefreshEventDeduper.lastSeen[itemID]; exists {
+		if now.Sub(lastSeen) <= ejDedupWindow {
+			return false
+		}
+	}
+
+	ejRefreshEventDeduper.lastSeen[itemID] = now
+	return true
+}
+
+func markEJItemsReApplied(itemIDs []string) {
+	ejReAppliedItems.mu.Lock()
+	defer ejReAppliedItems.mu.Unlock()
+	now := time.Now()
+	for _, itemID := range itemIDs {
+		if itemID != "" {
+			ejReAppliedItems.appliedAt[itemID] = now
+		}
+	}
+}
+
+// resolveEJUpdatedItemFromCache finds the movie or show that an updated item ID belongs to.
+// Season and episode IDs can only be matched once the show details (with seasons and episodes) are in the cache.
+func resolveEJUpdatedItemFromCache(itemID string) (updated MediaServerRefreshedItem, ok bool) {
+	item, itemType, found := cache.LibraryStore.GetMediaItemByAnyRatingKey(itemID)
+	if !found {
+		return MediaServerRefreshedItem{}, false
+	}
+	return MediaServerRefreshedItem{
+		MediaItem:     item,
+		ItemRatingKey: itemID,
+		ItemType:      itemType,
+	}, true
+}
+
+func getEJCachedRefreshedMediaItem(ratingKey string) (models.MediaItem, bool) {
+	if strings.TrimSpace(ratingKey) == "" {
+		return models.MediaItem{}, false
+	}
+
+	now := time.Now()
+
+	ejRefreshedItemsCache.mu.Lock()
+	defer ejRefreshedItemsCache.mu.Unlock()
+
+	for key, item := range ejRefreshedItemsCache.items {
+		if now.Sub(item.storedAt) > ejItemCacheTTL {
+			delete(ejRefreshedItemsCache.items, key)
+		}
+	}
+
+	cached, exists := ejRefreshedItemsCache.items[ratingKey]
+	if !exists {
+		return models.MediaItem{}, false
+	}
+
+	return cached.mediaItem, true
+}
+
+func setEJCachedRefreshedMediaItem(item models.MediaItem) {
+	if strings.TrimSpace(item.RatingKey) == "" {
+		return
+	}
+
+	ejRefreshedItemsCache.mu.Lock()
+	defer ejRefreshedItemsCache.mu.Unlock()
+
+	ejRefreshedItemsCache.items[item.RatingKey] = cachedPlexItem{
+		mediaItem: item,
+		storedAt:  time.Now(),
+	}
+}
+
+type ejWebSocketMessage struct {
+	MessageType string          `json:"MessageType"`
+	MessageId   string          `json:"MessageId,omitempty"`
+	Data        json.RawMessage `json:"Data,omitempty"`
+}
+
+type EJLibraryChangedData struct {
+	ItemsAdded         []string `json:"ItemsAdded"`
+	ItemsUpdated       []string `json:"ItemsUpdated"`
+	ItemsRemoved       []string `json:"ItemsRemoved"`
+	FoldersAddedTo     []string `json:"FoldersAddedTo"`
+	FoldersRemovedFrom []string `json:"FoldersRemovedFrom"`
+}
@@ -7,154 +7,437 @@ import (
 	"aura/mediux"
 	"aura/models"
 	"aura/utils"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"net/url"
-	"strconv"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 )
 
 const (
-	mediuxReconnectDelay = 5 * time.Second
+	mediuxListenerName = "MediUX Event Listener"
+	// mediuxReconnectDelay is the first wait after the connection drops, doubled for every failed attempt after it
+	mediuxReconnectDelay    = 5 * time.Second
+	mediuxReconnectMaxDelay = 5 * time.Minute
+	mediuxDisabledCheck     = 30 * time.Second
+	// mediuxReadTimeout closes the connection if MediUX sends nothing (not even a ping) for this long
+	mediuxReadTimeout = 2 * time.Minute
+	// mediuxDebounceWindow is how long a set has to be quiet before its items are checked,
+	// so a creator uploading many images to a set only triggers one check
+	mediuxDebounceWindow = 45 * time.Second
+	// mediuxDebounceMaxWait is the longest a check is pushed back while a set keeps changing
+	mediuxDebounceMaxWait = 5 * time.Minute
 )
 
-func StartMediuxWebSocketClient() {
-	go func() {
+// mediuxSetCollections maps the saved set type to the MediUX collection of the set and
+// the field that links an image to a set of that type
+var mediuxSetCollections = []struct {
+	setType    string
+	collection string
+	imageField string
+}{
+	{"show", "show_sets", "show_set"},
+	{"movie", "movie_sets", "movie_set"},
+	{"collection", "collection_sets", "collection_set"},
+}
+
+const mediuxFilesCollection = "directus_files"
+
+var (
+	mediuxWSControlMu sync.Mutex
+	mediuxWSStopChan  chan struct{}
+	// mediuxResubscribe is signalled when saved sets change, so the subscriptions are rebuilt
+	mediuxResubscribe = make(chan struct{}, 1)
+)
+
+func init() {
+	database.OnSavedSetsChanged(func() {
+		select {
+		case mediuxResubscribe <- struct{}{}:
+		default:
+		}
+	})
+}
+
+// StartOrRestartMediuxWebSocketClient stops any running MediUX WebSocket goroutine and starts a new one.
+// While Mediux.EnableEventListener is off, the goroutine only waits for it to be turned on.
+func StartOrRestartMediuxWebSocketClient() {
+	mediuxWSControlMu.Lock()
+	defer mediuxWSControlMu.Unlock()
+
+	// Stop previous goroutine if running
+	if mediuxWSStopChan != nil {
+		close(mediuxWSStopChan)
+		mediuxWSStopChan = nil
+	}
+
+	stopChan := make(chan struct{})
+	mediuxWSStopChan = stopChan
+
+	go func(stop <-chan struct{}) {
+		failures := 0
 		for {
-			err := connectAndSubscribeMediux()
+			if !config.Latest().Mediux.EnableEventListener || !config.Latest().Status.MediuxValid {
+				select {
+				case <-stop:
+					return
+				case <-time.After(mediuxDisabledCheck):
+				}
+				continue
+			}
+
+			connected, err := connectAndListenMediuxWithStop(stop)
 			if err != nil {
-				logging.LOGGER.Error().Timestamp().Err(err).Msg("Mediux WebSocket connection error")
+				logging.LOGGER.Error().Timestamp().Err(err).Msg("MediUX WebSocket connection error")
+			}
+			if connected {
+				failures = 0
+			}
+			delay := mediuxReconnectBackoff(failures)
+			failures++
+
+			logging.LOGGER.Warn().Timestamp().Msgf("Reconnecting to MediUX WebSocket in %s...", delay.Round(time.Second))
+			select {
+			case <-stop:
+				return
+			case <-time.After(delay):
 			}
-			logging.LOGGER.Warn().Timestamp().Msgf("Reconnecting to WebSocket in %s...", mediuxReconnectDelay)
-			// Wait before reconnecting
-			time.Sleep(mediuxReconnectDelay)
 		}
-	}()
+	}(stopChan)
+}
+
+// mediuxReconnectBackoff returns how long to wait before reconnecting after failures attempts in a row failed
+func mediuxReconnectBackoff(failures int) time.Duration {
+	delay := mediuxReconnectMaxDelay
+	if failures < 10 {
+		delay = min(mediuxReconnectDelay<<failures, mediuxReconnectMaxDelay)
+	}
+	// Spread reconnects out, so every instance doesn't reconnect at once when MediUX comes back
+	return delay/2 + rand.N(delay/2+1)
 }
 
-func connectAndSubscribeMediux() error {
-	// Build WebSocket URL with token
-	URL, err := buildMediuxWebSocketURL()
+// connectAndListenMediuxWithStop listens for updates to saved sets until the connection drops or stop is closed.
+// connected is true if the connection was made, so the caller can reset its backoff.
+func connectAndListenMediuxWithStop(stop <-chan struct{}) (connected bool, err error) {
+	defer func() { mediuxListenerState.setDisconnected(err) }()
+
+	wsURL, err := buildMediuxWebSocketURL()
 	if err != nil {
-		return err
+		return false, err
 	}
-	maskedToken := config.MaskToken(config.Current.Mediux.ApiToken)
-	maskedURL := strings.ReplaceAll(URL, config.Current.Mediux.ApiToken, maskedToken)
+	token := config.Latest().Mediux.ApiToken
+	wsURLForLog := strings.ReplaceAll(wsURL, url.QueryEscape(token), config.MaskToken(token))
 
-	logging.LOGGER.Info().Timestamp().Str("url", maskedURL).
-		Msg("Mediux Event Listener: Connecting to Mediux WebSocket")
+	logging.LOGGER.Info().Timestamp().Str("url", wsURLForLog).
+		Msg("MediUX Event Listener: Connecting to MediUX WebSocket")
 
 	// Connect to WebSocket
-	c, _, err := websocket.DefaultDialer.Dial(URL, nil)
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to connect to Mediux WebSocket at %s: %w", maskedURL, err)
+		return false, fmt.Errorf("failed to connect to MediUX WebSocket at %s: %w", wsURLForLog, err)
 	}
-	defer c.Close()
+	defer conn.Close()
 
-	collectionType := "show_sets"
-	subscribeMsg := map[string]any{
-		"type":       "subscribe",
-		"collection": collectionType,
-	}
+	logging.LOGGER.Info().Timestamp().
+		Msg("MediUX Event Listener: Connected — watching saved sets for updates")
+	mediuxListenerState.setConnected()
 
-	if err := c.WriteJSON(subscribeMsg); err != nil {
-		return err
+	// Only this goroutine writes to the connection, the reader below only reads
+	messages := make(chan []byte)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			_ = conn.SetReadDeadline(time.Now().Add(mediuxReadTimeout))
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case messages <- message:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	subscribed := map[string][]string{}
+	if err := resubscribeMediux(conn, subscribed); err != nil {
+		return true, err
 	}
-	logging.LOGGER.Debug().Timestamp().Str("collection", collectionType).Msg("Subscribed to Mediux WebSocket collection")
 
-	// Listen for messages until error/close
 	for {
-		_, message, err := c.ReadMessage()
-		if err != nil {
-			return err
+		select {
+		case <-stop:
+			logging.LOGGER.Info().Timestamp().Msg("MediUX Event Listener: Stopped")
+			return true, nil
+		case err := <-readErr:
+			return true, err
+		case <-mediuxResubscribe:
+			if err := resubscribeMediux(conn, subscribed); err != nil {
+				return true, err
+			}
+		case message := <-messages:
+			if err := handleMediuxMessage(conn, message); err != nil {
+				return true, err
+			}
 		}
+	}
+}
 
-		// Decode message
-		var msg MediuxWebSocketResponseMessage
-		if err := json.Unmarshal(message, &msg); err != nil {
-			logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to unmarshal WebSocket message")
+// resubscribeMediux subscribes to the saved sets that are checked by AutoDownload.
+// subscribed holds the set IDs of the current subscriptions by uid, and is updated in place.
+// Subscriptions whose set IDs didn't change are kept as they are.
+func resubscribeMediux(conn *websocket.Conn, subscribed map[string][]string) error {
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "MediUX Event Listener - Subscribe to Saved Sets")
+	logAction := ld.AddAction("Getting saved sets to subscribe to", logging.LevelDebug)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+	defer ld.Log()
+	defer logAction.Complete()
+
+	setIDsByType, Err := getMediuxSetIDsToWatch(ctx)
+	if Err.Message != "" {
+		// Keep the current subscriptions, they are rebuilt the next time saved sets change or the connection is made again
+		return nil
+	}
+
+	wanted := map[string]map[string]any{}
+	wantedIDs := map[string][]string{}
+	allSetFilters := []map[string]any{}
+	totalSets := 0
+	for _, c := range mediuxSetCollections {
+		ids := setIDsByType[c.setType]
+		if len(ids) == 0 {
 			continue
 		}
+		totalSets += len(ids)
+		wantedIDs[c.collection] = ids
+		wanted[c.collection] = map[string]any{
+			"filter": map[string]any{"id": map[string]any{"_in": ids}},
+			"fields": []string{"id", "set_title", "date_updated"},
+		}
+		allSetFilters = append(allSetFilters, map[string]any{c.imageField: map[string]any{"_in": ids}})
+	}
+	if len(allSetFilters) > 0 {
+		// New and replaced images don't always change the set itself, so the images are watched as well
+		fileIDs := []string{}
+		for _, c := range mediuxSetCollections {
+			fileIDs = append(fileIDs, wantedIDs[c.collection]...)
+		}
+		wantedIDs[mediuxFilesCollection] = fileIDs
+		fields := []string{"id"}
+		for _, c := range mediuxSetCollections {
+			fields = append(fields, c.imageField)
+		}
+		wanted[mediuxFilesCollection] = map[string]any{
+			"filter": map[string]any{"_or": allSetFilters},
+			"fields": fields,
+		}
+	}
 
-		// Handle Ping messages
-		if msg.Type == "ping" {
-			pongMsg := map[string]string{
-				"type": "pong",
-			}
-			if err := c.WriteJSON(pongMsg); err != nil {
-				logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to send pong message")
-			}
+	for uid := range subscribed {
+		if _, ok := wanted[uid]; ok && slices.Equal(subscribed[uid], wantedIDs[uid]) {
 			continue
 		}
-
-		// Handle message based on type/event as needed
-		if msg.Event == "init" {
+		if err := conn.WriteJSON(map[string]any{"type": "unsubscribe", "uid": uid}); err != nil {
+			return err
+		}
+		delete(subscribed, uid)
+	}
+	for uid, query := range wanted {
+		if _, ok := subscribed[uid]; ok {
 			continue
-		} else if msg.Event == "update" {
-			for set := range msg.UpdatedSets {
-				ctx, ld := logging.CreateLoggingContext(context.Background(), "Mediux Update - Processing Set")
-				logAction := ld.AddAction("Fetching All Items from DB", logging.LevelDebug)
-				ctx = logging.WithCurrentAction(ctx, logAction)
-
-				setID := msg.UpdatedSets[set].ID
-				showID := msg.UpdatedSets[set].ShowID
-				showTitle := msg.UpdatedSets[set].Title
-
-				logAction.AppendResult("set_id", setID)
-				logAction.AppendResult("set_show_id", showID)
-				logAction.AppendResult("set_title", showTitle)
-
-				logging.LOGGER.Info().Timestamp().Int("set_id", msg.UpdatedSets[set].ID).Str("set_title", msg.UpdatedSets[set].Title).Msg("Show set updated")
-
-				// Get all items from the database that match this Set
-				dbFilter := models.DBFilter{
-					ItemTMDB_ID: showID,
-					SetID:       strconv.Itoa(setID),
-				}
-				out, Err := database.GetAllSavedSets(ctx, dbFilter)
-				if Err.Message != "" {
-					logging.LOGGER.Error().Timestamp().Str("error", Err.Message).Msg("Failed to fetch items from DB for updated set")
-					continue
-				}
-				if len(out.Items) == 0 {
-					continue // No items found in database for this set, skip processing
-				}
+		}
+		if err := conn.WriteJSON(map[string]any{
+			"type":       "subscribe",
+			"collection": uid,
+			"uid":        uid,
+			"query":      query,
+		}); err != nil {
+			return err
+		}
+		subscribed[uid] = wantedIDs[uid]
+	}
+
+	mediuxListenerState.setSubscribedSets(totalSets)
+	logAction.AppendResult("subscribed_sets", totalSets)
+	logging.LOGGER.Debug().Timestamp().Int("subscribed_sets", totalSets).Msg("MediUX Event Listener: Subscribed to saved sets")
+	return nil
+}
+
+// getMediuxSetIDsToWatch returns the IDs of the saved MediUX sets that have AutoDownload on, by set type.
+// Other sets are skipped by CheckItem, so there is no reason to listen for their updates.
+func getMediuxSetIDsToWatch(ctx context.Context) (setIDsByType map[string][]string, Err logging.LogErrorInfo) {
+	out, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
+	if Err.Message != "" {
+		return nil, Err
+	}
+
+	seen := map[string]bool{}
+	setIDsByType = map[string][]string{}
+	for _, item := range out.Items {
+		for _, set := range item.PosterSets {
+			if !set.AutoDownload || (set.Source != "" && set.Source != "mediux") || seen[set.ID] {
+				continue
+			}
+			seen[set.ID] = true
+			setIDsByType[set.Type] = append(setIDsByType[set.Type], set.ID)
+		}
+	}
+	for setType := range setIDsByType {
+		slices.Sort(setIDsByType[setType])
+	}
+	return setIDsByType, logging.LogErrorInfo{}
+}
+
+func handleMediuxMessage(conn *websocket.Conn, message []byte) error {
+	var msg MediuxWebSocketResponseMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to unmarshal MediUX WebSocket message")
+		return nil
+	}
 
-				logging.LOGGER.Debug().Timestamp().Int("num_items", len(out.Items)).Msg("Found Items in DB for updated set")
-
-				// Process each item in the set
-				for _, dbItem := range out.Items {
-					logging.LOGGER.Debug().Timestamp().Msgf("Processing %s since a set it contains was updated", utils.MediaItemInfo(dbItem.MediaItem))
-					result := CheckItem(ctx, dbItem)
-					switch result.OverallResult {
-					case "Success":
-						logging.LOGGER.Info().Timestamp().Msgf("Auto-download success for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					case "Warn":
-						logging.LOGGER.Warn().Timestamp().Msgf("Auto-download warning for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					case "Error":
-						logging.LOGGER.Error().Timestamp().Msgf("Auto-download error for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					case "Skipped":
-						logging.LOGGER.Debug().Timestamp().Msgf("Auto-download skipped for item %s", utils.MediaItemInfo(dbItem.MediaItem))
-						logAction.AppendResult("auto_download_result", result)
-					}
+	switch {
+	case msg.Type == "ping":
+		return conn.WriteJSON(map[string]string{"type": "pong"})
+	case msg.Status == "error":
+		logging.LOGGER.Warn().Timestamp().
+			Str("type", msg.Type).
+			Str("uid", msg.UID).
+			RawJSON("error", nonEmptyJSON(msg.Error)).
+			Msg("MediUX Event Listener: MediUX returned an error")
+		return nil
+	case msg.Type != "subscription":
+		return nil
+	}
+
+	// init lists the current items of a subscription, only changes after it matter
+	if msg.Event != "create" && msg.Event != "update" {
+		return nil
+	}
+
+	var items []MediuxWebSocketUpdateData
+	if err := json.Unmarshal(msg.Data, &items); err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Str("uid", msg.UID).Msg("Failed to unmarshal MediUX WebSocket update")
+		return nil
+	}
+
+	for _, item := range items {
+		if msg.UID == mediuxFilesCollection {
+			for _, setID := range []mediuxID{item.ShowSet, item.MovieSet, item.CollectionSet} {
+				if setID != "" {
+					queueMediuxSetCheck(string(setID), fmt.Sprintf("image %s %sd", item.ID, msg.Event))
 				}
-				ld.Log()
 			}
-		} else {
-			logging.LOGGER.Warn().Timestamp().
-				Str("type", msg.Type).
-				Str("event", msg.Event).
-				Interface("full", msg).
-				Msg("Unhandled WebSocket message type/event")
+			continue
+		}
+		queueMediuxSetCheck(string(item.ID), fmt.Sprintf("set %sd", msg.Event))
+	}
+	return nil
+}
+
+func nonEmptyJSON(raw json.RawMessage) json.RawMessage {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
+
+type pendingMediuxSetCheck struct {
+	firstSeen time.Time
+	timer     *time.Timer
+	reasons   []string
+}
+
+var mediuxSetCheckDebouncer = struct {
+	mu      sync.Mutex
+	pending map[string]*pendingMediuxSetCheck
+}{
+	pending: make(map[string]*pendingMediuxSetCheck),
+}
+
+// mediuxSetCheckMu makes sure only one set is checked at a time, so items in several updated sets are not checked at once
+var mediuxSetCheckMu sync.Mutex
+
+// queueMediuxSetCheck checks the items of a set once it stopped changing for mediuxDebounceWindow
+func queueMediuxSetCheck(setID, reason string) {
+	mediuxSetCheckDebouncer.mu.Lock()
+	defer mediuxSetCheckDebouncer.mu.Unlock()
+
+	if pending, ok := mediuxSetCheckDebouncer.pending[setID]; ok {
+		if len(pending.reasons) < 20 {
+			pending.reasons = append(pending.reasons, reason)
 		}
+		if time.Since(pending.firstSeen)+mediuxDebounceWindow <= mediuxDebounceMaxWait {
+			pending.timer.Reset(mediuxDebounceWindow)
+		}
+		return
+	}
 
+	logging.LOGGER.Debug().Timestamp().Str("set_id", setID).Str("reason", reason).
+		Msgf("MediUX Event Listener: Set changed, checking its items in %s", mediuxDebounceWindow)
+	mediuxSetCheckDebouncer.pending[setID] = &pendingMediuxSetCheck{
+		firstSeen: time.Now(),
+		reasons:   []string{reason},
+		timer: time.AfterFunc(mediuxDebounceWindow, func() {
+			mediuxSetCheckDebouncer.mu.Lock()
+			pending := mediuxSetCheckDebouncer.pending[setID]
+			delete(mediuxSetCheckDebouncer.pending, setID)
+			mediuxSetCheckDebouncer.mu.Unlock()
+			if pending != nil {
+				checkItemsForUpdatedMediuxSet(setID, pending.reasons)
+			}
+		}),
+	}
+}
+
+// checkItemsForUpdatedMediuxSet runs the AutoDownload check for every saved item that uses the set
+func checkItemsForUpdatedMediuxSet(setID string, reasons []string) {
+	if !config.Latest().Mediux.EnableEventListener {
+		return
+	}
+
+	mediuxSetCheckMu.Lock()
+	defer mediuxSetCheckMu.Unlock()
+
+	ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "MediUX Event Listener - Set Updated")
+	logAction := ld.AddAction(fmt.Sprintf("Checking saved items for updated set %s", setID), logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+	defer ld.Log()
+	defer logAction.Complete()
+
+	logAction.AppendResult("set_id", setID)
+	logAction.AppendResult("changes", reasons)
+
+	out, Err := database.GetAllSavedSets(ctx, models.DBFilter{SetID: setID, ItemsPerPage: -1})
+	if Err.Message != "" {
+		return
+	}
+	logAction.AppendResult("items", len(out.Items))
+
+	for _, dbItem := range out.Items {
+		result := CheckItem(ctx, dbItem)
+		logAction.AppendResult("outcomes", result)
+		switch result.OverallResult {
+		case "error":
+			logging.LOGGER.Error().Timestamp().Str("set_id", setID).
+				Msgf("MediUX Event Listener: AutoDownload error for item %s", utils.MediaItemInfo(dbItem.MediaItem))
+		case "warning":
+			logging.LOGGER.Warn().Timestamp().Str("set_id", setID).
+				Msgf("MediUX Event Listener: AutoDownload warning for item %s", utils.MediaItemInfo(dbItem.MediaItem))
+		case "success":
+			logging.LOGGER.Info().Timestamp().Str("set_id", setID).
+				Msgf("MediUX Event Listener: AutoDownload updated item %s", utils.MediaItemInfo(dbItem.MediaItem))
+		}
 	}
 }
 
@@ -166,20 +449,56 @@ func buildMediuxWebSocketURL() (string, error) {
 	u.Scheme = "wss"
 	u.Path = "/websocket"
 	q := u.Query()
-	q.Set("access_token", config.Current.Mediux.ApiToken)
+	q.Set("access_token", config.Latest().Mediux.ApiToken)
 	u.RawQuery = q.Encode()
 	return u.String(), nil
 }
 
 type MediuxWebSocketResponseMessage struct {
-	Type        string                      `json:"type"`  // Always "subscription" for responses
-	Event       string                      `json:"event"` // Example: "init", "ping", "update"
-	UpdatedSets []MediuxWebSocketUpdateData `json:"data"`
+	Type   string          `json:"type"`             // "subscription" for subscription events, "ping" for keep-alives
+	Event  string          `json:"event,omitempty"`  // Example: "init", "create", "update", "delete"
+	UID    string          `json:"uid,omitempty"`    // The uid the subscription was made with (the collection name)
+	Status string          `json:"status,omitempty"` // "error" when a request failed
+	Error  json.RawMessage `json:"error,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
 }
 
+// MediuxWebSocketUpdateData is a changed set, or a changed image with the set it belongs to
 type MediuxWebSocketUpdateData struct {
-	ID          int    `json:"id"`
-	Title       string `json:"set_title"`
-	ShowID      string `json:"show_id"`
-	DateUpdated string `json:"date_updated"`
+	ID            mediuxID `json:"id"`
+	Title         string   `json:"set_title,omitempty"`
+	DateUpdated   string   `json:"date_updated,omitempty"`
+	ShowSet       mediuxID `json:"show_set,omitempty"`
+	MovieSet      mediuxID `json:"movie_set,omitempty"`
+	CollectionSet mediuxID `json:"collection_set,omitempty"`
+}
+
+// mediuxID is an ID that MediUX sends either as a number or as a string
+type mediuxID string
+
+func (id *mediuxID) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*id = ""
+	case string:
+		*id = mediuxID(v)
+	case float64:
+		*id = mediuxID(fmt.Sprintf("%.0f", v))
+	case map[string]any:
+		// A related set is sent as an object when more of its fields are requested
+		var related struct {
+			ID mediuxID `json:"id"`
+		}
+		if err := json.Unmarshal(data, &related); err != nil {
+			return err
+		}
+		*id = related.ID
+	default:
+		return fmt.Errorf("unexpected MediUX ID: %s", string(data))
+	}
+	return nil
 }
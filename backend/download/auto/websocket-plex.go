@@ -1,6 +1,7 @@
 package autodownload
 
 import (
+	"aura/artworkhistory"
 	"aura/cache"
 	"aura/config"
 	"aura/database"
@@ -21,6 +22,7 @@ import (
 )
 
 const (
+	plexListenerName   = "Plex Event Listener"
 	plexReconnectDelay = 10 * time.Second
 	plexScanCoolDown   = 30 * time.Second
 	plexDedupWindow    = 8 * time.Second
@@ -67,8 +69,8 @@ func StartOrRestartPlexWebSocketClient() {
 
 	go func(stop <-chan struct{}) {
 		for {
-			if config.Current.MediaServer.Type != "Plex" ||
-				!config.Current.MediaServer.EnablePlexEventListener {
+			if config.Latest().MediaServer.Type != "Plex" ||
+				!config.Latest().MediaServer.EnablePlexEventListener {
 				select {
 				case <-stop:
 					return
@@ -94,6 +96,8 @@ func StartOrRestartPlexWebSocketClient() {
 
 // connectAndListenPlexWithStop is like connectAndListenPlex but returns early if stop is closed.
 func connectAndListenPlexWithStop(stop <-chan struct{}) (err error) {
+	defer func() { plexListenerState.setDisconnected(err) }()
+
 	wsURL, wsURLForLog, err := buildPlexWebSocketURL()
 	if err != nil {
 		return err
@@ -111,6 +115,7 @@ func connectAndListenPlexWithStop(stop <-chan struct{}) (err error) {
 
 	logging.LOGGER.Info().Timestamp().
 		Msg("Plex Event Listener: Connected — watching for metadata refresh events")
+	plexListenerState.setConnected()
 
 	for {
 		select {
@@ -157,7 +162,7 @@ func connectAndListenPlex() (err error) {
 }
 
 func buildPlexWebSocketURL() (wsURL string, wsURLForLog string, err error) {
-	base := config.Current.MediaServer.URL
+	base := config.Latest().MediaServer.URL
 	base = strings.TrimRight(base, "/")
 
 	// Determine the ws/wss scheme from the http/https URL
@@ -170,7 +175,7 @@ func buildPlexWebSocketURL() (wsURL string, wsURLForLog string, err error) {
 		base = strings.TrimPrefix(base, "http://")
 	}
 
-	token := config.Current.MediaServer.ApiToken
+	token := config.Latest().MediaServer.ApiToken
 	wsURL = fmt.Sprintf("%s://%s/:/websockets/notifications?X-Plex-Token=%s", wsScheme, base, token)
 
 	maskedToken := config.MaskToken(token)
@@ -242,6 +247,11 @@ func processPlexRefreshMessage(messageInfo PlexRefreshMessage) {
 	}
 
 	refreshedItem, ok := resolveUpdatedItemFromCache(messageInfo)
+	if (!ok || refreshedItem.MediaItem.RatingKey == "") && isNewPlexLibraryItem(messageInfo) {
+		// Items that are not in the cache yet were just added to Plex
+		go handleNewPlexItem(messageInfo)
+		return
+	}
 	if !ok || refreshedItem.MediaItem.RatingKey == "" {
 		logging.LOGGER.Warn().Timestamp().
 			Str("subtitle", messageInfo.Subtitle).
@@ -253,7 +263,7 @@ func processPlexRefreshMessage(messageInfo PlexRefreshMessage) {
 
 	if cachedMediaItem, found := getCachedRefreshedMediaItem(refreshedItem.MediaItem.RatingKey); found {
 		refreshedItem.MediaItem = cachedMediaItem
-		go reApplySavedImages(refreshedItem)
+		go reApplySavedImages(plexListenerName, refreshedItem)
 		return
 	}
 
@@ -288,7 +298,51 @@ func processPlexRefreshMessage(messageInfo PlexRefreshMessage) {
 		Str("item_type", refreshedItem.ItemType).
 		Msgf("Plex Event Listener: Detected metadata refresh for item %s", utils.MediaItemInfo(refreshedItem.MediaItem))
 
-	go reApplySavedImages(refreshedItem)
+	go reApplySavedImages(plexListenerName, refreshedItem)
+}
+
+// isNewPlexLibraryItem returns true if the message is for a movie or show that can be auto-applied
+func isNewPlexLibraryItem(messageInfo PlexRefreshMessage) bool {
+	if !IsAutoApplyEnabled() || messageInfo.ItemRatingKey == "" {
+		return false
+	}
+	return messageInfo.ItemType == "movie" || messageInfo.ItemType == "show"
+}
+
+// handleNewPlexItem loads an item that was just added to Plex into the cache and auto-applies a set to it
+func handleNewPlexItem(messageInfo PlexRefreshMessage) {
+	ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Plex Event Listener")
+	logAction := ld.AddAction("Auto Apply Set to New Item", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+	defer ld.Log()
+
+	newItem := models.MediaItem{
+		RatingKey: messageInfo.ItemRatingKey,
+		Type:      messageInfo.ItemType,
+	}
+	found, Err := mediaserver.GetMediaItemDetails(ctx, &newItem)
+	if Err.Message != "" || !found {
+		logging.LOGGER.Warn().Timestamp().
+			Int("section_id", messageInfo.SectionID).
+			Str("item_rating_key", messageInfo.ItemRatingKey).
+			Msg("Plex Event Listener: Failed to load details for new item")
+		return
+	}
+	if !isConfiguredLibrary(ctx, newItem.LibraryTitle) {
+		logging.LOGGER.Debug().Timestamp().
+			Int("section_id", messageInfo.SectionID).
+			Str("library_title", newItem.LibraryTitle).
+			Msgf("Plex Event Listener: Skipping new item %s, its library is not configured", utils.MediaItemInfo(newItem))
+		return
+	}
+	setCachedRefreshedMediaItem(newItem)
+
+	logging.LOGGER.Info().Timestamp().
+		Int("section_id", messageInfo.SectionID).
+		Str("item_rating_key", messageInfo.ItemRatingKey).
+		Msgf("Plex Event Listener: Detected new item %s", utils.MediaItemInfo(newItem))
+
+	AutoApplyToNewItems(ctx, []models.MediaItem{newItem}, false)
 }
 
 func getCachedRefreshedMediaItem(ratingKey string) (models.MediaItem, bool) {
@@ -329,8 +383,19 @@ func setCachedRefreshedMediaItem(item models.MediaItem) {
 	}
 }
 
-func reApplySavedImages(item PlexRefreshedItem) {
-	logCtx, ld := logging.CreateLoggingContext(context.Background(), "Plex Event Listener")
+// reApplySavedImages re-applies the saved images of an item after the media server refreshed its metadata
+// listenerName is the event listener that detected the refresh and is only used for logging
+func reApplySavedImages(listenerName string, item MediaServerRefreshedItem) {
+	// Restoring the original artwork refreshes the item, its saved sets are removed once that is done
+	if mediaserver.IsRestoringArtwork(item.MediaItem.RatingKey) {
+		logging.LOGGER.Info().Timestamp().
+			Str("item_title", item.MediaItem.Title).
+			Str("item_rating_key", item.MediaItem.RatingKey).
+			Msgf("%s: Original artwork is being restored for refreshed item, skipping image re-application", listenerName)
+		return
+	}
+
+	logCtx, ld := logging.CreateLoggingContext(context.Background(), listenerName)
 	logAction := ld.AddAction("Re-Apply Saved Images After Metadata Refresh", logging.LevelInfo)
 	logCtx = logging.WithCurrentAction(logCtx, logAction)
 	//defer ld.Log()
@@ -352,7 +417,7 @@ func reApplySavedImages(item PlexRefreshedItem) {
 		logging.LOGGER.Info().Timestamp().
 			Str("item_title", item.MediaItem.Title).
 			Str("item_rating_key", item.MediaItem.RatingKey).
-			Msg("Plex Event Listener: No saved sets found for refreshed item, skipping image re-application")
+			Msgf("%s: No saved sets found for refreshed item, skipping image re-application", listenerName)
 		return
 	}
 
@@ -399,7 +464,7 @@ func reApplySavedImages(item PlexRefreshedItem) {
 				}
 
 				matchedImages = append(matchedImages, image)
-				applyErr := mediaserver.DownloadApplyImageToMediaItem(logCtx, &item.MediaItem, image)
+				applyErr := mediaserver.DownloadApplyImageToMediaItem(mediaserver.WithArtworkChanged(artworkhistory.WithReplacingSet(logCtx, posterSet.BaseSetInfo)), &item.MediaItem, image)
 				if applyErr.Message != "" {
 					logging.LOGGER.Error().Timestamp().
 						Str("error", applyErr.Message).
@@ -407,14 +472,14 @@ func reApplySavedImages(item PlexRefreshedItem) {
 						Str("image_type", image.Type).
 						Str("item_title", item.MediaItem.Title).
 						Str("item_rating_key", item.MediaItem.RatingKey).
-						Msg("Plex Event Listener: Failed to re-apply saved image to refreshed item")
+						Msgf("%s: Failed to re-apply saved image to refreshed item", listenerName)
 				} else {
 					applied++
 					logging.LOGGER.Info().Timestamp().
 						Str("image_type", image.Type).
 						Str("item_title", item.MediaItem.Title).
 						Str("item_rating_key", item.MediaItem.RatingKey).
-						Msg("Plex Event Listener: Successfully re-applied saved image to refreshed item")
+						Msgf("%s: Successfully re-applied saved image to refreshed item", listenerName)
 				}
 			}
 		}
@@ -432,7 +497,7 @@ func reApplySavedImages(item PlexRefreshedItem) {
 	logAction.Complete()
 }
 
-func shouldReApplyImage(item PlexRefreshedItem, image models.ImageFile) bool {
+func shouldReApplyImage(item MediaServerRefreshedItem, image models.ImageFile) bool {
 	if item.MediaItem.RatingKey == "" || item.MediaItem.LibraryTitle == "" {
 		return false
 	}
@@ -465,7 +530,7 @@ func shouldReApplyImage(item PlexRefreshedItem, image models.ImageFile) bool {
 	}
 }
 
-func getSeasonNumberForRefreshedItem(item PlexRefreshedItem) (int, bool) {
+func getSeasonNumberForRefreshedItem(item MediaServerRefreshedItem) (int, bool) {
 	if item.MediaItem.Series == nil {
 		return 0, false
 	}
@@ -477,7 +542,7 @@ func getSeasonNumberForRefreshedItem(item PlexRefreshedItem) (int, bool) {
 	return 0, false
 }
 
-func getEpisodeNumbersForRefreshedItem(item PlexRefreshedItem) (int, int, bool) {
+func getEpisodeNumbersForRefreshedItem(item MediaServerRefreshedItem) (int, int, bool) {
 	if item.MediaItem.Series == nil {
 		return 0, 0, false
 	}
@@ -589,20 +654,20 @@ func buildPlexRefreshMessageFromTyped(entry PlexTimelineEntry) (PlexRefreshMessa
 	}, true
 }
 
-func resolveUpdatedItemFromCache(msg PlexRefreshMessage) (updated PlexRefreshedItem, ok bool) {
+func resolveUpdatedItemFromCache(msg PlexRefreshMessage) (updated MediaServerRefreshedItem, ok bool) {
 	if msg.SectionID == 0 {
-		return PlexRefreshedItem{}, false
+		return MediaServerRefreshedItem{}, false
 	}
 
 	section, ok := getSectionByID(msg.SectionID)
 	if !ok || section == nil {
-		return PlexRefreshedItem{}, false
+		return MediaServerRefreshedItem{}, false
 	}
 
 	if msg.ItemRatingKey != "" {
 		for _, item := range section.MediaItems {
 			if item.RatingKey == msg.ItemRatingKey {
-				return PlexRefreshedItem{
+				return MediaServerRefreshedItem{
 					MediaItem:     item,
 					ItemRatingKey: msg.ItemRatingKey,
 					ItemType:      msg.ItemType,
@@ -616,7 +681,7 @@ func resolveUpdatedItemFromCache(msg PlexRefreshMessage) (updated PlexRefreshedI
 
 			for _, season := range item.Series.Seasons {
 				if season.RatingKey == msg.ItemRatingKey {
-					return PlexRefreshedItem{
+					return MediaServerRefreshedItem{
 						MediaItem:     item,
 						ItemRatingKey: msg.ItemRatingKey,
 						ItemType:      msg.ItemType,
@@ -625,7 +690,7 @@ func resolveUpdatedItemFromCache(msg PlexRefreshMessage) (updated PlexRefreshedI
 				}
 				for _, episode := range season.Episodes {
 					if episode.RatingKey == msg.ItemRatingKey {
-						return PlexRefreshedItem{
+						return MediaServerRefreshedItem{
 							MediaItem:     item,
 							ItemRatingKey: msg.ItemRatingKey,
 							ItemType:      msg.ItemType,
@@ -639,12 +704,12 @@ func resolveUpdatedItemFromCache(msg PlexRefreshMessage) (updated PlexRefreshedI
 
 	normalizedShowTitle := normalizeTitle(extractShowTitle(msg.Subtitle))
 	if normalizedShowTitle == "" {
-		return PlexRefreshedItem{}, false
+		return MediaServerRefreshedItem{}, false
 	}
 
 	for _, item := range section.MediaItems {
 		if normalizeTitle(item.Title) == normalizedShowTitle {
-			return PlexRefreshedItem{
+			return MediaServerRefreshedItem{
 				MediaItem:     item,
 				ItemRatingKey: msg.ItemRatingKey,
 				ItemType:      msg.ItemType,
@@ -653,7 +718,7 @@ func resolveUpdatedItemFromCache(msg PlexRefreshMessage) (updated PlexRefreshedI
 		}
 	}
 
-	return PlexRefreshedItem{}, false
+	return MediaServerRefreshedItem{}, false
 }
 
 func getSectionByID(sectionID int) (*models.LibrarySection, bool) {
@@ -887,7 +952,7 @@ type PlexRefreshMessage struct {
 	TimelineState int    `json:"timeline_state"`
 }
 
-type PlexRefreshedItem struct {
+type MediaServerRefreshedItem struct {
 	MediaItem     models.MediaItem `json:"media_item"`
 	ItemRatingKey string           `json:"item_rating_key"`
 	ItemType      string           `json:"item_type"`
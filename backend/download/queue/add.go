@@ -14,6 +14,13 @@ import (
 )
 
 func AddToQueue(ctx context.Context, saveItem models.DBSavedItem) (Err logging.LogErrorInfo) {
+	_, Err = addToQueue(ctx, saveItem, "")
+	return Err
+}
+
+// addToQueue writes the queue file and returns its name.
+// The suffix is added before the extension, batches use it to recognize their files.
+func addToQueue(ctx context.Context, saveItem models.DBSavedItem, suffix string) (queueFileName string, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx,
 		fmt.Sprintf("Add Entry for %s",
 			utils.MediaItemInfo(saveItem.MediaItem)),
@@ -23,11 +30,13 @@ func AddToQueue(ctx context.Context, saveItem models.DBSavedItem) (Err logging.L
 
 	// If no matching file is found, create a new file name with the format: LibraryTitle_TMDBID_timestamp.json
 	timestamp := time.Now().Unix()
-	fileName := path.Join(FolderPath, fmt.Sprintf("%s_%s_%d.json",
+	queueFileName = fmt.Sprintf("%s_%s_%d%s.json",
 		strings.ReplaceAll(saveItem.MediaItem.LibraryTitle, " ", `_`),
 		saveItem.MediaItem.TMDB_ID,
 		timestamp,
-	))
+		suffix,
+	)
+	fileName := path.Join(FolderPath, queueFileName)
 
 	// Marshal the saveItem to JSON
 	jsonData, marshallErr := json.Marshal(saveItem)
@@ -39,7 +48,7 @@ func AddToQueue(ctx context.Context, saveItem models.DBSavedItem) (Err logging.L
 				"item":  saveItem,
 			})
 		logAction.Complete()
-		return Err
+		return "", *logAction.Error
 	}
 
 	// Write the JSON data to a file in the download queue folder
@@ -52,10 +61,10 @@ func AddToQueue(ctx context.Context, saveItem models.DBSavedItem) (Err logging.L
 				"file":  fileName,
 			})
 		logAction.Complete()
-		return Err
+		return "", *logAction.Error
 	}
 
 	logAction.AppendResult("file", fileName)
 	logAction.Complete()
-	return Err
+	return queueFileName, Err
 }
@@ -0,0 +1,202 @@
+package downloadqueue
+
+import (
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+type BatchItemStatus string
+
+const (
+	BATCH_ITEM_PENDING BatchItemStatus = "Pending"
+	BATCH_ITEM_SUCCESS BatchItemStatus = "Success"
+	BATCH_ITEM_WARNING BatchItemStatus = "Warning"
+	BATCH_ITEM_ERROR   BatchItemStatus = "Error"
+)
+
+type BatchItem struct {
+	Item   string          `json:"item"`
+	SetID  string          `json:"set_id"`
+	File   string          `json:"file"`
+	Status BatchItemStatus `json:"status"`
+}
+
+// Batch groups queue entries that were added in one operation (e.g. a whole boxset)
+// so that the overall result can be followed as the queue processes them.
+type Batch struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	CreatedAt   time.Time   `json:"created_at"`
+	CompletedAt time.Time   `json:"completed_at,omitzero"`
+	Status      Status      `json:"status"`
+	Total       int         `json:"total"`
+	Pending     int         `json:"pending"`
+	Success     int         `json:"success"`
+	Warning     int         `json:"warning"`
+	Error       int         `json:"error"`
+	Items       []BatchItem `json:"items"`
+}
+
+const (
+	// batchRetention is how long a completed batch can still be looked up
+	batchRetention = time.Hour
+	// maxTrackedBatches is how many batches are kept at most. The batches that completed first are dropped first,
+	// batches that are still processing are never dropped.
+	maxTrackedBatches = 100
+)
+
+var (
+	batches   = map[string]*Batch{}
+	batchesMu sync.RWMutex
+
+	// Queue files that belong to a batch end with _batch-{batchID}-{index}.json
+	batchFileRegex = regexp.MustCompile(`_batch-([A-Za-z0-9]+)-\d+\.json$`)
+)
+
+// AddBatchToQueue adds every item to the download queue and tracks them as one batch.
+// Batches are kept in memory, so their progress is lost on restart even though the queued files are still processed.
+func AddBatchToQueue(ctx context.Context, title string, saveItems []models.DBSavedItem) (batch Batch, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Adding Batch '%s' to Download Queue (%d Items)", title, len(saveItems)), logging.LevelInfo)
+	defer logAction.Complete()
+
+	newBatch := &Batch{
+		ID:        fmt.Sprintf("%d", time.Now().UnixNano()),
+		Title:     title,
+		CreatedAt: time.Now(),
+		Status:    LAST_STATUS_PROCESSING,
+		Items:     []BatchItem{},
+	}
+
+	for idx, saveItem := range saveItems {
+		batchItem := BatchItem{Item: utils.MediaItemInfo(saveItem.MediaItem)}
+		if len(saveItem.PosterSets) > 0 {
+			batchItem.SetID = saveItem.PosterSets[0].ID
+		}
+
+		fileName, Err := addToQueue(ctx, saveItem, fmt.Sprintf("_batch-%s-%d", newBatch.ID, idx))
+		if Err.Message != "" {
+			batchItem.Status = BATCH_ITEM_ERROR
+			newBatch.Error++
+		} else {
+			batchItem.File = fileName
+			batchItem.Status = BATCH_ITEM_PENDING
+			newBatch.Pending++
+		}
+		newBatch.Items = append(newBatch.Items, batchItem)
+		newBatch.Total++
+	}
+	newBatch.updateStatus()
+
+	batchesMu.Lock()
+	batches[newBatch.ID] = newBatch
+	pruneBatches(time.Now())
+	batchesMu.Unlock()
+
+	logAction.AppendResult("batch_id", newBatch.ID)
+	logAction.AppendResult("queued", newBatch.Pending)
+	if newBatch.Pending == 0 && newBatch.Total > 0 {
+		logAction.SetError("Failed to add any batch items to the download queue", "Check the logs for more details", map[string]any{
+			"batch_id": newBatch.ID,
+		})
+		return *newBatch, *logAction.Error
+	}
+	return *newBatch, logging.LogErrorInfo{}
+}
+
+// GetBatch returns a copy of a tracked batch
+func GetBatch(batchID string) (Batch, bool) {
+	batchesMu.RLock()
+	defer batchesMu.RUnlock()
+
+	batch, ok := batches[batchID]
+	if !ok {
+		return Batch{}, false
+	}
+	copied := *batch
+	copied.Items = append([]BatchItem{}, batch.Items...)
+	return copied, true
+}
+
+// recordBatchFileResult updates the batch a processed queue file belongs to, if any
+func recordBatchFileResult(fileName string, hasErrors, hasWarnings bool) {
+	match := batchFileRegex.FindStringSubmatch(fileName)
+	if match == nil {
+		return
+	}
+
+	batchesMu.Lock()
+	defer batchesMu.Unlock()
+	defer pruneBatches(time.Now())
+
+	batch, ok := batches[match[1]]
+	if !ok {
+		return
+	}
+	for i := range batch.Items {
+		if batch.Items[i].File != fileName || batch.Items[i].Status != BATCH_ITEM_PENDING {
+			continue
+		}
+		batch.Pending--
+		switch {
+		case hasErrors:
+			batch.Items[i].Status = BATCH_ITEM_ERROR
+			batch.Error++
+		case hasWarnings:
+			batch.Items[i].Status = BATCH_ITEM_WARNING
+			batch.Warning++
+		default:
+			batch.Items[i].Status = BATCH_ITEM_SUCCESS
+			batch.Success++
+		}
+		break
+	}
+	batch.updateStatus()
+}
+
+func (b *Batch) updateStatus() {
+	switch {
+	case b.Pending > 0:
+		b.Status = LAST_STATUS_PROCESSING
+	case b.Error > 0:
+		b.Status = LAST_STATUS_ERROR
+	case b.Warning > 0:
+		b.Status = LAST_STATUS_WARNING
+	default:
+		b.Status = LAST_STATUS_SUCCESS
+	}
+	if b.Pending == 0 && b.CompletedAt.IsZero() {
+		b.CompletedAt = time.Now()
+	}
+}
+
+// pruneBatches drops the batches that completed more than batchRetention ago, and the batches that completed first
+// while more than maxTrackedBatches are left. Batches that are still processing are kept, so their result isn't lost.
+// batchesMu must be held for writing.
+func pruneBatches(now time.Time) {
+	for id, batch := range batches {
+		if !batch.CompletedAt.IsZero() && now.Sub(batch.CompletedAt) > batchRetention {
+			delete(batches, id)
+		}
+	}
+	for len(batches) > maxTrackedBatches {
+		oldestID := ""
+		for id, batch := range batches {
+			if batch.CompletedAt.IsZero() {
+				continue
+			}
+			if oldestID == "" || batch.CompletedAt.Before(batches[oldestID].CompletedAt) {
+				oldestID = id
+			}
+		}
+		if oldestID == "" {
+			return // Every batch is still processing
+		}
+		delete(batches, oldestID)
+	}
+}
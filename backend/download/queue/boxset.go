@@ -0,0 +1,202 @@
+package downloadqueue
+
+import (
+	"aura/cache"
+	"aura/logging"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type BoxsetMember struct {
+	SetID        string `json:"set_id"`
+	SetType      string `json:"set_type"`
+	SetTitle     string `json:"set_title"`
+	TMDB_ID      string `json:"tmdb_id"`
+	Title        string `json:"title"`
+	LibraryTitle string `json:"library_title,omitempty"`
+	Edition      string `json:"edition,omitempty"`
+	Result       string `json:"result"` // queued, would-queue, missing, skipped, error
+	Reason       string `json:"reason,omitempty"`
+}
+
+type BoxsetApplyResult struct {
+	Boxset  models.BoxsetRef `json:"boxset"`
+	Matched []BoxsetMember   `json:"matched"`
+	Missing []BoxsetMember   `json:"missing"`
+	Skipped []BoxsetMember   `json:"skipped"`
+	Batch   *Batch           `json:"batch,omitempty"`
+}
+
+// ApplyBoxset resolves every member set of a creator's boxset against the libraries and queues the matched items as one batch.
+// Every matched item is saved with the same selected types and AutoDownload setting.
+// Members not in any library are reported as missing. When libraryTitles is set, only those libraries are searched.
+// If an item is in both a movie set and a collection set of the boxset, the movie set is used.
+func ApplyBoxset(ctx context.Context, username string, boxsetID string, libraryTitles []string, selectedTypes models.SelectedTypes, autoDownload bool, dryRun bool) (result BoxsetApplyResult, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Applying Boxset '%s' by %s", boxsetID, username), logging.LevelInfo)
+	defer logAction.Complete()
+
+	result = BoxsetApplyResult{
+		Matched: []BoxsetMember{},
+		Missing: []BoxsetMember{},
+		Skipped: []BoxsetMember{},
+	}
+
+	creatorSets, Err := mediux.GetAllUserSets(ctx, username)
+	if Err.Message != "" {
+		return result, Err
+	}
+
+	boxsetIdx := slices.IndexFunc(creatorSets.Boxsets, func(b models.BoxsetRef) bool { return b.ID == boxsetID })
+	if boxsetIdx == -1 {
+		logAction.SetError("Boxset not found", "Make sure the boxset ID belongs to the given user", map[string]any{
+			"username":  username,
+			"boxset_id": boxsetID,
+		})
+		return result, *logAction.Error
+	}
+	result.Boxset = creatorSets.Boxsets[boxsetIdx]
+
+	setsByID := map[string]models.SetRef{}
+	for _, set := range slices.Concat(creatorSets.ShowSets, creatorSets.MovieSets, creatorSets.CollectionSets) {
+		setsByID[set.ID] = set
+	}
+
+	// Index the library items by TMDB ID and type once, instead of searching the cache per member
+	libraryItems := map[string][]models.MediaItem{}
+	for _, item := range cache.LibraryStore.GetAllMediaItems() {
+		if len(libraryTitles) > 0 && !slices.Contains(libraryTitles, item.LibraryTitle) {
+			continue
+		}
+		key := item.Type + "|" + item.TMDB_ID
+		libraryItems[key] = append(libraryItems[key], item)
+	}
+
+	// Movie and show sets come first so that they win over collection sets for the same item
+	queuedItems := map[string]bool{}
+	saveItems := []models.DBSavedItem{}
+	for _, setType := range []string{"show", "movie", "collection"} {
+		for _, setID := range result.Boxset.SetIDs[setType] {
+			set, ok := setsByID[setID]
+			if !ok {
+				result.Skipped = append(result.Skipped, BoxsetMember{
+					SetID:   setID,
+					SetType: setType,
+					Result:  "skipped",
+					Reason:  "Set details not found in the creator's sets",
+				})
+				continue
+			}
+
+			itemType := setType
+			if setType == "collection" {
+				itemType = "movie"
+			}
+
+			for _, tmdbID := range set.ItemIDs {
+				member := BoxsetMember{
+					SetID:    set.ID,
+					SetType:  setType,
+					SetTitle: set.Title,
+					TMDB_ID:  tmdbID,
+					Title:    creatorSets.IncludedItems[tmdbID].MediuxInfo.Title,
+				}
+
+				matches := libraryItems[itemType+"|"+tmdbID]
+				if len(matches) == 0 {
+					member.Result = "missing"
+					member.Reason = "Item is not in the library"
+					result.Missing = append(result.Missing, member)
+					continue
+				}
+
+				for _, item := range matches {
+					member.Title = item.Title
+					member.LibraryTitle = item.LibraryTitle
+					member.Edition = item.Edition
+
+					itemKey := item.LibraryTitle + "|" + item.TMDB_ID + "|" + item.Edition
+					if queuedItems[itemKey] {
+						member.Result = "skipped"
+						member.Reason = "Item is already covered by another set in this boxset"
+						result.Skipped = append(result.Skipped, member)
+						continue
+					}
+					if item.IgnoredInDB {
+						member.Result = "skipped"
+						member.Reason = "Item is ignored"
+						result.Skipped = append(result.Skipped, member)
+						continue
+					}
+					queuedItems[itemKey] = true
+
+					member.Result = "would-queue"
+					if !dryRun {
+						member.Result = "queued"
+					}
+					result.Matched = append(result.Matched, member)
+					saveItems = append(saveItems, boxsetSaveItem(item, set, selectedTypes, autoDownload))
+				}
+			}
+		}
+	}
+
+	logAction.AppendResult("matched", len(result.Matched))
+	logAction.AppendResult("missing", len(result.Missing))
+	logAction.AppendResult("skipped", len(result.Skipped))
+	if dryRun || len(saveItems) == 0 {
+		return result, logging.LogErrorInfo{}
+	}
+
+	batch, Err := AddBatchToQueue(ctx, fmt.Sprintf("Boxset: %s", result.Boxset.Title), saveItems)
+	result.Batch = &batch
+	if Err.Message != "" {
+		return result, Err
+	}
+
+	// Reflect items that failed to be written to the queue in the matched results
+	failedItems := map[string]bool{}
+	for _, batchItem := range batch.Items {
+		if batchItem.Status == BATCH_ITEM_ERROR {
+			failedItems[batchItem.Item+"|"+batchItem.SetID] = true
+		}
+	}
+	for i, member := range result.Matched {
+		info := utils.MediaItemInfo(saveItems[i].MediaItem)
+		if failedItems[info+"|"+member.SetID] {
+			result.Matched[i].Result = "error"
+			result.Matched[i].Reason = "Failed to add the item to the download queue"
+		}
+	}
+
+	return result, logging.LogErrorInfo{}
+}
+
+// boxsetSaveItem builds the queue entry for one library item of a boxset member set.
+// Collection sets hold the images of every movie in the collection, so only the images for this item are kept.
+func boxsetSaveItem(item models.MediaItem, set models.SetRef, selectedTypes models.SelectedTypes, autoDownload bool) models.DBSavedItem {
+	posterSet := set.PosterSet
+	posterSet.Images = []models.ImageFile{}
+	for _, image := range set.Images {
+		if image.ItemTMDB_ID == "" || strings.EqualFold(image.ItemTMDB_ID, item.TMDB_ID) {
+			posterSet.Images = append(posterSet.Images, image)
+		}
+	}
+
+	return models.DBSavedItem{
+		MediaItem: item,
+		PosterSets: []models.DBPosterSetDetail{
+			{
+				PosterSet:      posterSet,
+				LastDownloaded: time.Now(),
+				SelectedTypes:  selectedTypes,
+				AutoDownload:   autoDownload,
+			},
+		},
+	}
+}
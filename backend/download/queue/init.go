@@ -22,19 +22,23 @@ const (
 
 var (
 	LatestInfo = struct {
-		Time     time.Time
-		Status   Status
-		Message  string
-		Errors   []string
-		Warnings []string
+		Time       time.Time
+		Status     Status
+		Message    string
+		Errors     []string
+		Warnings   []string
+		Verified   []string
+		Unverified []string
 	}{}
 
 	FolderPath string = ""
 )
 
 type FileIssues struct {
-	Errors   []string
-	Warnings []string
+	Errors     []string
+	Warnings   []string
+	Verified   []string // Images the media server was checked to show (Images.VerifyApply)
+	Unverified []string // Images the media server did not show after applying them (Images.VerifyApply)
 }
 
 func init() {
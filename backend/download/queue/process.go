@@ -1,6 +1,8 @@
 package downloadqueue
 
 import (
+	"aura/artworkhistory"
+	"aura/config"
 	"aura/database"
 	"aura/logging"
 	"aura/mediaserver"
@@ -16,6 +18,7 @@ import (
 )
 
 func finalizeQueueFile(filePath, fileName string, hasErrors, hasWarnings bool) error {
+	recordBatchFileResult(fileName, hasErrors, hasWarnings)
 	if hasErrors {
 		return os.Rename(filePath, path.Join(FolderPath, fmt.Sprintf("error_%s", fileName)))
 	}
@@ -26,7 +29,10 @@ func finalizeQueueFile(filePath, fileName string, hasErrors, hasWarnings bool) e
 }
 
 func ProcessQueueItems() {
-	ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue Processing")
+	// Use the same config for the whole batch, even if it is changed while the queue is processed
+	runCfg := config.Latest()
+
+	ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), runCfg), "Download Queue Processing")
 	logAction := ld.AddAction("Processing Download Queue", logging.LevelInfo)
 	defer logAction.Complete()
 	ctx = logging.WithCurrentAction(ctx, logAction)
@@ -59,7 +65,7 @@ func ProcessQueueItems() {
 			continue
 		}
 
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Download Queue - Processing")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), runCfg), "Download Queue - Processing")
 		subAction := ld.AddAction(fmt.Sprintf("Processing file: %s", file.Name()), logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, subAction)
 
@@ -68,6 +74,8 @@ func ProcessQueueItems() {
 		LatestInfo.Message = fmt.Sprintf("Processing file: %s", file.Name())
 		LatestInfo.Errors = []string{}
 		LatestInfo.Warnings = []string{}
+		LatestInfo.Verified = []string{}
+		LatestInfo.Unverified = []string{}
 
 		// Create an array of errors and warnings for this file
 		fileErrors := []string{}
@@ -170,6 +178,10 @@ func ProcessQueueItems() {
 			}
 
 			LatestInfo.Message = fmt.Sprintf("%s (Set: %s)", queueItem.MediaItem.Title, posterSet.ID)
+			setVerified := []string{}
+			setUnverified := []string{}
+			setCtx := artworkhistory.WithReplacingSet(ctx, posterSet.BaseSetInfo)
+			verifier := mediaserver.NewApplyVerifier()
 
 			for idx, image := range posterSet.Images {
 				switch image.Type {
@@ -240,15 +252,34 @@ func ProcessQueueItems() {
 				}
 
 				downloadFileName := utils.GetFileDownloadName(queueItem.MediaItem.Title, image)
-				Err := mediaserver.DownloadApplyImageToMediaItem(ctx, &queueItem.MediaItem, image)
+				verification, Err := verifier.DownloadApplyAndVerifyImage(setCtx, &queueItem.MediaItem, image)
 				if Err.Message != "" {
 					setErrors = append(setErrors, fmt.Sprintf("%s: %s", downloadFileName, Err.Message))
+					continue
+				}
+				switch verification {
+				case mediaserver.APPLY_VERIFIED:
+					setVerified = append(setVerified, downloadFileName)
+				case mediaserver.APPLY_UNVERIFIED:
+					setUnverified = append(setUnverified, downloadFileName)
+					setWarnings = append(setWarnings, fmt.Sprintf("%s: media server did not show the applied image", downloadFileName))
+				}
+			}
+
+			// Images that didn't match right away are checked again together
+			for _, retry := range verifier.RetryPending(setCtx) {
+				downloadFileName := utils.GetFileDownloadName(queueItem.MediaItem.Title, retry.ImageFile)
+				if retry.Verification == mediaserver.APPLY_VERIFIED {
+					setVerified = append(setVerified, downloadFileName)
+					continue
 				}
+				setUnverified = append(setUnverified, downloadFileName)
+				setWarnings = append(setWarnings, fmt.Sprintf("%s: media server did not show the applied image", downloadFileName))
 			}
 
 			// Per-set notification (success/warning/error)
 			SendNotification(
-				FileIssues{Errors: setErrors, Warnings: setWarnings},
+				FileIssues{Errors: setErrors, Warnings: setWarnings, Verified: setVerified, Unverified: setUnverified},
 				queueItem.MediaItem,
 				posterSet,
 				mediuxItemInfo.TMDB_PosterPath,
@@ -2,10 +2,12 @@ package downloadqueue
 
 import (
 	"aura/config"
+	"aura/imagesource"
 	"aura/logging"
 	"aura/mediux"
 	"aura/models"
 	"aura/notification"
+	"aura/tmdb"
 	"aura/utils"
 	"context"
 	"fmt"
@@ -20,19 +22,19 @@ func SendNotification(
 	tmdbBackdrop string,
 ) {
 	// If notifications are disabled, skip
-	if !config.Current.Notifications.Enabled {
+	if !config.Latest().Notifications.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping app start notification")
 		return
 	}
 
 	// If notification providers are not configured, skip
-	if len(config.Current.Notifications.Providers) == 0 {
+	if len(config.Latest().Notifications.Providers) == 0 {
 		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping app start notification")
 		return
 	}
 
 	// If download queue notification is disabled, skip
-	if !config.Current.Notifications.NotificationTemplate.DownloadQueue.Enabled {
+	if !config.Latest().Notifications.NotificationTemplate.DownloadQueue.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Download queue notification is disabled, skipping app start notification")
 		return
 	}
@@ -66,10 +68,10 @@ func SendNotification(
 	}
 
 	vars := utils.TemplateVars_DownloadQueue(mediaItem, posterSet, fileIssues.Errors, fileIssues.Warnings)
-	title := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.DownloadQueue.Title, vars)
-	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.DownloadQueue.Message, vars)
+	title := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.DownloadQueue.Title, vars)
+	message := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.DownloadQueue.Message, vars)
 	imageURL := ""
-	if config.Current.Notifications.NotificationTemplate.DownloadQueue.IncludeImage {
+	if config.Latest().Notifications.NotificationTemplate.DownloadQueue.IncludeImage {
 		imageURL = getImageURLFromPosterSet(posterSet, tmdbPoster, tmdbBackdrop)
 	}
 
@@ -79,6 +81,8 @@ func SendNotification(
 	LatestInfo.Message = fmt.Sprintf("%s (Set: %s)", mediaItem.Title, posterSet.ID)
 	LatestInfo.Errors = fileIssues.Errors
 	LatestInfo.Warnings = fileIssues.Warnings
+	LatestInfo.Verified = fileIssues.Verified
+	LatestInfo.Unverified = fileIssues.Unverified
 
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Notification - Send Download Queue Update")
 	logAction := ld.AddAction("Sending Download Queue Notification", logging.LevelInfo)
@@ -87,7 +91,7 @@ func SendNotification(
 	defer logAction.Complete()
 
 	// Send a notification to all configured providers
-	for _, provider := range config.Current.Notifications.Providers {
+	for _, provider := range config.Current(ctx).Notifications.Providers {
 		if provider.Enabled {
 			switch provider.Provider {
 			case "Discord":
@@ -127,6 +131,18 @@ func SendNotification(
 	}
 }
 
+func getImageURLFromImageFile(img models.ImageFile) string {
+	switch imagesource.GetImageSource(img) {
+	case imagesource.SourceTMDB:
+		// TMDB fallback images are not hosted on MediUX
+		return tmdb.GetImageURL(img)
+	case imagesource.SourceLocal:
+		// Local artwork has no public URL, so the notification falls back to the TMDB images
+		return ""
+	}
+	return mediux.GetImageURLFromSrc(img.Src)
+}
+
 func getImageURLFromPosterSet(posterSet models.DBPosterSetDetail, tmdbPoster, tmdbBackdrop string) string {
 	item_tmdb_id := ""
 	posterURL := ""
@@ -139,25 +155,25 @@ func getImageURLFromPosterSet(posterSet models.DBPosterSetDetail, tmdbPoster, tm
 	for _, img := range posterSet.Images {
 		switch img.Type {
 		case "poster":
-			posterURL = mediux.GetImageURLFromSrc(img.Src)
+			posterURL = getImageURLFromImageFile(img)
 			if item_tmdb_id == "" {
 				item_tmdb_id = img.ItemTMDB_ID
 			}
 		case "backdrop":
-			backdropURL = mediux.GetImageURLFromSrc(img.Src)
+			backdropURL = getImageURLFromImageFile(img)
 			if item_tmdb_id == "" {
 				item_tmdb_id = img.ItemTMDB_ID
 			}
 		case "seasonPoster":
 			if seasonURL == "" {
-				seasonURL = mediux.GetImageURLFromSrc(img.Src)
+				seasonURL = getImageURLFromImageFile(img)
 				if item_tmdb_id == "" {
 					item_tmdb_id = img.ItemTMDB_ID
 				}
 			}
 		case "titlecard":
 			if titlecardURL == "" {
-				titlecardURL = mediux.GetImageURLFromSrc(img.Src)
+				titlecardURL = getImageURLFromImageFile(img)
 				if item_tmdb_id == "" {
 					item_tmdb_id = img.ItemTMDB_ID
 				}
@@ -0,0 +1,396 @@
+package health
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	autodownload "aura/download/auto"
+	downloadqueue "aura/download/queue"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/mediux"
+	sonarr_radarr "aura/sonarr-radarr"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type CheckStatus string
+
+const (
+	CHECK_OK      CheckStatus = "ok"      // The dependency works
+	CHECK_FAIL    CheckStatus = "fail"    // The dependency doesn't work
+	CHECK_SKIPPED CheckStatus = "skipped" // The dependency is not used (e.g. the event listener is disabled)
+)
+
+type Status string
+
+const (
+	STATUS_HEALTHY   Status = "healthy"   // Every check passed
+	STATUS_DEGRADED  Status = "degraded"  // An optional check failed
+	STATUS_UNHEALTHY Status = "unhealthy" // A critical check failed
+)
+
+const (
+	// checkTimeout bounds each check, a check that takes longer fails
+	checkTimeout = 10 * time.Second
+	// reportTTL is how long a report is reused, so frequent probes don't hit every dependency each time
+	reportTTL = 15 * time.Second
+)
+
+// Check is the result of a single dependency check
+type Check struct {
+	Name        string         `json:"name"`             // Check name, as used in Health.Criticality
+	Target      string         `json:"target,omitempty"` // Which instance was checked, when there are several (e.g. a Sonarr/Radarr app)
+	Status      CheckStatus    `json:"status"`
+	Criticality string         `json:"criticality"`
+	LatencyMs   int64          `json:"latency_ms"`
+	Message     string         `json:"message,omitempty"`
+	Detail      map[string]any `json:"detail,omitempty"`
+}
+
+// Report is the readiness of the app and every dependency it uses
+type Report struct {
+	Status     Status    `json:"status"`
+	AppVersion string    `json:"app_version"`
+	CheckedAt  time.Time `json:"checked_at"`
+	Checks     []Check   `json:"checks"`
+	// Hosts whose outbound requests currently fail right away (open) or wait for a trial request (half open)
+	CircuitBreakers []httpx.CircuitBreakerState `json:"circuit_breakers"`
+}
+
+// PublicCheck is a check without its target, latency, message and detail
+type PublicCheck struct {
+	Name   string      `json:"name"`
+	Status CheckStatus `json:"status"`
+}
+
+// PublicReport is the part of a report that is shown to callers that are not logged in.
+// The full report names the apps, servers and hosts in use, so it is only shown after login.
+type PublicReport struct {
+	Status    Status        `json:"status"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Checks    []PublicCheck `json:"checks"`
+}
+
+// Public returns the overall status and the status of each check
+func (r Report) Public() PublicReport {
+	public := PublicReport{
+		Status:    r.Status,
+		CheckedAt: r.CheckedAt,
+		Checks:    make([]PublicCheck, 0, len(r.Checks)),
+	}
+	for _, check := range r.Checks {
+		public.Checks = append(public.Checks, PublicCheck{Name: check.Name, Status: check.Status})
+	}
+	return public
+}
+
+var latest = struct {
+	mu     sync.Mutex
+	report *Report
+}{}
+
+// Ready checks every dependency and returns the overall readiness.
+// A report younger than reportTTL is returned as is.
+func Ready(ctx context.Context) Report {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Checking Readiness", logging.LevelTrace)
+	defer logAction.Complete()
+
+	// Holding the lock while checking makes concurrent probes wait for one run instead of starting their own
+	latest.mu.Lock()
+	defer latest.mu.Unlock()
+	if latest.report != nil && time.Since(latest.report.CheckedAt) < reportTTL {
+		logAction.AppendResult("cached", true)
+		return *latest.report
+	}
+
+	report := runChecks(ctx)
+	if latest.report != nil && latest.report.Status != report.Status {
+		logStatusChange(latest.report.Status, report)
+	}
+	latest.report = &report
+
+	logAction.AppendResult("status", report.Status)
+	return report
+}
+
+func runChecks(ctx context.Context) Report {
+	healthConfig := config.Current(ctx).Health
+
+	type checkFunc func(ctx context.Context) []Check
+	checks := []struct {
+		name string
+		run  checkFunc
+	}{
+		{config.HealthCheckDatabase, checkDatabase},
+		{config.HealthCheckMediaServer, checkMediaServer},
+		{config.HealthCheckMediux, checkMediux},
+		{config.HealthCheckSonarrRadarr, checkSonarrRadarr},
+		{config.HealthCheckEventListener, checkEventListener},
+		{config.HealthCheckMediuxListener, checkMediuxListener},
+		{config.HealthCheckCacheWarmup, checkCacheWarmup},
+		{config.HealthCheckDownloadQueue, checkDownloadQueue},
+	}
+
+	// Run the checks at the same time, so the slowest check decides how long a report takes
+	results := make([][]Check, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		criticality := healthConfig.CriticalityOf(check.name)
+		if criticality == config.HealthIgnore {
+			continue
+		}
+		wg.Go(func() {
+			results[i] = runWithTimeout(ctx, check.name, check.run)
+			for j := range results[i] {
+				results[i][j].Criticality = criticality
+			}
+		})
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:     STATUS_HEALTHY,
+		AppVersion: config.AppVersion,
+		CheckedAt:  time.Now(),
+		Checks:     []Check{},
+	}
+	report.CircuitBreakers = httpx.OpenCircuitBreakers()
+	for _, result := range results {
+		for _, check := range result {
+			report.Checks = append(report.Checks, check)
+			if check.Status != CHECK_FAIL {
+				continue
+			}
+			if check.Criticality == config.HealthCritical {
+				report.Status = STATUS_UNHEALTHY
+			} else if report.Status == STATUS_HEALTHY {
+				report.Status = STATUS_DEGRADED
+			}
+		}
+	}
+	return report
+}
+
+// runWithTimeout runs a check and fails it if it doesn't finish in time.
+// Some checks retry their requests, so they can't be stopped through ctx alone.
+// The report is shared by every probe, so a probe that hangs up doesn't cancel the checks.
+func runWithTimeout(ctx context.Context, name string, run func(ctx context.Context) []Check) []Check {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), checkTimeout)
+	defer cancel()
+
+	done := make(chan []Check, 1)
+	start := time.Now()
+	go func() {
+		done <- run(ctx)
+	}()
+
+	select {
+	case checks := <-done:
+		return checks
+	case <-ctx.Done():
+		return []Check{{
+			Name:      name,
+			Status:    CHECK_FAIL,
+			LatencyMs: time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf("Check did not finish within %s", checkTimeout),
+		}}
+	}
+}
+
+func checkDatabase(ctx context.Context) []Check {
+	check := Check{Name: config.HealthCheckDatabase}
+	start := time.Now()
+
+	Err := database.Ping(ctx)
+	if Err.Message == "" {
+		// Reading the version also fails when the database is locked
+		var version int
+		version, Err = database.GetCurrentVersion(ctx)
+		check.Detail = map[string]any{
+			"schema_version": version,
+			"latest_version": database.LATEST_DB_VERSION,
+		}
+	}
+	check.LatencyMs = time.Since(start).Milliseconds()
+	return []Check{finishCheck(check, Err)}
+}
+
+func checkMediaServer(ctx context.Context) []Check {
+	check := Check{Name: config.HealthCheckMediaServer}
+	start := time.Now()
+
+	msConfig := config.Current(ctx).MediaServer
+	check.Target = msConfig.Type
+	_, serverName, serverVersion, Err := mediaserver.TestConnection(ctx, &msConfig)
+	check.LatencyMs = time.Since(start).Milliseconds()
+	if Err.Message == "" {
+		check.Detail = map[string]any{
+			"server_name":    serverName,
+			"server_version": serverVersion,
+		}
+	}
+	return []Check{finishCheck(check, Err)}
+}
+
+func checkMediux(ctx context.Context) []Check {
+	check := Check{Name: config.HealthCheckMediux}
+	start := time.Now()
+
+	_, Err := mediux.ValidateToken(ctx, config.Current(ctx).Mediux.ApiToken)
+	check.LatencyMs = time.Since(start).Milliseconds()
+	return []Check{finishCheck(check, Err)}
+}
+
+func checkSonarrRadarr(ctx context.Context) []Check {
+	apps := config.Current(ctx).SonarrRadarr.Applications
+	if len(apps) == 0 {
+		return []Check{{Name: config.HealthCheckSonarrRadarr, Status: CHECK_SKIPPED, Message: "No Sonarr/Radarr apps are configured"}}
+	}
+
+	// Each app is its own check, so one unreachable app doesn't hide the others
+	checks := make([]Check, len(apps))
+	var wg sync.WaitGroup
+	for i, app := range apps {
+		wg.Go(func() {
+			check := Check{Name: config.HealthCheckSonarrRadarr, Target: fmt.Sprintf("%s | %s", app.Type, app.Library)}
+			start := time.Now()
+			_, Err := sonarr_radarr.TestConnection(ctx, app)
+			check.LatencyMs = time.Since(start).Milliseconds()
+			checks[i] = finishCheck(check, Err)
+		})
+	}
+	wg.Wait()
+	return checks
+}
+
+func checkEventListener(ctx context.Context) []Check {
+	check := Check{Name: config.HealthCheckEventListener}
+
+	state := autodownload.EventListenerState()
+	check.Target = state.Name
+	if !state.Enabled {
+		check.Status = CHECK_SKIPPED
+		check.Message = "The media server event listener is disabled"
+		return []Check{check}
+	}
+
+	check.Detail = map[string]any{"since": state.Since}
+	if state.Connected {
+		check.Status = CHECK_OK
+	} else {
+		check.Status = CHECK_FAIL
+		check.Message = "Not connected to the media server"
+		if state.LastError != "" {
+			check.Message = state.LastError
+		}
+	}
+	return []Check{check}
+}
+
+func checkMediuxListener(ctx context.Context) []Check {
+	check := Check{Name: config.HealthCheckMediuxListener}
+
+	state := autodownload.MediuxListenerState()
+	if !state.Enabled {
+		check.Status = CHECK_SKIPPED
+		check.Message = "The MediUX event listener is disabled"
+		return []Check{check}
+	}
+
+	check.Detail = map[string]any{"since": state.Since, "subscribed_sets": state.SubscribedSets}
+	if state.Connected {
+		check.Status = CHECK_OK
+	} else {
+		check.Status = CHECK_FAIL
+		check.Message = "Not connected to MediUX"
+		if state.LastError != "" {
+			check.Message = state.LastError
+		}
+	}
+	return []Check{check}
+}
+
+func checkCacheWarmup(ctx context.Context) []Check {
+	check := Check{Name: config.HealthCheckCacheWarmup}
+
+	if cache.LibraryStore.LastFullUpdate > 0 {
+		check.Detail = map[string]any{
+			"library_last_full_update": time.Unix(cache.LibraryStore.LastFullUpdate, 0),
+		}
+	}
+	if config.AppFullyLoaded.Load() {
+		check.Status = CHECK_OK
+	} else {
+		check.Status = CHECK_FAIL
+		check.Message = fmt.Sprintf("Still starting up: %s", config.AppLoadingStep.Load())
+	}
+	return []Check{check}
+}
+
+func checkDownloadQueue(ctx context.Context) []Check {
+	check := Check{Name: config.HealthCheckDownloadQueue}
+	if downloadqueue.FolderPath == "" {
+		check.Status = CHECK_SKIPPED
+		check.Message = "The download queue is not initialized"
+		return []Check{check}
+	}
+
+	// Items that failed are kept in the queue folder as error_ files until they are removed
+	files, err := os.ReadDir(downloadqueue.FolderPath)
+	if err != nil {
+		check.Status = CHECK_FAIL
+		check.Message = fmt.Sprintf("Failed to read the download queue folder: %s", err.Error())
+		return []Check{check}
+	}
+	errorFiles := 0
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), "error_") {
+			errorFiles++
+		}
+	}
+
+	check.Detail = map[string]any{"error_files": errorFiles}
+	if errorFiles > 0 {
+		check.Status = CHECK_FAIL
+		check.Message = fmt.Sprintf("%d download queue item(s) failed", errorFiles)
+	} else {
+		check.Status = CHECK_OK
+	}
+	return []Check{check}
+}
+
+// finishCheck sets the status of a check from the error of the dependency call
+func finishCheck(check Check, Err logging.LogErrorInfo) Check {
+	if Err.Message != "" {
+		check.Status = CHECK_FAIL
+		check.Message = Err.Message
+		return check
+	}
+	check.Status = CHECK_OK
+	return check
+}
+
+func logStatusChange(previous Status, report Report) {
+	failed := []string{}
+	for _, check := range report.Checks {
+		if check.Status == CHECK_FAIL {
+			failed = append(failed, strings.TrimSuffix(check.Name+" "+check.Target, " "))
+		}
+	}
+
+	event := logging.LOGGER.Info()
+	if report.Status != STATUS_HEALTHY {
+		event = logging.LOGGER.Warn()
+	}
+	event.Timestamp().
+		Str("previous_status", string(previous)).
+		Str("status", string(report.Status)).
+		Strs("failed_checks", failed).
+		Msg("Readiness status changed")
+}
@@ -0,0 +1,179 @@
+package imagesource
+
+import (
+	"aura/artworkhistory"
+	"aura/cache"
+	"aura/localartwork"
+	"aura/logging"
+	"aura/mediux"
+	"aura/models"
+	"aura/tmdb"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	SourceMediUX  = mediux.SourceName
+	SourceTMDB    = tmdb.SourceName
+	SourceLocal   = localartwork.SourceName
+	SourceHistory = artworkhistory.SourceName
+)
+
+type ImageSourceInterface interface {
+
+	// Get all sets for a Media Item
+	GetItemSets(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string, filter models.ImageFilter) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo)
+
+	// Get a single set by its ID
+	GetSetByID(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo)
+
+	// Get the full quality image bytes for an Image File
+	GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo)
+
+	// Get a URL the Media Server can download the Image File from
+	// An empty URL means the image is not reachable by URL and must be uploaded
+	GetImageURL(ctx context.Context, imageFile models.ImageFile) (imageURL string, Err logging.LogErrorInfo)
+
+	// Get the last time a set was updated in the source
+	GetSetUpdatedAt(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (updatedAt time.Time, Err logging.LogErrorInfo)
+}
+
+func NewImageSourceClient(source string) (ImageSourceInterface, logging.LogErrorInfo) {
+	switch source {
+	case SourceMediUX, "":
+		return &mediux.MediUX{}, logging.LogErrorInfo{}
+	case SourceTMDB:
+		return &tmdb.TMDB{}, logging.LogErrorInfo{}
+	case SourceLocal:
+		return &localartwork.Local{}, logging.LogErrorInfo{}
+	case SourceHistory:
+		return &artworkhistory.History{}, logging.LogErrorInfo{}
+	default:
+		return nil, logging.LogErrorInfo{
+			Message: fmt.Sprintf("unsupported image source: %s", source),
+		}
+	}
+}
+
+// GetSetSource returns the image source for a set
+// Sets saved before sources were tracked have no source, so it is determined from the set ID
+func GetSetSource(set models.BaseSetInfo) string {
+	if set.Source != "" {
+		return set.Source
+	}
+	if tmdb.IsTMDBSet(set.ID) {
+		return SourceTMDB
+	}
+	if localartwork.IsLocalSet(set.ID) {
+		return SourceLocal
+	}
+	return SourceMediUX
+}
+
+// GetImageSource returns the image source for an Image File
+func GetImageSource(imageFile models.ImageFile) string {
+	if imageFile.Source != "" {
+		return imageFile.Source
+	}
+	if tmdb.IsTMDBImage(imageFile) {
+		return SourceTMDB
+	}
+	if localartwork.IsLocalImage(imageFile) {
+		return SourceLocal
+	}
+	if artworkhistory.IsHistoryImage(imageFile) {
+		return SourceHistory
+	}
+	return SourceMediUX
+}
+
+// GetItemSets gets the sets for a Media Item from all image sources
+// TMDB is only used as a fallback when MediUX has no sets for the item
+// Local artwork sets are always listed alongside the other sets
+func GetItemSets(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string, filter models.ImageFilter) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	sets = []models.SetRef{}
+	includedItems = map[string]models.IncludedItem{}
+
+	sources := []string{}
+	if cache.MediuxItems.CheckItemExists(itemType, tmdbID) {
+		sources = append(sources, SourceMediUX)
+	} else if tmdb.IsEnabled(ctx) {
+		sources = append(sources, SourceTMDB)
+	}
+	if localartwork.IsEnabled(ctx) {
+		sources = append(sources, SourceLocal)
+	}
+	if len(sources) == 0 {
+		// Let MediUX report that there are no sets for the item
+		sources = append(sources, SourceMediUX)
+	}
+
+	for _, source := range sources {
+		client, Err := NewImageSourceClient(source)
+		if Err.Message != "" {
+			return sets, includedItems, Err
+		}
+		sourceSets, sourceItems, Err := client.GetItemSets(ctx, tmdbID, itemType, itemLibraryTitle, edition, filter)
+		if Err.Message != "" {
+			return sets, includedItems, Err
+		}
+		for _, set := range sourceSets {
+			sets = append(sets, stampSource(set, source))
+		}
+		for id, item := range sourceItems {
+			if _, exists := includedItems[id]; !exists {
+				includedItems[id] = item
+			}
+		}
+	}
+
+	return sets, includedItems, logging.LogErrorInfo{}
+}
+
+// GetSetByID gets the latest version of a set from the image source it came from
+func GetSetByID(ctx context.Context, set models.BaseSetInfo, itemTMDB_ID, itemLibraryTitle, edition string) (models.SetRef, map[string]models.IncludedItem, logging.LogErrorInfo) {
+	source := GetSetSource(set)
+	client, Err := NewImageSourceClient(source)
+	if Err.Message != "" {
+		return models.SetRef{}, nil, Err
+	}
+	sourceSet, includedItems, Err := client.GetSetByID(ctx, set.ID, set.Type, itemTMDB_ID, itemLibraryTitle, edition)
+	if Err.Message != "" {
+		return sourceSet, includedItems, Err
+	}
+	return stampSource(sourceSet, source), includedItems, Err
+}
+
+func GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	client, Err := NewImageSourceClient(GetImageSource(imageFile))
+	if Err.Message != "" {
+		return nil, "", Err
+	}
+	return client.GetImage(ctx, imageFile)
+}
+
+func GetImageURL(ctx context.Context, imageFile models.ImageFile) (imageURL string, Err logging.LogErrorInfo) {
+	client, Err := NewImageSourceClient(GetImageSource(imageFile))
+	if Err.Message != "" {
+		return "", Err
+	}
+	return client.GetImageURL(ctx, imageFile)
+}
+
+func GetSetUpdatedAt(ctx context.Context, set models.BaseSetInfo, itemTMDB_ID, itemLibraryTitle, edition string) (updatedAt time.Time, Err logging.LogErrorInfo) {
+	client, Err := NewImageSourceClient(GetSetSource(set))
+	if Err.Message != "" {
+		return updatedAt, Err
+	}
+	return client.GetSetUpdatedAt(ctx, set.ID, set.Type, itemTMDB_ID, itemLibraryTitle, edition)
+}
+
+// stampSource records the image source on a set and all of its images
+func stampSource(set models.SetRef, source string) models.SetRef {
+	set.Source = source
+	for i := range set.Images {
+		set.Images[i].Source = source
+	}
+	return set
+}
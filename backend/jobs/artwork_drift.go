@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/mediaserver"
+	"context"
+	"runtime/debug"
+)
+
+func StartArtworkDriftJob() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c == nil {
+		logging.LOGGER.Error().Timestamp().Msg("Cron Jobs Scheduler is not initialized")
+		return nil
+	}
+
+	if artworkDriftJobID != 0 {
+		c.Remove(artworkDriftJobID)
+		artworkDriftJobID = 0
+	}
+
+	driftDetection := config.Latest().Images.DriftDetection
+	if !driftDetection.Enabled {
+		logging.LOGGER.Info().Timestamp().Msg("Artwork Drift Detection Job Stopped")
+		return nil
+	}
+
+	spec := driftDetection.Cron
+	if spec == "" {
+		spec = "0 3 * * *" // Default to daily at 3 AM
+	}
+
+	var err error
+	artworkDriftJobID, err = c.AddFunc(spec, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().
+					Timestamp().
+					Interface("recover", r).
+					Str("stack", string(debug.Stack())).
+					Msg("PANIC: in scheduled Artwork Drift Detection Job")
+			}
+		}()
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Cron Job")
+		action := ld.AddAction("Artwork Drift Detection", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, action)
+		report, Err := mediaserver.CheckForArtworkDrift(ctx)
+		if Err.Message != "" {
+			logging.LOGGER.Error().Timestamp().Str("error", Err.Message).
+				Str("next_run", c.Entry(artworkDriftJobID).Next.String()).
+				Msg("Error running Artwork Drift Detection Job")
+		} else {
+			logging.LOGGER.Info().Timestamp().
+				Int("checked", report.Checked).
+				Int("drifted", report.Drifted).
+				Int("reapplied", report.Reapplied).
+				Str("next_run", c.Entry(artworkDriftJobID).Next.String()).
+				Msg("Artwork Drift Detection Job Completed")
+		}
+		ld.Log()
+	})
+	if err != nil {
+		return err
+	}
+	jobSpecs[artworkDriftJobID] = spec
+
+	logging.LOGGER.Info().Timestamp().
+		Str("cron", spec).
+		Bool("reapply", config.Latest().Images.DriftDetection.Reapply).
+		Msg("Artwork Drift Detection Job Started")
+	return nil
+}
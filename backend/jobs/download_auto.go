@@ -22,13 +22,14 @@ func StartAutoDownloadJob() error {
 		autodownloadJobID = 0
 	}
 
-	enabled := config.Current.AutoDownload.Enabled
+	cfg := config.Latest()
+	enabled := cfg.AutoDownload.Enabled
 	if !enabled {
 		logging.LOGGER.Info().Timestamp().Msg("AutoDownload Job Stopped")
 		return nil
 	}
 
-	spec := config.Current.AutoDownload.Cron
+	spec := cfg.AutoDownload.Cron
 	if spec == "" {
 		spec = "0 0 * * *" // Default to daily at midnight
 	}
@@ -45,7 +46,7 @@ func StartAutoDownloadJob() error {
 					Msg("PANIC: in scheduled AutoDownload Job")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Cron Job")
 		action := ld.AddAction("AutoDownload Check", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		Err := autodownload.CheckAllItems(ctx)
@@ -74,7 +75,7 @@ func RunAutoDownloadJobNow() {
 	mu.Lock()
 	defer mu.Unlock()
 
-	if config.Current.AutoDownload.Enabled == false {
+	if config.Latest().AutoDownload.Enabled == false {
 		logging.LOGGER.Warn().Timestamp().Msg("AutoDownload is disabled, cannot run job")
 		return
 	}
@@ -95,7 +96,7 @@ func RunAutoDownloadJobNow() {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("Panic in AutoDownload Job")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Manual Job Run")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Manual Job Run")
 		action := ld.AddAction("AutoDownload Check", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		Err := autodownload.CheckAllItems(ctx)
@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"aura/config"
 	"aura/logging"
 	"aura/mediaserver"
 	"context"
@@ -28,7 +29,7 @@ func StartHandleTempIgnoredItemsJob() error {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in scheduled HandleTempIgnoredItemsJob")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Cron Job")
 		action := ld.AddAction("Handle Temp Ignored Items", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		Err := mediaserver.HandleTempIgnoredItems(ctx)
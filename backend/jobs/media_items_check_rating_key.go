@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"aura/config"
 	"aura/logging"
 	"aura/mediaserver"
 	"context"
@@ -28,7 +29,7 @@ func StartCheckForMediaItemChangesJob() error {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in scheduled CheckForMediaItemChangesJob")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Cron Job")
 		action := ld.AddAction("Check for Media Item Changes", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		Err := mediaserver.CheckForMediaItemChanges(ctx)
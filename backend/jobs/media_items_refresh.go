@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"aura/config"
+	autodownload "aura/download/auto"
 	"aura/logging"
 	"aura/mediaserver"
 	"context"
@@ -28,10 +30,10 @@ func StartRefreshMediaItemsAndCollectionsJob() error {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in scheduled RefreshMediaItemsAndCollectionsJob")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Cron Job")
 		action := ld.AddAction("Refresh Media Items and Collections", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
-		mediaserver.GetAllLibrarySectionsAndItems(ctx, true)
+		refreshMediaItemsAndCollections(ctx, false)
 		ld.Log()
 	})
 	if err != nil {
@@ -45,3 +47,37 @@ func StartRefreshMediaItemsAndCollectionsJob() error {
 		Msg("Refresh Media Items and Collections Job Started")
 	return nil
 }
+
+// RunRefreshMediaItemsAndCollectionsJobNow refreshes the library cache right away.
+// A full resync reloads every library instead of only the changed items, e.g. after switching media servers.
+func RunRefreshMediaItemsAndCollectionsJobNow(full bool) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in RefreshMediaItemsAndCollectionsJob")
+			}
+		}()
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Manual Job Run")
+		action := ld.AddAction("Refresh Media Items and Collections", logging.LevelInfo)
+		ctx = logging.WithCurrentAction(ctx, action)
+		refreshMediaItemsAndCollections(ctx, full)
+		ld.Log()
+	}()
+}
+
+func refreshMediaItemsAndCollections(ctx context.Context, full bool) {
+	previousItemKeys := autodownload.GetLibraryItemKeys()
+	if full {
+		mediaserver.GetAllLibrarySectionsAndItems(ctx, true)
+	} else {
+		mediaserver.RefreshLibrarySectionsAndItems(ctx)
+	}
+	wantedItemKeys := autodownload.CheckWantedItems(ctx)
+	if autodownload.IsAutoApplyEnabled() {
+		// Wanted items already have a set chosen for them
+		newItems := autodownload.ExcludeLibraryItems(autodownload.GetNewLibraryItems(previousItemKeys), wantedItemKeys)
+		if len(newItems) > 0 {
+			autodownload.AutoApplyToNewItems(ctx, newItems, false)
+		}
+	}
+}
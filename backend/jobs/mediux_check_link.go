@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"aura/config"
 	"aura/logging"
 	"aura/mediux"
 	"context"
@@ -28,7 +29,7 @@ func StartCheckMediuxSiteLinkJob() error {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in scheduled CheckMediuxSiteLinkJob")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Cron Job")
 		action := ld.AddAction("Check Mediux Site Link Availability", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		mediux.CheckSiteLinkAvailability()
@@ -66,7 +67,7 @@ func RunCheckMediuxSiteLinkJobNow() {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in CheckMediuxSiteLinkJob")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Manual Job Run")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Manual Job Run")
 		action := ld.AddAction("Check Mediux Site Link Availability", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		mediux.CheckSiteLinkAvailability()
@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"aura/config"
 	"aura/logging"
 	"aura/mediux"
 	"context"
@@ -28,7 +29,7 @@ func StartRefreshMediuxUsersJob() error {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in scheduled RefreshMediuxUsersJob")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Cron Job")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Cron Job")
 		action := ld.AddAction("Refresh Mediux Users", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		_, Err := mediux.GetAllUsers(ctx)
@@ -75,7 +76,7 @@ func RunRefreshMediuxUsersJobNow() {
 				logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in RefreshMediuxUsersJob")
 			}
 		}()
-		ctx, ld := logging.CreateLoggingContext(context.Background(), "Manual Job Run")
+		ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Manual Job Run")
 		action := ld.AddAction("Refresh Mediux Users", logging.LevelInfo)
 		ctx = logging.WithCurrentAction(ctx, action)
 		mediux.GetAllUsers(ctx)
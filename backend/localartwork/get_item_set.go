@@ -0,0 +1,275 @@
+package localartwork
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	posterFileRegex    = regexp.MustCompile(`(?i)^poster$`)
+	backdropFileRegex  = regexp.MustCompile(`(?i)^(backdrop|background|fanart)$`)
+	seasonFileRegex    = regexp.MustCompile(`(?i)^season[\s_-]*(\d{1,3})$`)
+	specialsFileRegex  = regexp.MustCompile(`(?i)^(specials?|season[\s_-]*specials?)$`)
+	titlecardFileRegex = regexp.MustCompile(`(?i)^s(\d{1,3})e(\d{1,4})$`)
+)
+
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".webp": true,
+}
+
+// GetItemSet builds a single set from the artwork folder of an item.
+// The folder must be directly under the artwork root and named either by TMDB ID or "Title (Year)".
+// File names decide the image type:
+//   - poster, backdrop (or background/fanart)
+//   - Season01 for season posters (Season00 or Specials for the special season)
+//   - S01E01 for titlecards
+//
+// The file modification time is used as the image Modified date, so replacing a file re-applies it on the next AutoDownload run.
+func GetItemSet(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Local Artwork: Get %s Item Set for TMDB ID '%s'", itemType, tmdbID), logging.LevelDebug)
+	defer logAction.Complete()
+
+	sets = []models.SetRef{}
+	includedItems = map[string]models.IncludedItem{}
+	Err = logging.LogErrorInfo{}
+
+	if !IsEnabled(ctx) {
+		logAction.SetError("Local artwork folder not configured", "Enable Images.LocalArtwork and set the Path in the settings to use local artwork", nil)
+		return sets, includedItems, *logAction.Error
+	}
+	if itemType != "movie" && itemType != "show" {
+		logAction.SetError("Invalid Item Type", "Item type must be 'movie' or 'show'", map[string]any{"item_type": itemType})
+		return sets, includedItems, *logAction.Error
+	}
+
+	mediaItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBIDAndEdition(itemLibraryTitle, tmdbID, edition)
+	title := ""
+	year := 0
+	if found {
+		title = mediaItem.Title
+		year = mediaItem.Year
+	}
+
+	root := filepath.Clean(config.Current(ctx).Images.LocalArtwork.Path)
+	itemFolder := findItemFolder(root, tmdbID, title, year)
+	if itemFolder == "" {
+		logAction.AppendResult("item_folder", "not found")
+		return sets, includedItems, Err
+	}
+	logAction.AppendResult("item_folder", itemFolder)
+
+	images := []models.ImageFile{}
+	walkErr := filepath.WalkDir(itemFolder, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !imageExtensions[ext] {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		relativePath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return nil
+		}
+
+		imageFile, ok := parseImageFile(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())), itemType)
+		if !ok {
+			return nil
+		}
+		imageFile.ID = buildImageID(relativePath)
+		imageFile.Modified = info.ModTime().UTC().Truncate(time.Second)
+		imageFile.FileSize = info.Size()
+		imageFile.ItemTMDB_ID = tmdbID
+		imageFile.Source = SourceName
+		if imageFile.Type == "titlecard" && found {
+			imageFile.Title = getEpisodeTitle(mediaItem.Series, *imageFile.SeasonNumber, *imageFile.EpisodeNumber)
+		}
+		imageFile.Src = GetImageSrc(imageFile)
+		images = append(images, imageFile)
+		return nil
+	})
+	if walkErr != nil {
+		logAction.SetError("Failed to read local artwork folder", walkErr.Error(), map[string]any{"item_folder": itemFolder})
+		return sets, includedItems, *logAction.Error
+	}
+
+	logAction.AppendResult("images_found", len(images))
+	if len(images) == 0 {
+		return sets, includedItems, Err
+	}
+
+	// The set is as old as its oldest file and as new as its newest file
+	dateCreated := images[0].Modified
+	dateUpdated := images[0].Modified
+	for _, image := range images {
+		if image.Modified.Before(dateCreated) {
+			dateCreated = image.Modified
+		}
+		if image.Modified.After(dateUpdated) {
+			dateUpdated = image.Modified
+		}
+	}
+
+	includedItem := models.IncludedItem{
+		MediuxInfo: models.BaseMediuxItemInfo{
+			TMDB_ID: tmdbID,
+			Type:    itemType,
+			Title:   title,
+		},
+	}
+	if found {
+		includedItem.MediaItem = *mediaItem
+	}
+	includedItems[tmdbID] = includedItem
+
+	setTitle := filepath.Base(itemFolder)
+	if title != "" {
+		setTitle = title
+	}
+	sets = append(sets, models.SetRef{
+		PosterSet: models.PosterSet{
+			BaseSetInfo: models.BaseSetInfo{
+				ID:          BuildSetID(itemType, tmdbID),
+				Title:       fmt.Sprintf("%s (Local)", setTitle),
+				Type:        itemType,
+				UserCreated: SetUserCreated,
+				Source:      SourceName,
+				DateCreated: dateCreated,
+				DateUpdated: dateUpdated,
+			},
+			Images: images,
+		},
+		ItemIDs: []string{tmdbID},
+	})
+	return sets, includedItems, Err
+}
+
+// ItemFolders holds the item folders of the local artwork folder, to check many items without reading the folder for each one
+type ItemFolders map[string]bool
+
+// GetItemFolders reads the item folders of the local artwork folder. It is empty when local artwork is not enabled.
+func GetItemFolders(ctx context.Context) ItemFolders {
+	folders := ItemFolders{}
+	if !IsEnabled(ctx) {
+		return folders
+	}
+	entries, err := os.ReadDir(filepath.Clean(config.Current(ctx).Images.LocalArtwork.Path))
+	if err != nil {
+		return folders
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			folders[entry.Name()] = true
+			folders[normalizeFolderName(entry.Name())] = true
+		}
+	}
+	return folders
+}
+
+// Has returns true if there is an artwork folder for the item, matched like findItemFolder
+func (f ItemFolders) Has(tmdbID, title string, year int) bool {
+	if f[tmdbID] {
+		return true
+	}
+	return title != "" && year != 0 && f[normalizeFolderName(fmt.Sprintf("%s (%d)", title, year))]
+}
+
+// findItemFolder returns the artwork folder for an item, matched by TMDB ID first and then by "Title (Year)"
+func findItemFolder(root, tmdbID, title string, year int) string {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return ""
+	}
+
+	titleFolder := ""
+	if title != "" && year != 0 {
+		titleFolder = normalizeFolderName(fmt.Sprintf("%s (%d)", title, year))
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() == tmdbID {
+			return filepath.Join(root, entry.Name())
+		}
+	}
+	if titleFolder == "" {
+		return ""
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && normalizeFolderName(entry.Name()) == titleFolder {
+			return filepath.Join(root, entry.Name())
+		}
+	}
+	return ""
+}
+
+func normalizeFolderName(name string) string {
+	name = utils.InvalidFolderChars.ReplaceAllString(name, "")
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// parseImageFile determines the image type (and season/episode) from a file name without its extension
+func parseImageFile(name string, itemType string) (imageFile models.ImageFile, ok bool) {
+	name = strings.TrimSpace(name)
+	switch {
+	case posterFileRegex.MatchString(name):
+		imageFile.Type = "poster"
+	case backdropFileRegex.MatchString(name):
+		imageFile.Type = "backdrop"
+	case itemType != "show":
+		return imageFile, false
+	case specialsFileRegex.MatchString(name):
+		seasonNumber := 0
+		imageFile.Type = "season_poster"
+		imageFile.SeasonNumber = &seasonNumber
+	case seasonFileRegex.MatchString(name):
+		seasonNumber, _ := strconv.Atoi(seasonFileRegex.FindStringSubmatch(name)[1])
+		imageFile.Type = "season_poster"
+		imageFile.SeasonNumber = &seasonNumber
+	case titlecardFileRegex.MatchString(name):
+		match := titlecardFileRegex.FindStringSubmatch(name)
+		seasonNumber, _ := strconv.Atoi(match[1])
+		episodeNumber, _ := strconv.Atoi(match[2])
+		imageFile.Type = "titlecard"
+		imageFile.SeasonNumber = &seasonNumber
+		imageFile.EpisodeNumber = &episodeNumber
+	default:
+		return imageFile, false
+	}
+	return imageFile, true
+}
+
+func getEpisodeTitle(series *models.MediaItemSeries, seasonNumber, episodeNumber int) string {
+	if series == nil {
+		return ""
+	}
+	for _, season := range series.Seasons {
+		if season.SeasonNumber != seasonNumber {
+			continue
+		}
+		for _, episode := range season.Episodes {
+			if episode.EpisodeNumber == episodeNumber {
+				return episode.Title
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package localartwork
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ParseSetID splits a local artwork set ID into its item type and TMDB ID
+func ParseSetID(setID string) (itemType, tmdbID string, ok bool) {
+	if !IsLocalSet(setID) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(setID, SetIDPrefix), "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// GetSetByID re-indexes the artwork folder for the item the set belongs to
+func GetSetByID(ctx context.Context, setID, itemLibraryTitle, edition string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Local Artwork: Get Set By ID '%s'", setID), logging.LevelDebug)
+	defer logAction.Complete()
+
+	itemType, tmdbID, ok := ParseSetID(setID)
+	if !ok {
+		logAction.SetError("Invalid Local Artwork Set ID", "Local artwork Set IDs must be in the format local_<type>_<tmdb_id>", map[string]any{"set_id": setID})
+		return set, includedItems, *logAction.Error
+	}
+
+	sets, includedItems, Err := GetItemSet(ctx, tmdbID, itemType, itemLibraryTitle, edition)
+	if Err.Message != "" {
+		return set, includedItems, Err
+	}
+	if len(sets) == 0 {
+		logAction.SetError("No local artwork found", "The artwork folder for this item is missing or has no images", map[string]any{"set_id": setID})
+		return set, includedItems, *logAction.Error
+	}
+
+	return sets[0], includedItems, logging.LogErrorInfo{}
+}
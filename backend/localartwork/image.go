@@ -0,0 +1,38 @@
+package localartwork
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// GetImage reads a local artwork image from disk
+func GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	_, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Local Artwork: Reading Image '%s'", imageFile.ID), logging.LevelTrace)
+	defer logAction.Complete()
+
+	if !IsEnabled(ctx) {
+		logAction.SetError("Local artwork folder not configured", "Enable Images.LocalArtwork and set the Path in the settings to use local artwork", nil)
+		return nil, "", *logAction.Error
+	}
+
+	imagePath := getImagePath(ctx, imageFile.ID)
+	if !IsLocalImage(imageFile) || imagePath == "" {
+		logAction.SetError("Invalid Local Artwork Image ID", "The image ID does not point to a file inside the local artwork folder", map[string]any{"image_id": imageFile.ID})
+		return nil, "", *logAction.Error
+	}
+
+	imageData, err := os.ReadFile(imagePath)
+	if err != nil {
+		logAction.SetError("Failed to read local artwork image", "Ensure the file still exists and is readable by AURA", map[string]any{
+			"error": err.Error(),
+			"path":  imagePath,
+		})
+		return nil, "", *logAction.Error
+	}
+
+	return imageData, http.DetectContentType(imageData), logging.LogErrorInfo{}
+}
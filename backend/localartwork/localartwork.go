@@ -0,0 +1,61 @@
+package localartwork
+
+import (
+	"aura/config"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	SourceName = "local"
+	// SetIDPrefix is prepended to every local artwork set ID so that it can never collide with a MediUX set ID
+	SetIDPrefix = "local_"
+	// ImageIDPrefix is prepended to every local image ID (the file path relative to the artwork root)
+	ImageIDPrefix = "local_"
+	// SetUserCreated is used as the "creator" of all local artwork sets
+	SetUserCreated = "Local"
+)
+
+// IsEnabled returns true when a local artwork folder has been configured
+func IsEnabled(ctx context.Context) bool {
+	localArtwork := config.Current(ctx).Images.LocalArtwork
+	return localArtwork.Enabled && strings.TrimSpace(localArtwork.Path) != ""
+}
+
+// BuildSetID returns the set ID for the local artwork of an item
+func BuildSetID(itemType, tmdbID string) string {
+	return fmt.Sprintf("%s%s_%s", SetIDPrefix, itemType, tmdbID)
+}
+
+// IsLocalSet returns true if the set ID belongs to a local artwork set
+func IsLocalSet(setID string) bool {
+	return strings.HasPrefix(setID, SetIDPrefix)
+}
+
+// IsLocalImage returns true if the image file was sourced from the local artwork folder
+func IsLocalImage(imageFile models.ImageFile) bool {
+	return strings.HasPrefix(imageFile.ID, ImageIDPrefix)
+}
+
+// GetImageSrc returns the AURA API URL used by the web UI to display a local image
+func GetImageSrc(imageFile models.ImageFile) string {
+	query := url.Values{}
+	query.Set("image_id", imageFile.ID)
+	query.Set("modified", imageFile.Modified.Format("20060102150405"))
+	return "/api/images/local/item?" + query.Encode()
+}
+
+func buildImageID(relativePath string) string {
+	return ImageIDPrefix + filepath.ToSlash(relativePath)
+}
+
+// getImagePath resolves an image ID to a file path inside the artwork root
+// An empty path is returned if the image ID points outside of the artwork root
+func getImagePath(ctx context.Context, imageID string) string {
+	return utils.PathInFolder(config.Current(ctx).Images.LocalArtwork.Path, strings.TrimPrefix(imageID, ImageIDPrefix))
+}
@@ -0,0 +1,36 @@
+package localartwork
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"time"
+)
+
+// Local is the image source for artwork stored in a local folder
+type Local struct{}
+
+func (l *Local) GetItemSets(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string, filter models.ImageFilter) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	return GetItemSet(ctx, tmdbID, itemType, itemLibraryTitle, edition)
+}
+
+func (l *Local) GetSetByID(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	return GetSetByID(ctx, setID, itemLibraryTitle, edition)
+}
+
+func (l *Local) GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	return GetImage(ctx, imageFile)
+}
+
+// Local images are not reachable by the Media Server, so they always have to be uploaded
+func (l *Local) GetImageURL(ctx context.Context, imageFile models.ImageFile) (imageURL string, Err logging.LogErrorInfo) {
+	return "", logging.LogErrorInfo{}
+}
+
+func (l *Local) GetSetUpdatedAt(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (updatedAt time.Time, Err logging.LogErrorInfo) {
+	set, _, Err := l.GetSetByID(ctx, setID, setType, itemTMDB_ID, itemLibraryTitle, edition)
+	if Err.Message != "" {
+		return updatedAt, Err
+	}
+	return set.DateUpdated, Err
+}
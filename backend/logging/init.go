@@ -9,7 +9,6 @@ import (
 	"sync/atomic"
 
 	"github.com/rs/zerolog"
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var LOGGER *zerolog.Logger                      // Global logger instance
@@ -73,14 +72,13 @@ func init() {
 	LogFilePath = path.Join(LogFolder, "aura.log")
 
 	// rotate logs using lumberjack; output is written as JSON lines (jsonl)
-	logFile := &lumberjack.Logger{
-		Filename:   LogFilePath,
-		MaxSize:    25,    // megabytes
-		MaxBackups: 7,     // number of backups
-		MaxAge:     14,    // days
-		LocalTime:  true,  // use local time
-		Compress:   false, // disabled by default
-	}
+	// The rotation settings from the config are applied once it is loaded
+	logWriter = newRotatingWriter(LogFilePath, LogRotation{
+		MaxSizeMB:  DefaultLogMaxSizeMB,
+		MaxBackups: DefaultLogMaxBackups,
+		MaxAgeDays: DefaultLogMaxAgeDays,
+		Compress:   false,
+	})
 
 	// Create a console writer for pretty printing to console
 	consoleWriter := zerolog.ConsoleWriter{
@@ -108,7 +106,7 @@ func init() {
 	}
 
 	// Combine log file writer and console writer
-	multi := zerolog.MultiLevelWriter(logFile, consoleWriter)
+	multi := zerolog.MultiLevelWriter(logWriter, consoleWriter)
 
 	// Use the global LogLevel
 	zerolog.SetGlobalLevel(LogLevel)
@@ -33,6 +33,9 @@ func (ld *LogData) Log() {
 
 	highestLevel := getHighestActionLevel(ld.Actions)
 
+	// Export before the actions are filtered by log level, traces always get every action
+	ld.exportTrace(highestLevel)
+
 	// Skip logging if below global log level and no route info
 	if int(LogLevel) > LogLevelToInt(highestLevel) && ld.Route == nil {
 		return
@@ -89,6 +92,9 @@ func (ld *LogData) Log() {
 	if ld.Route != nil {
 		event.Interface("route", ld.Route)
 	}
+	if ld.TraceID != "" {
+		event.Str("trace_id", ld.TraceID)
+	}
 
 	event.Msg(ld.Message)
 }
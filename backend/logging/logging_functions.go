@@ -66,13 +66,18 @@ func AddSubActionToContext(ctx context.Context, name, level string) (context.Con
 
 // NewLogData creates a new LogData instance.
 func NewLogData(name string) *LogData {
-	return &LogData{
+	ld := &LogData{
 		Status:    "",
 		Message:   name,
 		Timestamp: time.Now(),
 		Route:     nil,
 		Actions:   []*LogAction{},
+		spanID:    newSpanID(),
 	}
+	if TracingEnabled() {
+		ld.TraceID = newTraceID()
+	}
+	return ld
 }
 
 // AddAction adds a top-level action to LogData.
@@ -83,6 +88,7 @@ func (ld *LogData) AddAction(name, level string) *LogAction {
 		Name:      name,
 		Timestamp: time.Now(),
 		Level:     ifEmpty(level, LevelDebug),
+		spanID:    newSpanID(),
 	}
 	ld.Actions = append(ld.Actions, action)
 	return action
@@ -98,6 +104,7 @@ func (a *LogAction) AddSubAction(name, level string) *LogAction {
 		Level:     ifEmpty(level, a.Level),
 		Result:    make(map[string]any),
 		Warnings:  make(map[string]any),
+		spanID:    newSpanID(),
 	}
 	a.SubActions = append(a.SubActions, sub)
 	return sub
@@ -33,6 +33,7 @@ func ShouldSkipLogging(request *http.Request, ld *LogData) bool {
 		`^/api/images/.*$`,
 		`^/api/config$`,
 		`^/api/download/queue$`,
+		`^/api/health/(live|ready)$`,
 	}
 
 	if ld != nil {
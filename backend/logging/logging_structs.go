@@ -2,6 +2,7 @@ package logging
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,15 +20,19 @@ const (
 
 // LogData is the base element used for both HTTP routes and background jobs.
 type LogData struct {
-	Level               string        `json:"level,omitempty"` // Optional level for UI filtering (e.g. "trace", "debug","info","warn","error")
-	Status              string        `json:"status"`          // Status: "success", "error", or "warn"
-	Message             string        `json:"message"`         // Human name for the overall operation: "Add to DB", "Fetch All Media Items" or Route.Path
-	Timestamp           time.Time     `json:"timestamp"`       // When the operation started
-	Time                time.Time     `json:"time,omitzero"`   // Time of the log entry (same as Timestamp, kept for compatibility)
-	ElapsedMicroseconds int64         `json:"elapsed_us"`      // Elapsed time in microseconds
-	Route               *LogRouteInfo `json:"route,omitempty"` // Optional route metadata (nil for background jobs)
-	Actions             []*LogAction  `json:"actions"`         // Slice of actions within this operation
+	Level               string        `json:"level,omitempty"`    // Optional level for UI filtering (e.g. "trace", "debug","info","warn","error")
+	Status              string        `json:"status"`             // Status: "success", "error", or "warn"
+	Message             string        `json:"message"`            // Human name for the overall operation: "Add to DB", "Fetch All Media Items" or Route.Path
+	Timestamp           time.Time     `json:"timestamp"`          // When the operation started
+	Time                time.Time     `json:"time,omitzero"`      // Time of the log entry (same as Timestamp, kept for compatibility)
+	ElapsedMicroseconds int64         `json:"elapsed_us"`         // Elapsed time in microseconds
+	Route               *LogRouteInfo `json:"route,omitempty"`    // Optional route metadata (nil for background jobs)
+	Actions             []*LogAction  `json:"actions"`            // Slice of actions within this operation
+	TraceID             string        `json:"trace_id,omitempty"` // Trace ID of the exported spans (only set when tracing is enabled)
 	mu                  sync.Mutex    // Mutex to protect concurrent access to Actions
+	spanID              string        // Span ID of the operation itself
+	parentSpanID        string        // Span ID of the caller, when the trace was continued from a traceparent header
+	exported            atomic.Bool   // The trace was already handed to the exporter
 }
 
 // RouteInfo holds request-specific metadata populated by the Chi middleware.
@@ -52,6 +57,8 @@ type LogAction struct {
 	SubActions          []*LogAction   `json:"sub_actions,omitempty"` // Optional nested sub-actions
 	Completed           bool           `json:"-"`
 	mu                  sync.Mutex     // Mutex to protect concurrent access to SubActions
+	spanID              string         // Span ID used when the action is exported as a trace span
+	outbound            bool           // The action makes an outbound request, it is exported as a client span
 }
 
 // LogErrorInfo contains structured error information.
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Rotation settings used until the config is loaded, and for any setting left at 0
+const (
+	DefaultLogMaxSizeMB  = 25 // Size the log file is rotated at
+	DefaultLogMaxBackups = 7  // Rotated log files kept
+	DefaultLogMaxAgeDays = 14 // Days rotated log files are kept
+)
+
+// Layout lumberjack uses for the time in a rotated file name (e.g. aura-2024-01-02T15-04-05.000.log)
+const rotatedLogTimeFormat = "2006-01-02T15-04-05.000"
+
+// LogRotation holds how the log file is rotated and how long rotated files are kept
+type LogRotation struct {
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+// rotatingWriter writes to the log file through lumberjack.
+// The lumberjack logger is swapped when the rotation settings change.
+type rotatingWriter struct {
+	mu       sync.Mutex
+	logger   *lumberjack.Logger
+	rotation LogRotation
+}
+
+var logWriter *rotatingWriter
+
+func newRotatingWriter(filePath string, rotation LogRotation) *rotatingWriter {
+	return &rotatingWriter{logger: newLumberjackLogger(filePath, rotation), rotation: rotation}
+}
+
+func newLumberjackLogger(filePath string, rotation LogRotation) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   filePath,
+		MaxSize:    rotation.MaxSizeMB, // megabytes
+		MaxBackups: rotation.MaxBackups,
+		MaxAge:     rotation.MaxAgeDays, // days
+		LocalTime:  true,                // use local time
+		Compress:   rotation.Compress,
+	}
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.logger.Write(p)
+}
+
+// SetLogRotation changes how the log file is rotated and how long rotated files are kept.
+// Settings left at 0 use the defaults.
+func SetLogRotation(rotation LogRotation) {
+	if rotation.MaxSizeMB <= 0 {
+		rotation.MaxSizeMB = DefaultLogMaxSizeMB
+	}
+	if rotation.MaxBackups <= 0 {
+		rotation.MaxBackups = DefaultLogMaxBackups
+	}
+	if rotation.MaxAgeDays <= 0 {
+		rotation.MaxAgeDays = DefaultLogMaxAgeDays
+	}
+	if logWriter == nil {
+		return
+	}
+
+	logWriter.mu.Lock()
+	defer logWriter.mu.Unlock()
+	if logWriter.rotation == rotation {
+		return
+	}
+
+	// Close the current file, the new logger opens it again on the next write
+	// and applies the retention policy on its next rotation
+	_ = logWriter.logger.Close()
+	logWriter.logger = newLumberjackLogger(LogFilePath, rotation)
+	logWriter.rotation = rotation
+}
+
+// LogFile is the current log file or one of the rotated ones
+type LogFile struct {
+	Path       string
+	Compressed bool
+	RotatedAt  time.Time // Zero for the current log file
+}
+
+// LogFiles returns the current log file followed by the rotated ones, newest first
+func LogFiles() []LogFile {
+	files := []LogFile{}
+	if _, err := os.Stat(LogFilePath); err == nil {
+		files = append(files, LogFile{Path: LogFilePath})
+	}
+
+	entries, err := os.ReadDir(LogFolder)
+	if err != nil {
+		return files
+	}
+	ext := filepath.Ext(LogFilePath)
+	prefix := strings.TrimSuffix(filepath.Base(LogFilePath), ext) + "-"
+
+	rotated := []LogFile{}
+	for _, entry := range entries {
+		name := entry.Name()
+		compressed := strings.HasSuffix(name, ext+".gz")
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || (!strings.HasSuffix(name, ext) && !compressed) {
+			continue
+		}
+		timestamp := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".gz"), ext)
+		rotatedAt, err := time.ParseInLocation(rotatedLogTimeFormat, timestamp, time.Local)
+		if err != nil {
+			continue
+		}
+		rotated = append(rotated, LogFile{
+			Path:       filepath.Join(LogFolder, name),
+			Compressed: compressed,
+			RotatedAt:  rotatedAt,
+		})
+	}
+	sort.Slice(rotated, func(i, j int) bool {
+		return rotated[i].RotatedAt.After(rotated[j].RotatedAt)
+	})
+	return append(files, rotated...)
+}
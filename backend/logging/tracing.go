@@ -0,0 +1,416 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceConfig holds where LogData traces are exported to over OTLP/HTTP
+type TraceConfig struct {
+	Enabled     bool
+	Endpoint    string            // OTLP/HTTP endpoint of the collector (e.g. http://tempo:4318)
+	ServiceName string            // service.name of the exported spans
+	Headers     map[string]string // Extra headers sent with every export (e.g. an auth token)
+}
+
+const (
+	defaultTraceServiceName = "aura"
+	traceExportInterval     = 5 * time.Second
+	traceExportBatchSize    = 512
+	traceQueueSize          = 4096
+)
+
+// OTLP span kinds and status codes
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+	spanKindClient   = 3
+	spanStatusOK     = 1
+	spanStatusError  = 2
+)
+
+// traceExporter batches finished spans and posts them to the collector
+type traceExporter struct {
+	config TraceConfig
+	url    string
+	client *http.Client
+	queue  chan otlpSpan
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+var (
+	tracingMu      sync.Mutex
+	tracing        atomic.Pointer[traceExporter] // nil when tracing is disabled
+	traceExportErr atomic.Bool                   // A failed export was already logged
+)
+
+// SetTracing starts, restarts or stops the OTLP trace exporter
+func SetTracing(cfg TraceConfig) {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+
+	current := tracing.Load()
+	if current != nil && tracingConfigEqual(current.config, cfg) {
+		return
+	}
+	if current != nil {
+		tracing.Store(nil)
+		close(current.stop)
+		<-current.done
+	}
+	if !cfg.Enabled || cfg.Endpoint == "" {
+		return
+	}
+
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = defaultTraceServiceName
+	}
+	exporter := &traceExporter{
+		config: cfg,
+		url:    otlpTracesURL(cfg.Endpoint),
+		client: &http.Client{Timeout: 10 * time.Second},
+		queue:  make(chan otlpSpan, traceQueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	traceExportErr.Store(false)
+	go exporter.run()
+	tracing.Store(exporter)
+	LOGGER.Info().Timestamp().Str("endpoint", exporter.url).Str("service_name", cfg.ServiceName).Msg("OTLP trace export started")
+}
+
+// TracingEnabled returns true if LogData traces are being exported
+func TracingEnabled() bool {
+	return tracing.Load() != nil
+}
+
+func tracingConfigEqual(a, b TraceConfig) bool {
+	if a.Enabled != b.Enabled || a.Endpoint != b.Endpoint || a.ServiceName != b.ServiceName || len(a.Headers) != len(b.Headers) {
+		return false
+	}
+	for key, value := range a.Headers {
+		if b.Headers[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// otlpTracesURL adds the OTLP/HTTP traces path to the collector endpoint, unless it is already there
+func otlpTracesURL(endpoint string) string {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if strings.HasSuffix(endpoint, "/v1/traces") {
+		return endpoint
+	}
+	return endpoint + "/v1/traces"
+}
+
+// --- Trace Context ---
+
+func newTraceID() string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(rand.Uint32())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+func newSpanID() string {
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(rand.Uint32())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// SetRemoteParent continues the trace from a W3C traceparent header (e.g. sent by Sonarr or a reverse proxy).
+// Invalid headers are ignored and the LogData keeps its own trace.
+func (ld *LogData) SetRemoteParent(traceparent string) {
+	// version-traceid-parentid-flags
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return
+	}
+	if !TracingEnabled() {
+		return
+	}
+	if _, err := hex.DecodeString(parts[1] + parts[2]); err != nil {
+		return
+	}
+	if parts[1] == strings.Repeat("0", 32) || parts[2] == strings.Repeat("0", 16) {
+		return
+	}
+	ld.TraceID = strings.ToLower(parts[1])
+	ld.parentSpanID = strings.ToLower(parts[2])
+}
+
+// ContinueTraceFrom makes the LogData part of the trace in ctx, as a child of the current action.
+// Use it for background work started by a request, the work then shows up in the request's trace.
+func (ld *LogData) ContinueTraceFrom(ctx context.Context) {
+	if !TracingEnabled() {
+		return
+	}
+	parent := LogDataFromContext(ctx)
+	if parent == nil || parent.TraceID == "" {
+		return
+	}
+	ld.TraceID = parent.TraceID
+	ld.parentSpanID = parent.spanID
+	if action := CurrentActionFromContext(ctx); action != nil {
+		ld.parentSpanID = action.spanID
+	}
+}
+
+// TraceParent returns the W3C traceparent header for an outbound request made in ctx,
+// so the request shows up as a child of the current action. The action is exported as a client span.
+// Returns "" when tracing is disabled.
+func TraceParent(ctx context.Context) string {
+	if !TracingEnabled() {
+		return ""
+	}
+	ld := LogDataFromContext(ctx)
+	if ld == nil || ld.TraceID == "" {
+		return ""
+	}
+	spanID := ld.spanID
+	if action := CurrentActionFromContext(ctx); action != nil {
+		action.mu.Lock()
+		action.outbound = true
+		action.mu.Unlock()
+		spanID = action.spanID
+	}
+	return fmt.Sprintf("00-%s-%s-01", ld.TraceID, spanID)
+}
+
+// --- OTLP/HTTP JSON Export ---
+
+type otlpAttribute struct {
+	Key   string        `json:"key"`
+	Value otlpAttrValue `json:"value"`
+}
+
+type otlpAttrValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+// exportTrace queues the LogData and its actions as spans. It is called once the LogData is complete.
+func (ld *LogData) exportTrace(highestLevel string) {
+	exporter := tracing.Load()
+	if exporter == nil || ld.TraceID == "" || ld.exported.Swap(true) {
+		return
+	}
+
+	status := ld.Status
+	if highestLevel == LevelError {
+		status = StatusError
+	}
+
+	root := otlpSpan{
+		TraceID:           ld.TraceID,
+		SpanID:            ld.spanID,
+		ParentSpanID:      ld.parentSpanID,
+		Name:              ld.Message,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: unixNanoString(ld.Timestamp),
+		EndTimeUnixNano:   unixNanoString(ld.Timestamp.Add(time.Duration(ld.ElapsedMicroseconds) * time.Microsecond)),
+		Status:            spanStatus(status, ""),
+	}
+	if ld.Route != nil {
+		root.Kind = spanKindServer
+		root.Name = routeSpanName(ld.Route.Method, ld.Route.Path)
+		root.Attributes = append(root.Attributes,
+			stringAttribute("http.request.method", ld.Route.Method),
+			stringAttribute("url.path", ld.Route.Path),
+		)
+		if ld.Route.IP != "" {
+			root.Attributes = append(root.Attributes, stringAttribute("client.address", ld.Route.IP))
+		}
+	}
+	exporter.enqueue(root)
+
+	ld.mu.Lock()
+	actions := append([]*LogAction(nil), ld.Actions...)
+	ld.mu.Unlock()
+	for _, action := range actions {
+		exporter.enqueueAction(ld.TraceID, ld.spanID, action)
+	}
+}
+
+func (e *traceExporter) enqueueAction(traceID, parentSpanID string, action *LogAction) {
+	action.mu.Lock()
+	span := otlpSpan{
+		TraceID:           traceID,
+		SpanID:            action.spanID,
+		ParentSpanID:      parentSpanID,
+		Name:              action.Name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: unixNanoString(action.Timestamp),
+		EndTimeUnixNano:   unixNanoString(action.Timestamp.Add(time.Duration(action.ElapsedMicroseconds) * time.Microsecond)),
+		Attributes:        []otlpAttribute{stringAttribute("aura.level", action.Level)},
+	}
+	if action.outbound {
+		span.Kind = spanKindClient
+	}
+	errorMessage := ""
+	if action.Error != nil {
+		errorMessage = action.Error.Message
+	}
+	span.Status = spanStatus(action.Status, errorMessage)
+	for key, value := range action.Result {
+		span.Attributes = append(span.Attributes, stringAttribute("aura.result."+key, fmt.Sprint(value)))
+	}
+	for key, value := range action.Warnings {
+		span.Attributes = append(span.Attributes, stringAttribute("aura.warning."+key, fmt.Sprint(value)))
+	}
+	subActions := append([]*LogAction(nil), action.SubActions...)
+	action.mu.Unlock()
+
+	e.enqueue(span)
+	for _, sub := range subActions {
+		e.enqueueAction(traceID, action.spanID, sub)
+	}
+}
+
+// enqueue drops the span if the queue is full, tracing must never slow down or block AURA
+func (e *traceExporter) enqueue(span otlpSpan) {
+	select {
+	case e.queue <- span:
+	default:
+	}
+}
+
+func (e *traceExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(traceExportInterval)
+	defer ticker.Stop()
+
+	batch := make([]otlpSpan, 0, traceExportBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		e.export(batch)
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= traceExportBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stop:
+			// Send what is left before stopping
+			for {
+				select {
+				case span := <-e.queue:
+					batch = append(batch, span)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *traceExporter) export(spans []otlpSpan) {
+	payload := map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"resource": map[string]any{
+				"attributes": []otlpAttribute{stringAttribute("service.name", e.config.ServiceName)},
+			},
+			"scopeSpans": []any{map[string]any{
+				"scope": map[string]any{"name": "aura/logging"},
+				"spans": spans,
+			}},
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		e.exportFailed(err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		e.exportFailed(err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.config.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		e.exportFailed(err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		e.exportFailed(fmt.Errorf("collector returned %s", resp.Status))
+		return
+	}
+	if traceExportErr.Swap(false) {
+		LOGGER.Info().Timestamp().Str("endpoint", e.url).Msg("OTLP trace export recovered")
+	}
+}
+
+// exportFailed logs the first failure of a streak, so an unreachable collector doesn't flood the log
+func (e *traceExporter) exportFailed(err error) {
+	if !traceExportErr.Swap(true) {
+		LOGGER.Warn().Timestamp().Str("endpoint", e.url).Err(err).Msg("OTLP trace export failed, spans are dropped until the collector is reachable")
+	}
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAttrValue{StringValue: value}}
+}
+
+func spanStatus(status, message string) otlpStatus {
+	if status == StatusError {
+		return otlpStatus{Code: spanStatusError, Message: message}
+	}
+	return otlpStatus{Code: spanStatusOK}
+}
+
+func routeSpanName(method, path string) string {
+	if method == "" {
+		return path
+	}
+	return method + " " + path
+}
+
+func unixNanoString(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
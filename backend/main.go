@@ -18,6 +18,7 @@ import (
 	"aura/logging"
 	"aura/notification"
 	"aura/routing"
+	"context"
 	"os"
 	"strings"
 	"sync/atomic"
@@ -41,9 +42,9 @@ func init() {
 
 func main() {
 	// Serve immediately with onboarding/public routes first.
-	config.AppFullyLoaded = false
+	config.AppFullyLoaded.Store(false)
 	config.AppVersion = APP_VERSION
-	config.AppLoadingStep = "Initializing Application"
+	config.AppLoadingStep.Store("Initializing Application")
 	activeHandler.Store(routing.NewRouter())
 
 	// Start API now (non-blocking for init pipeline).
@@ -65,7 +66,7 @@ func main() {
 				logging.LOGGER.Fatal().Timestamp().Msg("Warmup failed during OnboardingComplete. Exiting application.")
 				return
 			}
-			config.AppFullyLoaded = true
+			config.AppFullyLoaded.Store(true)
 			activeHandler.Store(routing.NewRouter())
 			logging.LOGGER.Info().Timestamp().Msg("Onboarding complete. Main routes active.")
 		}
@@ -73,7 +74,9 @@ func main() {
 		if bootStrapSuccess {
 			preflightSuccess := runPreFlight()
 			if !preflightSuccess {
-				config.Valid = false
+				config.Update(context.Background(), func(cfg *config.Config) {
+					cfg.Status.Valid = false
+				})
 				activeHandler.Store(routing.NewRouter()) // stays onboarding
 				return
 			}
@@ -84,15 +87,15 @@ func main() {
 				os.Exit(1)
 			}
 
-			config.AppFullyLoaded = true
-			config.AppLoadingStep = "App Fully Loaded"
+			config.AppFullyLoaded.Store(true)
+			config.AppLoadingStep.Store("App Fully Loaded")
 			// Send App Start Notification
 			// Send notification (only if not dev & notifications enabled)
 			if !strings.Contains(APP_VERSION, "dev") &&
-				config.Current.Notifications.Enabled {
+				config.Latest().Notifications.Enabled {
 				notification.SendAppStartNotification(APP_PORT, APP_NAME, APP_VERSION)
 			} else {
-				logging.LOGGER.Warn().Timestamp().Bool("notifications_enabled", config.Current.Notifications.Enabled).Bool("dev_version", strings.Contains(APP_VERSION, "dev")).Msg("App start notification not sent")
+				logging.LOGGER.Warn().Timestamp().Bool("notifications_enabled", config.Latest().Notifications.Enabled).Bool("dev_version", strings.Contains(APP_VERSION, "dev")).Msg("App start notification not sent")
 			}
 			activeHandler.Store(routing.NewRouter()) // swap to full routes
 			return
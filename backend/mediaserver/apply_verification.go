@@ -0,0 +1,196 @@
+package mediaserver
+
+import (
+	"aura/config"
+	"aura/imagesource"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Result of checking an applied image against the media server
+const (
+	APPLY_VERIFIED   = "verified"
+	APPLY_UNVERIFIED = "unverified"
+	// APPLY_PENDING means the image did not match yet, it is checked again by ApplyVerifier.RetryPending
+	APPLY_PENDING = "pending"
+)
+
+// How long to wait before an image that didn't match is fetched again
+const verifyRetryDelay = 2 * time.Second
+
+// ApplyVerifier verifies the images applied in one batch (e.g. one set).
+// Images that don't match right away are fetched again together by RetryPending,
+// so the batch waits for the media server once instead of once per image.
+type ApplyVerifier struct {
+	mu           sync.Mutex
+	pending      []pendingVerification
+	mismatchedAt time.Time
+}
+
+type pendingVerification struct {
+	item                  *models.MediaItem
+	imageFile             models.ImageFile
+	imageRatingKey        string
+	imageType             string
+	appliedHash           string
+	appliedPerceptualHash string
+}
+
+// VerifyResult is the verification of an image that was checked again by RetryPending
+type VerifyResult struct {
+	ImageFile    models.ImageFile
+	Verification string
+}
+
+func NewApplyVerifier() *ApplyVerifier {
+	return &ApplyVerifier{}
+}
+
+// DownloadApplyAndVerifyImage applies the image like DownloadApplyImageToMediaItem.
+// When Images.VerifyApply is enabled, the image the media server shows afterwards is compared with the applied image
+// and verification is APPLY_VERIFIED or APPLY_UNVERIFIED. It is empty when the check is disabled.
+// A mismatch is checked again after a short wait before this returns, use ApplyVerifier for batches.
+func DownloadApplyAndVerifyImage(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (verification string, Err logging.LogErrorInfo) {
+	verifier := NewApplyVerifier()
+	verification, Err = verifier.DownloadApplyAndVerifyImage(ctx, item, imageFile)
+	if verification == APPLY_PENDING {
+		results := verifier.RetryPending(ctx)
+		verification = results[0].Verification
+	}
+	return verification, Err
+}
+
+// DownloadApplyAndVerifyImage applies and verifies an image like the package level DownloadApplyAndVerifyImage.
+// A mismatch returns APPLY_PENDING, the image is then checked again by RetryPending.
+func (v *ApplyVerifier) DownloadApplyAndVerifyImage(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (verification string, Err logging.LogErrorInfo) {
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	if Err.Message != "" {
+		return "", Err
+	}
+	captureCurrentArtwork(ctx, msClient, item, imageFile)
+	Err = msClient.DownloadApplyImageToMediaItem(ctx, item, imageFile)
+	if Err.Message != "" {
+		return "", Err
+	}
+
+	var appliedImage []byte
+	if config.Current(ctx).Images.VerifyApply.Enabled {
+		verification, appliedImage = v.verifyAppliedImage(ctx, msClient, item, imageFile)
+	}
+	recordAppliedFingerprint(ctx, item, imageFile, appliedImage)
+	return verification, logging.LogErrorInfo{}
+}
+
+// RetryPending fetches every pending image again, once the media server had verifyRetryDelay to process it.
+// The results are in the order the images were applied.
+func (v *ApplyVerifier) RetryPending(ctx context.Context) (results []VerifyResult) {
+	v.mu.Lock()
+	pending := v.pending
+	mismatchedAt := v.mismatchedAt
+	v.pending = nil
+	v.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Verifying %d applied images again", len(pending)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Until(mismatchedAt.Add(verifyRetryDelay))):
+	}
+
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	for _, p := range pending {
+		result := VerifyResult{ImageFile: p.imageFile, Verification: APPLY_UNVERIFIED}
+		if Err.Message == "" {
+			if match, _ := matchesMediaServerImage(ctx, logAction, msClient, p); match {
+				result.Verification = APPLY_VERIFIED
+			}
+		}
+		logAction.AppendResult(utils.GetFileDownloadName(p.item.Title, p.imageFile), result.Verification)
+		results = append(results, result)
+	}
+	return results
+}
+
+// verifyAppliedImage fetches the image the media server shows and compares it with the applied image.
+// When they don't match, the image is left for RetryPending, which fetches it again past any cache.
+// The item is not refreshed: a metadata refresh can replace the applied image, and the event listener re-applies
+// images after a refresh, so a lasting mismatch would apply, verify and refresh the item over and over.
+// The applied image is returned so it doesn't have to be read from the image source again.
+func (v *ApplyVerifier) verifyAppliedImage(ctx context.Context, msClient MediaServerInterface, item *models.MediaItem, imageFile models.ImageFile) (verification string, appliedImage []byte) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Verifying applied %s for %s", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	Err := ensureShowDetails(ctx, item, imageFile.Type)
+	if Err.Message != "" {
+		logAction.AppendWarning("message", "Failed to get show details, the applied image could not be verified")
+		logAction.AppendWarning("error", Err)
+		return APPLY_UNVERIFIED, nil
+	}
+
+	imageRatingKey, imageType := getCurrentArtworkRequest(*item, imageFile)
+	if imageRatingKey == "" {
+		logAction.AppendWarning("message", "Could not determine the rating key of the applied image")
+		return APPLY_UNVERIFIED, nil
+	}
+
+	appliedImage, _, Err = imagesource.GetImage(ctx, imageFile)
+	if Err.Message != "" || len(appliedImage) == 0 {
+		logAction.AppendWarning("message", "Failed to get the applied image from the image source")
+		logAction.AppendWarning("error", Err)
+		return APPLY_UNVERIFIED, nil
+	}
+	appliedHash, appliedPerceptualHash := utils.ImageFingerprint(appliedImage)
+
+	p := pendingVerification{
+		item:                  item,
+		imageFile:             imageFile,
+		imageRatingKey:        imageRatingKey,
+		imageType:             imageType,
+		appliedHash:           appliedHash,
+		appliedPerceptualHash: appliedPerceptualHash,
+	}
+	match, comparable := matchesMediaServerImage(ctx, logAction, msClient, p)
+	if match {
+		logAction.AppendResult("verification", APPLY_VERIFIED)
+		return APPLY_VERIFIED, appliedImage
+	}
+	if !comparable {
+		// Fetching again won't help when the image format can't be decoded
+		logAction.AppendWarning("message", "The image format can't be compared, the applied image could not be verified")
+		logAction.AppendResult("verification", APPLY_UNVERIFIED)
+		return APPLY_UNVERIFIED, appliedImage
+	}
+
+	// Check again later, the media server may still be processing the image or serving the old one from its cache
+	logAction.AppendWarning("message", "Applied image does not match the media server image, checking again later")
+
+	v.mu.Lock()
+	v.pending = append(v.pending, p)
+	v.mismatchedAt = time.Now()
+	v.mu.Unlock()
+
+	logAction.AppendResult("verification", APPLY_PENDING)
+	return APPLY_PENDING, appliedImage
+}
+
+// matchesMediaServerImage fetches the image the media server shows and compares it with the applied image
+func matchesMediaServerImage(ctx context.Context, logAction *logging.LogAction, msClient MediaServerInterface, p pendingVerification) (match bool, comparable bool) {
+	imageData, Err := msClient.GetMediaItemImage(ctx, p.item, p.imageRatingKey, p.imageType)
+	if Err.Message != "" || len(imageData) == 0 {
+		logAction.AppendWarning("error", Err)
+		return false, true
+	}
+	hash, perceptualHash := utils.ImageFingerprint(imageData)
+	return utils.ImagesMatch(p.appliedHash, p.appliedPerceptualHash, hash, perceptualHash, driftMaxHashDistance)
+}
@@ -0,0 +1,155 @@
+package mediaserver
+
+import (
+	"aura/artworkhistory"
+	"aura/config"
+	"aura/imagesource"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+)
+
+type artworkChangedKey struct{}
+
+// WithArtworkChanged marks that the current artwork may have been changed outside of AURA,
+// e.g. by a metadata refresh or a new file, so it has to be fetched from the media server to capture it
+func WithArtworkChanged(ctx context.Context) context.Context {
+	return context.WithValue(ctx, artworkChangedKey{}, true)
+}
+
+// captureCurrentArtwork saves the artwork currently on the media server before it is replaced by imageFile.
+// Unless ctx is marked with WithArtworkChanged, the current artwork is taken to be the image AURA applied last,
+// and a reference to that image is saved instead of fetching it.
+// Failing to capture never stops the new image from being applied, it is only logged as a warning.
+func captureCurrentArtwork(ctx context.Context, msClient MediaServerInterface, item *models.MediaItem, imageFile models.ImageFile) {
+	if !artworkhistory.IsEnabled(ctx) {
+		return
+	}
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Artwork History: Capturing current %s for %s", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	replacedBy := artworkhistory.NewReplacedBy(ctx, imageFile, imagesource.GetImageSource(imageFile))
+	if changed, _ := ctx.Value(artworkChangedKey{}).(bool); !changed {
+		version, saved, known, Err := artworkhistory.SaveAppliedVersion(ctx, *item, imageFile, replacedBy)
+		if Err.Message != "" {
+			logAction.AppendWarning("message", "Failed to save the current artwork to the artwork history")
+			logAction.AppendWarning("error", Err)
+			return
+		}
+		if known {
+			logAction.AppendResult("version_id", version.ID)
+			logAction.AppendResult("saved", saved)
+			return
+		}
+	}
+
+	imageRatingKey, imageType := getCurrentArtworkRequest(*item, imageFile)
+	if imageRatingKey == "" {
+		logAction.AppendWarning("message", "Could not determine the rating key of the current artwork, it was not captured")
+		return
+	}
+
+	imageData, Err := msClient.GetMediaItemImage(ctx, item, imageRatingKey, imageType)
+	if Err.Message != "" {
+		logAction.AppendWarning("message", "Failed to get the current artwork from the media server, it was not captured")
+		logAction.AppendWarning("error", Err)
+		return
+	}
+
+	version, saved, Err := artworkhistory.SaveVersion(ctx, *item, imageFile, replacedBy, imageData)
+	if Err.Message != "" {
+		logAction.AppendWarning("message", "Failed to save the current artwork to the artwork history")
+		logAction.AppendWarning("error", Err)
+		return
+	}
+	logAction.AppendResult("version_id", version.ID)
+	logAction.AppendResult("saved", saved)
+}
+
+// getCurrentArtworkRequest returns the rating key and image type used to fetch the current artwork for an image type
+func getCurrentArtworkRequest(item models.MediaItem, imageFile models.ImageFile) (imageRatingKey string, imageType string) {
+	switch imageFile.Type {
+	case "poster", "backdrop":
+		return item.RatingKey, imageFile.Type
+	case "season_poster", "special_season_poster":
+		if item.Series == nil || imageFile.SeasonNumber == nil {
+			return "", ""
+		}
+		for _, season := range item.Series.Seasons {
+			if season.SeasonNumber == *imageFile.SeasonNumber {
+				return season.RatingKey, "poster"
+			}
+		}
+	case "titlecard":
+		if item.Series == nil || imageFile.SeasonNumber == nil || imageFile.EpisodeNumber == nil {
+			return "", ""
+		}
+		// Plex stores episode images as the thumb, Emby/Jellyfin as the primary image
+		imageType = "poster"
+		if config.Latest().MediaServer.Type == "Plex" {
+			imageType = "thumb"
+		}
+		for _, season := range item.Series.Seasons {
+			if season.SeasonNumber != *imageFile.SeasonNumber {
+				continue
+			}
+			for _, episode := range season.Episodes {
+				if episode.EpisodeNumber == *imageFile.EpisodeNumber {
+					return episode.RatingKey, imageType
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// RevertArtwork re-applies a captured version of an item's artwork.
+// The artwork it replaces is captured as well, so a revert can itself be reverted.
+func RevertArtwork(ctx context.Context, item *models.MediaItem, versionID string) (version artworkhistory.Version, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Reverting Artwork for %s to version '%s'", utils.MediaItemInfo(*item), versionID), logging.LevelInfo)
+	defer logAction.Complete()
+
+	version, Err = artworkhistory.GetVersion(ctx, *item, versionID)
+	if Err.Message != "" {
+		return version, Err
+	}
+
+	Err = ensureShowDetails(ctx, item, version.ImageType)
+	if Err.Message != "" {
+		return version, Err
+	}
+
+	Err = DownloadApplyImageToMediaItem(ctx, item, artworkhistory.ImageFileForVersion(*item, version))
+	if Err.Message != "" {
+		return version, Err
+	}
+
+	logAction.AppendResult("image_type", version.ImageType)
+	logAction.AppendResult("captured_at", version.CapturedAt)
+	return version, logging.LogErrorInfo{}
+}
+
+// ensureShowDetails fetches the seasons and episodes of a show when they are needed for the image type
+func ensureShowDetails(ctx context.Context, item *models.MediaItem, imageType string) (Err logging.LogErrorInfo) {
+	if imageType == "poster" || imageType == "backdrop" || (item.Series != nil && len(item.Series.Seasons) > 0) {
+		return logging.LogErrorInfo{}
+	}
+
+	found, Err := GetMediaItemDetails(ctx, item)
+	if Err.Message != "" {
+		return Err
+	}
+	if !found {
+		_, logAction := logging.AddSubActionToContext(ctx, "Getting Show Details", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Media Item not found on the media server", "The item may have been removed from the media server", map[string]any{
+			"rating_key": item.RatingKey,
+		})
+		return *logAction.Error
+	}
+	return logging.LogErrorInfo{}
+}
@@ -111,26 +111,26 @@ func CheckForMediaItemChanges(ctx context.Context) (Err logging.LogErrorInfo) {
 
 func sendNotFoundNotification(mediaItem models.MediaItem, reason string, action string, moreInfo string) {
 	// If notifications are disabled, skip
-	if !config.Current.Notifications.Enabled {
+	if !config.Latest().Notifications.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping app start notification")
 		return
 	}
 
 	// If notification providers are not configured, skip
-	if len(config.Current.Notifications.Providers) == 0 {
+	if len(config.Latest().Notifications.Providers) == 0 {
 		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping app start notification")
 		return
 	}
 
 	// If check for media item changes job notification is disabled, skip
-	if !config.Current.Notifications.NotificationTemplate.CheckForMediaItemChangesJob.Enabled {
+	if !config.Latest().Notifications.NotificationTemplate.CheckForMediaItemChangesJob.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Check for media item changes job notification is disabled, skipping notification")
 		return
 	}
 
 	vars := utils.TemplateVars_CheckForMediaItemChangesJob(mediaItem, reason, action, moreInfo)
-	title := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.CheckForMediaItemChangesJob.Title, vars)
-	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.CheckForMediaItemChangesJob.Message, vars)
+	title := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.CheckForMediaItemChangesJob.Title, vars)
+	message := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.CheckForMediaItemChangesJob.Message, vars)
 	imageURL := ""
 
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Notification - Send Not Found Alert")
@@ -140,7 +140,7 @@ func sendNotFoundNotification(mediaItem models.MediaItem, reason string, action
 	defer logAction.Complete()
 
 	// Send a notification to all configured providers
-	for _, provider := range config.Current.Notifications.Providers {
+	for _, provider := range config.Current(ctx).Notifications.Providers {
 		if provider.Enabled {
 			switch provider.Provider {
 			case "Discord":
@@ -0,0 +1,141 @@
+package mediaserver
+
+import (
+	"aura/cache"
+	"aura/logging"
+	"aura/mediux"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"strings"
+)
+
+type CreateCollectionFromSetResult struct {
+	CollectionItem models.CollectionItem `json:"collection_item"`
+	Created        bool                  `json:"created"`        // False when the collection already existed and the items were added to it
+	AddedItems     []string              `json:"added_items"`    // Member movies found in the library
+	MissingItems   []string              `json:"missing_items"`  // Member movies not found in the library
+	AppliedImages  []string              `json:"applied_images"` // Collection image types that were applied
+	ImageErrors    []string              `json:"image_errors"`   // Collection image types that failed to apply
+}
+
+// CreateCollectionFromSet creates the media server collection for a MediUX movie collection set.
+// The member movies found in the library are added, and the collection poster and backdrop of the set are applied.
+// If a collection with the same TMDB ID or title already exists in the library, the members are added to it instead.
+func CreateCollectionFromSet(ctx context.Context, setID string, tmdbID string, libraryTitle string, title string) (result CreateCollectionFromSetResult, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Creating Collection from Set '%s' in Library '%s'", setID, libraryTitle), logging.LevelInfo)
+	defer logAction.Complete()
+
+	result = CreateCollectionFromSetResult{
+		AddedItems:    []string{},
+		MissingItems:  []string{},
+		AppliedImages: []string{},
+		ImageErrors:   []string{},
+	}
+
+	// Get the member movies of the set, matched against the library cache
+	set, includedItems, Err := mediux.GetMovieCollectionSetByID(ctx, setID, tmdbID, libraryTitle, "", false)
+	if Err.Message != "" {
+		return result, Err
+	}
+
+	members := []models.MediaItem{}
+	memberTMDBIDs := []string{}
+	for _, itemID := range set.ItemIDs {
+		includedItem, ok := includedItems[itemID]
+		if !ok {
+			continue
+		}
+		if includedItem.MediaItem.RatingKey == "" {
+			result.MissingItems = append(result.MissingItems, fmt.Sprintf("%s [%s]", includedItem.MediuxInfo.Title, itemID))
+			continue
+		}
+		members = append(members, includedItem.MediaItem)
+		memberTMDBIDs = append(memberTMDBIDs, itemID)
+		result.AddedItems = append(result.AddedItems, utils.MediaItemInfo(includedItem.MediaItem))
+	}
+	logAction.AppendResult("members_found", len(members))
+	logAction.AppendResult("members_missing", len(result.MissingItems))
+	if len(members) == 0 {
+		logAction.SetError("None of the movies in this collection are in the library", "Make sure the library contains at least one movie from the collection", map[string]any{
+			"set_id":        setID,
+			"library_title": libraryTitle,
+		})
+		return result, *logAction.Error
+	}
+
+	// The collection level images live on the collection sets, not the movie collection set
+	collectionImages := []models.ImageFile{}
+	collectionSets, setsErr := mediux.GetCollectionImagesByMovieTMDBIDs(ctx, memberTMDBIDs)
+	if setsErr.Message != "" {
+		logAction.AppendWarning("collection_images", setsErr.Message)
+	}
+	for _, collectionSet := range collectionSets {
+		if collectionSet.ID == setID {
+			collectionImages = collectionSet.Images
+			break
+		}
+	}
+
+	// The images carry the TMDB collection ID, which Emby/Jellyfin use to identify the BoxSet
+	collectionTMDBID := ""
+	for _, image := range collectionImages {
+		if image.ItemTMDB_ID != "" {
+			collectionTMDBID = image.ItemTMDB_ID
+			break
+		}
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = set.Title
+	}
+
+	collectionItem, exists := findExistingCollection(libraryTitle, title, collectionTMDBID)
+	if exists {
+		Err = AddItemsToCollection(ctx, &collectionItem, members)
+		if Err.Message != "" {
+			return result, Err
+		}
+	} else {
+		collectionItem, Err = CreateCollection(ctx, libraryTitle, title, collectionTMDBID, members)
+		if Err.Message != "" {
+			return result, Err
+		}
+		result.Created = true
+	}
+	collectionItem.MediaItems = members
+	collectionItem.ChildCount = max(collectionItem.ChildCount, len(members))
+	cache.CollectionsStore.UpsertCollection(&collectionItem)
+
+	// Apply the first poster and backdrop of the collection set
+	appliedTypes := map[string]bool{}
+	for _, image := range collectionImages {
+		if (image.Type != "collection_poster" && image.Type != "collection_backdrop") || appliedTypes[image.Type] {
+			continue
+		}
+		appliedTypes[image.Type] = true
+		Err = ApplyCollectionImage(ctx, &collectionItem, image)
+		if Err.Message != "" {
+			result.ImageErrors = append(result.ImageErrors, image.Type)
+			continue
+		}
+		result.AppliedImages = append(result.AppliedImages, image.Type)
+	}
+
+	result.CollectionItem = collectionItem
+	return result, logging.LogErrorInfo{}
+}
+
+func findExistingCollection(libraryTitle, title, tmdbID string) (models.CollectionItem, bool) {
+	for _, collection := range cache.CollectionsStore.GetCollectionsByLibrary(libraryTitle) {
+		if tmdbID != "" && collection.TMDB_ID == tmdbID {
+			return collection, true
+		}
+		if strings.EqualFold(strings.TrimSpace(collection.Title), title) {
+			return collection, true
+		}
+	}
+	return models.CollectionItem{}, false
+}
@@ -0,0 +1,278 @@
+package mediaserver
+
+import (
+	"aura/artworkhistory"
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/imagesource"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Maximum number of differing perceptual hash bits for two images to still count as the same image.
+// Re-encoding and resizing by the media server stays well below this, a different poster does not.
+const driftMaxHashDistance = 10
+
+// DriftedImage is an applied image that no longer matches what the media server shows
+type DriftedImage struct {
+	TMDB_ID      string `json:"tmdb_id"`
+	LibraryTitle string `json:"library_title"`
+	Edition      string `json:"edition,omitempty"`
+	Title        string `json:"title"`
+	Year         int    `json:"year"`
+	RatingKey    string `json:"rating_key"`
+	Slot         string `json:"slot"`
+	ImageType    string `json:"image_type"`
+	SetID        string `json:"set_id,omitempty"`
+	Reapplied    bool   `json:"reapplied"`
+	Error        string `json:"error,omitempty"`
+}
+
+// DriftReport is the result of the last drift check
+type DriftReport struct {
+	CheckedAt time.Time      `json:"checked_at"`
+	Checked   int            `json:"checked"`
+	Drifted   int            `json:"drifted"`
+	Reapplied int            `json:"reapplied"`
+	Errors    int            `json:"errors"`
+	Images    []DriftedImage `json:"images"`
+}
+
+var (
+	latestDriftReport   DriftReport
+	latestDriftReportMu sync.RWMutex
+)
+
+// GetLatestDriftReport returns the report of the last drift check
+func GetLatestDriftReport() DriftReport {
+	latestDriftReportMu.RLock()
+	defer latestDriftReportMu.RUnlock()
+	report := latestDriftReport
+	report.Images = append([]DriftedImage{}, latestDriftReport.Images...)
+	return report
+}
+
+// recordAppliedFingerprint saves a fingerprint of the image that was applied.
+// The media server may re-encode the image, so drift checks compare the perceptual hash.
+// appliedImage is used when the image was already read from its image source to verify it.
+func recordAppliedFingerprint(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile, appliedImage []byte) {
+	if !config.Current(ctx).Images.DriftDetection.Enabled {
+		return
+	}
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Drift Detection: Recording fingerprint of %s for %s", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	imageData := appliedImage
+	if len(imageData) == 0 {
+		var Err logging.LogErrorInfo
+		imageData, _, Err = imagesource.GetImage(ctx, imageFile)
+		if Err.Message != "" || len(imageData) == 0 {
+			logAction.AppendWarning("message", "Failed to get the applied image from the image source, no fingerprint was recorded")
+			logAction.AppendWarning("error", Err)
+			return
+		}
+	}
+
+	hash, perceptualHash := utils.ImageFingerprint(imageData)
+	Err := database.UpsertImageFingerprint(ctx, models.DBImageFingerprint{
+		TMDB_ID:        item.TMDB_ID,
+		LibraryTitle:   item.LibraryTitle,
+		Edition:        item.Edition,
+		Slot:           artworkhistory.SlotKey(imageFile.Type, imageFile.SeasonNumber, imageFile.EpisodeNumber),
+		ImageFile:      imageFile,
+		SetID:          artworkhistory.NewReplacedBy(ctx, imageFile, imagesource.GetImageSource(imageFile)).SetID,
+		Hash:           hash,
+		PerceptualHash: perceptualHash,
+		AppliedAt:      time.Now(),
+	})
+	if Err.Message != "" {
+		logAction.AppendWarning("message", "Failed to save the fingerprint of the applied image")
+		logAction.AppendWarning("error", Err)
+	}
+}
+
+// CheckForArtworkDrift compares every recorded fingerprint with the image the media server currently shows.
+// Images that were changed outside of AURA are reported, and re-applied when Images.DriftDetection.Reapply is enabled.
+// Fingerprints of items or image types that are no longer saved are removed.
+func CheckForArtworkDrift(ctx context.Context) (report DriftReport, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Checking for Artwork Drift", logging.LevelInfo)
+	defer logAction.Complete()
+
+	report = DriftReport{CheckedAt: time.Now(), Images: []DriftedImage{}}
+
+	fingerprints, Err := database.GetAllImageFingerprints(ctx)
+	if Err.Message != "" {
+		return report, Err
+	}
+
+	savedItems, Err := database.GetAllSavedSets(ctx, models.DBFilter{ItemsPerPage: -1})
+	if Err.Message != "" {
+		return report, Err
+	}
+	selectedTypes := map[string]models.SelectedTypes{}
+	for _, savedItem := range savedItems.Items {
+		key := driftItemKey(savedItem.MediaItem.TMDB_ID, savedItem.MediaItem.LibraryTitle, savedItem.MediaItem.Edition)
+		types := selectedTypes[key]
+		for _, posterSet := range savedItem.PosterSets {
+			types.Poster = types.Poster || posterSet.SelectedTypes.Poster
+			types.Backdrop = types.Backdrop || posterSet.SelectedTypes.Backdrop
+			types.SeasonPoster = types.SeasonPoster || posterSet.SelectedTypes.SeasonPoster
+			types.SpecialSeasonPoster = types.SpecialSeasonPoster || posterSet.SelectedTypes.SpecialSeasonPoster
+			types.Titlecard = types.Titlecard || posterSet.SelectedTypes.Titlecard
+		}
+		selectedTypes[key] = types
+	}
+
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	if Err.Message != "" {
+		return report, Err
+	}
+
+	// Items are copied once, so show details are only fetched once per show
+	items := map[string]*models.MediaItem{}
+	for _, fingerprint := range fingerprints {
+		key := driftItemKey(fingerprint.TMDB_ID, fingerprint.LibraryTitle, fingerprint.Edition)
+		types, saved := selectedTypes[key]
+		if !saved || !isTypeSelected(types, fingerprint.ImageFile) {
+			Err := database.DeleteImageFingerprints(ctx, fingerprint.TMDB_ID, fingerprint.LibraryTitle, fingerprint.Edition, fingerprint.Slot)
+			if Err.Message != "" {
+				logAction.AppendWarning(fmt.Sprintf("delete_%s_%s", driftItemKey(fingerprint.TMDB_ID, fingerprint.LibraryTitle, fingerprint.Edition), fingerprint.Slot), Err.Message)
+			}
+			continue
+		}
+
+		item, ok := items[key]
+		if !ok {
+			cachedItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBIDAndEdition(fingerprint.LibraryTitle, fingerprint.TMDB_ID, fingerprint.Edition)
+			if !found {
+				continue
+			}
+			itemCopy := *cachedItem
+			item = &itemCopy
+			items[key] = item
+		}
+
+		drifted, checkErr := checkFingerprint(ctx, msClient, item, fingerprint)
+		report.Checked++
+		if checkErr.Message != "" {
+			report.Errors++
+			report.Images = append(report.Images, newDriftedImage(*item, fingerprint, checkErr.Message))
+			continue
+		}
+		if !drifted {
+			continue
+		}
+
+		report.Drifted++
+		driftedImage := newDriftedImage(*item, fingerprint, "")
+		if config.Current(ctx).Images.DriftDetection.Reapply {
+			reapplyCtx := WithArtworkChanged(artworkhistory.WithReplacingSet(ctx, models.BaseSetInfo{ID: fingerprint.SetID}))
+			Err := DownloadApplyImageToMediaItem(reapplyCtx, item, fingerprint.ImageFile)
+			if Err.Message != "" {
+				driftedImage.Error = Err.Message
+				report.Errors++
+			} else {
+				driftedImage.Reapplied = true
+				report.Reapplied++
+			}
+		}
+		report.Images = append(report.Images, driftedImage)
+	}
+
+	latestDriftReportMu.Lock()
+	latestDriftReport = report
+	latestDriftReportMu.Unlock()
+
+	logAction.AppendResult("checked", report.Checked)
+	logAction.AppendResult("drifted", report.Drifted)
+	logAction.AppendResult("reapplied", report.Reapplied)
+	return report, logging.LogErrorInfo{}
+}
+
+// checkFingerprint fetches the current image from the media server and records whether it still matches the fingerprint
+func checkFingerprint(ctx context.Context, msClient MediaServerInterface, item *models.MediaItem, fingerprint models.DBImageFingerprint) (drifted bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Drift Detection: Checking %s for %s", fingerprint.Slot, utils.MediaItemInfo(*item)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	Err = ensureShowDetails(ctx, item, fingerprint.ImageFile.Type)
+	if Err.Message != "" {
+		return false, Err
+	}
+
+	imageRatingKey, imageType := getCurrentArtworkRequest(*item, fingerprint.ImageFile)
+	if imageRatingKey == "" {
+		logAction.SetError("Could not determine the rating key of the image", "The season or episode may no longer exist on the media server", map[string]any{
+			"slot": fingerprint.Slot,
+		})
+		return false, *logAction.Error
+	}
+
+	imageData, Err := msClient.GetMediaItemImage(ctx, item, imageRatingKey, imageType)
+	if Err.Message != "" {
+		return false, Err
+	}
+
+	hash, perceptualHash := utils.ImageFingerprint(imageData)
+	match, comparable := utils.ImagesMatch(fingerprint.Hash, fingerprint.PerceptualHash, hash, perceptualHash, driftMaxHashDistance)
+	if !comparable {
+		// Formats that can't be decoded (e.g. WebP) have no perceptual hash, so a re-encoded image can't be told apart from a different one
+		logAction.AppendResult("skipped", "the image format can't be compared")
+		return false, logging.LogErrorInfo{}
+	}
+	drifted = !match
+
+	fingerprint.LastCheckedAt = time.Now()
+	fingerprint.Drifted = drifted
+	Err = database.UpsertImageFingerprint(ctx, fingerprint)
+	if Err.Message != "" {
+		logAction.AppendWarning("message", "Failed to save the result of the drift check")
+		logAction.AppendWarning("error", Err)
+	}
+
+	logAction.AppendResult("drifted", drifted)
+	return drifted, logging.LogErrorInfo{}
+}
+
+func newDriftedImage(item models.MediaItem, fingerprint models.DBImageFingerprint, errMessage string) DriftedImage {
+	return DriftedImage{
+		TMDB_ID:      item.TMDB_ID,
+		LibraryTitle: item.LibraryTitle,
+		Edition:      item.Edition,
+		Title:        item.Title,
+		Year:         item.Year,
+		RatingKey:    item.RatingKey,
+		Slot:         fingerprint.Slot,
+		ImageType:    fingerprint.ImageFile.Type,
+		SetID:        fingerprint.SetID,
+		Error:        errMessage,
+	}
+}
+
+func driftItemKey(tmdbID, libraryTitle, edition string) string {
+	return libraryTitle + "|" + tmdbID + "|" + edition
+}
+
+func isTypeSelected(types models.SelectedTypes, imageFile models.ImageFile) bool {
+	switch imageFile.Type {
+	case "poster":
+		return types.Poster
+	case "backdrop":
+		return types.Backdrop
+	case "season_poster", "special_season_poster":
+		if imageFile.SeasonNumber != nil && *imageFile.SeasonNumber == 0 {
+			return types.SpecialSeasonPoster
+		}
+		return types.SeasonPoster
+	case "titlecard":
+		return types.Titlecard
+	}
+	return false
+}
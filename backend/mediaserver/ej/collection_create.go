@@ -0,0 +1,173 @@
+package ej
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+type embyJellyCreateCollectionResponse struct {
+	ID string `json:"Id"`
+}
+
+func (e *EJ) CreateCollection(ctx context.Context, libraryTitle string, title string, tmdbID string, items []models.MediaItem) (collection models.CollectionItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("%s: Creating BoxSet '%s' for Library '%s'", config.Current(ctx).MediaServer.Type, title, libraryTitle), logging.LevelInfo)
+	defer logAction.Complete()
+
+	collection = models.CollectionItem{}
+	Err = logging.LogErrorInfo{}
+
+	// Construct the URL for the Emby/Jellyfin API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return collection, *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Collections")
+	query := u.Query()
+	query.Set("Name", title)
+	if ids := joinItemIDs(items); ids != "" {
+		query.Set("Ids", ids)
+	}
+	u.RawQuery = query.Encode()
+	URL := u.String()
+
+	// Make the HTTP Request to Emby/Jellyfin
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "POST", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return collection, *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	// Decode the Response
+	var ejResp embyJellyCreateCollectionResponse
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Create Collection Response", config.Current(ctx).MediaServer.Type))
+	if Err.Message != "" {
+		return collection, Err
+	}
+	if ejResp.ID == "" {
+		logAction.SetError(fmt.Sprintf("%s did not return the new BoxSet", config.Current(ctx).MediaServer.Type), "Check the media server logs for more details", nil)
+		return collection, *logAction.Error
+	}
+
+	collection.RatingKey = ejResp.ID
+	collection.Index = ejResp.ID // Emby/Jellyfin does not have an index, so we use the RatingKey
+	collection.TMDB_ID = tmdbID
+	collection.Title = title
+	collection.ChildCount = len(items)
+	collection.LibraryTitle = libraryTitle
+	logAction.AppendResult("rating_key", collection.RatingKey)
+
+	// BoxSets without a TMDB ID are not listed as collections, so set it on the new BoxSet
+	if tmdbID != "" {
+		setErr := setBoxSetTMDBID(ctx, ejResp.ID, tmdbID)
+		if setErr.Message != "" {
+			logAction.AppendWarning("tmdb_id", fmt.Sprintf("Failed to set the TMDB ID on the new BoxSet: %s", setErr.Message))
+		}
+	}
+
+	return collection, logging.LogErrorInfo{}
+}
+
+func (e *EJ) AddItemsToCollection(ctx context.Context, collection *models.CollectionItem, items []models.MediaItem) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("%s: Adding %d Items to BoxSet '%s' [%s]", config.Current(ctx).MediaServer.Type, len(items), collection.Title, collection.RatingKey), logging.LevelInfo)
+	defer logAction.Complete()
+
+	ids := joinItemIDs(items)
+	if ids == "" {
+		return logging.LogErrorInfo{}
+	}
+
+	// Construct the URL for the Emby/Jellyfin API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Collections", collection.RatingKey, "Items")
+	query := u.Query()
+	query.Set("Ids", ids)
+	u.RawQuery = query.Encode()
+	URL := u.String()
+
+	// Make the HTTP Request to Emby/Jellyfin
+	resp, _, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "POST", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
+
+// setBoxSetTMDBID sets the TMDB provider ID on a BoxSet.
+// The item update endpoint expects the full item, so the current item is fetched and sent back with the ID added.
+func setBoxSetTMDBID(ctx context.Context, boxSetID string, tmdbID string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("%s: Setting TMDB ID '%s' on BoxSet [%s]", config.Current(ctx).MediaServer.Type, tmdbID, boxSetID), logging.LevelDebug)
+	defer logAction.Complete()
+
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items", boxSetID)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, u.String(), "GET", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	var item map[string]any
+	if err := json.Unmarshal(respBody, &item); err != nil {
+		logAction.SetError("Failed to decode BoxSet details", err.Error(), nil)
+		return *logAction.Error
+	}
+	providerIDs, _ := item["ProviderIds"].(map[string]any)
+	if providerIDs == nil {
+		providerIDs = map[string]any{}
+	}
+	providerIDs["Tmdb"] = tmdbID
+	item["ProviderIds"] = providerIDs
+
+	body, err := json.Marshal(item)
+	if err != nil {
+		logAction.SetError("Failed to encode BoxSet details", err.Error(), nil)
+		return *logAction.Error
+	}
+
+	u, err = url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Items", boxSetID)
+	updateResp, _, Err := makeRequest(ctx, config.Current(ctx).MediaServer, u.String(), "POST", body, "application/json")
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer updateResp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
+
+func joinItemIDs(items []models.MediaItem) string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.RatingKey != "" {
+			ids = append(ids, item.RatingKey)
+		}
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,179 @@
+package ej
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+)
+
+func (e *EJ) GetLibrarySectionItemsChangedSince(ctx context.Context, section models.LibrarySection, since int64) (items []models.MediaItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"%s: Fetching Changed Items for Library Section: %s", config.Current(ctx).MediaServer.Type, section.Title,
+	), logging.LevelInfo)
+	defer logAction.Complete()
+
+	// MinDateLastSaved covers both new items and items with updated metadata
+	query := url.Values{}
+	query.Add("MinDateLastSaved", time.Unix(since, 0).UTC().Format(time.RFC3339))
+	items, _, Err = fetchLibrarySectionItems(ctx, logAction, section, query)
+	if Err.Message != "" {
+		return items, Err
+	}
+
+	// A series is not saved again when an episode is added to it, so look for new episodes separately
+	if section.Type == "show" {
+		latestEpAdded, fetchErr := fetchLatestEpisodeAddedAtBySeries(ctx, section.ID, since)
+		if fetchErr.Message != "" {
+			logAction.AppendWarning("latest_episode_added_at", "Failed to fetch new episodes for shows")
+			latestEpAdded = map[string]int64{}
+		}
+
+		for i := range items {
+			items[i].LatestEpisodeAddedAt = max(items[i].LatestEpisodeAddedAt, latestEpAdded[items[i].RatingKey])
+			delete(latestEpAdded, items[i].RatingKey)
+		}
+
+		// Shows that only got new episodes
+		for seriesID, addedAt := range latestEpAdded {
+			cached, found := cache.LibraryStore.GetMediaItemByRatingKey(seriesID)
+			if !found || cached.LibraryTitle != section.Title {
+				continue
+			}
+			show := *cached
+			show.LatestEpisodeAddedAt = max(show.LatestEpisodeAddedAt, addedAt)
+			items = append(items, show)
+		}
+	}
+
+	logAction.AppendResult("changed_items", len(items))
+	return items, logging.LogErrorInfo{}
+}
+
+func (e *EJ) GetLibrarySectionRatingKeys(ctx context.Context, section models.LibrarySection) (ratingKeys map[string]bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"%s: Listing Rating Keys for Library Section: %s", config.Current(ctx).MediaServer.Type, section.Title,
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	ratingKeys = make(map[string]bool)
+
+	sectionItems, Err := listItemIDs(ctx, section.ID)
+	if Err.Message != "" {
+		return ratingKeys, Err
+	}
+	// The listing is recursive, so movies in BoxSets are listed as well
+	for _, item := range sectionItems {
+		ratingKeys[item.ID] = true
+	}
+
+	logAction.AppendResult("rating_keys", len(ratingKeys))
+	return ratingKeys, logging.LogErrorInfo{}
+}
+
+type ejItemID struct {
+	ID   string `json:"Id"`
+	Type string `json:"Type"`
+}
+
+// listItemIDs lists the ID and type of every movie and series under parentID, without any other fields
+func listItemIDs(ctx context.Context, parentID string) (items []ejItemID, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Listing Item IDs for Parent ID: %s", parentID), logging.LevelTrace)
+	defer logAction.Complete()
+
+	// Construct the URL for the EJ server API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return nil, *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items")
+	query := u.Query()
+	query.Add("Recursive", "true")
+	query.Add("IncludeItemTypes", "Movie,Series")
+	query.Add("ParentId", parentID)
+	query.Add("EnableImages", "false")
+	query.Add("EnableUserData", "false")
+	u.RawQuery = query.Encode()
+	URL := u.String()
+
+	// Make the HTTP Request to EJ
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return nil, *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	// Decode the Response
+	var ejResp struct {
+		Items []ejItemID `json:"Items"`
+	}
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Item IDs Response", config.Current(ctx).MediaServer.Type))
+	if Err.Message != "" {
+		return nil, Err
+	}
+
+	return ejResp.Items, logging.LogErrorInfo{}
+}
+
+// fetchLatestEpisodeAddedAtBySeries returns the newest DateCreated of the episodes saved since the given Unix time, by series ID
+func fetchLatestEpisodeAddedAtBySeries(ctx context.Context, sectionID string, since int64) (latest map[string]int64, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Fetching New Episodes for Library Section ID: %s", sectionID), logging.LevelDebug)
+	defer logAction.Complete()
+
+	// Construct the URL for the EJ server API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return nil, *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items")
+	query := u.Query()
+	query.Add("Recursive", "true")
+	query.Add("IncludeItemTypes", "Episode")
+	query.Add("ParentId", sectionID)
+	query.Add("MinDateLastSaved", time.Unix(since, 0).UTC().Format(time.RFC3339))
+	query.Add("Fields", "DateCreated")
+	query.Add("EnableImages", "false")
+	query.Add("EnableUserData", "false")
+	u.RawQuery = query.Encode()
+	URL := u.String()
+
+	// Make the HTTP Request to EJ
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return nil, *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	// Decode the Response
+	var ejResp struct {
+		Items []struct {
+			SeriesID    string    `json:"SeriesId"`
+			DateCreated time.Time `json:"DateCreated"`
+		} `json:"Items"`
+	}
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s New Episodes Response", config.Current(ctx).MediaServer.Type))
+	if Err.Message != "" {
+		return nil, Err
+	}
+
+	latest = make(map[string]int64)
+	for _, episode := range ejResp.Items {
+		// Episodes saved again for a metadata refresh keep their DateCreated, so they don't count as new
+		if episode.SeriesID == "" || episode.DateCreated.Unix() < since {
+			continue
+		}
+		latest[episode.SeriesID] = max(latest[episode.SeriesID], episode.DateCreated.Unix())
+	}
+	logAction.AppendResult("series_with_new_episodes", len(latest))
+	return latest, logging.LogErrorInfo{}
+}
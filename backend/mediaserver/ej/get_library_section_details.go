@@ -13,7 +13,7 @@ import (
 )
 
 func (e *EJ) GetLibrarySectionDetails(ctx context.Context, library *models.LibrarySection) (found bool, Err logging.LogErrorInfo) {
-	serverType := config.Current.MediaServer.Type
+	serverType := config.Current(ctx).MediaServer.Type
 
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Fetching Details for Library Section: %s from %s Media Server", library.Title, serverType), logging.LevelDebug)
 	defer logAction.Complete()
@@ -22,16 +22,16 @@ func (e *EJ) GetLibrarySectionDetails(ctx context.Context, library *models.Libra
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return found, *logAction.Error
 	}
-	u.Path = path.Join(u.Path, "Users", config.Current.MediaServer.UserID, "Items")
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items")
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return found, *logAction.Error
@@ -72,7 +72,7 @@ func (e *EJ) GetLibrarySectionDetails(ctx context.Context, library *models.Libra
 	}
 
 	// Get the path for the library section
-	u, err = url.Parse(config.Current.MediaServer.URL)
+	u, err = url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL for section path", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return found, *logAction.Error
@@ -81,7 +81,7 @@ func (e *EJ) GetLibrarySectionDetails(ctx context.Context, library *models.Libra
 	URL = u.String()
 
 	// Make the HTTP Request to EJ for section path
-	resp, respBody, Err = makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err = makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return found, *logAction.Error
@@ -109,12 +109,14 @@ func (e *EJ) GetLibrarySectionDetails(ctx context.Context, library *models.Libra
 	}
 	if len(library.Paths) > 0 {
 		// Update the library section in the config with the path info
-		for i, lib := range config.Current.MediaServer.Libraries {
-			if lib.Title == library.Title {
-				config.Current.MediaServer.Libraries[i].Paths = library.Paths
-				break
+		config.Update(ctx, func(cfg *config.Config) {
+			for i, lib := range cfg.MediaServer.Libraries {
+				if lib.Title == library.Title {
+					cfg.MediaServer.Libraries[i].Paths = library.Paths
+					break
+				}
 			}
-		}
+		})
 	}
 
 	return found, logging.LogErrorInfo{}
@@ -18,26 +18,34 @@ import (
 
 func (e *EJ) GetLibrarySectionItems(ctx context.Context, section models.LibrarySection, sectionStartIndex string, limit string) (items []models.MediaItem, totalSize int, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"%s: Fetching Items for Library Section: %s", config.Current.MediaServer.Type, section.Title,
+		"%s: Fetching Items for Library Section: %s", config.Current(ctx).MediaServer.Type, section.Title,
 	), logging.LevelInfo)
 	defer logAction.Complete()
 
-	items = []models.MediaItem{}
-	totalSize = 0
-	Err = logging.LogErrorInfo{}
-
 	// If limit is empty, set a default limit
 	if limit == "" {
 		limit = "500"
 	}
 
+	query := url.Values{}
+	query.Add("StartIndex", sectionStartIndex)
+	query.Add("Limit", limit)
+	return fetchLibrarySectionItems(ctx, logAction, section, query)
+}
+
+// fetchLibrarySectionItems gets the items of a library section matching filters (paging or Emby/Jellyfin filters)
+func fetchLibrarySectionItems(ctx context.Context, logAction *logging.LogAction, section models.LibrarySection, filters url.Values) (items []models.MediaItem, totalSize int, Err logging.LogErrorInfo) {
+	items = []models.MediaItem{}
+	totalSize = 0
+	Err = logging.LogErrorInfo{}
+
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return items, totalSize, *logAction.Error
 	}
-	u.Path = path.Join(u.Path, "Users", config.Current.MediaServer.UserID, "Items")
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items")
 	query := u.Query()
 	query.Add("Recursive", "true")
 	query.Add("SortBy", "Name")
@@ -45,13 +53,14 @@ func (e *EJ) GetLibrarySectionItems(ctx context.Context, section models.LibraryS
 	query.Add("IncludeItemTypes", "Movie,Series")
 	query.Add("Fields", "DateLastContentAdded,PremiereDate,DateCreated,ProviderIds,BasicSyncInfo,CanDelete,CanDownload,PrimaryImageAspectRatio,ProductionYear,Status,EndDate")
 	query.Add("ParentId", section.ID)
-	query.Add("StartIndex", sectionStartIndex)
-	query.Add("Limit", limit)
+	for key, values := range filters {
+		query[key] = values
+	}
 	u.RawQuery = query.Encode()
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return items, totalSize, *logAction.Error
@@ -60,7 +69,7 @@ func (e *EJ) GetLibrarySectionItems(ctx context.Context, section models.LibraryS
 
 	// Decode the Response
 	var ejResp EmbyJellyLibraryItemsResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Library Section Items Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Library Section Items Response", config.Current(ctx).MediaServer.Type))
 	if Err.Message != "" {
 		return items, totalSize, *logAction.Error
 	}
@@ -179,12 +188,12 @@ func splitCollectionIntoIndividualItems(ctx context.Context, collectionName, par
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return items, *logAction.Error
 	}
-	u.Path = path.Join(u.Path, "Users", config.Current.MediaServer.UserID, "Items")
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items")
 	query := u.Query()
 	query.Add("Recursive", "true")
 	query.Add("SortBy", "Name")
@@ -196,7 +205,7 @@ func splitCollectionIntoIndividualItems(ctx context.Context, collectionName, par
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return items, *logAction.Error
@@ -205,7 +214,7 @@ func splitCollectionIntoIndividualItems(ctx context.Context, collectionName, par
 
 	// Decode the Response
 	var ejResp EmbyJellyLibraryItemsResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s BoxSet Individual Items Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s BoxSet Individual Items Response", config.Current(ctx).MediaServer.Type))
 	if Err.Message != "" {
 		return items, *logAction.Error
 	}
@@ -217,7 +226,7 @@ func splitCollectionIntoIndividualItems(ctx context.Context, collectionName, par
 	}
 
 	validLibraryPaths := []string{}
-	for _, lib := range config.Current.MediaServer.Libraries {
+	for _, lib := range config.Current(ctx).MediaServer.Libraries {
 		if len(lib.Paths) > 0 {
 			validLibraryPaths = append(validLibraryPaths, lib.Paths...)
 		}
@@ -242,7 +251,7 @@ func splitCollectionIntoIndividualItems(ctx context.Context, collectionName, par
 		}
 		// Check to see if the path starts with one of the known library paths, if not, skip the item
 		validPath := false
-		for _, lib := range config.Current.MediaServer.Libraries {
+		for _, lib := range config.Current(ctx).MediaServer.Libraries {
 			for _, libPath := range lib.Paths {
 				if libPath != "" && strings.HasPrefix(itemPath, libPath) {
 					validPath = true
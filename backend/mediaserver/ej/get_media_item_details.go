@@ -17,7 +17,7 @@ import (
 
 func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (found bool, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"%s: Fetching Full Info for %s", config.Current.MediaServer.Type,
+		"%s: Fetching Full Info for %s", config.Current(ctx).MediaServer.Type,
 		utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -26,12 +26,12 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return found, *logAction.Error
 	}
-	u.Path = path.Join(u.Path, "Users", config.Current.MediaServer.UserID, "Items", item.RatingKey)
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items", item.RatingKey)
 	query := u.Query()
 	query.Set("fields", "ShareLevel")
 	query.Set("ExcludeFields", "VideoChapters,VideoMediaSources,MediaStreams")
@@ -40,7 +40,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return found, *logAction.Error
@@ -49,7 +49,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 
 	// Decode the Response
 	var ejResp EmbyJellyItemContentResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Media Item Details Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Media Item Details Response", config.Current(ctx).MediaServer.Type))
 	if Err.Message != "" {
 		return found, *logAction.Error
 	}
@@ -103,7 +103,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 		}
 	}
 	if item.TMDB_ID == "" {
-		logAction.SetError("No TMDB ID found for the media item", fmt.Sprintf("Ensure the media item has a valid TMDB GUID in %s", config.Current.MediaServer.Type),
+		logAction.SetError("No TMDB ID found for the media item", fmt.Sprintf("Ensure the media item has a valid TMDB GUID in %s", config.Current(ctx).MediaServer.Type),
 			map[string]any{
 				"rating_key":     item.RatingKey,
 				"library_title":  item.LibraryTitle,
@@ -174,7 +174,7 @@ func (e *EJ) GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (f
 
 func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"%s: Fetching Seasons for Show %s", config.Current.MediaServer.Type,
+		"%s: Fetching Seasons for Show %s", config.Current(ctx).MediaServer.Type,
 		utils.MediaItemInfo(*itemInfo),
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -182,7 +182,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
@@ -194,7 +194,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return Err
@@ -203,7 +203,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 
 	// Decode the Response
 	var ejResp EmbyJellyItemContentChildResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Show Seasons Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Show Seasons Response", config.Current(ctx).MediaServer.Type))
 	if Err.Message != "" {
 		return Err
 	}
@@ -240,7 +240,7 @@ func fetchSeasonsForShow(ctx context.Context, itemInfo *models.MediaItem) (Err l
 
 func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season models.MediaItemSeason) (models.MediaItemSeason, logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"%s: Fetching Episodes for Season %d of Show RatingKey %s", config.Current.MediaServer.Type,
+		"%s: Fetching Episodes for Season %d of Show RatingKey %s", config.Current(ctx).MediaServer.Type,
 		season.SeasonNumber, showRatingKey,
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -248,7 +248,7 @@ func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season mo
 	Err := logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return season, *logAction.Error
@@ -261,7 +261,7 @@ func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season mo
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return season, *logAction.Error
@@ -270,7 +270,7 @@ func fetchEpisodesForSeason(ctx context.Context, showRatingKey string, season mo
 
 	// Decode the Response
 	var ejResp EmbyJellyItemContentChildResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Season Episodes Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Season Episodes Response", config.Current(ctx).MediaServer.Type))
 	if Err.Message != "" {
 		return season, *logAction.Error
 	}
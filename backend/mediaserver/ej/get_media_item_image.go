@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strconv"
+	"time"
 )
 
 func (e *EJ) GetMediaItemImage(ctx context.Context, item *models.MediaItem, imageRatingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Fetching Image (%s) for %s",
-		config.Current.MediaServer.Type,
+		config.Current(ctx).MediaServer.Type,
 		imageType,
 		utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
@@ -35,7 +37,7 @@ func (e *EJ) GetMediaItemImage(ctx context.Context, item *models.MediaItem, imag
 func (e *EJ) GetCollectionItemImage(ctx context.Context, collection *models.CollectionItem, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Fetching Image (%s) for Collection '%s' [%s]",
-		config.Current.MediaServer.Type,
+		config.Current(ctx).MediaServer.Type,
 		imageType, collection.Title, collection.RatingKey,
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -67,7 +69,7 @@ func GetImageFromEJ(ctx context.Context, ratingKey string, imageType string) (im
 	}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		return imageData, logging.LogErrorInfo{
 			Message: "Failed to parse base URL",
@@ -76,10 +78,14 @@ func GetImageFromEJ(ctx context.Context, ratingKey string, imageType string) (im
 		}
 	}
 	u.Path = path.Join(u.Path, "Items", ratingKey, "Images", imageType)
+	// A unique query makes caches between AURA and the server (e.g. a reverse proxy) return the current image
+	query := u.Query()
+	query.Set("cb", strconv.FormatInt(time.Now().UnixNano(), 10))
+	u.RawQuery = query.Encode()
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		return imageData, Err
 	}
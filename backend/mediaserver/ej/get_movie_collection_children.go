@@ -15,7 +15,7 @@ import (
 func (e *EJ) GetMovieCollectionChildrenItems(ctx context.Context, collection *models.CollectionItem) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Fetching Collection Children for '%s' | %s [%s | %s]",
-		config.Current.MediaServer.Type,
+		config.Current(ctx).MediaServer.Type,
 		collection.Title, collection.LibraryTitle, collection.TMDB_ID, collection.RatingKey,
 	), logging.LevelDebug)
 	defer logAction.Complete()
@@ -23,12 +23,12 @@ func (e *EJ) GetMovieCollectionChildrenItems(ctx context.Context, collection *mo
 	Err = logging.LogErrorInfo{}
 
 	// Construct the URL for the EJ server API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
 	}
-	u.Path = path.Join(u.Path, "Users", config.Current.MediaServer.UserID, "Items")
+	u.Path = path.Join(u.Path, "Users", config.Current(ctx).MediaServer.UserID, "Items")
 	query := u.Query()
 	query.Set("ParentId", collection.RatingKey)
 	query.Set("IncludeItemTypes", "Movie")
@@ -37,7 +37,7 @@ func (e *EJ) GetMovieCollectionChildrenItems(ctx context.Context, collection *mo
 	URL := u.String()
 
 	// Make the HTTP Request to EJ
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -46,7 +46,7 @@ func (e *EJ) GetMovieCollectionChildrenItems(ctx context.Context, collection *mo
 
 	// Decode the Response
 	var ejResp EmbyJellyLibraryItemsResponse
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Collection Children Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &ejResp, fmt.Sprintf("%s Collection Children Response", config.Current(ctx).MediaServer.Type))
 	if Err.Message != "" {
 		return *logAction.Error
 	}
@@ -16,7 +16,7 @@ import (
 func (ej *EJ) ApplyCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Applying %s Image to %s",
-		config.Current.MediaServer.Type, cases.Title(language.English).String(imageFile.Type), utils.CollectionItemInfo(*collectionItem),
+		config.Current(ctx).MediaServer.Type, cases.Title(language.English).String(imageFile.Type), utils.CollectionItemInfo(*collectionItem),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
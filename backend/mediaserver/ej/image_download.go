@@ -2,8 +2,8 @@ package ej
 
 import (
 	"aura/config"
+	"aura/imagesource"
 	"aura/logging"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils"
 	"context"
@@ -13,14 +13,13 @@ import (
 func (e *EJ) DownloadApplyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"%s: Downloading and Applying %s Image for %s",
-		config.Current.MediaServer.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
+		config.Current(ctx).MediaServer.Type, utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item),
 	), logging.LevelDebug)
 	defer logAction.Complete()
 
-	// Get the Image from MediUX
+	// Get the Image from the image source it came from
 	// mediux.GetImage will handle checking the temp folder and caching based on config
-	formatDate := imageFile.Modified.Format("20060102150405")
-	imageData, imageType, Err := mediux.GetImage(ctx, imageFile.ID, formatDate, mediux.ImageQualityOriginal)
+	imageData, imageType, Err := imagesource.GetImage(ctx, imageFile)
 	if Err.Message != "" {
 		return Err
 	}
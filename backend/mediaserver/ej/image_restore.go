@@ -0,0 +1,49 @@
+package ej
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+)
+
+// RestoreOriginalImage refreshes the images of an item with image replacement, so the metadata providers' artwork is downloaded again.
+// Emby/Jellyfin do not keep the replaced artwork, so every image of the rating key is refreshed, not only the given type.
+func (e *EJ) RestoreOriginalImage(ctx context.Context, item *models.MediaItem, imageRatingKey string, imageType string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"%s: Restoring Original '%s' Image for %s",
+		config.Current(ctx).MediaServer.Type, imageType, utils.MediaItemInfo(*item),
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	// Construct the URL for the Emby/Jellyfin API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "Items", imageRatingKey, "Refresh")
+	query := u.Query()
+	query.Add("Recursive", "false")
+	query.Add("ImageRefreshMode", "FullRefresh")
+	query.Add("MetadataRefreshMode", "Default")
+	query.Add("ReplaceAllImages", "true")
+	query.Add("RegenerateTrickplay", "false")
+	query.Add("ReplaceAllMetadata", "false")
+	u.RawQuery = query.Encode()
+	URL := u.String()
+
+	// Make the HTTP Request to Emby/Jellyfin
+	resp, _, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "POST", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
@@ -10,19 +10,17 @@ import (
 	"strings"
 )
 
-// contentType is optional and only relevant for image upload requests (POST to
-// .../Primary or .../Backdrop). When omitted, it defaults to "image/jpeg" for
-// those requests to preserve prior behavior.
+// contentType is optional. It is mostly relevant for image upload requests (POST to
+// .../Primary or .../Backdrop), where it defaults to "image/jpeg" when omitted to
+// preserve prior behavior. Requests with a JSON body pass "application/json".
 func makeRequest(ctx context.Context, msConfig config.Config_MediaServer, url string, method string, body []byte, contentType ...string) (resp *http.Response, respBody []byte, Err logging.LogErrorInfo) {
 	// Make the HTTP Headers for this request
 	headers := make(map[string]string)
 
-	if (strings.HasSuffix(url, "Primary") || strings.HasSuffix(url, "Backdrop")) && method == "POST" {
-		if len(contentType) > 0 && contentType[0] != "" {
-			headers["Content-Type"] = contentType[0]
-		} else {
-			headers["Content-Type"] = "image/jpeg"
-		}
+	if len(contentType) > 0 && contentType[0] != "" {
+		headers["Content-Type"] = contentType[0]
+	} else if (strings.HasSuffix(url, "Primary") || strings.HasSuffix(url, "Backdrop")) && method == "POST" {
+		headers["Content-Type"] = "image/jpeg"
 	}
 	headers = AddEJAuthHeaders(msConfig, headers)
 
@@ -4,7 +4,9 @@ import (
 	"aura/cache"
 	"aura/config"
 	"aura/logging"
+	"aura/models"
 	"context"
+	"slices"
 	"sort"
 	"strconv"
 	"sync"
@@ -41,7 +43,8 @@ func getAllLibrarySectionsAndItemsImpl(ctx context.Context) (success bool) {
 
 	success = true
 
-	configuredSections := config.Current.MediaServer.Libraries
+	// Copy the libraries before sorting, the config snapshot is shared
+	configuredSections := slices.Clone(config.Current(ctx).MediaServer.Libraries)
 
 	// Sort sections by Title to ensure consistent order
 	sort.SliceStable(configuredSections, func(i, j int) bool {
@@ -51,24 +54,24 @@ func getAllLibrarySectionsAndItemsImpl(ctx context.Context) (success bool) {
 	logAction.AppendResult("num_sections", len(configuredSections))
 
 	ejRanCollections := false
+	configuredTitles := make([]string, 0, len(configuredSections))
 
 	for _, section := range configuredSections {
+		configuredTitles = append(configuredTitles, section.Title)
+
 		found, Err := GetLibrarySectionDetails(ctx, &section)
 		if Err.Message != "" || !found {
 			continue
 		}
 
-		// Update the collections cache for this section
-		if (section.Type == "movie" || section.Type == "mixed") && !ejRanCollections {
-			GetMovieCollections(ctx, section)
-			if config.Current.MediaServer.Type == "Emby" || config.Current.MediaServer.Type == "Jellyfin" {
-				ejRanCollections = true
-			}
-		}
+		// Update the collections cache for this section. The items of the collections are filled in again below.
+		cache.CollectionsStore.ClearCollectionItems(section.Title)
+		refreshSectionCollections(ctx, section, &ejRanCollections)
 
 		pageSize := 1000
 		start := 0
 		expectedTotal := 0
+		seenItemKeys := make(map[string]bool)
 
 		for {
 
@@ -91,6 +94,10 @@ func getAllLibrarySectionsAndItemsImpl(ctx context.Context) (success bool) {
 				break
 			}
 
+			for i := range items {
+				seenItemKeys[cache.MediaItemCacheKey(&items[i])] = true
+			}
+
 			sectionForCache := section
 			sectionForCache.TotalSize = expectedTotal
 			sectionForCache.MediaItems = items
@@ -106,8 +113,41 @@ func getAllLibrarySectionsAndItemsImpl(ctx context.Context) (success bool) {
 
 		}
 
+		// Every page was fetched, drop the items that are no longer on the media server
+		if removed := cache.LibraryStore.RemoveItemsNotIn(section.Title, seenItemKeys); removed > 0 {
+			logAction.AppendResult("removed_items_"+section.Title, removed)
+		}
 	}
+
+	// Drop the libraries that are no longer configured
+	cache.LibraryStore.RemoveSectionsNotIn(configuredTitles)
+	cache.CollectionsStore.RemoveLibrariesNotIn(configuredTitles)
+
 	cache.LibraryStore.LastFullUpdate = time.Now().Unix()
+	cache.LibraryStore.LastSync = cache.LibraryStore.LastFullUpdate
 	cache.CollectionsStore.LastFullUpdate = time.Now().Unix()
+
+	// Snapshot the caches so the next start can serve them right away
+	cache.SaveLibrarySnapshot(ctx)
 	return true
 }
+
+// refreshSectionCollections updates the collections cache for a movie or mixed section.
+// Emby/Jellyfin collections are not tied to a library, ejRanCollections makes sure they are only fetched once per refresh.
+func refreshSectionCollections(ctx context.Context, section models.LibrarySection, ejRanCollections *bool) {
+	if (section.Type != "movie" && section.Type != "mixed") || *ejRanCollections {
+		return
+	}
+	collections, Err := GetMovieCollections(ctx, section)
+	if Err.Message == "" {
+		// Drop the collections that were deleted on the media server
+		ratingKeys := make(map[string]bool, len(collections))
+		for _, coll := range collections {
+			ratingKeys[coll.RatingKey] = true
+		}
+		cache.CollectionsStore.RemoveCollectionsNotIn(section.Title, ratingKeys)
+	}
+	if config.Current(ctx).MediaServer.Type == "Emby" || config.Current(ctx).MediaServer.Type == "Jellyfin" {
+		*ejRanCollections = true
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"aura/utils"
 	"context"
 	"fmt"
+	"time"
 )
 
 func HandleTempIgnoredItems(ctx context.Context) (Err logging.LogErrorInfo) {
@@ -18,6 +19,16 @@ func HandleTempIgnoredItems(ctx context.Context) (Err logging.LogErrorInfo) {
 
 	Err = logging.LogErrorInfo{}
 
+	// Items ignored until a date don't depend on the available sets or the library cache
+	expiredItems, dbErr := database.ExpireIgnoredItems(ctx, time.Now())
+	if dbErr.Message != "" {
+		logAction.AppendWarning("expire_error", dbErr.Message)
+	}
+	for _, mediaItem := range expiredItems {
+		logging.LOGGER.Info().Timestamp().Str("tmdb_id", mediaItem.TMDB_ID).Str("library_title", mediaItem.LibraryTitle).Time("ignore_until", *mediaItem.IgnoredUntil).Msgf("Stopped ignoring media item %s because the ignore expired", mediaItem.Title)
+		go sendIgnoreExpiredNotification(mediaItem)
+	}
+
 	// Get all temp ignored items from the database
 	tempIgnoredItems, dbErr := database.GetTempIgnoredItems(ctx)
 	if dbErr.Message != "" {
@@ -77,28 +88,28 @@ func HandleTempIgnoredItems(ctx context.Context) (Err logging.LogErrorInfo) {
 
 func sendNotification(mediaItem models.MediaItem, setCount int, mainImage models.ImageFile) {
 	// If notifications are disabled, skip
-	if !config.Current.Notifications.Enabled {
+	if !config.Latest().Notifications.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping app start notification")
 		return
 	}
 
 	// If notification providers are not configured, skip
-	if len(config.Current.Notifications.Providers) == 0 {
+	if len(config.Latest().Notifications.Providers) == 0 {
 		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping app start notification")
 		return
 	}
 
 	// If new sets available for ignored items notification is disabled, skip
-	if !config.Current.Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.Enabled {
+	if !config.Latest().Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("New sets available for ignored items notification is disabled, skipping notification")
 		return
 	}
 
 	vars := utils.TemplateVars_NewSetsAvailableForIgnoredItems(mediaItem, setCount)
-	title := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.Title, vars)
-	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.Message, vars)
+	title := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.Title, vars)
+	message := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.Message, vars)
 	imageURL := ""
-	if config.Current.Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.IncludeImage {
+	if config.Latest().Notifications.NotificationTemplate.NewSetsAvailableForIgnoredItems.IncludeImage {
 		mediuxInfo, Err := mediux.GetBaseItemInfoByTMDB_ID(mediaItem.TMDB_ID, mediaItem.Type)
 		if Err.Message != "" {
 			imageURL = fmt.Sprintf("%s/%s?v=%s&key=jpg",
@@ -132,7 +143,92 @@ func sendNotification(mediaItem models.MediaItem, setCount int, mainImage models
 	defer logAction.Complete()
 
 	// Send a notification to all configured providers
-	for _, provider := range config.Current.Notifications.Providers {
+	for _, provider := range config.Current(ctx).Notifications.Providers {
+		if provider.Enabled {
+			switch provider.Provider {
+			case "Discord":
+				notification.SendDiscordMessage(
+					ctx,
+					provider.Discord,
+					message,
+					imageURL,
+					title,
+				)
+			case "Pushover":
+				notification.SendPushoverMessage(
+					ctx,
+					provider.Pushover,
+					message,
+					imageURL,
+					title,
+				)
+			case "Gotify":
+				notification.SendGotifyMessage(
+					ctx,
+					provider.Gotify,
+					message,
+					imageURL,
+					title,
+				)
+			case "Webhook":
+				notification.SendWebhookMessage(
+					ctx,
+					provider.Webhook,
+					message,
+					imageURL,
+					title,
+				)
+			}
+		}
+	}
+}
+
+func sendIgnoreExpiredNotification(mediaItem models.MediaItem) {
+	// If notifications are disabled, skip
+	if !config.Latest().Notifications.Enabled {
+		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping ignore expired notification")
+		return
+	}
+
+	// If notification providers are not configured, skip
+	if len(config.Latest().Notifications.Providers) == 0 {
+		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping ignore expired notification")
+		return
+	}
+
+	// If ignored item expired notification is disabled, skip
+	if !config.Latest().Notifications.NotificationTemplate.IgnoredItemExpired.Enabled {
+		logging.LOGGER.Debug().Timestamp().Msg("Ignored item expired notification is disabled, skipping notification")
+		return
+	}
+
+	var ignoredUntil time.Time
+	if mediaItem.IgnoredUntil != nil {
+		ignoredUntil = *mediaItem.IgnoredUntil
+	}
+	vars := utils.TemplateVars_IgnoredItemExpired(mediaItem, ignoredUntil)
+	title := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.IgnoredItemExpired.Title, vars)
+	message := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.IgnoredItemExpired.Message, vars)
+	imageURL := ""
+	if config.Latest().Notifications.NotificationTemplate.IgnoredItemExpired.IncludeImage {
+		mediuxInfo, Err := mediux.GetBaseItemInfoByTMDB_ID(mediaItem.TMDB_ID, mediaItem.Type)
+		if Err.Message == "" {
+			if mediuxInfo.TMDB_PosterPath != "" {
+				imageURL = fmt.Sprintf("https://image.tmdb.org/t/p/original%s", mediuxInfo.TMDB_PosterPath)
+			} else if mediuxInfo.TMDB_BackdropPath != "" {
+				imageURL = fmt.Sprintf("https://image.tmdb.org/t/p/original%s", mediuxInfo.TMDB_BackdropPath)
+			}
+		}
+	}
+
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "Notification - Send Ignored Item Expired")
+	logAction := ld.AddAction("Sending Ignored Item Expired Notification", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+	defer ld.Log()
+	defer logAction.Complete()
+
+	// Send a notification to all configured providers
+	for _, provider := range config.Current(ctx).Notifications.Providers {
 		if provider.Enabled {
 			switch provider.Provider {
 			case "Discord":
@@ -28,6 +28,12 @@ type MediaServerInterface interface {
 	// Get items in a specific library section
 	GetLibrarySectionItems(ctx context.Context, section models.LibrarySection, sectionStartIndex string, limit string) ([]models.MediaItem, int, logging.LogErrorInfo)
 
+	// Get items in a specific library section that were added or updated since a time (Unix seconds)
+	GetLibrarySectionItemsChangedSince(ctx context.Context, section models.LibrarySection, since int64) (items []models.MediaItem, Err logging.LogErrorInfo)
+
+	// Get the Rating Key of every item in a specific library section, without the item details
+	GetLibrarySectionRatingKeys(ctx context.Context, section models.LibrarySection) (ratingKeys map[string]bool, Err logging.LogErrorInfo)
+
 	// Get Movie Collections for a specific library section
 	GetMovieCollections(ctx context.Context, section models.LibrarySection) (collections []models.CollectionItem, Err logging.LogErrorInfo)
 
@@ -44,6 +50,12 @@ type MediaServerInterface interface {
 	// Get a collection's children items
 	GetMovieCollectionChildrenItems(ctx context.Context, collection *models.CollectionItem) (Err logging.LogErrorInfo)
 
+	// Create a movie collection (Plex Collection or Emby/Jellyfin BoxSet) containing the given items
+	CreateCollection(ctx context.Context, libraryTitle string, title string, tmdbID string, items []models.MediaItem) (collection models.CollectionItem, Err logging.LogErrorInfo)
+
+	// Add items to an existing collection
+	AddItemsToCollection(ctx context.Context, collection *models.CollectionItem, items []models.MediaItem) (Err logging.LogErrorInfo)
+
 	///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 	// Get full details about a specific media item
@@ -65,11 +77,14 @@ type MediaServerInterface interface {
 
 	// Apply a collection image to a specific Collection Item
 	ApplyCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile) (Err logging.LogErrorInfo)
+
+	// Put back the artwork provided by the Media Server's metadata agents for a specific image
+	RestoreOriginalImage(ctx context.Context, item *models.MediaItem, imageRatingKey string, imageType string) (Err logging.LogErrorInfo)
 }
 
 func resolveMediaServerConfig(ms *config.Config_MediaServer) *config.Config_MediaServer {
 	if ms == nil {
-		return &config.Current.MediaServer
+		return &config.Latest().MediaServer
 	}
 	return ms
 }
@@ -116,7 +131,7 @@ func GetLibrarySections(ctx context.Context, mediaServerConfig *config.Config_Me
 }
 
 func GetLibrarySectionDetails(ctx context.Context, library *models.LibrarySection) (found bool, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return false, Err
 	}
@@ -124,15 +139,31 @@ func GetLibrarySectionDetails(ctx context.Context, library *models.LibrarySectio
 }
 
 func GetLibrarySectionItems(ctx context.Context, section models.LibrarySection, sectionStartIndex string, limit string) ([]models.MediaItem, int, logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return nil, 0, Err
 	}
 	return msClient.GetLibrarySectionItems(ctx, section, sectionStartIndex, limit)
 }
 
+func GetLibrarySectionItemsChangedSince(ctx context.Context, section models.LibrarySection, since int64) (items []models.MediaItem, Err logging.LogErrorInfo) {
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	if Err.Message != "" {
+		return nil, Err
+	}
+	return msClient.GetLibrarySectionItemsChangedSince(ctx, section, since)
+}
+
+func GetLibrarySectionRatingKeys(ctx context.Context, section models.LibrarySection) (ratingKeys map[string]bool, Err logging.LogErrorInfo) {
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	if Err.Message != "" {
+		return nil, Err
+	}
+	return msClient.GetLibrarySectionRatingKeys(ctx, section)
+}
+
 func GetMovieCollections(ctx context.Context, section models.LibrarySection) (collections []models.CollectionItem, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return nil, Err
 	}
@@ -142,7 +173,7 @@ func GetMovieCollections(ctx context.Context, section models.LibrarySection) (co
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func GetMediaItemImage(ctx context.Context, item *models.MediaItem, imageRatingKey string, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return nil, Err
 	}
@@ -150,7 +181,7 @@ func GetMediaItemImage(ctx context.Context, item *models.MediaItem, imageRatingK
 }
 
 func GetCollectionItemImage(ctx context.Context, item *models.CollectionItem, imageType string) (imageData []byte, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return nil, Err
 	}
@@ -160,17 +191,33 @@ func GetCollectionItemImage(ctx context.Context, item *models.CollectionItem, im
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func GetCollectionChildrenItems(ctx context.Context, collection *models.CollectionItem) (Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return Err
 	}
 	return msClient.GetMovieCollectionChildrenItems(ctx, collection)
 }
 
+func CreateCollection(ctx context.Context, libraryTitle string, title string, tmdbID string, items []models.MediaItem) (collection models.CollectionItem, Err logging.LogErrorInfo) {
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	if Err.Message != "" {
+		return collection, Err
+	}
+	return msClient.CreateCollection(ctx, libraryTitle, title, tmdbID, items)
+}
+
+func AddItemsToCollection(ctx context.Context, collection *models.CollectionItem, items []models.MediaItem) (Err logging.LogErrorInfo) {
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	if Err.Message != "" {
+		return Err
+	}
+	return msClient.AddItemsToCollection(ctx, collection, items)
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (found bool, Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return false, Err
 	}
@@ -178,7 +225,7 @@ func GetMediaItemDetails(ctx context.Context, item *models.MediaItem) (found boo
 }
 
 func RefreshMediaItemMetadata(ctx context.Context, item *models.MediaItem, refreshRatingKey string, updateImage bool) (Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return Err
 	}
@@ -186,12 +233,12 @@ func RefreshMediaItemMetadata(ctx context.Context, item *models.MediaItem, refre
 }
 
 func AddLabelToMediaItem(ctx context.Context, item models.MediaItem, selectedTypes models.SelectedTypes) (Err logging.LogErrorInfo) {
-	if config.Current.MediaServer.Type != "Plex" {
+	if config.Current(ctx).MediaServer.Type != "Plex" {
 		return logging.LogErrorInfo{}
-	} else if len(config.Current.LabelsAndTags.Applications) == 0 {
+	} else if len(config.Current(ctx).LabelsAndTags.Applications) == 0 {
 		return logging.LogErrorInfo{}
 	}
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return Err
 	}
@@ -199,10 +246,10 @@ func AddLabelToMediaItem(ctx context.Context, item models.MediaItem, selectedTyp
 }
 
 func RateMediaItem(ctx context.Context, item *models.MediaItem, rating float64) (Err logging.LogErrorInfo) {
-	if config.Current.MediaServer.Type != "Plex" {
+	if config.Current(ctx).MediaServer.Type != "Plex" {
 		return logging.LogErrorInfo{}
 	}
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return Err
 	}
@@ -212,15 +259,12 @@ func RateMediaItem(ctx context.Context, item *models.MediaItem, rating float64)
 ///////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func DownloadApplyImageToMediaItem(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
-	if Err.Message != "" {
-		return Err
-	}
-	return msClient.DownloadApplyImageToMediaItem(ctx, item, imageFile)
+	_, Err = DownloadApplyAndVerifyImage(ctx, item, imageFile)
+	return Err
 }
 
 func ApplyCollectionImage(ctx context.Context, collectionItem *models.CollectionItem, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
-	msClient, Err := NewMediaServerClient(&config.Current.MediaServer)
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return Err
 	}
@@ -0,0 +1,177 @@
+package plex
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+func (p *Plex) CreateCollection(ctx context.Context, libraryTitle string, title string, tmdbID string, items []models.MediaItem) (collection models.CollectionItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Plex: Creating Collection '%s' in Library '%s'", title, libraryTitle), logging.LevelInfo)
+	defer logAction.Complete()
+
+	collection = models.CollectionItem{}
+	Err = logging.LogErrorInfo{}
+
+	if len(items) == 0 {
+		logAction.SetError("No items to add to the collection", "Plex collections must be created with at least one item", nil)
+		return collection, *logAction.Error
+	}
+
+	librarySection, found := cache.LibraryStore.GetSectionByTitle(libraryTitle)
+	if !found || librarySection.ID == "" {
+		logAction.SetError("Library section not found in cache", "Try refreshing the cache if this issue persists", map[string]any{
+			"library_title": libraryTitle,
+		})
+		return collection, *logAction.Error
+	}
+
+	itemsURI, Err := buildLibraryMetadataURI(ctx, items)
+	if Err.Message != "" {
+		return collection, Err
+	}
+
+	// Construct the URL for the Plex API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return collection, *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "collections")
+	query := u.Query()
+	query.Set("type", "1") // Movie collection
+	query.Set("title", title)
+	query.Set("smart", "0")
+	query.Set("sectionId", librarySection.ID)
+	query.Set("uri", itemsURI)
+	u.RawQuery = query.Encode()
+	URL := u.String()
+
+	// Make the HTTP Request to Plex
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "POST", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return collection, *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	// Decode the Response
+	var plexResp PlexLibraryItemsWrapper
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &plexResp, "Plex Create Collection Response")
+	if Err.Message != "" {
+		return collection, Err
+	}
+	if len(plexResp.MediaContainer.Metadata) == 0 || plexResp.MediaContainer.Metadata[0].RatingKey == "" {
+		logAction.SetError("Plex did not return the new collection", "Check the Plex server logs for more details", nil)
+		return collection, *logAction.Error
+	}
+
+	created := plexResp.MediaContainer.Metadata[0]
+	collection.RatingKey = created.RatingKey
+	collection.Index = strconv.Itoa(created.Index)
+	collection.TMDB_ID = tmdbID
+	collection.Title = created.Title
+	collection.Summary = created.Summary
+	collection.ChildCount = len(items)
+	collection.LibraryTitle = libraryTitle
+	if collection.Title == "" {
+		collection.Title = title
+	}
+	logAction.AppendResult("rating_key", collection.RatingKey)
+
+	return collection, logging.LogErrorInfo{}
+}
+
+func (p *Plex) AddItemsToCollection(ctx context.Context, collection *models.CollectionItem, items []models.MediaItem) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Plex: Adding %d Items to Collection '%s' [%s]", len(items), collection.Title, collection.RatingKey), logging.LevelInfo)
+	defer logAction.Complete()
+
+	if len(items) == 0 {
+		return logging.LogErrorInfo{}
+	}
+
+	itemsURI, Err := buildLibraryMetadataURI(ctx, items)
+	if Err.Message != "" {
+		return Err
+	}
+
+	// Construct the URL for the Plex API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "collections", collection.RatingKey, "items")
+	query := u.Query()
+	query.Set("uri", itemsURI)
+	u.RawQuery = query.Encode()
+	URL := u.String()
+
+	// Make the HTTP Request to Plex
+	resp, _, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "PUT", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
+
+// buildLibraryMetadataURI builds the server URI Plex uses to reference library items
+// Structure: server://{machineIdentifier}/com.plexapp.plugins.library/library/metadata/{ratingKey1},{ratingKey2}
+func buildLibraryMetadataURI(ctx context.Context, items []models.MediaItem) (uri string, Err logging.LogErrorInfo) {
+	machineIdentifier, Err := getMachineIdentifier(ctx)
+	if Err.Message != "" {
+		return "", Err
+	}
+
+	ratingKeys := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.RatingKey != "" {
+			ratingKeys = append(ratingKeys, item.RatingKey)
+		}
+	}
+
+	return fmt.Sprintf("server://%s/com.plexapp.plugins.library/library/metadata/%s", machineIdentifier, strings.Join(ratingKeys, ",")), logging.LogErrorInfo{}
+}
+
+func getMachineIdentifier(ctx context.Context) (machineIdentifier string, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Plex: Getting Server Machine Identifier", logging.LevelTrace)
+	defer logAction.Complete()
+
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return "", *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "/")
+	URL := u.String()
+
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return "", *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	var plexResp PlexConnectionInfoWrapper
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &plexResp, "Plex Connection Info Wrapper")
+	if Err.Message != "" {
+		return "", Err
+	}
+	if plexResp.MediaContainer.MachineIdentifier == "" {
+		logAction.SetError("Plex did not return a machine identifier", "Check the Plex server connection", nil)
+		return "", *logAction.Error
+	}
+
+	return plexResp.MediaContainer.MachineIdentifier, logging.LogErrorInfo{}
+}
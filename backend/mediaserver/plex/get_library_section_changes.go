@@ -0,0 +1,130 @@
+package plex
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+func (p *Plex) GetLibrarySectionItemsChangedSince(ctx context.Context, section models.LibrarySection, since int64) (items []models.MediaItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Plex: Fetching Changed Items for Library Section: %s", section.Title,
+	), logging.LevelInfo)
+	defer logAction.Complete()
+
+	items = []models.MediaItem{}
+	sinceStr := strconv.FormatInt(since, 10)
+
+	// Plex keeps addedAt and updatedAt separately, so ask for both and merge them by Rating Key
+	// (the '>>' suffix is Plex's "after" filter for dates)
+	seen := make(map[string]bool)
+	for _, filter := range []string{"updatedAt>>", "addedAt>>"} {
+		query := url.Values{}
+		query.Set(filter, sinceStr)
+		changed, _, Err := fetchLibrarySectionItems(ctx, logAction, section, query)
+		if Err.Message != "" {
+			return items, Err
+		}
+		for _, item := range changed {
+			if !seen[item.RatingKey] {
+				seen[item.RatingKey] = true
+				items = append(items, item)
+			}
+		}
+	}
+
+	// A show is not updated when an episode is added to it, so look for new episodes separately
+	if section.Type == "show" && config.Current(ctx).MediaServer.EnableSortByEpisodeAddedDate {
+		latestEpAdded, fetchErr := fetchLatestEpisodeAddedAtByShow(ctx, section.ID, since)
+		if fetchErr.Message != "" {
+			logAction.AppendWarning("latest_episode_added_at", "Failed to fetch new episodes for shows")
+			latestEpAdded = map[string]int64{}
+		}
+
+		for i := range items {
+			// Keep the value from the cache, only new episodes were fetched
+			if cached, found := cache.LibraryStore.GetMediaItemByRatingKey(items[i].RatingKey); found {
+				items[i].LatestEpisodeAddedAt = cached.LatestEpisodeAddedAt
+			}
+			items[i].LatestEpisodeAddedAt = max(items[i].LatestEpisodeAddedAt, latestEpAdded[items[i].RatingKey])
+			delete(latestEpAdded, items[i].RatingKey)
+		}
+
+		// Shows that only got new episodes
+		for showKey, addedAt := range latestEpAdded {
+			cached, found := cache.LibraryStore.GetMediaItemByRatingKey(showKey)
+			if !found || cached.LibraryTitle != section.Title {
+				continue
+			}
+			show := *cached
+			show.LatestEpisodeAddedAt = max(show.LatestEpisodeAddedAt, addedAt)
+			items = append(items, show)
+		}
+	}
+
+	logAction.AppendResult("changed_items", len(items))
+	return items, logging.LogErrorInfo{}
+}
+
+// Number of items listed per request by GetLibrarySectionRatingKeys
+const ratingKeysPageSize = 1000
+
+func (p *Plex) GetLibrarySectionRatingKeys(ctx context.Context, section models.LibrarySection) (ratingKeys map[string]bool, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Plex: Listing Rating Keys for Library Section: %s", section.Title,
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	ratingKeys = make(map[string]bool)
+
+	// Construct the URL for the Plex library sections API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
+		return ratingKeys, *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "sections", section.ID, "all")
+	query := u.Query()
+	// Only the Rating Keys are needed, so leave out the tags, media and other fields
+	query.Set("includeGuids", "0")
+	query.Set("includeFields", "ratingKey")
+	query.Set("excludeElements", "Media,Genre,Country,Director,Writer,Role,Collection,Label,Guid,Image,UltraBlurColors")
+	query.Set("X-Plex-Container-Size", strconv.Itoa(ratingKeysPageSize))
+
+	for start := 0; ; start += ratingKeysPageSize {
+		query.Set("X-Plex-Container-Start", strconv.Itoa(start))
+		u.RawQuery = query.Encode()
+
+		// Make the HTTP Request to Plex
+		resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, u.String(), "GET", nil)
+		if Err.Message != "" {
+			logAction.SetErrorFromInfo(Err)
+			return ratingKeys, *logAction.Error
+		}
+		resp.Body.Close()
+
+		// Decode the Response
+		var plexResp PlexLibraryItemsWrapper
+		Err = httpx.DecodeResponseToJSON(ctx, respBody, &plexResp, "Plex Library Rating Keys Response")
+		if Err.Message != "" {
+			return ratingKeys, Err
+		}
+
+		for _, metadata := range plexResp.MediaContainer.Metadata {
+			ratingKeys[metadata.RatingKey] = true
+		}
+		if len(plexResp.MediaContainer.Metadata) < ratingKeysPageSize || start+ratingKeysPageSize >= plexResp.MediaContainer.TotalSize {
+			break
+		}
+	}
+
+	logAction.AppendResult("rating_keys", len(ratingKeys))
+	return ratingKeys, logging.LogErrorInfo{}
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -22,31 +23,60 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 	), logging.LevelInfo)
 	defer logAction.Complete()
 
-	items = []models.MediaItem{}
-	totalSize = 0
-	Err = logging.LogErrorInfo{}
-
 	// If limit is empty, set a default limit
 	if limit == "" {
 		limit = "1000"
 	}
 
+	query := url.Values{}
+	query.Set("X-Plex-Container-Start", sectionStartIndex)
+	query.Set("X-Plex-Container-Size", limit)
+	items, totalSize, Err = fetchLibrarySectionItems(ctx, logAction, section, query)
+	if Err.Message != "" {
+		return items, totalSize, Err
+	}
+	for i := range items {
+		cache.LibraryStore.UpdateMediaItem(section.Title, &items[i])
+	}
+
+	// For show sections, bulk-fetch all episodes to compute LatestEpisodeAddedAt per show.
+	if section.Type == "show" && config.Current(ctx).MediaServer.EnableSortByEpisodeAddedDate {
+		latestEpAdded, fetchErr := fetchLatestEpisodeAddedAtByShow(ctx, section.ID, 0)
+		if fetchErr.Message != "" {
+			logAction.AppendWarning("latest_episode_added_at", "Failed to bulk-fetch latest episode addedAt for shows")
+		} else {
+			for i := range items {
+				items[i].LatestEpisodeAddedAt = latestEpAdded[items[i].RatingKey]
+			}
+		}
+	}
+
+	return items, totalSize, logging.LogErrorInfo{}
+}
+
+// fetchLibrarySectionItems gets the items of a library section matching filters (paging or Plex filters)
+func fetchLibrarySectionItems(ctx context.Context, logAction *logging.LogAction, section models.LibrarySection, filters url.Values) (items []models.MediaItem, totalSize int, Err logging.LogErrorInfo) {
+	items = []models.MediaItem{}
+	totalSize = 0
+	Err = logging.LogErrorInfo{}
+
 	// Construct the URL for the Plex library sections API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return items, totalSize, *logAction.Error
 	}
 	u.Path = path.Join(u.Path, "library", "sections", section.ID, "all")
 	query := u.Query()
-	query.Set("X-Plex-Container-Start", sectionStartIndex)
-	query.Set("X-Plex-Container-Size", limit)
+	for key, values := range filters {
+		query[key] = values
+	}
 	query.Set("includeGuids", "1")
 	u.RawQuery = query.Encode()
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return items, totalSize, *logAction.Error
@@ -175,28 +205,16 @@ func (p *Plex) GetLibrarySectionItems(ctx context.Context, section models.Librar
 			}
 		}
 
-		cache.LibraryStore.UpdateMediaItem(section.Title, &item)
 		items = append(items, item)
 	}
 
-	// For show sections, bulk-fetch all episodes to compute LatestEpisodeAddedAt per show.
-	if section.Type == "show" && config.Current.MediaServer.EnableSortByEpisodeAddedDate {
-		latestEpAdded, fetchErr := fetchLatestEpisodeAddedAtByShow(ctx, section.ID)
-		if fetchErr.Message != "" {
-			logAction.AppendWarning("latest_episode_added_at", "Failed to bulk-fetch latest episode addedAt for shows")
-		} else {
-			for i := range items {
-				items[i].LatestEpisodeAddedAt = latestEpAdded[items[i].RatingKey]
-			}
-		}
-	}
-
 	return items, totalSize, logging.LogErrorInfo{}
 }
 
 // fetchLatestEpisodeAddedAtByShow fetches all episodes for a library section in one bulk
 // request and returns a map of show RatingKey -> latest episode addedAt timestamp.
-func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map[string]int64, logging.LogErrorInfo) {
+// If addedSince is set, only the episodes added since then (Unix seconds) are fetched.
+func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string, addedSince int64) (map[string]int64, logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
 		"Plex: Bulk-fetching episode addedAt for section %s", sectionID,
 	), logging.LevelDebug)
@@ -204,7 +222,7 @@ func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map
 
 	logging.DevMsgf("Bulk-fetching latest episode addedAt for shows in section %s", sectionID)
 
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return nil, *logAction.Error
@@ -214,11 +232,14 @@ func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map
 	// First pass: get total episode count (size=0 returns totalSize without data)
 	query := u.Query()
 	query.Set("type", "4") // 4 = episode
+	if addedSince > 0 {
+		query.Set("addedAt>>", strconv.FormatInt(addedSince, 10))
+	}
 	query.Set("X-Plex-Container-Start", "0")
 	query.Set("X-Plex-Container-Size", "0")
 	u.RawQuery = query.Encode()
 
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, u.String(), "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, u.String(), "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return nil, *logAction.Error
@@ -239,7 +260,7 @@ func fetchLatestEpisodeAddedAtByShow(ctx context.Context, sectionID string) (map
 	query.Set("X-Plex-Container-Size", fmt.Sprintf("%d", totalEpisodes))
 	u.RawQuery = query.Encode()
 
-	resp, respBody, Err = makeRequest(ctx, config.Current.MediaServer, u.String(), "GET", nil)
+	resp, respBody, Err = makeRequest(ctx, config.Current(ctx).MediaServer, u.String(), "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return nil, *logAction.Error
@@ -66,7 +66,7 @@ func GetImageFromPlex(ctx context.Context, ratingKey string, imageType string) (
 	// Construct the URL for the Plex Image request
 	photoPath := path.Join("/library/metadata", ratingKey, imageType, fmt.Sprintf("%d", time.Now().Unix()))
 	encodedPhotoPath := url.QueryEscape(photoPath)
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		return imageData, logging.LogErrorInfo{
 			Message: "Failed to parse base URL",
@@ -83,7 +83,7 @@ func GetImageFromPlex(ctx context.Context, ratingKey string, imageType string) (
 	URL = fmt.Sprintf("%s&url=%s", URL, encodedPhotoPath)
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		return imageData, Err
 	}
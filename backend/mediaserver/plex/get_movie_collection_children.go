@@ -23,7 +23,7 @@ func (p *Plex) GetMovieCollectionChildrenItems(ctx context.Context, collection *
 
 	// Construct the URL for the Plex API request
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return *logAction.Error
@@ -35,7 +35,7 @@ func (p *Plex) GetMovieCollectionChildrenItems(ctx context.Context, collection *
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return *logAction.Error
@@ -56,7 +56,7 @@ func (p *Plex) GetMovieCollectionChildrenItems(ctx context.Context, collection *
 		}
 
 		isInSelectedLibraryTitles := false
-		for _, library := range config.Current.MediaServer.Libraries {
+		for _, library := range config.Current(ctx).MediaServer.Libraries {
 			if library.Title == item.LibrarySectionTitle {
 				isInSelectedLibraryTitles = true
 				break
@@ -20,9 +20,9 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 	defer logAction.Complete()
 
 	// Do one last check to ensure we are a Plex server
-	if p.Config.Type != "Plex" || config.Current.MediaServer.Type != "Plex" {
+	if p.Config.Type != "Plex" || config.Current(ctx).MediaServer.Type != "Plex" {
 		return logging.LogErrorInfo{}
-	} else if len(config.Current.LabelsAndTags.Applications) == 0 {
+	} else if len(config.Current(ctx).LabelsAndTags.Applications) == 0 {
 		return logging.LogErrorInfo{}
 	}
 
@@ -45,7 +45,7 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 	}
 
 	// Get all of the applications configured for labels and tags
-	for _, app := range config.Current.LabelsAndTags.Applications {
+	for _, app := range config.Current(ctx).LabelsAndTags.Applications {
 		if app.Application != "Plex" {
 			continue
 		}
@@ -90,7 +90,7 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 		// Make a comma-separated string of labels to remove
 		labelsToRemove := ""
 		if len(app.Remove) > 0 {
-			if config.Current.LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload && !selectedTypes.Poster {
+			if config.Current(ctx).LabelsAndTags.RemoveOverlayLabelOnlyOnPosterDownload && !selectedTypes.Poster {
 				// If the "RemoveOverlayLabelOnlyOnPosterDownload" setting is enabled and the selected types do not include Poster, filter out "Overlay" from the removal list
 				filteredLabels := []string{}
 				for _, label := range app.Remove {
@@ -236,7 +236,7 @@ func (p *Plex) AddLabelToMediaItem(ctx context.Context, item models.MediaItem, s
 		URL += "&" + combinedParams
 
 		// Make the API request to add/remove labels
-		_, _, Err := makeRequest(ctx, config.Current.MediaServer, URL, "PUT", nil)
+		_, _, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "PUT", nil)
 		if Err.Message != "" {
 			continue
 		}
@@ -2,8 +2,8 @@ package plex
 
 import (
 	"aura/config"
+	"aura/imagesource"
 	"aura/logging"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils"
 	"context"
@@ -17,24 +17,33 @@ import (
 	"golang.org/x/text/language"
 )
 
-func applyImageToMediaItemViaMediuxURL(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
+func applyImageToMediaItemViaSourceURL(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageFile models.ImageFile) (Err logging.LogErrorInfo) {
 	fileDownloadName := utils.GetFileDownloadName(item.Title, imageFile)
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
-		"Plex: Applying '%s' Image via MediUX URL", fileDownloadName), logging.LevelDebug)
+		"Plex: Applying '%s' Image via %s URL", fileDownloadName, imagesource.GetImageSource(imageFile)), logging.LevelDebug)
 	defer logAction.Complete()
 
 	Err = logging.LogErrorInfo{}
 
-	// Construct the MediUX Image URL
-	imageURL, Err := mediux.ConstructImageUrl(ctx, imageFile.ID, imageFile.Modified.String(), mediux.ImageQualityOriginal)
+	// Construct the Image URL from the image source it came from
+	imageURL, Err := imagesource.GetImageURL(ctx, imageFile)
 	if Err.Message != "" {
-		return *logAction.Error
+		return Err
+	}
+
+	// Some image sources (e.g. local artwork) are not reachable by Plex, so the image is uploaded instead
+	if imageURL == "" {
+		imageData, _, Err := imagesource.GetImage(ctx, imageFile)
+		if Err.Message != "" {
+			return Err
+		}
+		return uploadImageToMediaItem(ctx, item, itemRatingKey, imageData, imageFile.Type)
 	}
 
 	// Refresh the Plex Item
 	//RefreshItemMetadata(ctx, item, itemRatingKey, false)
 
-	// Set the Poster using the MediUX URL
+	// Set the Poster using the image source URL
 	Err = applyImageToMediaItem(ctx, item, itemRatingKey, imageURL, imageFile.Type)
 	if Err.Message != "" {
 		return Err
@@ -81,7 +90,7 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRati
 	}
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
 		return *logAction.Error
@@ -93,7 +102,7 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRati
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, requestMethod, nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, requestMethod, nil)
 	if Err.Message != "" {
 		return Err
 	}
@@ -108,3 +117,36 @@ func applyImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRati
 
 	return logging.LogErrorInfo{}
 }
+
+func uploadImageToMediaItem(ctx context.Context, item *models.MediaItem, itemRatingKey string, imageData []byte, imageType string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Plex: Uploading '%s' Image to %s",
+		cases.Title(language.English).String(imageType), utils.MediaItemInfo(*item),
+	), logging.LevelDebug)
+	defer logAction.Complete()
+
+	// Uploads use the same plural posterType as remote assets (posters or arts)
+	if imageType == "backdrop" {
+		imageType = "arts"
+	} else {
+		imageType = "posters"
+	}
+
+	// Construct the URL for the Plex API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "metadata", itemRatingKey, imageType)
+	URL := u.String()
+
+	// Make the HTTP Request to Plex with the image as the body
+	resp, _, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, http.MethodPost, imageData)
+	if Err.Message != "" {
+		return Err
+	}
+	defer resp.Body.Close()
+
+	return logging.LogErrorInfo{}
+}
@@ -3,8 +3,8 @@ package plex
 import (
 	"aura/cache"
 	"aura/config"
+	"aura/imagesource"
 	"aura/logging"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils"
 	"context"
@@ -34,14 +34,13 @@ func (p *Plex) DownloadApplyImageToMediaItem(ctx context.Context, item *models.M
 	logAction.AppendResult("image_rating_key", itemRatingKey)
 
 	// If SaveImageLocally is disabled, skip downloading the image
-	if !config.Current.Images.SaveImagesLocally.Enabled {
-		return applyImageToMediaItemViaMediuxURL(ctx, item, itemRatingKey, imageFile)
+	if !config.Current(ctx).Images.SaveImagesLocally.Enabled {
+		return applyImageToMediaItemViaSourceURL(ctx, item, itemRatingKey, imageFile)
 	}
 
-	// Get the Image from MediUX
+	// Get the Image from the image source it came from
 	// mediux.GetImage will handle checking the temp folder and caching based on config
-	formatDate := imageFile.Modified.Format("20060102150405")
-	imageData, imageType, Err := mediux.GetImage(ctx, imageFile.ID, formatDate, mediux.ImageQualityOriginal)
+	imageData, imageType, Err := imagesource.GetImage(ctx, imageFile)
 	if Err.Message != "" {
 		return Err
 	}
@@ -60,11 +59,11 @@ func (p *Plex) DownloadApplyImageToMediaItem(ctx context.Context, item *models.M
 		return Err
 	}
 
-	// If Save Image Next to Content is enabled and the Path is set, set the poster in Plex via the MediUX URL
+	// If Save Image Next to Content is enabled and the Path is set, set the poster in Plex via the image source URL
 	// When the Path is set, the image is saved in a different location than Plex expects it to be.
-	// So we need to upload the image to Plex via the MediUX URL.
+	// So we need to upload the image to Plex via the image source URL.
 	// if isCustomLocalPath {
-	applyImageToMediaItemViaMediuxURL(ctx, item, itemRatingKey, imageFile)
+	applyImageToMediaItemViaSourceURL(ctx, item, itemRatingKey, imageFile)
 	if Err.Message != "" {
 		return Err
 	}
@@ -89,7 +88,7 @@ func (p *Plex) DownloadApplyImageToMediaItem(ctx context.Context, item *models.M
 
 	// 	// If failedOnGetPosters is true, use the MediUX URL to set the poster
 	// 	if failedToGetPosterKey {
-	// 		applyImageToMediaItemViaMediuxURL(ctx, item, itemRatingKey, imageFile)
+	// 		applyImageToMediaItemViaSourceURL(ctx, item, itemRatingKey, imageFile)
 	// 		if Err.Message != "" {
 	// 			return Err
 	// 		}
@@ -159,7 +158,7 @@ func saveImageLocally(ctx context.Context, p *Plex, item *models.MediaItem, imag
 		case "special_season_poster":
 			newFileName = "season-specials-poster" + ext
 		case "titlecard":
-			episodeNamingConvention := config.Current.Images.SaveImagesLocally.EpisodeNamingConvention
+			episodeNamingConvention := config.Current(ctx).Images.SaveImagesLocally.EpisodeNamingConvention
 			// For titlecards, get the file path from Plex
 			episodePath := getEpisodePathFromImageFile(*item, imageFile)
 			getFilePathAction.AppendResult("episode_path_lookup", episodePath)
@@ -208,7 +207,7 @@ func saveImageLocally(ctx context.Context, p *Plex, item *models.MediaItem, imag
 	getFilePathAction.AppendResult("newFilePath", newFilePath)
 	getFilePathAction.Complete()
 
-	if config.Current.Images.SaveImagesLocally.Enabled && config.Current.Images.SaveImagesLocally.Path != "" {
+	if config.Current(ctx).Images.SaveImagesLocally.Enabled && config.Current(ctx).Images.SaveImagesLocally.Path != "" {
 		isCustomLocalPath = true
 		newPathAction := logAction.AddSubAction("Building New File Path for Local Image Save", logging.LevelDebug)
 		// Build newFilePath based on library, content, and config path
@@ -264,7 +263,7 @@ func saveImageLocally(ctx context.Context, p *Plex, item *models.MediaItem, imag
 			newPathAction.AppendResult("relative_path", relativePath)
 
 			// Final path: /local/images/movies/Inception (2020), etc.
-			newFilePath = path.Join(config.Current.Images.SaveImagesLocally.Path, relativePath)
+			newFilePath = path.Join(config.Current(ctx).Images.SaveImagesLocally.Path, relativePath)
 			newPathAction.AppendResult("final_path", newFilePath)
 		} else {
 			newPathAction.AppendResult("library_found_in_cache", false)
@@ -283,7 +282,7 @@ func saveImageLocally(ctx context.Context, p *Plex, item *models.MediaItem, imag
 
 				// Final path:  /local/images/movies/Inception (2020)
 				//				/local/images/shows/Breaking Bad
-				newFilePath = path.Join(config.Current.Images.SaveImagesLocally.Path, libraryPath, contentPath)
+				newFilePath = path.Join(config.Current(ctx).Images.SaveImagesLocally.Path, libraryPath, contentPath)
 				newPathAction.AppendResult("final_path", newFilePath)
 			} else if item.Type == "show" && (imageFile.Type == "titlecard") {
 				// For shows with season_posters/titlecard
@@ -297,7 +296,7 @@ func saveImageLocally(ctx context.Context, p *Plex, item *models.MediaItem, imag
 				newPathAction.AppendResult("library_path", libraryPath)
 
 				// Final path: /local/images/shows/Breaking Bad/Season 01
-				newFilePath = path.Join(config.Current.Images.SaveImagesLocally.Path, libraryPath, contentPath, seasonPath)
+				newFilePath = path.Join(config.Current(ctx).Images.SaveImagesLocally.Path, libraryPath, contentPath, seasonPath)
 				newPathAction.AppendResult("final_path", newFilePath)
 			} else {
 				// Error: unable to determine path
@@ -360,7 +359,7 @@ func saveImageLocally(ctx context.Context, p *Plex, item *models.MediaItem, imag
 }
 
 func convertWindowsPathToDockerPath(windowsPath string) string {
-	if !config.Current.Images.SaveImagesLocally.RunningOnWindows {
+	if !config.Latest().Images.SaveImagesLocally.RunningOnWindows {
 		return windowsPath
 	} else {
 		logging.LOGGER.Debug().Timestamp().Msg("ConvertWindowsPathToDockerPath called, fixing path for Windows")
@@ -29,7 +29,7 @@ func getAllImages(ctx context.Context, item *models.MediaItem, itemRatingKey str
 	}
 
 	// Construct the URL for the Plex API request
-	u, err := url.Parse(config.Current.MediaServer.URL)
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
 	if err != nil {
 		logAction.SetError(logging.Error_BaseUrlParsing(err))
 		return images, *logAction.Error
@@ -38,7 +38,7 @@ func getAllImages(ctx context.Context, item *models.MediaItem, itemRatingKey str
 	URL := u.String()
 
 	// Make the HTTP Request to Plex
-	resp, respBody, Err := makeRequest(ctx, config.Current.MediaServer, URL, "GET", nil)
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
 	if Err.Message != "" {
 		logAction.SetErrorFromInfo(Err)
 		return images, *logAction.Error
@@ -47,7 +47,7 @@ func getAllImages(ctx context.Context, item *models.MediaItem, itemRatingKey str
 
 	// Decode the Response
 	var respData PlexGetAllImagesWrapper
-	Err = httpx.DecodeResponseToJSON(ctx, respBody, &respData, fmt.Sprintf("%s Media Item Images Response", config.Current.MediaServer.Type))
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &respData, fmt.Sprintf("%s Media Item Images Response", config.Current(ctx).MediaServer.Type))
 	if Err.Message != "" {
 		return images, *logAction.Error
 	}
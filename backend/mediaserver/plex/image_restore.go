@@ -0,0 +1,87 @@
+package plex
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// RestoreOriginalImage selects the artwork provided by the Plex agent for an image, undoing any uploaded or local artwork.
+// Plex keeps every poster it knows about, so the agent poster is still in the list even after AURA replaced it.
+func (p *Plex) RestoreOriginalImage(ctx context.Context, item *models.MediaItem, imageRatingKey string, imageType string) (Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Plex: Restoring Original '%s' Image for %s", imageType, utils.MediaItemInfo(*item)), logging.LevelDebug)
+	defer logAction.Complete()
+
+	listType := "posters"
+	selectType := "poster"
+	if imageType == "backdrop" {
+		listType = "arts"
+		selectType = "art"
+	}
+
+	// Construct the URL for the Plex API request
+	u, err := url.Parse(config.Current(ctx).MediaServer.URL)
+	if err != nil {
+		logAction.SetError(logging.Error_BaseUrlParsing(err))
+		return *logAction.Error
+	}
+	u.Path = path.Join(u.Path, "library", "metadata", imageRatingKey, listType)
+	URL := u.String()
+
+	// Make the HTTP Request to Plex
+	resp, respBody, Err := makeRequest(ctx, config.Current(ctx).MediaServer, URL, "GET", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	var respData PlexGetAllImagesWrapper
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &respData, "Plex Media Item Images Response")
+	if Err.Message != "" {
+		return Err
+	}
+
+	// Uploaded images have no provider, local assets use the "local" provider
+	// Everything else came from a metadata agent
+	agentImageKey := ""
+	for _, img := range respData.MediaContainer.Metadata {
+		if img.Provider == "" || img.Provider == "local" || strings.HasPrefix(img.RatingKey, "upload://") {
+			continue
+		}
+		agentImageKey = img.RatingKey
+		break
+	}
+	if agentImageKey == "" {
+		logAction.SetError("No agent provided image found", "Refresh the metadata of the item in Plex so the agent images are downloaded again", map[string]any{
+			"rating_key": imageRatingKey,
+			"image_type": imageType,
+			"images":     len(respData.MediaContainer.Metadata),
+		})
+		return *logAction.Error
+	}
+
+	// Selecting an image that Plex already has always uses PUT with the singular type
+	u.Path = path.Join(path.Dir(u.Path), selectType)
+	query := u.Query()
+	query.Set("url", agentImageKey)
+	u.RawQuery = query.Encode()
+
+	resp, _, Err = makeRequest(ctx, config.Current(ctx).MediaServer, u.String(), "PUT", nil)
+	if Err.Message != "" {
+		logAction.SetErrorFromInfo(Err)
+		return *logAction.Error
+	}
+	defer resp.Body.Close()
+
+	logAction.AppendResult("image_key", agentImageKey)
+	return logging.LogErrorInfo{}
+}
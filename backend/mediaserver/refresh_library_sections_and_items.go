@@ -0,0 +1,136 @@
+package mediaserver
+
+import (
+	"aura/cache"
+	"aura/config"
+	"aura/logging"
+	"context"
+	"fmt"
+	"time"
+)
+
+// How often the refresh does a full resync instead of an incremental one.
+// The full resync catches what the incremental one can't see, like items that got a TMDB ID.
+const fullResyncInterval = 24 * time.Hour
+
+// Items changed a little before the last sync are fetched again, in case the media server clock is behind
+const incrementalSyncOverlap = 5 * time.Minute
+
+// RefreshLibrarySectionsAndItems brings the library cache up to date with the media server.
+// Only the items added or updated since the last sync are fetched, and removed items are found by listing Rating Keys.
+// Collections are few compared with items, so they are fetched in full every time. Their cached items are kept,
+// the changed items are added to them and the full resync drops the items that were taken out of a collection.
+// A full resync is done instead when the cache was never fully loaded, a configured library is not in the cache
+// or the last full resync is older than fullResyncInterval.
+func RefreshLibrarySectionsAndItems(ctx context.Context) (success bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Refreshing Library Sections and Items", logging.LevelInfo)
+	defer logAction.Complete()
+
+	if reason := fullResyncReason(ctx); reason != "" {
+		logAction.AppendResult("mode", "full")
+		logAction.AppendResult("reason", reason)
+		return GetAllLibrarySectionsAndItems(ctx, true)
+	}
+
+	since := max(cache.LibraryStore.LastSync, cache.LibraryStore.LastFullUpdate) - int64(incrementalSyncOverlap.Seconds())
+	syncStart := time.Now().Unix()
+	logAction.AppendResult("mode", "incremental")
+	logAction.AppendResult("since", time.Unix(since, 0).Format(time.RFC3339))
+
+	success = true
+	ejRanCollections := false
+	for _, library := range config.Current(ctx).MediaServer.Libraries {
+		cachedSection, found := cache.LibraryStore.GetSectionByTitle(library.Title)
+		if !found {
+			continue
+		}
+		section := library
+		section.LibrarySectionBase = cachedSection.LibrarySectionBase
+
+		refreshSectionCollections(ctx, section, &ejRanCollections)
+
+		// Added and updated items
+		changedItems, Err := GetLibrarySectionItemsChangedSince(ctx, section, since)
+		if Err.Message != "" {
+			success = false
+			continue
+		}
+		for i := range changedItems {
+			cache.LibraryStore.UpdateMediaItem(section.Title, &changedItems[i])
+		}
+
+		// Removed items
+		ratingKeys, Err := GetLibrarySectionRatingKeys(ctx, section)
+		if Err.Message != "" {
+			success = false
+			continue
+		}
+		if len(ratingKeys) == 0 && len(cachedSection.MediaItems) > 0 {
+			// More likely a media server hiccup than an emptied library, leave it to the next full resync
+			logAction.AppendWarning("skipped_removals_"+section.Title, "media server listed no items for this library")
+			continue
+		}
+		removed := cache.LibraryStore.RemoveItemsWithRatingKeyNotIn(section.Title, ratingKeys)
+
+		logAction.AppendResult("changed_items_"+section.Title, len(changedItems))
+		logAction.AppendResult("removed_items_"+section.Title, removed)
+	}
+
+	// On failure the next refresh starts from the same point again
+	if success {
+		cache.LibraryStore.LastSync = syncStart
+		cache.CollectionsStore.LastFullUpdate = time.Now().Unix()
+		cache.SaveLibrarySnapshot(ctx)
+	}
+	return success
+}
+
+// RefreshLibrarySection fetches the items of one library section that were added or updated since the given Unix time
+// and updates them in the library cache. Removed items are left to RefreshLibrarySectionsAndItems.
+func RefreshLibrarySection(ctx context.Context, libraryTitle string, since int64) (success bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Refreshing Library Section '%s'", libraryTitle), logging.LevelDebug)
+	defer logAction.Complete()
+
+	cachedSection, found := cache.LibraryStore.GetSectionByTitle(libraryTitle)
+	if !found {
+		logAction.SetError("Library section not found in cache", "Make sure the library is configured and the library cache has been loaded", map[string]any{
+			"library_title": libraryTitle,
+		})
+		return false
+	}
+	section := *cachedSection
+	for _, library := range config.Current(ctx).MediaServer.Libraries {
+		if library.Title == libraryTitle {
+			section = library
+			section.LibrarySectionBase = cachedSection.LibrarySectionBase
+			break
+		}
+	}
+
+	changedItems, Err := GetLibrarySectionItemsChangedSince(ctx, section, since)
+	if Err.Message != "" {
+		return false
+	}
+	for i := range changedItems {
+		cache.LibraryStore.UpdateMediaItem(section.Title, &changedItems[i])
+	}
+	logAction.AppendResult("changed_items", len(changedItems))
+	return true
+}
+
+// fullResyncReason returns why a full resync is needed, or "" if an incremental refresh is enough
+func fullResyncReason(ctx context.Context) string {
+	lastFullUpdate := cache.LibraryStore.LastFullUpdate
+	if lastFullUpdate == 0 {
+		return "library cache was never fully loaded"
+	}
+	if time.Since(time.Unix(lastFullUpdate, 0)) > fullResyncInterval {
+		return fmt.Sprintf("last full resync is older than %s", fullResyncInterval)
+	}
+	for _, library := range config.Current(ctx).MediaServer.Libraries {
+		if _, found := cache.LibraryStore.GetSectionByTitle(library.Title); !found {
+			return fmt.Sprintf("library '%s' is not in the cache", library.Title)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+package mediaserver
+
+import (
+	"aura/artworkhistory"
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"aura/utils"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	RESTORE_METHOD_HISTORY      = "artwork_history"
+	RESTORE_METHOD_MEDIA_SERVER = "media_server"
+)
+
+// restoringCoolDown is how long the event listeners leave an item alone after its artwork was restored.
+// The refresh that restores the artwork sends update events, which can arrive a while later.
+const restoringCoolDown = 2 * time.Minute
+
+// restoringItems holds the rating keys of the items whose artwork is being restored
+var restoringItems = struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}{
+	until: make(map[string]time.Time),
+}
+
+// MarkRestoringArtwork tells the event listeners not to re-apply the saved sets of an item for restoringCoolDown.
+// Call it before the artwork of the item is restored, and again once its saved sets are removed.
+func MarkRestoringArtwork(ratingKey string) {
+	if ratingKey == "" {
+		return
+	}
+	restoringItems.mu.Lock()
+	defer restoringItems.mu.Unlock()
+	restoringItems.until[ratingKey] = time.Now().Add(restoringCoolDown)
+}
+
+// IsRestoringArtwork returns true if the artwork of an item is being restored or was restored within restoringCoolDown
+func IsRestoringArtwork(ratingKey string) bool {
+	restoringItems.mu.Lock()
+	defer restoringItems.mu.Unlock()
+	now := time.Now()
+	for key, until := range restoringItems.until {
+		if now.After(until) {
+			delete(restoringItems.until, key)
+		}
+	}
+	_, restoring := restoringItems.until[ratingKey]
+	return restoring
+}
+
+// RestoreOriginalArtwork puts back the artwork an item had before AURA first replaced the given image.
+// The oldest captured version in the artwork history is used when there is one.
+// Otherwise the Media Server is asked for the artwork of its metadata agents.
+func RestoreOriginalArtwork(ctx context.Context, item *models.MediaItem, imageFile models.ImageFile) (method string, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf(
+		"Restoring Original %s for %s", utils.GetFileDownloadName(item.Title, imageFile), utils.MediaItemInfo(*item)),
+		logging.LevelDebug)
+	defer logAction.Complete()
+
+	Err = ensureShowDetails(ctx, item, imageFile.Type)
+	if Err.Message != "" {
+		return "", Err
+	}
+
+	if version, found := OriginalArtworkVersion(ctx, *item, imageFile); found {
+		Err = DownloadApplyImageToMediaItem(ctx, item, artworkhistory.ImageFileForVersion(*item, version))
+		if Err.Message != "" {
+			return RESTORE_METHOD_HISTORY, Err
+		}
+		logAction.AppendResult("version_id", version.ID)
+		return RESTORE_METHOD_HISTORY, logging.LogErrorInfo{}
+	}
+
+	imageRatingKey, imageType := getCurrentArtworkRequest(*item, imageFile)
+	if imageRatingKey == "" {
+		logAction.SetError("Could not determine the rating key of the image", "The season or episode may no longer exist on the media server", map[string]any{
+			"image_type":     imageFile.Type,
+			"season_number":  imageFile.SeasonNumber,
+			"episode_number": imageFile.EpisodeNumber,
+		})
+		return RESTORE_METHOD_MEDIA_SERVER, *logAction.Error
+	}
+
+	msClient, Err := NewMediaServerClient(&config.Current(ctx).MediaServer)
+	if Err.Message != "" {
+		return RESTORE_METHOD_MEDIA_SERVER, Err
+	}
+	return RESTORE_METHOD_MEDIA_SERVER, msClient.RestoreOriginalImage(ctx, item, imageRatingKey, imageType)
+}
+
+// OriginalArtworkVersion returns the original version of an image, the artwork from before AURA first replaced it.
+// Histories saved before the original was pinned fall back to the oldest captured version.
+func OriginalArtworkVersion(ctx context.Context, item models.MediaItem, imageFile models.ImageFile) (version artworkhistory.Version, found bool) {
+	history, Err := artworkhistory.GetItemHistory(ctx, item)
+	if Err.Message != "" {
+		return version, false
+	}
+
+	slot := artworkhistory.SlotKey(imageFile.Type, imageFile.SeasonNumber, imageFile.EpisodeNumber)
+	// Versions are newest first, so the last match is the oldest
+	for _, v := range history.Versions {
+		if artworkhistory.SlotKey(v.ImageType, v.SeasonNumber, v.EpisodeNumber) != slot {
+			continue
+		}
+		if v.Original {
+			return v, true
+		}
+		version = v
+		found = true
+	}
+	return version, found
+}
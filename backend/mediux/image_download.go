@@ -52,7 +52,7 @@ func GetImage(ctx context.Context, assetID string, formatDate string, imageQuali
 		}
 
 		// Check if caching is enabled
-		if config.Current.Images.CacheImages.Enabled {
+		if config.Current(ctx).Images.CacheImages.Enabled {
 			// Create a new logging data for this goroutine
 			ctx, ld := logging.CreateLoggingContext(context.Background(), "Caching - MediUX Image")
 			logAction := ld.AddAction("Caching MediUX Image", logging.LevelDebug)
@@ -90,7 +90,7 @@ func GetImage(ctx context.Context, assetID string, formatDate string, imageQuali
 	// If not, we fetch it from MediUX and save it to the temp/full folder based on qualityParam
 	// If config.Images.CacheImages.Enabled is false, we always fetch from MediUX
 
-	if config.Current.Images.CacheImages.Enabled {
+	if config.Current(ctx).Images.CacheImages.Enabled {
 		// Check if the folder exists, if not create it
 		Err := utils.CreateFolderIfNotExists(ctx, folderPath)
 		if Err.Message != "" {
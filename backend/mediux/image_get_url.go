@@ -47,7 +47,7 @@ func ConstructImageUrl(ctx context.Context, assetID, dateTimeString string, imag
 	}
 
 	// Override qualityParam based on global config if it is set to optimized
-	if imageQuality != ImageQualityThumb && config.Current.Mediux.DownloadQuality == "optimized" {
+	if imageQuality != ImageQualityThumb && config.Current(ctx).Mediux.DownloadQuality == "optimized" {
 		qualityParam = "jpg"
 	}
 
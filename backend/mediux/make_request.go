@@ -5,9 +5,12 @@ import (
 	"aura/logging"
 	"aura/utils/httpx"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -49,14 +52,51 @@ type MediuxGraphQLQueryBody struct {
 	QueryName string         `json:"query_name,omitempty"`
 }
 
+// graphQLAttemptTimeout bounds each attempt of a GraphQL request.
+// With the retries of the "MediUX GraphQL" policy, a request gives up after about 2 minutes.
+const graphQLAttemptTimeout = 30 * time.Second
+
 func makeGraphQLRequest(ctx context.Context, queryBody MediuxGraphQLQueryBody) (resp *resty.Response, Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Send GraphQL Request to MediUX", logging.LevelTrace)
 	defer logAction.Complete()
 
-	resp, err := mediuxRestyClient.R().
-		SetHeader("Authorization", fmt.Sprintf("Bearer %s", config.Current.Mediux.ApiToken)).
-		SetBody(queryBody).
-		Post(fmt.Sprintf("%s/graphql", MediuxApiURL))
+	graphQLURL := fmt.Sprintf("%s/graphql", MediuxApiURL)
+	host := ""
+	if parsedURL, err := url.Parse(graphQLURL); err == nil {
+		host = parsedURL.Host
+	}
+	traceparent := logging.TraceParent(ctx)
+	token := config.Current(ctx).Mediux.ApiToken
+
+	attempts, err := httpx.WithRetries(ctx, "MediUX GraphQL", http.MethodPost, host, func(ctx context.Context) (int, http.Header, error) {
+		attemptCtx, cancel := context.WithTimeout(ctx, graphQLAttemptTimeout)
+		defer cancel()
+
+		req := mediuxRestyClient.R().
+			SetContext(attemptCtx).
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+			SetBody(queryBody)
+		if traceparent != "" {
+			req.SetHeader("traceparent", traceparent)
+		}
+		var err error
+		resp, err = req.Post(graphQLURL)
+		if err != nil {
+			return 0, nil, err
+		}
+		return resp.StatusCode(), resp.Header(), nil
+	})
+	if attempts > 1 {
+		logAction.AppendResult("attempts", attempts)
+	}
+	if circuitErr := (*httpx.CircuitOpenError)(nil); errors.As(err, &circuitErr) {
+		logAction.SetError("Requests to MediUX are paused", fmt.Sprintf("MediUX failed repeatedly, requests are sent again after %s", circuitErr.OpenUntil.Format(time.TimeOnly)),
+			map[string]any{
+				"last_error": circuitErr.LastError,
+				"query_name": queryBody.QueryName,
+			})
+		return nil, *logAction.Error
+	}
 	if err != nil {
 		logAction.SetError("Failed to send GraphQL request to MediUX", "Ensure the MediUX API is reachable and the token is valid.",
 			map[string]any{
@@ -81,7 +121,7 @@ func makeGraphQLRequest(ctx context.Context, queryBody MediuxGraphQLQueryBody) (
 
 func AddMediuxAuthHeader(url string, token string, isImageRequest bool, headers map[string]string) map[string]string {
 	if token == "" {
-		token = config.Current.Mediux.ApiToken
+		token = config.Latest().Mediux.ApiToken
 	}
 	if strings.HasPrefix(url, MediuxApiURL) || strings.HasPrefix(url, "https://api.mediux.io/") {
 		headers["Authorization"] = fmt.Sprintf("Bearer %s", token)
@@ -0,0 +1,71 @@
+package mediux
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+	"time"
+)
+
+const SourceName = "mediux"
+
+// MediUX is the image source for sets hosted on MediUX
+type MediUX struct{}
+
+func (m *MediUX) GetItemSets(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string, filter models.ImageFilter) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	switch itemType {
+	case "show":
+		// For Shows, we get just Show Sets
+		return GetShowItemSets(ctx, tmdbID, itemLibraryTitle, edition)
+	case "movie":
+		// For Movies, we get Movie Sets and Movie Collection Sets
+		includedItems = map[string]models.IncludedItem{}
+		movieSets, Err := GetMovieItemSets(ctx, tmdbID, itemLibraryTitle, edition, &includedItems)
+		if Err.Message != "" {
+			return sets, includedItems, Err
+		}
+		collectionSets, Err := GetMovieItemCollectionSets(ctx, tmdbID, itemLibraryTitle, edition, &includedItems)
+		if Err.Message != "" {
+			return sets, includedItems, Err
+		}
+		return append(movieSets, collectionSets...), includedItems, Err
+	default:
+		_, logAction := logging.AddSubActionToContext(ctx, "MediUX: Get Item Sets", logging.LevelTrace)
+		defer logAction.Complete()
+		logAction.SetError("Invalid Item Type", "Item type must be 'movie' or 'show'", map[string]any{"item_type": itemType})
+		return sets, includedItems, *logAction.Error
+	}
+}
+
+func (m *MediUX) GetSetByID(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	switch setType {
+	case "show":
+		return GetShowSetByID(ctx, setID, itemLibraryTitle, edition)
+	case "movie":
+		return GetMovieSetByID(ctx, setID, itemLibraryTitle, edition)
+	case "collection":
+		return GetMovieCollectionSetByID(ctx, setID, itemTMDB_ID, itemLibraryTitle, edition, true)
+	default:
+		_, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("MediUX: Get Set By ID '%s'", setID), logging.LevelTrace)
+		defer logAction.Complete()
+		logAction.SetError("Invalid Poster Set Type", "Poster Set type must be either 'show', 'movie' or 'collection'", map[string]any{"set_type": setType})
+		return set, includedItems, *logAction.Error
+	}
+}
+
+func (m *MediUX) GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	return GetImage(ctx, imageFile.ID, imageFile.Modified.Format("20060102150405"), ImageQualityOriginal)
+}
+
+func (m *MediUX) GetImageURL(ctx context.Context, imageFile models.ImageFile) (imageURL string, Err logging.LogErrorInfo) {
+	return ConstructImageUrl(ctx, imageFile.ID, imageFile.Modified.String(), ImageQualityOriginal)
+}
+
+func (m *MediUX) GetSetUpdatedAt(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (updatedAt time.Time, Err logging.LogErrorInfo) {
+	set, _, Err := m.GetSetByID(ctx, setID, setType, itemTMDB_ID, itemLibraryTitle, edition)
+	if Err.Message != "" {
+		return updatedAt, Err
+	}
+	return set.DateUpdated, Err
+}
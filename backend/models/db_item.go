@@ -19,6 +19,50 @@ type DBPosterSetDetail struct {
 	ToDelete                  bool          `json:"to_delete"` // Flag to indicate if the poster set should be deleted (Not used in DB)
 }
 
+// DBSavedCollection is a media server collection with the collection set that was applied to it
+// Collections are saved separately from media items, since a collection has no TMDB ID of its own on Plex
+type DBSavedCollection struct {
+	CollectionItem CollectionItem          `json:"collection_item"`
+	PosterSet      PosterSet               `json:"poster_set"`
+	SelectedTypes  CollectionSelectedTypes `json:"selected_types"`
+	AutoDownload   bool                    `json:"auto_download"`
+	LastDownloaded time.Time               `json:"last_downloaded"`
+}
+
+// DBImageFingerprint is the fingerprint of an image AURA applied to a media item
+// It is compared to the current image on the media server to find images that were changed outside of AURA
+type DBImageFingerprint struct {
+	TMDB_ID        string    `json:"tmdb_id"`
+	LibraryTitle   string    `json:"library_title"`
+	Edition        string    `json:"edition,omitempty"`
+	Slot           string    `json:"slot"` // Which image of the item (e.g. "poster" or "titlecard_S01E02")
+	ImageFile      ImageFile `json:"image_file"`
+	SetID          string    `json:"set_id,omitempty"`
+	Hash           string    `json:"hash"`
+	PerceptualHash string    `json:"perceptual_hash,omitempty"`
+	AppliedAt      time.Time `json:"applied_at"`
+	LastCheckedAt  time.Time `json:"last_checked_at,omitzero"`
+	Drifted        bool      `json:"drifted"`
+}
+
+// DBWantedItem is a movie or show that is not in the library yet, with the set to apply once it lands
+type DBWantedItem struct {
+	TMDB_ID       string        `json:"tmdb_id"`
+	Type          string        `json:"type"` // "movie" or "show"
+	Title         string        `json:"title,omitempty"`
+	Year          int           `json:"year,omitempty"`
+	LibraryTitle  string        `json:"library_title"` // Library the item is expected to land in
+	Set           BaseSetInfo   `json:"set"`
+	SelectedTypes SelectedTypes `json:"selected_types"`
+	AutoDownload  bool          `json:"auto_download"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type CollectionSelectedTypes struct {
+	Poster   bool `json:"poster"`
+	Backdrop bool `json:"backdrop"`
+}
+
 type PosterSet struct {
 	BaseSetInfo
 	Images []ImageFile `json:"images"`
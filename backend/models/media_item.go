@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type MediaItem struct {
 	TMDB_ID      string           `json:"tmdb_id"`          // TMDB ID of the media item
 	LibraryTitle string           `json:"library_title"`    // Title of the library/section the item belongs to
@@ -11,10 +13,11 @@ type MediaItem struct {
 	Series       *MediaItemSeries `json:"series,omitempty"` // Present if Type is "show"; Contains seasons and episodes info
 
 	// Used in MediaItem Details Page - For poster sets
-	DBSavedSets []DBSavedSet `json:"db_saved_sets"` // Poster sets saved in the database for this item
-	IgnoredInDB bool         `json:"ignored_in_db"` // Whether the item is marked as ignored
-	IgnoredMode string       `json:"ignored_mode"`  // Mode of ignoring (e.g., "always", "until-set-available", "until-new-set-available")
-	IgnoredSets []string     `json:"ignored_sets"`  // List of set IDs that were present when the item was ignored (used for "until-new-set-available" mode)
+	DBSavedSets  []DBSavedSet `json:"db_saved_sets"`           // Poster sets saved in the database for this item
+	IgnoredInDB  bool         `json:"ignored_in_db"`           // Whether the item is marked as ignored
+	IgnoredMode  string       `json:"ignored_mode"`            // Mode of ignoring (e.g., "always", "until-set-available", "until-new-set-available")
+	IgnoredSets  []string     `json:"ignored_sets"`            // List of set IDs that were present when the item was ignored (used for "until-new-set-available" mode)
+	IgnoredUntil *time.Time   `json:"ignored_until,omitempty"` // When the ignore expires (used for "until-date" mode)
 
 	// Used in Home Page - sorting and filtering
 	HasMediuxSets        bool  `json:"has_mediux_sets"` // Whether the item has MediUX sets
@@ -73,6 +76,7 @@ type MediaItemFile struct {
 
 type DBSavedSet struct {
 	ID            string        `json:"id"`
+	Source        string        `json:"source"`
 	UserCreated   string        `json:"user_created"`
 	SelectedTypes SelectedTypes `json:"selected_types"`
 }
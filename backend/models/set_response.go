@@ -27,6 +27,7 @@ type BaseSetInfo struct {
 	DateUpdated      time.Time `json:"date_updated"`      // Last updated date
 	Popularity       int       `json:"popularity"`        // Popularity score of the set
 	PopularityGlobal int       `json:"popularity_global"` // Global popularity score of the set
+	Source           string    `json:"source,omitempty"`  // Image source the set came from (mediux, tmdb, local). Empty means mediux
 }
 
 type ImageFile struct {
@@ -41,7 +42,7 @@ type ImageFile struct {
 	Title         string    `json:"title,omitempty"`          // Present for Titlecards
 	SeasonNumber  *int      `json:"season_number,omitempty"`  // Present for Season Posters and Titlecards
 	EpisodeNumber *int      `json:"episode_number,omitempty"` // Present for Titlecards
-
+	Source        string    `json:"source,omitempty"`         // Image source the file came from (mediux, tmdb, local). Empty means mediux
 }
 
 type SetRef struct {
@@ -71,3 +72,8 @@ type CreatorSetsResponse struct {
 	Boxsets        []BoxsetRef             `json:"boxsets"`         // List of boxsets
 	IncludedItems  map[string]IncludedItem `json:"included_items"`  // Map of included items by TMDB ID
 }
+
+type ImageFilter struct {
+	Languages      []string `json:"languages"`        // ISO 639-1 codes. Use "none" for textless images. Empty means all languages
+	MinVoteAverage float64  `json:"min_vote_average"` // Minimum vote average (0-10), only used by sources that have votes
+}
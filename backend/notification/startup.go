@@ -15,29 +15,29 @@ func SendAppStartNotification(app_port int, app_name string, app_version string)
 	defer logAction.Complete()
 
 	// If notifications are disabled, skip
-	if !config.Current.Notifications.Enabled {
+	if !config.Current(ctx).Notifications.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping app start notification")
 		return
 	}
 
 	// If notification providers are not configured, skip
-	if len(config.Current.Notifications.Providers) == 0 {
+	if len(config.Current(ctx).Notifications.Providers) == 0 {
 		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping app start notification")
 		return
 	}
 
 	// If app startup notification is disabled, skip
-	if !config.Current.Notifications.NotificationTemplate.AppStartup.Enabled {
+	if !config.Current(ctx).Notifications.NotificationTemplate.AppStartup.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("App startup notification is disabled, skipping app start notification")
 		return
 	}
 
 	vars := utils.TemplateVars_AppStartup(app_name, app_version, app_port)
-	title := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.AppStartup.Title, vars)
-	startMessage := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.AppStartup.Message, vars)
+	title := utils.RenderTemplate(config.Current(ctx).Notifications.NotificationTemplate.AppStartup.Title, vars)
+	startMessage := utils.RenderTemplate(config.Current(ctx).Notifications.NotificationTemplate.AppStartup.Message, vars)
 	imageURL := ""
 
-	for _, provider := range config.Current.Notifications.Providers {
+	for _, provider := range config.Current(ctx).Notifications.Providers {
 		if provider.Enabled {
 			switch provider.Provider {
 			case "Discord":
@@ -0,0 +1,304 @@
+package restore
+
+import (
+	"aura/artworkhistory"
+	"aura/cache"
+	"aura/config"
+	"aura/database"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/models"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StartJob collects the saved sets in the scope and starts restoring their items in the background.
+// The job is saved before it starts, so it is resumed by ResumeJobs if AURA restarts before it finishes.
+func StartJob(ctx context.Context, scope Scope) (job Job, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Starting Restore Originals Job", logging.LevelInfo)
+	defer logAction.Complete()
+
+	scope.LibraryTitle = strings.TrimSpace(scope.LibraryTitle)
+	scope.Creator = strings.TrimSpace(scope.Creator)
+	scope.SetID = strings.TrimSpace(scope.SetID)
+	if scope.LibraryTitle == "" && scope.Creator == "" && scope.SetID == "" {
+		logAction.SetError("No scope given", "Provide a library title, creator or set ID to restore", nil)
+		return job, *logAction.Error
+	}
+
+	job = Job{
+		ID:        fmt.Sprintf("%d", time.Now().UnixNano()),
+		Scope:     scope,
+		Status:    JOB_PENDING,
+		CreatedAt: time.Now(),
+		Items:     []JobItem{},
+	}
+
+	Err = collectItems(ctx, &job)
+	if Err.Message != "" {
+		return job, Err
+	}
+	job.updateCounts()
+
+	Err = writeJob(ctx, job)
+	if Err.Message != "" {
+		return job, Err
+	}
+
+	logAction.AppendResult("job_id", job.ID)
+	logAction.AppendResult("items", job.Total)
+	go runJob(job.ID)
+	return job, logging.LogErrorInfo{}
+}
+
+// ResumeJobs restarts every job that was pending or running when AURA stopped
+func ResumeJobs(ctx context.Context) {
+	jobs, Err := GetAllJobs(ctx)
+	if Err.Message != "" {
+		return
+	}
+	// Oldest first, so the jobs continue in the order they were started
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+	for _, job := range jobs {
+		if job.Status != JOB_PENDING && job.Status != JOB_RUNNING {
+			continue
+		}
+		logging.LOGGER.Info().Timestamp().Str("job_id", job.ID).Int("pending", job.Pending).Msg("Resuming Restore Originals job")
+		go runJob(job.ID)
+	}
+}
+
+// collectItems adds every saved item in the scope, with the images of the matching sets that AURA applied
+func collectItems(ctx context.Context, job *Job) (Err logging.LogErrorInfo) {
+	filter := models.DBFilter{
+		SetID:        job.Scope.SetID,
+		ItemsPerPage: -1,
+	}
+	if job.Scope.LibraryTitle != "" {
+		filter.LibraryTitles = []string{job.Scope.LibraryTitle}
+	}
+	if job.Scope.Creator != "" {
+		filter.Usernames = []string{job.Scope.Creator}
+	}
+
+	out, Err := database.GetAllSavedSets(ctx, filter)
+	if Err.Message != "" {
+		return Err
+	}
+
+	for _, savedItem := range out.Items {
+		jobItem := JobItem{
+			TMDB_ID:      savedItem.MediaItem.TMDB_ID,
+			LibraryTitle: savedItem.MediaItem.LibraryTitle,
+			Edition:      savedItem.MediaItem.Edition,
+			Type:         savedItem.MediaItem.Type,
+			Title:        savedItem.MediaItem.Title,
+			Year:         savedItem.MediaItem.Year,
+			RatingKey:    savedItem.MediaItem.RatingKey,
+			SetIDs:       []string{},
+			Status:       ITEM_PENDING,
+			Images:       []ImageResult{},
+		}
+
+		slots := map[string]bool{}
+		for _, posterSet := range savedItem.PosterSets {
+			if !job.Scope.matchesSet(posterSet) {
+				continue
+			}
+			jobItem.SetIDs = append(jobItem.SetIDs, posterSet.ID)
+
+			for _, image := range posterSet.Images {
+				if image.ItemTMDB_ID != "" && !strings.EqualFold(image.ItemTMDB_ID, savedItem.MediaItem.TMDB_ID) {
+					continue
+				}
+				if !isSelected(posterSet.SelectedTypes, image) {
+					continue
+				}
+				slot := artworkhistory.SlotKey(image.Type, image.SeasonNumber, image.EpisodeNumber)
+				if slots[slot] {
+					continue
+				}
+				slots[slot] = true
+				jobItem.Images = append(jobItem.Images, ImageResult{
+					ImageType:     image.Type,
+					SeasonNumber:  image.SeasonNumber,
+					EpisodeNumber: image.EpisodeNumber,
+					Status:        ITEM_PENDING,
+				})
+			}
+		}
+		if len(jobItem.SetIDs) == 0 {
+			continue
+		}
+		job.Items = append(job.Items, jobItem)
+	}
+	return logging.LogErrorInfo{}
+}
+
+func (s Scope) matchesSet(posterSet models.DBPosterSetDetail) bool {
+	if s.SetID != "" && posterSet.ID != s.SetID {
+		return false
+	}
+	if s.Creator != "" && !strings.EqualFold(posterSet.UserCreated, s.Creator) {
+		return false
+	}
+	return true
+}
+
+func isSelected(selectedTypes models.SelectedTypes, image models.ImageFile) bool {
+	switch image.Type {
+	case "poster":
+		return selectedTypes.Poster
+	case "backdrop":
+		return selectedTypes.Backdrop
+	case "season_poster", "special_season_poster":
+		if image.SeasonNumber != nil && *image.SeasonNumber == 0 {
+			return selectedTypes.SpecialSeasonPoster
+		}
+		return selectedTypes.SeasonPoster
+	case "titlecard":
+		return selectedTypes.Titlecard
+	}
+	return false
+}
+
+// runJob restores every pending item of a job, saving the job after each item
+func runJob(jobID string) {
+	runMu.Lock()
+	defer runMu.Unlock()
+
+	ctx, ld := logging.CreateLoggingContext(config.WithSnapshot(context.Background(), config.Latest()), "Restore Originals")
+	logAction := ld.AddAction(fmt.Sprintf("Running Restore Originals Job '%s'", jobID), logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+	defer ld.Log()
+	defer logAction.Complete()
+
+	job, Err := GetJob(ctx, jobID)
+	if Err.Message != "" {
+		return
+	}
+
+	job.Status = JOB_RUNNING
+	if job.StartedAt == nil {
+		now := time.Now()
+		job.StartedAt = &now
+	}
+	// Without the job file the progress can't be followed or resumed, so don't start
+	Err = writeJob(ctx, job)
+	if Err.Message != "" {
+		return
+	}
+
+	for idx := range job.Items {
+		if job.Items[idx].Status != ITEM_PENDING {
+			continue
+		}
+		restoreItem(ctx, &job.Items[idx])
+		job.updateCounts()
+		if Err := writeJob(ctx, job); Err.Message != "" {
+			logAction.AppendWarning(fmt.Sprintf("save_progress_%d", idx), Err.Message)
+		}
+	}
+
+	job.Status = JOB_SUCCESS
+	if job.Error > 0 {
+		job.Status = JOB_WITH_ERRORS
+	}
+	now := time.Now()
+	job.FinishedAt = &now
+	if Err := writeJob(ctx, job); Err.Message != "" {
+		logAction.AppendWarning("save_result", Err.Message)
+	}
+
+	logAction.AppendResult("restored", job.Restored)
+	logAction.AppendResult("errors", job.Error)
+}
+
+// restoreItem restores the images of one item and removes its saved sets in the scope.
+// The saved sets are kept when an image fails, so the item is still managed and can be restored again.
+func restoreItem(ctx context.Context, jobItem *JobItem) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Restoring Original Artwork for %s (%d) [%s]", jobItem.Title, jobItem.Year, jobItem.LibraryTitle), logging.LevelInfo)
+	defer logAction.Complete()
+
+	cachedItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBIDAndEdition(jobItem.LibraryTitle, jobItem.TMDB_ID, jobItem.Edition)
+	if !found {
+		jobItem.Status = ITEM_ERROR
+		jobItem.Error = "Item was not found on the media server"
+		logAction.SetError(jobItem.Error, "Refresh the media server cache and restore the item again", map[string]any{
+			"tmdb_id":       jobItem.TMDB_ID,
+			"library_title": jobItem.LibraryTitle,
+		})
+		return
+	}
+	// Work on a copy so that fetching show details doesn't change the cached item
+	item := *cachedItem
+	// The restore refreshes the item, the event listeners must not re-apply the saved sets that are removed below
+	mediaserver.MarkRestoringArtwork(item.RatingKey)
+	defer mediaserver.MarkRestoringArtwork(item.RatingKey)
+
+	// On Emby/Jellyfin a media server restore refreshes every image of the item,
+	// so those run first and captured versions are applied over them afterwards
+	order := make([]int, 0, len(jobItem.Images))
+	for idx, image := range jobItem.Images {
+		if _, hasVersion := mediaserver.OriginalArtworkVersion(ctx, item, image.imageFile(item)); !hasVersion {
+			order = append(order, idx)
+		}
+	}
+	for idx := range jobItem.Images {
+		if !slices.Contains(order, idx) {
+			order = append(order, idx)
+		}
+	}
+
+	hasErrors := false
+	for _, idx := range order {
+		image := &jobItem.Images[idx]
+		if image.Status == ITEM_RESTORED {
+			continue
+		}
+		method, Err := mediaserver.RestoreOriginalArtwork(ctx, &item, image.imageFile(item))
+		image.Method = method
+		if Err.Message != "" {
+			image.Status = ITEM_ERROR
+			image.Error = Err.Message
+			hasErrors = true
+			continue
+		}
+		image.Status = ITEM_RESTORED
+		image.Error = ""
+	}
+
+	if hasErrors {
+		jobItem.Status = ITEM_ERROR
+		jobItem.Error = "Some images could not be restored, the saved sets were kept"
+		logAction.AppendWarning("message", jobItem.Error)
+		return
+	}
+
+	for _, setID := range jobItem.SetIDs {
+		Err := database.DeletePosterSetForMediaItem(ctx, jobItem.TMDB_ID, jobItem.LibraryTitle, jobItem.Edition, setID)
+		if Err.Message != "" {
+			jobItem.Status = ITEM_ERROR
+			jobItem.Error = fmt.Sprintf("Artwork was restored but saved set '%s' could not be removed", setID)
+			return
+		}
+	}
+
+	jobItem.Status = ITEM_RESTORED
+	jobItem.Error = ""
+	logAction.AppendResult("images", len(jobItem.Images))
+	logAction.AppendResult("removed_sets", jobItem.SetIDs)
+}
+
+func (r ImageResult) imageFile(item models.MediaItem) models.ImageFile {
+	return models.ImageFile{
+		Type:          r.ImageType,
+		ItemTMDB_ID:   item.TMDB_ID,
+		SeasonNumber:  r.SeasonNumber,
+		EpisodeNumber: r.EpisodeNumber,
+	}
+}
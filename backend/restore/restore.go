@@ -0,0 +1,210 @@
+package restore
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	JOB_PENDING     JobStatus = "Pending"
+	JOB_RUNNING     JobStatus = "Running"
+	JOB_SUCCESS     JobStatus = "Success"
+	JOB_WITH_ERRORS JobStatus = "Completed With Errors"
+)
+
+type ItemStatus string
+
+const (
+	ITEM_PENDING  ItemStatus = "Pending"
+	ITEM_RESTORED ItemStatus = "Restored"
+	ITEM_ERROR    ItemStatus = "Error"
+)
+
+// Scope limits a restore job to the saved sets of a library, a creator or a single set.
+// When more than one field is set, a saved set has to match all of them.
+type Scope struct {
+	LibraryTitle string `json:"library_title,omitempty"`
+	Creator      string `json:"creator,omitempty"`
+	SetID        string `json:"set_id,omitempty"`
+}
+
+// ImageResult is the outcome of restoring a single image of an item
+type ImageResult struct {
+	ImageType     string     `json:"image_type"`
+	SeasonNumber  *int       `json:"season_number,omitempty"`
+	EpisodeNumber *int       `json:"episode_number,omitempty"`
+	Status        ItemStatus `json:"status"`
+	Method        string     `json:"method,omitempty"` // artwork_history or media_server
+	Error         string     `json:"error,omitempty"`
+}
+
+// JobItem is a media item whose artwork is restored, with the saved sets that are removed afterwards
+type JobItem struct {
+	TMDB_ID      string        `json:"tmdb_id"`
+	LibraryTitle string        `json:"library_title"`
+	Edition      string        `json:"edition,omitempty"`
+	Type         string        `json:"type"`
+	Title        string        `json:"title"`
+	Year         int           `json:"year"`
+	RatingKey    string        `json:"rating_key"`
+	SetIDs       []string      `json:"set_ids"`
+	Status       ItemStatus    `json:"status"`
+	Error        string        `json:"error,omitempty"`
+	Images       []ImageResult `json:"images"`
+}
+
+// Job is a restore originals run. It is written to disk after every item so it can be resumed after a restart.
+type Job struct {
+	ID         string     `json:"id"`
+	Scope      Scope      `json:"scope"`
+	Status     JobStatus  `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Total      int        `json:"total"`
+	Pending    int        `json:"pending"`
+	Restored   int        `json:"restored"`
+	Error      int        `json:"error"`
+	Items      []JobItem  `json:"items"`
+}
+
+var (
+	// FolderPath is where the restore jobs are kept, one JSON file per job
+	FolderPath string
+
+	// Guards reading and writing the job files
+	jobsMu sync.Mutex
+
+	// Only one job runs at a time, others wait for it to finish
+	runMu sync.Mutex
+)
+
+func init() {
+	FolderPath = path.Join(config.ConfigPath, "restore-originals")
+}
+
+// GetJob returns a restore job with its full report
+func GetJob(ctx context.Context, jobID string) (job Job, Err logging.LogErrorInfo) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	return readJob(ctx, jobID)
+}
+
+// GetAllJobs returns every restore job, newest first, without the per item report
+func GetAllJobs(ctx context.Context) (jobs []Job, Err logging.LogErrorInfo) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+
+	jobs = []Job{}
+	entries, err := os.ReadDir(FolderPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return jobs, logging.LogErrorInfo{}
+	}
+	if err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Restore Originals: Reading jobs folder", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Failed to read the restore originals folder", "Ensure the config folder is readable", map[string]any{
+			"error": err.Error(),
+			"path":  FolderPath,
+		})
+		return jobs, *logAction.Error
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		job, Err := readJob(ctx, strings.TrimSuffix(entry.Name(), ".json"))
+		if Err.Message != "" {
+			continue
+		}
+		job.Items = nil
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs, logging.LogErrorInfo{}
+}
+
+// updateCounts recalculates the totals and the overall status from the items
+func (j *Job) updateCounts() {
+	j.Total = len(j.Items)
+	j.Pending, j.Restored, j.Error = 0, 0, 0
+	for _, item := range j.Items {
+		switch item.Status {
+		case ITEM_PENDING:
+			j.Pending++
+		case ITEM_RESTORED:
+			j.Restored++
+		case ITEM_ERROR:
+			j.Error++
+		}
+	}
+}
+
+func jobFilePath(jobID string) string {
+	return filepath.Join(FolderPath, filepath.Base(jobID)+".json")
+}
+
+func readJob(ctx context.Context, jobID string) (job Job, Err logging.LogErrorInfo) {
+	data, err := os.ReadFile(jobFilePath(jobID))
+	if err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Restore Originals: Reading job file", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Restore job not found", "Make sure the job ID is correct", map[string]any{
+			"error":  err.Error(),
+			"job_id": jobID,
+		})
+		return job, *logAction.Error
+	}
+	if err := json.Unmarshal(data, &job); err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Restore Originals: Parsing job file", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Failed to parse restore job", "The job file may be corrupted", map[string]any{
+			"error":  err.Error(),
+			"job_id": jobID,
+		})
+		return job, *logAction.Error
+	}
+	return job, logging.LogErrorInfo{}
+}
+
+func writeJob(ctx context.Context, job Job) (Err logging.LogErrorInfo) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+
+	Err = utils.CreateFolderIfNotExists(ctx, FolderPath)
+	if Err.Message != "" {
+		return Err
+	}
+
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Restore Originals: Encoding job file", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Failed to encode restore job", "Check the logs for more details", map[string]any{"error": err.Error()})
+		return *logAction.Error
+	}
+	if err := os.WriteFile(jobFilePath(job.ID), data, 0644); err != nil {
+		_, logAction := logging.AddSubActionToContext(ctx, "Restore Originals: Writing job file", logging.LevelDebug)
+		defer logAction.Complete()
+		logAction.SetError("Failed to write restore job", "Ensure the config folder is writable", map[string]any{
+			"error":  err.Error(),
+			"job_id": job.ID,
+		})
+		return *logAction.Error
+	}
+	return logging.LogErrorInfo{}
+}
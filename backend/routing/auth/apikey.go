@@ -50,7 +50,7 @@ func GenerateAPIKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newConfig := config.Current
+	newConfig := *config.Latest()
 	newConfig.Auth.APIKeyHash = hash
 
 	if saveErr := newConfig.Save(ctx); saveErr.Message != "" {
@@ -58,7 +58,7 @@ func GenerateAPIKey(w http.ResponseWriter, r *http.Request) {
 		httpx.SendResponse(w, ld, response)
 		return
 	}
-	config.Current = newConfig
+	config.Set(ctx, newConfig)
 
 	logAction.AppendResult("api_key_regenerated", true)
 
@@ -78,10 +78,10 @@ func generateAPIKeySecret() (string, error) {
 // VerifyAPIKey compares a plaintext API key against the configured hash. Always false if no
 // key has been generated yet.
 func VerifyAPIKey(key string) bool {
-	if key == "" || config.Current.Auth.APIKeyHash == "" {
+	if key == "" || config.Latest().Auth.APIKeyHash == "" {
 		return false
 	}
-	ok, err := argon2id.ComparePasswordAndHash(key, config.Current.Auth.APIKeyHash)
+	ok, err := argon2id.ComparePasswordAndHash(key, config.Latest().Auth.APIKeyHash)
 	if err != nil {
 		return false
 	}
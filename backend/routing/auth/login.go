@@ -35,7 +35,7 @@ func AttemptLogin(w http.ResponseWriter, r *http.Request) {
 	var req loginRequest
 	var response loginResponse
 
-	if !config.Current.Auth.Enabled {
+	if !config.Current(ctx).Auth.Enabled {
 		httpx.SendResponse(w, ld, "Authentication is disabled")
 		return
 	}
@@ -53,7 +53,7 @@ func AttemptLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Compare password
-	ok, err := argon2id.ComparePasswordAndHash(req.Password, config.Current.Auth.Password)
+	ok, err := argon2id.ComparePasswordAndHash(req.Password, config.Current(ctx).Auth.Password)
 	if err != nil || !ok {
 		logAction.SetError("Invalid credentials", "The provided password is incorrect", map[string]any{
 			"error": err,
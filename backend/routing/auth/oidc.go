@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"net/http"
 	"strings"
+	"sync/atomic"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"golang.org/x/oauth2"
@@ -17,22 +18,23 @@ import (
 const oidcStateCookieName = "aura_oidc_state"
 const oidcStateTTLSeconds = 5 * 60
 
-var (
-	oidcProvider   *oidc.Provider
-	oidcOAuth2Cfg  *oauth2.Config
-	oidcIDVerifier *oidc.IDTokenVerifier
-)
+// oidcClient holds everything needed for an OIDC login.
+// It is replaced as a whole when OIDC is initialized again, so a login never mixes two configs.
+type oidcClient struct {
+	oauth2Cfg  *oauth2.Config
+	idVerifier *oidc.IDTokenVerifier
+}
+
+var oidcCurrent atomic.Pointer[oidcClient]
 
 // InitOIDC performs OIDC discovery against the configured issuer and, on success, makes OIDC
 // login available. If discovery fails (unreachable/misconfigured IdP), OIDC is simply left
 // unavailable - the rest of the app must keep working, since a broken IdP shouldn't take down
 // the whole server.
 func InitOIDC(ctx context.Context) {
-	oidcProvider = nil
-	oidcOAuth2Cfg = nil
-	oidcIDVerifier = nil
+	oidcCurrent.Store(nil)
 
-	cfg := config.Current.Auth.OIDC
+	cfg := config.Current(ctx).Auth.OIDC
 	if !cfg.Enabled {
 		return
 	}
@@ -43,22 +45,23 @@ func InitOIDC(ctx context.Context) {
 		return
 	}
 
-	oidcProvider = provider
-	oidcOAuth2Cfg = &oauth2.Config{
-		ClientID:     cfg.ClientID,
-		ClientSecret: cfg.ClientSecret,
-		RedirectURL:  cfg.RedirectURL,
-		Endpoint:     provider.Endpoint(),
-		Scopes:       []string{oidc.ScopeOpenID, "profile", "email"},
-	}
-	oidcIDVerifier = provider.Verifier(&oidc.Config{ClientID: cfg.ClientID})
+	oidcCurrent.Store(&oidcClient{
+		oauth2Cfg: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "profile", "email"},
+		},
+		idVerifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+	})
 
 	logging.LOGGER.Info().Timestamp().Str("issuer_url", cfg.IssuerURL).Msg("OIDC initialized successfully")
 }
 
 // OIDCAvailable reports whether OIDC login is configured, enabled, and successfully initialized.
 func OIDCAvailable() bool {
-	return config.Current.Auth.OIDC.Enabled && oidcProvider != nil && oidcOAuth2Cfg != nil && oidcIDVerifier != nil
+	return config.Latest().Auth.OIDC.Enabled && oidcCurrent.Load() != nil
 }
 
 // OIDCLoginRedirect godoc
@@ -68,7 +71,8 @@ func OIDCAvailable() bool {
 // @Success      302
 // @Router       /api/auth/oidc/login [get]
 func OIDCLoginRedirect(w http.ResponseWriter, r *http.Request) {
-	if !OIDCAvailable() {
+	client := oidcCurrent.Load()
+	if !OIDCAvailable() || client == nil {
 		http.Redirect(w, r, "/login?error=oidc_unavailable", http.StatusFound)
 		return
 	}
@@ -89,7 +93,7 @@ func OIDCLoginRedirect(w http.ResponseWriter, r *http.Request) {
 		MaxAge:   oidcStateTTLSeconds,
 	})
 
-	http.Redirect(w, r, oidcOAuth2Cfg.AuthCodeURL(state), http.StatusFound)
+	http.Redirect(w, r, client.oauth2Cfg.AuthCodeURL(state), http.StatusFound)
 }
 
 // OIDCCallback godoc
@@ -106,7 +110,8 @@ func OIDCCallback(w http.ResponseWriter, r *http.Request) {
 
 	clearOIDCStateCookie(w, r)
 
-	if !OIDCAvailable() {
+	client := oidcCurrent.Load()
+	if !OIDCAvailable() || client == nil {
 		http.Redirect(w, r, "/login?error=oidc_unavailable", http.StatusFound)
 		return
 	}
@@ -125,7 +130,7 @@ func OIDCCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oauth2Token, err := oidcOAuth2Cfg.Exchange(ctx, code)
+	oauth2Token, err := client.oauth2Cfg.Exchange(ctx, code)
 	if err != nil {
 		logAction.SetError("OIDC code exchange failed", err.Error(), nil)
 		http.Redirect(w, r, "/login?error=oidc_failed", http.StatusFound)
@@ -139,7 +144,7 @@ func OIDCCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	idToken, err := oidcIDVerifier.Verify(ctx, rawIDToken)
+	idToken, err := client.idVerifier.Verify(ctx, rawIDToken)
 	if err != nil {
 		logAction.SetError("OIDC ID token verification failed", err.Error(), nil)
 		http.Redirect(w, r, "/login?error=oidc_failed", http.StatusFound)
@@ -176,7 +181,7 @@ func OIDCCallback(w http.ResponseWriter, r *http.Request) {
 // If both AllowedEmails and AllowedDomains are empty, any successfully authenticated IdP user
 // is allowed - this is surfaced as a loud warning in the Settings UI, not hidden here.
 func isOIDCIdentityAllowed(email string) bool {
-	cfg := config.Current.Auth.OIDC
+	cfg := config.Latest().Auth.OIDC
 	if len(cfg.AllowedEmails) == 0 && len(cfg.AllowedDomains) == 0 {
 		return true
 	}
@@ -240,7 +245,7 @@ type authMethodsResponse struct {
 func GetAuthMethods(w http.ResponseWriter, r *http.Request) {
 	_, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
 	httpx.SendResponse(w, ld, authMethodsResponse{
-		PasswordEnabled: config.Current.Auth.Enabled,
+		PasswordEnabled: config.Latest().Auth.Enabled,
 		OIDCEnabled:     OIDCAvailable(),
 	})
 }
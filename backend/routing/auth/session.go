@@ -4,6 +4,8 @@ import (
 	"aura/config"
 	"net/http"
 	"time"
+
+	"github.com/go-chi/jwtauth/v5"
 )
 
 // SessionCookieName is the name of the browser session cookie used for interactive login
@@ -53,12 +55,37 @@ func ClearSessionCookie(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// IsAuthenticated reports whether the request carries a valid API key or session cookie.
+// Every request counts as authenticated when Auth is disabled. Public routes use it to
+// decide how much they show, the Authenticator middleware guards the other routes.
+func IsAuthenticated(r *http.Request) bool {
+	if !config.Latest().Auth.Enabled {
+		return true
+	}
+	if apiKey := r.Header.Get("X-Api-Key"); apiKey != "" {
+		return VerifyAPIKey(apiKey)
+	}
+	if TokenAuth == nil {
+		return false
+	}
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil {
+		return false
+	}
+	token, err := jwtauth.VerifyToken(TokenAuth, cookie.Value)
+	if err != nil {
+		return false
+	}
+	sub, ok := token.Subject()
+	return ok && sub != ""
+}
+
 // resolveSecure decides whether a cookie should carry the Secure attribute for this request.
 // This app is very commonly self-hosted on a plain-HTTP LAN (http://192.168.x.x:PORT), where a
 // hardcoded Secure=true would make the browser silently discard the cookie and login would
 // appear to fail with no clear error. Default ("auto") reflects the actual inbound transport.
 func resolveSecure(r *http.Request) bool {
-	switch config.Current.Auth.SessionCookieSecure {
+	switch config.Latest().Auth.SessionCookieSecure {
 	case "always":
 		return true
 	case "never":
@@ -68,7 +95,7 @@ func resolveSecure(r *http.Request) bool {
 	if r.TLS != nil {
 		return true
 	}
-	if config.Current.Auth.TrustProxyForCookieSecure && r.Header.Get("X-Forwarded-Proto") == "https" {
+	if config.Latest().Auth.TrustProxyForCookieSecure && r.Header.Get("X-Forwarded-Proto") == "https" {
 		return true
 	}
 	return false
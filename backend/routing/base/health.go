@@ -2,8 +2,11 @@ package routes_base
 
 import (
 	"aura/config"
+	"aura/health"
 	"aura/logging"
+	routes_auth "aura/routing/auth"
 	"aura/utils/httpx"
+	"encoding/json"
 	"net/http"
 )
 
@@ -29,3 +32,61 @@ func HealthCheck(w http.ResponseWriter, r *http.Request) {
 	response.AppVersion = config.AppVersion
 	httpx.SendResponse(w, ld, response)
 }
+
+// LivenessCheck godoc
+// @Summary      Health Check - Liveness
+// @Description  Check that the application is running and able to answer requests. It does not check any dependency, use /api/health/ready for that.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  httpx.JSONResponse{data=routes_base.healthCheckResponse}
+// @Router       /api/health/live [get]
+func LivenessCheck(w http.ResponseWriter, r *http.Request) {
+	_, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	ld.Status = logging.StatusSuccess
+
+	var response healthCheckResponse
+	response.Status = "ok"
+	response.AppVersion = config.AppVersion
+	httpx.SendResponse(w, ld, response)
+}
+
+// ReadinessCheck godoc
+// @Summary      Health Check - Readiness
+// @Description  Check every dependency (database, media server, MediUX, Sonarr/Radarr, event listener, cache warmup and download queue) and report their status and latency. The overall status is degraded when an optional check fails and unhealthy when a critical check fails (see Health.Criticality). Results are reused for 15 seconds. When Auth is enabled, callers that are not logged in only get the overall status and the status of each check (health.PublicReport).
+// @Tags         Health
+// @Produce      json
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Success      200  {object}  httpx.JSONResponse{data=health.Report} "Healthy or degraded"
+// @Failure      503  {object}  httpx.JSONResponse{data=health.Report} "Unhealthy"
+// @Router       /api/health/ready [get]
+func ReadinessCheck(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Health Check - Readiness", logging.LevelTrace)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	report := health.Ready(ctx)
+	logAction.Complete()
+
+	// Orchestrators only look at the status code, so unhealthy is sent as 503 instead of the usual 500
+	statusCode := http.StatusOK
+	switch report.Status {
+	case health.STATUS_UNHEALTHY:
+		ld.Status = logging.StatusError
+		statusCode = http.StatusServiceUnavailable
+	case health.STATUS_DEGRADED:
+		ld.Status = logging.StatusWarn
+	default:
+		ld.Status = logging.StatusSuccess
+	}
+
+	// The route is public, so only logged in callers see which apps, servers and hosts are checked
+	var data any = report
+	if !routes_auth.IsAuthenticated(r) {
+		data = report.Public()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(httpx.JSONResponse{Status: ld.Status, Data: data})
+}
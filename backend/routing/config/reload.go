@@ -4,6 +4,7 @@ import (
 	"aura/config"
 	"aura/logging"
 	"aura/utils/httpx"
+	"context"
 	"net/http"
 )
 
@@ -28,23 +29,28 @@ func ReloadAppConfig(w http.ResponseWriter, r *http.Request) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	var response reloadConfigResponse
 
+	response.Status = reloadConfig(ctx)
+	httpx.SendResponse(w, ld, response)
+}
+
+// reloadConfig reloads the config file and returns the new config status
+func reloadConfig(ctx context.Context) AppConfigStatus {
 	// Reload the config file
 	config.LoadYAML(ctx)
 
 	// Print the config details (sanitized)
-	config.Current.PrintDetails()
+	config.Current(ctx).PrintDetails()
 
 	// Sanitize the config before sending it back
-	sanitizedConfig := config.Current.SanitizeConfig(ctx)
+	sanitizedConfig := config.Current(ctx).SanitizeConfig(ctx)
 
-	response.Status = AppConfigStatus{
-		ConfigLoaded:     config.Loaded,
-		ConfigValid:      (config.Valid && config.MediuxValid && config.MediaServerValid),
-		NeedsSetup:       !(config.Loaded && config.Valid && config.MediuxValid && config.MediaServerValid),
+	return AppConfigStatus{
+		ConfigLoaded:     config.Latest().Status.Loaded,
+		ConfigValid:      config.Latest().Status.Valid && config.Latest().Status.MediuxValid && config.Latest().Status.MediaServerValid,
+		NeedsSetup:       !config.Latest().Status.Ready(),
 		CurrentSetup:     *sanitizedConfig,
-		MediaServerName:  config.MediaServerName,
-		APIKeyConfigured: config.Current.Auth.APIKeyHash != "",
+		MediaServerName:  config.Latest().Status.MediaServerName,
+		APIKeyConfigured: config.Current(ctx).Auth.APIKeyHash != "",
+		ConfigSources:    config.ConfigSources(),
 	}
-
-	httpx.SendResponse(w, ld, response)
 }
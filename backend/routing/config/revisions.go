@@ -0,0 +1,134 @@
+package routes_config
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+type getConfigRevisionsResponse struct {
+	Revisions []config.ConfigRevision `json:"revisions"`
+}
+
+type getConfigRevisionDiffResponse struct {
+	From    string                `json:"from"`
+	To      string                `json:"to,omitempty"`
+	Changes []config.ConfigChange `json:"changes"`
+}
+
+type rollbackConfigRevisionRequest struct {
+	ID string `json:"id"`
+}
+
+type rollbackConfigRevisionResponse struct {
+	Message string          `json:"message"`
+	Status  AppConfigStatus `json:"status"`
+}
+
+// GetConfigRevisions godoc
+// @Summary      Get Config Revisions
+// @Description  Get every saved version of config.yaml, newest first. Each revision lists the changes from the revision before it, with secrets masked.
+// @Tags         Config
+// @Produce      json
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=getConfigRevisionsResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/config/revisions [get]
+func GetConfigRevisions(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get Config Revisions", logging.LevelDebug)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response getConfigRevisionsResponse
+	revisions, Err := config.ListRevisions(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Revisions = revisions
+	httpx.SendResponse(w, ld, response)
+}
+
+// GetConfigRevisionDiff godoc
+// @Summary      Compare Config Revisions
+// @Description  Get the changes between two config revisions, with secrets masked. If 'to' is not set, the latest revision is used.
+// @Tags         Config
+// @Produce      json
+// @Param        from  query     string  true   "ID of the older revision"
+// @Param        to    query     string  false  "ID of the newer revision"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=getConfigRevisionDiffResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/config/revisions/diff [get]
+func GetConfigRevisionDiff(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Compare Config Revisions", logging.LevelDebug)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response getConfigRevisionDiffResponse
+	response.From = r.URL.Query().Get("from")
+	response.To = r.URL.Query().Get("to")
+	if response.From == "" {
+		logAction.SetError("Missing query parameter", "from is required", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	changes, Err := config.DiffRevisions(ctx, response.From, response.To)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Changes = changes
+	httpx.SendResponse(w, ld, response)
+}
+
+// RollbackConfigRevision godoc
+// @Summary      Roll Back Config
+// @Description  Restore config.yaml to a saved revision. The revision is validated first and the current config is kept if it is not valid. The config is then reloaded the same way as 'Reload Config'. The rollback is saved as a new revision.
+// @Tags         Config
+// @Accept       json
+// @Produce      json
+// @Param        req  body      rollbackConfigRevisionRequest  true  "Revision to restore"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=rollbackConfigRevisionResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/config/revisions/rollback [post]
+func RollbackConfigRevision(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Roll Back Config", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var req rollbackConfigRevisionRequest
+	var response rollbackConfigRevisionResponse
+
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Roll Back Config - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if req.ID == "" {
+		logAction.SetError("Missing revision ID", "id is required", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	Err = config.RollbackToRevision(ctx, req.ID)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Status = reloadConfig(ctx)
+	response.Message = "Config rolled back to revision " + req.ID
+	httpx.SendResponse(w, ld, response)
+}
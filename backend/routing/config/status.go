@@ -20,6 +20,10 @@ type AppConfigStatus struct {
 	AppVersion       string        `json:"app_version"`                 // Current version of the app
 	AppLoadingStep   string        `json:"app_loading_step"`            // Current loading step of the app
 	APIKeyConfigured bool          `json:"api_key_configured"`          // Whether a global API key has been generated (the key itself is never exposed)
+
+	// ConfigSources lists the config values set by environment variables or _FILE secrets, keyed by their JSON path (e.g. "media_server.api_token").
+	// Values that are not listed come from config.yaml.
+	ConfigSources map[string]config.ConfigSource `json:"config_sources,omitempty"`
 }
 
 type configStatusResponse struct {
@@ -42,19 +46,20 @@ func GetAppConfigStatus(w http.ResponseWriter, r *http.Request) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	var response configStatusResponse
 
-	currentConfig := config.Current // Make a local copy of the current config for sanitization
+	currentConfig := config.Current(ctx) // Sanitizing makes a copy, the snapshot itself is never changed
 
 	response.Status = AppConfigStatus{
-		ConfigLoaded:     config.Loaded,
-		ConfigValid:      (config.Valid && config.MediuxValid && config.MediaServerValid),
-		NeedsSetup:       !(config.Loaded && config.Valid && config.MediuxValid && config.MediaServerValid),
+		ConfigLoaded:     config.Latest().Status.Loaded,
+		ConfigValid:      config.Latest().Status.Valid && config.Latest().Status.MediuxValid && config.Latest().Status.MediaServerValid,
+		NeedsSetup:       !config.Latest().Status.Ready(),
 		CurrentSetup:     *currentConfig.SanitizeConfig(ctx),
-		MediaServerName:  config.MediaServerName,
+		MediaServerName:  config.Latest().Status.MediaServerName,
 		MediuxSiteLink:   mediux.MediuxSiteLink,
-		AppFullyLoaded:   config.AppFullyLoaded,
+		AppFullyLoaded:   config.AppFullyLoaded.Load(),
 		AppVersion:       config.AppVersion,
-		AppLoadingStep:   config.AppLoadingStep,
-		APIKeyConfigured: config.Current.Auth.APIKeyHash != "",
+		AppLoadingStep:   config.AppLoadingStep.Load(),
+		APIKeyConfigured: config.Current(ctx).Auth.APIKeyHash != "",
+		ConfigSources:    config.ConfigSources(),
 	}
 	httpx.SendResponse(w, ld, response)
 }
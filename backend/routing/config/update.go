@@ -2,8 +2,6 @@ package routes_config
 
 import (
 	"aura/config"
-	autodownload "aura/download/auto"
-	"aura/jobs"
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/mediux"
@@ -15,6 +13,7 @@ import (
 	"maps"
 	"net/http"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -57,19 +56,34 @@ func UpdateAppConfig(w http.ResponseWriter, r *http.Request) {
 	}
 	newConfig := req.Config
 
-	authChanged, authValid := checkConfigDifferences_Auth(ctx, config.Current.Auth, &newConfig.Auth)
-	loggingChanged, loggingValid := checkConfigDifferences_Logging(ctx, config.Current.Logging, &newConfig.Logging)
-	mediaServerChanged, mediaServerValid, newMediaServerName := checkConfigDifferences_MediaServer(ctx, config.Current.MediaServer, &newConfig.MediaServer)
-	mediuxChanged, mediuxValid := checkConfigDifferences_Mediux(ctx, config.Current.Mediux, &newConfig.Mediux)
-	autoDownloadChanged, autoDownloadValid := checkConfigDifferences_Autodownload(ctx, config.Current.AutoDownload, &newConfig.AutoDownload)
-	imagesChanged, imagesValid := checkConfigDifferences_Images(ctx, config.Current.Images, &newConfig.Images, newConfig.MediaServer)
-	tmdbChanged, tmdbValid := checkConfigDifferences_TMDB(ctx, config.Current.TMDB, &newConfig.TMDB)
-	labelsAndTagsChanged, labelsAndTagsValid := checkConfigDifferences_LabelsAndTags(ctx, config.Current.LabelsAndTags, &newConfig.LabelsAndTags)
-	notificationsChanged, notificationsValid := checkConfigDifferences_Notifications(ctx, config.Current.Notifications, &newConfig.Notifications)
-	sonarrRadarrChanged, sonarrRadarrValid := checkConfigDifferences_SonarrRadarr(ctx, config.Current.SonarrRadarr, &newConfig.SonarrRadarr, newConfig.MediaServer)
-	databaseChanged, databaseValid := checkConfigDifferences_Database(ctx, config.Current.Database, &newConfig.Database)
-
-	if !authValid || !loggingValid || !mediaServerValid || !mediuxValid || !autoDownloadValid || !imagesValid || !tmdbValid || !labelsAndTagsValid || !notificationsValid || !sonarrRadarrValid || !databaseValid {
+	// Overrides in lists are matched by index, so entries of those lists can't be added, removed or moved here
+	if jsonPath, changed := config.EnvOverriddenListChanged(config.Current(ctx), &newConfig); changed {
+		ld.Status = logging.StatusError
+		logAction.SetError("List is set by environment variables",
+			"Entries of a list that environment variables set values in or add entries to can't be added, removed or moved. Change the environment variables instead.",
+			map[string]any{"key": jsonPath})
+		response.Message = "Entries of a list set by environment variables can't be added, removed or moved"
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	// Environment variables and _FILE secrets always win over values sent from the UI
+	config.ApplyEnvOverrides(ctx, &newConfig)
+
+	authChanged, authValid := checkConfigDifferences_Auth(ctx, config.Current(ctx).Auth, &newConfig.Auth)
+	loggingChanged, loggingValid := checkConfigDifferences_Logging(ctx, config.Current(ctx).Logging, &newConfig.Logging)
+	mediaServerChanged, mediaServerValid, newMediaServerName := checkConfigDifferences_MediaServer(ctx, config.Current(ctx).MediaServer, &newConfig.MediaServer)
+	mediuxChanged, mediuxValid := checkConfigDifferences_Mediux(ctx, config.Current(ctx).Mediux, &newConfig.Mediux)
+	autoDownloadChanged, autoDownloadValid := checkConfigDifferences_Autodownload(ctx, config.Current(ctx).AutoDownload, &newConfig.AutoDownload)
+	imagesChanged, imagesValid := checkConfigDifferences_Images(ctx, config.Current(ctx).Images, &newConfig.Images, newConfig.MediaServer)
+	tmdbChanged, tmdbValid := checkConfigDifferences_TMDB(ctx, config.Current(ctx).TMDB, &newConfig.TMDB)
+	labelsAndTagsChanged, labelsAndTagsValid := checkConfigDifferences_LabelsAndTags(ctx, config.Current(ctx).LabelsAndTags, &newConfig.LabelsAndTags)
+	notificationsChanged, notificationsValid := checkConfigDifferences_Notifications(ctx, config.Current(ctx).Notifications, &newConfig.Notifications)
+	sonarrRadarrChanged, sonarrRadarrValid := checkConfigDifferences_SonarrRadarr(ctx, config.Current(ctx).SonarrRadarr, &newConfig.SonarrRadarr, newConfig.MediaServer)
+	databaseChanged, databaseValid := checkConfigDifferences_Database(ctx, config.Current(ctx).Database, &newConfig.Database)
+	healthChanged, healthValid := checkConfigDifferences_Health(ctx, config.Current(ctx).Health, &newConfig.Health)
+
+	if !authValid || !loggingValid || !mediaServerValid || !mediuxValid || !autoDownloadValid || !imagesValid || !tmdbValid || !labelsAndTagsValid || !notificationsValid || !sonarrRadarrValid || !databaseValid || !healthValid {
 		ld.Status = logging.StatusError
 		logAction.SetError("Invalid configuration", "The provided configuration is invalid. Check the results for details.", map[string]any{
 			"auth_valid":            authValid,
@@ -83,6 +97,7 @@ func UpdateAppConfig(w http.ResponseWriter, r *http.Request) {
 			"notifications_valid":   notificationsValid,
 			"sonarr_radarr_valid":   sonarrRadarrValid,
 			"database_valid":        databaseValid,
+			"health_valid":          healthValid,
 		})
 		response.Message = "Invalid configuration. Check the results for details."
 		httpx.SendResponse(w, ld, response)
@@ -91,24 +106,27 @@ func UpdateAppConfig(w http.ResponseWriter, r *http.Request) {
 
 	if !authChanged && !loggingChanged && !mediaServerChanged && !mediuxChanged &&
 		!autoDownloadChanged && !imagesChanged && !tmdbChanged && !labelsAndTagsChanged &&
-		!notificationsChanged && !sonarrRadarrChanged && !databaseChanged {
+		!notificationsChanged && !sonarrRadarrChanged && !databaseChanged && !healthChanged {
 		// If nothing has changed AND the config is valid, log a warning
-		if config.Valid {
+		if config.Latest().Status.Valid {
 			ld.Status = logging.StatusWarn
 			response.Message = "No changes detected in configuration"
 			logging.LOGGER.Warn().Timestamp().Msg(response.Message)
 			httpx.SendResponse(w, ld, response)
 			return
-		} else if !config.Valid {
+		} else {
 			// If nothing has changed AND the config is invalid, re-validate
 			newConfig.Validate(ctx)
-			if !config.Valid {
+			if !newConfig.Status.Valid {
 				ld.Status = logging.StatusError
 				response.Message = "Configuration is still invalid after update attempt"
 				logging.LOGGER.Error().Timestamp().Msg(response.Message)
 				httpx.SendResponse(w, ld, response)
 				return
 			} else {
+				config.Update(ctx, func(cfg *config.Config) {
+					cfg.Status.Valid = true
+				})
 				ld.Status = logging.StatusSuccess
 				response.Message = "Configuration has been validated successfully"
 				logging.LOGGER.Info().Timestamp().Msg(response.Message)
@@ -125,29 +143,25 @@ func UpdateAppConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update the global config variable
-	config.Current = newConfig
-	config.Loaded = true
-	config.Valid = true
-	config.MediaServerValid = true
-	config.MediaServerName = newMediaServerName
-	config.MediuxValid = true
-
-	if autoDownloadChanged {
-		jobs.StartAutoDownloadJob()
-	}
-
-	if mediaServerChanged {
-		autodownload.StartOrRestartPlexWebSocketClient()
+	// Swap in the new config together with its status.
+	// The change subscribers restart the jobs and listeners that use the changed settings.
+	newConfig.Status = config.Status{
+		Loaded:           true,
+		Valid:            true,
+		MediaServerValid: true,
+		MediaServerName:  newMediaServerName,
+		MediuxValid:      true,
 	}
+	config.Set(ctx, newConfig)
 
 	response.Status = AppConfigStatus{
-		ConfigLoaded:     config.Loaded,
-		ConfigValid:      (config.Valid && config.MediuxValid && config.MediaServerValid),
-		NeedsSetup:       !(config.Loaded && config.Valid && config.MediuxValid && config.MediaServerValid),
+		ConfigLoaded:     newConfig.Status.Loaded,
+		ConfigValid:      newConfig.Status.Ready(),
+		NeedsSetup:       !newConfig.Status.Ready(),
 		CurrentSetup:     *newConfig.SanitizeConfig(ctx),
-		MediaServerName:  config.MediaServerName,
-		APIKeyConfigured: config.Current.Auth.APIKeyHash != "",
+		MediaServerName:  newConfig.Status.MediaServerName,
+		APIKeyConfigured: config.Current(ctx).Auth.APIKeyHash != "",
+		ConfigSources:    config.ConfigSources(),
 	}
 
 	httpx.SendResponse(w, ld, response)
@@ -230,6 +244,14 @@ func checkConfigDifferences_Logging(ctx context.Context, oldLogging config.Confi
 	defer logAction.Complete()
 	changed = false
 	newValid = false
+
+	// Tracing headers are masked on the way out (MaskToken, "***" prefix) - preserve the old values
+	for key, value := range newLogging.Tracing.Headers {
+		if oldValue, found := oldLogging.Tracing.Headers[key]; found && strings.HasPrefix(value, "***") {
+			newLogging.Tracing.Headers[key] = oldValue
+		}
+	}
+
 	if !reflect.DeepEqual(oldLogging, newLogging) {
 		if oldLogging.Level != newLogging.Level {
 			logAction.AppendResult("Logging.Level changed", fmt.Sprintf("from '%s' to '%s'", oldLogging.Level, newLogging.Level))
@@ -240,6 +262,30 @@ func checkConfigDifferences_Logging(ctx context.Context, oldLogging config.Confi
 				Msg("Logging.Level changed")
 			changed = true
 		}
+
+		if oldLogging.Rotation() != newLogging.Rotation() {
+			logAction.AppendResult("Logging rotation changed", fmt.Sprintf("from '%+v' to '%+v'", oldLogging.Rotation(), newLogging.Rotation()))
+			logging.LOGGER.Info().
+				Timestamp().
+				Interface("old_rotation", oldLogging.Rotation()).
+				Interface("new_rotation", newLogging.Rotation()).
+				Msg("Logging rotation changed")
+			changed = true
+		}
+
+		if !reflect.DeepEqual(oldLogging.Tracing, newLogging.Tracing) {
+			// Headers can hold a collector token, so they are never logged
+			logAction.AppendResult("Logging.Tracing changed", fmt.Sprintf("from enabled '%v' (%s) to enabled '%v' (%s)",
+				oldLogging.Tracing.Enabled, oldLogging.Tracing.Endpoint, newLogging.Tracing.Enabled, newLogging.Tracing.Endpoint))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_enabled", oldLogging.Tracing.Enabled).
+				Bool("new_enabled", newLogging.Tracing.Enabled).
+				Str("old_endpoint", oldLogging.Tracing.Endpoint).
+				Str("new_endpoint", newLogging.Tracing.Endpoint).
+				Msg("Logging.Tracing changed")
+			changed = true
+		}
 	}
 
 	newValid = config.ValidateLogging(ctx, newLogging)
@@ -319,6 +365,16 @@ func checkConfigDifferences_MediaServer(ctx context.Context, oldMediaServer conf
 				Msg("MediaServer.PlexEventListener.Enabled changed")
 			changed = true
 		}
+
+		if oldMediaServer.EnableEmbyJellyfinEventListener != newMediaServer.EnableEmbyJellyfinEventListener {
+			logAction.AppendResult("MediaServer.EnableEmbyJellyfinEventListener changed", fmt.Sprintf("from '%v' to '%v'", oldMediaServer.EnableEmbyJellyfinEventListener, newMediaServer.EnableEmbyJellyfinEventListener))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_enabled", oldMediaServer.EnableEmbyJellyfinEventListener).
+				Bool("new_enabled", newMediaServer.EnableEmbyJellyfinEventListener).
+				Msg("MediaServer.EnableEmbyJellyfinEventListener changed")
+			changed = true
+		}
 	}
 	newValid = config.ValidateMediaServer(ctx, newMediaServer)
 	// If the Media Server config doesn't pass validation, return early
@@ -365,6 +421,16 @@ func checkConfigDifferences_Mediux(ctx context.Context, oldMediux config.Config_
 				Msg("Mediux.DownloadQuality changed")
 			changed = true
 		}
+
+		if oldMediux.EnableEventListener != newMediux.EnableEventListener {
+			logAction.AppendResult("Mediux.EnableEventListener changed", fmt.Sprintf("from '%v' to '%v'", oldMediux.EnableEventListener, newMediux.EnableEventListener))
+			logging.LOGGER.Info().
+				Timestamp().
+				Bool("old_enable_event_listener", oldMediux.EnableEventListener).
+				Bool("new_enable_event_listener", newMediux.EnableEventListener).
+				Msg("Mediux.EnableEventListener changed")
+			changed = true
+		}
 	}
 	newValid = config.ValidateMediux(ctx, newMediux)
 	// If the MediUX config doesn't pass validation, return early
@@ -458,6 +524,46 @@ func checkConfigDifferences_Images(ctx context.Context, oldImages config.Config_
 				Msg("Images.SaveImagesLocally.EpisodeNamingConvention changed")
 			changed = true
 		}
+
+		if oldImages.LocalArtwork != newImages.LocalArtwork {
+			logAction.AppendResult("Images.LocalArtwork changed", fmt.Sprintf("from '%+v' to '%+v'", oldImages.LocalArtwork, newImages.LocalArtwork))
+			logging.LOGGER.Info().
+				Timestamp().
+				Interface("old_local_artwork", oldImages.LocalArtwork).
+				Interface("new_local_artwork", newImages.LocalArtwork).
+				Msg("Images.LocalArtwork changed")
+			changed = true
+		}
+
+		if oldImages.ArtworkHistory != newImages.ArtworkHistory {
+			logAction.AppendResult("Images.ArtworkHistory changed", fmt.Sprintf("from '%+v' to '%+v'", oldImages.ArtworkHistory, newImages.ArtworkHistory))
+			logging.LOGGER.Info().
+				Timestamp().
+				Interface("old_artwork_history", oldImages.ArtworkHistory).
+				Interface("new_artwork_history", newImages.ArtworkHistory).
+				Msg("Images.ArtworkHistory changed")
+			changed = true
+		}
+
+		if oldImages.DriftDetection != newImages.DriftDetection {
+			logAction.AppendResult("Images.DriftDetection changed", fmt.Sprintf("from '%+v' to '%+v'", oldImages.DriftDetection, newImages.DriftDetection))
+			logging.LOGGER.Info().
+				Timestamp().
+				Interface("old_drift_detection", oldImages.DriftDetection).
+				Interface("new_drift_detection", newImages.DriftDetection).
+				Msg("Images.DriftDetection changed")
+			changed = true
+		}
+
+		if oldImages.VerifyApply != newImages.VerifyApply {
+			logAction.AppendResult("Images.VerifyApply changed", fmt.Sprintf("from '%+v' to '%+v'", oldImages.VerifyApply, newImages.VerifyApply))
+			logging.LOGGER.Info().
+				Timestamp().
+				Interface("old_verify_apply", oldImages.VerifyApply).
+				Interface("new_verify_apply", newImages.VerifyApply).
+				Msg("Images.VerifyApply changed")
+			changed = true
+		}
 	}
 	newValid = config.ValidateImages(ctx, newImages, msConfig)
 	return changed, newValid
@@ -483,7 +589,18 @@ func checkConfigDifferences_TMDB(ctx context.Context, oldTMDB config.Config_TMDB
 				newTMDB.ApiToken = oldTMDB.ApiToken
 			}
 		}
+
+		if !slices.Equal(oldTMDB.Languages, newTMDB.Languages) {
+			logAction.AppendResult("TMDB.Languages changed", fmt.Sprintf("from '%v' to '%v'", oldTMDB.Languages, newTMDB.Languages))
+			changed = true
+		}
+
+		if oldTMDB.MinVoteAverage != newTMDB.MinVoteAverage {
+			logAction.AppendResult("TMDB.MinVoteAverage changed", fmt.Sprintf("from '%v' to '%v'", oldTMDB.MinVoteAverage, newTMDB.MinVoteAverage))
+			changed = true
+		}
 	}
+	newValid = config.ValidateTMDB(ctx, newTMDB)
 	return changed, newValid
 }
 
@@ -922,6 +1039,8 @@ func diffNotificationTemplates(oldT, newT config.Config_NotificationTemplate) []
 		"new_sets_available_for_ignored_items": oldT.NewSetsAvailableForIgnoredItems,
 		"check_for_media_item_changes_job":     oldT.CheckForMediaItemChangesJob,
 		"sonarr_notification":                  oldT.SonarrNotification,
+		"wanted_item_available":                oldT.WantedItemAvailable,
+		"ignored_item_expired":                 oldT.IgnoredItemExpired,
 	}
 	newMap := map[string]config.Config_CustomNotification{
 		"app_startup":                          newT.AppStartup,
@@ -931,6 +1050,8 @@ func diffNotificationTemplates(oldT, newT config.Config_NotificationTemplate) []
 		"new_sets_available_for_ignored_items": newT.NewSetsAvailableForIgnoredItems,
 		"check_for_media_item_changes_job":     newT.CheckForMediaItemChangesJob,
 		"sonarr_notification":                  newT.SonarrNotification,
+		"wanted_item_available":                newT.WantedItemAvailable,
+		"ignored_item_expired":                 newT.IgnoredItemExpired,
 	}
 
 	diffs := make([]notificationTemplateDiff, 0)
@@ -1117,6 +1238,24 @@ func checkConfigDifferences_Database(ctx context.Context, oldDB config.Config_Da
 	return changed, newValid
 }
 
+func checkConfigDifferences_Health(ctx context.Context, oldHealth config.Config_Health, newHealth *config.Config_Health) (changed, newValid bool) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, "Check Config Differences: Health", logging.LevelTrace)
+	defer logAction.Complete()
+	changed = false
+	newValid = false
+	if !reflect.DeepEqual(oldHealth.Criticality, newHealth.Criticality) {
+		logAction.AppendResult("Health.Criticality changed", fmt.Sprintf("from '%v' to '%v'", oldHealth.Criticality, newHealth.Criticality))
+		logging.LOGGER.Info().
+			Timestamp().
+			Interface("old_criticality", oldHealth.Criticality).
+			Interface("new_criticality", newHealth.Criticality).
+			Msg("Health.Criticality changed")
+		changed = true
+	}
+	newValid = config.ValidateHealth(ctx, newHealth)
+	return changed, newValid
+}
+
 // libraryNames returns a comma-separated string of library names from the given slice.
 func libraryNames(libs []models.LibrarySection) string {
 	names := make([]string, 0, len(libs))
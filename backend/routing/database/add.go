@@ -3,9 +3,9 @@ package routes_db
 import (
 	"aura/cache"
 	"aura/database"
+	"aura/imagesource"
 	"aura/logging"
 	"aura/mediaserver"
-	"aura/mediux"
 	"aura/models"
 	sonarr_radarr "aura/sonarr-radarr"
 	"aura/utils/httpx"
@@ -96,36 +96,13 @@ func AddNewItemToDB(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// We also need a full PosterSet with ImageFiles
-		switch req.PosterSet.Type {
-		case "show":
-			showSet, _, Err := mediux.GetShowSetByID(ctx, req.PosterSet.ID, req.MediaItem.LibraryTitle, req.MediaItem.Edition)
-			if Err.Message != "" {
-				httpx.SendResponse(w, ld, response)
-				return
-			}
-			fullSet.PosterSet = showSet.PosterSet
-		case "movie":
-			movieSet, _, Err := mediux.GetMovieSetByID(ctx, req.PosterSet.ID, req.MediaItem.LibraryTitle, req.MediaItem.Edition)
-			if Err.Message != "" {
-				httpx.SendResponse(w, ld, response)
-				return
-			}
-			fullSet.PosterSet = movieSet.PosterSet
-		case "collection":
-			collectionSet, _, Err := mediux.GetMovieCollectionSetByID(ctx, req.PosterSet.ID, req.MediaItem.TMDB_ID, req.MediaItem.LibraryTitle, req.MediaItem.Edition, true)
-			if Err.Message != "" {
-				httpx.SendResponse(w, ld, response)
-				return
-			}
-			fullSet.PosterSet = collectionSet.PosterSet
-		default:
-			logAction.SetError("Invalid Poster Set Type", "Poster Set type must be either 'show' or 'movie'", map[string]any{
-				"set_type": req.PosterSet.Type,
-			})
+		// We also need a full PosterSet with ImageFiles from the image source the set came from
+		sourceSet, _, Err := imagesource.GetSetByID(ctx, req.PosterSet.BaseSetInfo, req.MediaItem.TMDB_ID, req.MediaItem.LibraryTitle, req.MediaItem.Edition)
+		if Err.Message != "" {
 			httpx.SendResponse(w, ld, response)
 			return
 		}
+		fullSet.PosterSet = sourceSet.PosterSet
 	}
 
 	saveItem := models.DBSavedItem{
@@ -0,0 +1,51 @@
+package routes_db
+
+import (
+	"aura/cache"
+	autodownload "aura/download/auto"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+	"strings"
+)
+
+type autoApplyDryRunResponse struct {
+	Results []autodownload.AutoApplyResult `json:"results"`
+}
+
+// AutoApplyDryRun godoc
+// @Summary      Auto Apply - Dry Run
+// @Description  Show which set AutoApply would pick for every library item that has nothing saved in the database, using the current creator preferences. Nothing is queued.
+// @Tags         Database
+// @Produce      json
+// @Param        library_title  query     string  false  "Only check items in this library"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=autoApplyDryRunResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/auto-apply/dry-run [get]
+func AutoApplyDryRun(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Auto Apply - Dry Run", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response autoApplyDryRunResponse
+	libraryTitle := strings.TrimSpace(r.URL.Query().Get("library_title"))
+
+	// Only items without saved sets are candidates, the rest would be skipped anyway
+	items := []models.MediaItem{}
+	for _, item := range cache.LibraryStore.GetAllMediaItems() {
+		if libraryTitle != "" && item.LibraryTitle != libraryTitle {
+			continue
+		}
+		if item.IgnoredInDB || len(item.DBSavedSets) > 0 {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	response.Results = autodownload.AutoApplyToNewItems(ctx, items, true)
+	httpx.SendResponse(w, ld, response)
+}
@@ -2,9 +2,9 @@ package routes_db
 
 import (
 	autodownload "aura/download/auto"
+	"aura/imagesource"
 	"aura/logging"
 	"aura/mediaserver"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils/httpx"
 	"net/http"
@@ -92,38 +92,13 @@ func AutoDownloadForceCheck(w http.ResponseWriter, r *http.Request) {
 		}
 
 		for _, posterSet := range req.Item.PosterSets {
-			switch posterSet.Type {
-			case "show":
-				showSet, _, Err := mediux.GetShowSetByID(ctx, posterSet.ID, req.Item.MediaItem.LibraryTitle, req.Item.MediaItem.Edition)
-				if Err.Message != "" {
-					httpx.SendResponse(w, ld, response)
-					return
-				}
-				posterSet.PosterSet = showSet.PosterSet
-				fullSets = append(fullSets, posterSet)
-			case "movie":
-				movieSet, _, Err := mediux.GetMovieSetByID(ctx, posterSet.ID, req.Item.MediaItem.LibraryTitle, req.Item.MediaItem.Edition)
-				if Err.Message != "" {
-					httpx.SendResponse(w, ld, response)
-					return
-				}
-				posterSet.PosterSet = movieSet.PosterSet
-				fullSets = append(fullSets, posterSet)
-			case "collection":
-				collectionSet, _, Err := mediux.GetMovieCollectionSetByID(ctx, posterSet.ID, req.Item.MediaItem.TMDB_ID, req.Item.MediaItem.LibraryTitle, req.Item.MediaItem.Edition, true)
-				if Err.Message != "" {
-					httpx.SendResponse(w, ld, response)
-					return
-				}
-				posterSet.PosterSet = collectionSet.PosterSet
-				fullSets = append(fullSets, posterSet)
-			default:
-				logAction.SetError("Invalid Poster Set Type", "Poster Set type must be either 'show' or 'movie'", map[string]any{
-					"set_type": posterSet.Type,
-				})
+			sourceSet, _, Err := imagesource.GetSetByID(ctx, posterSet.BaseSetInfo, req.Item.MediaItem.TMDB_ID, req.Item.MediaItem.LibraryTitle, req.Item.MediaItem.Edition)
+			if Err.Message != "" {
 				httpx.SendResponse(w, ld, response)
 				return
 			}
+			posterSet.PosterSet = sourceSet.PosterSet
+			fullSets = append(fullSets, posterSet)
 		}
 	} else {
 		fullSets = req.Item.PosterSets
@@ -0,0 +1,160 @@
+package routes_db
+
+import (
+	"aura/database"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+	"time"
+)
+
+type saveCollectionRequest struct {
+	CollectionItem models.CollectionItem          `json:"collection_item"`
+	PosterSet      models.PosterSet               `json:"poster_set"`
+	SelectedTypes  models.CollectionSelectedTypes `json:"selected_types"`
+	AutoDownload   bool                           `json:"auto_download"`
+}
+
+type saveCollectionResponse struct {
+	SavedCollection models.DBSavedCollection `json:"saved_collection"`
+}
+
+type getAllCollectionsResponse struct {
+	Collections []models.DBSavedCollection `json:"collections"`
+}
+
+type deleteCollectionResponse struct {
+	Message string `json:"message"`
+}
+
+// SaveCollection godoc
+// @Summary      Save Collection To Database
+// @Description  Save the collection set applied to a media server collection. If AutoDownload is enabled, the collection poster and backdrop are re-applied whenever the set images change. If the collection is already saved, it is replaced.
+// @Tags         Database
+// @Accept       json
+// @Produce      json
+// @Param        req  body      saveCollectionRequest  true  "Save Collection Request"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=saveCollectionResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/collection [post]
+func SaveCollection(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Save Collection To Database", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var req saveCollectionRequest
+	var response saveCollectionResponse
+
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Save Collection To Database - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	if req.CollectionItem.RatingKey == "" || req.CollectionItem.LibraryTitle == "" {
+		logAction.SetError("Invalid Collection Item Data", "RatingKey and LibraryTitle are required in Collection Item", map[string]any{
+			"rating_key":    req.CollectionItem.RatingKey,
+			"library_title": req.CollectionItem.LibraryTitle,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if req.PosterSet.ID == "" {
+		logAction.SetError("Invalid Poster Set Data", "The Poster Set must have an ID", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	// The collection children are not stored, they are fetched from the media server when needed
+	req.CollectionItem.MediaItems = nil
+	if req.PosterSet.Type == "" {
+		req.PosterSet.Type = "collection"
+	}
+
+	saved := models.DBSavedCollection{
+		CollectionItem: req.CollectionItem,
+		PosterSet:      req.PosterSet,
+		SelectedTypes:  req.SelectedTypes,
+		AutoDownload:   req.AutoDownload,
+		LastDownloaded: time.Now(),
+	}
+	Err = database.UpsertSavedCollection(ctx, saved)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.SavedCollection = saved
+	httpx.SendResponse(w, ld, response)
+}
+
+// GetAllCollections godoc
+// @Summary      Get All Saved Collections
+// @Description  Get every media server collection saved in the database along with its collection set.
+// @Tags         Database
+// @Produce      json
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=getAllCollectionsResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/collection [get]
+func GetAllCollections(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get All Saved Collections", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response getAllCollectionsResponse
+	collections, Err := database.GetAllSavedCollections(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Collections = collections
+	httpx.SendResponse(w, ld, response)
+}
+
+// DeleteCollection godoc
+// @Summary      Delete Collection From Database
+// @Description  Delete a saved collection from the database. The images already applied on the media server are not changed.
+// @Tags         Database
+// @Produce      json
+// @Param        rating_key     query     string  true  "Rating Key of the Collection"
+// @Param        library_title  query     string  true  "Library Title of the Collection"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=deleteCollectionResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/collection [delete]
+func DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Delete Collection From Database", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response deleteCollectionResponse
+	ratingKey := r.URL.Query().Get("rating_key")
+	libraryTitle := r.URL.Query().Get("library_title")
+	if ratingKey == "" || libraryTitle == "" {
+		logAction.SetError("Missing query parameters", "rating_key and library_title are required", map[string]any{
+			"rating_key":    ratingKey,
+			"library_title": libraryTitle,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	Err := database.DeleteSavedCollection(ctx, libraryTitle, ratingKey)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Message = "Deleted saved collection successfully"
+	httpx.SendResponse(w, ld, response)
+}
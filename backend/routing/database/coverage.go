@@ -0,0 +1,90 @@
+package routes_db
+
+import (
+	"aura/coverage"
+	"aura/logging"
+	"aura/utils/httpx"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GetCoverageReport godoc
+// @Summary      Library Coverage Report
+// @Description  Report per library how many items have saved sets, have MediUX or local sets but nothing saved, have no sets at all or are ignored. Items without sets that TMDB is asked for as a fallback are flagged with tmdb_fallback, whether TMDB has images for them is not checked. Saved shows whose newest season or episode is missing the season poster or titlecard their saved sets select are listed with their gaps. Use format=csv or format=json to download the report as a file.
+// @Tags         Database
+// @Produce      json
+// @Produce      text/csv
+// @Param        library_title  query     string  false  "Only include these libraries (comma separated)"
+// @Param        type           query     string  false  "Only include movie or show libraries"
+// @Param        status         query     string  false  "Only list items with these statuses (comma separated): saved, mediux_available, local_available, no_sets, ignored"
+// @Param        gaps_only      query     bool    false  "Only list shows with gaps"
+// @Param        format         query     string  false  "Download the report as csv or json"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=coverage.Report}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/coverage [get]
+func GetCoverageReport(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Library Coverage Report", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	query := r.URL.Query()
+	filter := coverage.Filter{
+		LibraryTitles: splitQueryList(query.Get("library_title")),
+		Type:          strings.TrimSpace(query.Get("type")),
+		GapsOnly:      query.Get("gaps_only") == "true",
+	}
+	for _, status := range splitQueryList(query.Get("status")) {
+		if !slices.Contains(coverage.ItemStatuses, coverage.ItemStatus(status)) {
+			logAction.SetError("Invalid status", "Use a comma separated list of: saved, mediux_available, local_available, no_sets, ignored", map[string]any{"status": status})
+			httpx.SendResponse(w, ld, nil)
+			return
+		}
+		filter.Statuses = append(filter.Statuses, coverage.ItemStatus(status))
+	}
+
+	format := strings.ToLower(strings.TrimSpace(query.Get("format")))
+	if format != "" && format != "csv" && format != "json" {
+		logAction.SetError("Invalid format", "Use 'csv' or 'json', or leave it empty", map[string]any{"format": format})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	report, Err := coverage.BuildReport(ctx, filter)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, report)
+		return
+	}
+
+	fileName := fmt.Sprintf("aura-coverage-%s.%s", report.GeneratedAt.Format(time.DateOnly), format)
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+		w.WriteHeader(http.StatusOK)
+		coverage.WriteCSV(w, report)
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(report)
+	default:
+		httpx.SendResponse(w, ld, report)
+	}
+}
+
+func splitQueryList(value string) []string {
+	values := []string{}
+	for part := range strings.SplitSeq(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
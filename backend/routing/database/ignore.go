@@ -4,15 +4,20 @@ import (
 	"aura/database"
 	"aura/logging"
 	"aura/utils/httpx"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type ignoreItemResponse struct {
-	Ignored      bool   `json:"ignored"`
-	TmdbID       string `json:"tmdb_id"`
-	LibraryTitle string `json:"library_title"`
-	Mode         string `json:"mode,omitempty"`         // e.g., "always", "until-set-available", "until-new-set-available"
-	CurrentSets  string `json:"current_sets,omitempty"` // comma-separated list of current sets for the item, used for temporary ignore modes
+	Ignored      bool       `json:"ignored"`
+	TmdbID       string     `json:"tmdb_id"`
+	LibraryTitle string     `json:"library_title"`
+	Mode         string     `json:"mode,omitempty"`         // e.g., "always", "until-set-available", "until-new-set-available", "until-date"
+	CurrentSets  string     `json:"current_sets,omitempty"` // comma-separated list of current sets for the item, used for temporary ignore modes
+	IgnoreUntil  *time.Time `json:"ignore_until,omitempty"` // when the ignore expires, used for "until-date" mode
 }
 
 // IgnoreItem godoc
@@ -24,7 +29,9 @@ type ignoreItemResponse struct {
 // @Param        tmdb_id       query     string  true  "TMDB ID of the Media Item"
 // @Param        library_title  query     string  true  "Library Title of the Media Item"
 // @Param        edition        query     string  false "Edition of the Media Item (e.g. Director's Cut), empty for the standard edition"
-// @Param        mode           query     string  true  "Ignore mode (e.g., 'always' for permanent ignore, 'until-set-available' for temporary ignore until a set is available, 'until-new-set-available' for temporary ignore until a new set is available)"
+// @Param        mode           query     string  true  "Ignore mode (e.g., 'always' for permanent ignore, 'until-set-available' for temporary ignore until a set is available, 'until-new-set-available' for temporary ignore until a new set is available, 'until-date' for temporary ignore until a date)"
+// @Param        until          query     string  false "Date the ignore expires for 'until-date' mode (YYYY-MM-DD or RFC3339)"
+// @Param        duration       query     string  false "How long to ignore the item for 'until-date' mode (e.g. '30d', '2w', '12h'), used when until is empty"
 // @Security     SessionCookie
 // @Security     ApiKeyAuth
 // @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
@@ -43,6 +50,8 @@ func IgnoreItemInDB(w http.ResponseWriter, r *http.Request) {
 	edition := r.URL.Query().Get("edition")
 	mode := r.URL.Query().Get("mode")                // e.g., "always", "until-set-available", "until-new-set-available"
 	currentSets := r.URL.Query().Get("current_sets") // comma-separated list of current sets for the item, used for temporary ignore modes
+	until := r.URL.Query().Get("until")              // date the ignore expires, used for "until-date" mode
+	duration := r.URL.Query().Get("duration")        // how long to ignore the item, used for "until-date" mode
 
 	if tmdbID == "" || libraryTitle == "" || mode == "" {
 		logAction.SetError("Missing required query parameters", "TMDB ID, Library Title, and Mode are required",
@@ -54,8 +63,8 @@ func IgnoreItemInDB(w http.ResponseWriter, r *http.Request) {
 			})
 		httpx.SendResponse(w, ld, response)
 		return
-	} else if mode != "always" && mode != "until-set-available" && mode != "until-new-set-available" {
-		logAction.SetError("Invalid mode parameter", "Ignore mode must be 'always', 'until-set-available', or 'until-new-set-available'", map[string]any{
+	} else if mode != "always" && mode != "until-set-available" && mode != "until-new-set-available" && mode != "until-date" {
+		logAction.SetError("Invalid mode parameter", "Ignore mode must be 'always', 'until-set-available', 'until-new-set-available', or 'until-date'", map[string]any{
 			"mode": mode,
 		})
 		httpx.SendResponse(w, ld, response)
@@ -69,7 +78,22 @@ func IgnoreItemInDB(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	Err := database.IgnoreMediaItem(ctx, tmdbID, libraryTitle, edition, mode, currentSets)
+	var ignoreUntil time.Time
+	if mode == "until-date" {
+		var err error
+		ignoreUntil, err = parseIgnoreUntil(until, duration, time.Now())
+		if err != nil {
+			logAction.SetError("Invalid until or duration parameter", err.Error(), map[string]any{
+				"until":    until,
+				"duration": duration,
+			})
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+		response.IgnoreUntil = &ignoreUntil
+	}
+
+	Err := database.IgnoreMediaItem(ctx, tmdbID, libraryTitle, edition, mode, currentSets, ignoreUntil)
 	if Err.Message != "" {
 		httpx.SendResponse(w, ld, response)
 		return
@@ -130,3 +154,52 @@ func StopIgnoringItemInDB(w http.ResponseWriter, r *http.Request) {
 	response.LibraryTitle = libraryTitle
 	httpx.SendResponse(w, ld, response)
 }
+
+// parseIgnoreUntil returns when an 'until-date' ignore expires.
+// until is a date (YYYY-MM-DD, the ignore expires at the start of that day in local time) or an RFC3339 timestamp.
+// duration is used when until is empty and is a number of days ("30d"), weeks ("2w") or a Go duration ("12h").
+func parseIgnoreUntil(until, duration string, now time.Time) (time.Time, error) {
+	until = strings.TrimSpace(until)
+	duration = strings.ToLower(strings.TrimSpace(duration))
+
+	var ignoreUntil time.Time
+	switch {
+	case until != "":
+		if t, err := time.ParseInLocation(time.DateOnly, until, time.Local); err == nil {
+			ignoreUntil = t
+		} else if t, err := time.Parse(time.RFC3339, until); err == nil {
+			ignoreUntil = t
+		} else {
+			return time.Time{}, fmt.Errorf("until must be a date (YYYY-MM-DD) or an RFC3339 timestamp")
+		}
+	case duration != "":
+		var d time.Duration
+		if days, ok := strings.CutSuffix(duration, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("duration '%s' is not a number of days", duration)
+			}
+			d = time.Duration(n) * 24 * time.Hour
+		} else if weeks, ok := strings.CutSuffix(duration, "w"); ok {
+			n, err := strconv.Atoi(weeks)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("duration '%s' is not a number of weeks", duration)
+			}
+			d = time.Duration(n) * 7 * 24 * time.Hour
+		} else {
+			parsed, err := time.ParseDuration(duration)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("duration must be like '30d', '2w' or '12h'")
+			}
+			d = parsed
+		}
+		ignoreUntil = now.Add(d)
+	default:
+		return time.Time{}, fmt.Errorf("until or duration is required when mode is 'until-date'")
+	}
+
+	if !ignoreUntil.After(now) {
+		return time.Time{}, fmt.Errorf("the ignore must expire in the future")
+	}
+	return ignoreUntil, nil
+}
@@ -0,0 +1,164 @@
+package routes_db
+
+import (
+	"aura/cache"
+	"aura/database"
+	autodownload "aura/download/auto"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+	"time"
+)
+
+type saveWantedItemResponse struct {
+	WantedItem models.DBWantedItem `json:"wanted_item"`
+	Queued     bool                `json:"queued"` // The item was already in the library and its set was queued right away
+}
+
+type getAllWantedItemsResponse struct {
+	WantedItems []models.DBWantedItem `json:"wanted_items"`
+}
+
+type deleteWantedItemResponse struct {
+	Message string `json:"message"`
+}
+
+// SaveWantedItem godoc
+// @Summary      Add Item To Wanted List
+// @Description  Pre-assign a set to a movie or show that is not in the library yet. When the item lands in the library (found by the refresh job or a Sonarr/Radarr webhook), the set is saved and queued and the item is removed from the wanted list. If the item is already wanted, it is replaced.
+// @Tags         Database
+// @Accept       json
+// @Produce      json
+// @Param        req  body      models.DBWantedItem  true  "Wanted Item"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=saveWantedItemResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/wanted [post]
+func SaveWantedItem(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Add Item To Wanted List", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var req models.DBWantedItem
+	var response saveWantedItemResponse
+
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Add Item To Wanted List - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	if req.TMDB_ID == "" || req.LibraryTitle == "" {
+		logAction.SetError("Invalid Wanted Item Data", "tmdb_id and library_title are required", map[string]any{
+			"tmdb_id":       req.TMDB_ID,
+			"library_title": req.LibraryTitle,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if req.Type != "movie" && req.Type != "show" {
+		logAction.SetError("Invalid Wanted Item Type", "type must be 'movie' or 'show'", map[string]any{"type": req.Type})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if req.Set.ID == "" {
+		logAction.SetError("Invalid Set Data", "The set must have an ID", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if section, found := cache.LibraryStore.GetSectionByTitle(req.LibraryTitle); found && section.Type != req.Type {
+		logAction.SetError("Library type does not match", "Choose a library that holds this type of item", map[string]any{
+			"library_title": req.LibraryTitle,
+			"library_type":  section.Type,
+			"type":          req.Type,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	req.CreatedAt = time.Now().UTC()
+	Err = database.UpsertWantedItem(ctx, req)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	response.WantedItem = req
+
+	// The item may already be in the library, there is no reason to wait for the next refresh
+	if _, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(req.LibraryTitle, req.TMDB_ID); found {
+		autodownload.CheckWantedItems(ctx)
+		response.Queued = !autodownload.IsWantedItem(ctx, req.TMDB_ID, req.Type)
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
+
+// GetAllWantedItems godoc
+// @Summary      Get Wanted List
+// @Description  Get every item on the wanted list along with the set chosen for it.
+// @Tags         Database
+// @Produce      json
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=getAllWantedItemsResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/wanted [get]
+func GetAllWantedItems(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get Wanted List", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response getAllWantedItemsResponse
+	wantedItems, Err := database.GetAllWantedItems(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.WantedItems = wantedItems
+	httpx.SendResponse(w, ld, response)
+}
+
+// DeleteWantedItem godoc
+// @Summary      Remove Item From Wanted List
+// @Description  Remove an item from the wanted list. Nothing is saved or queued for it when it lands in the library.
+// @Tags         Database
+// @Produce      json
+// @Param        tmdb_id  query     string  true  "TMDB ID of the Item"
+// @Param        type     query     string  true  "Type of the Item (movie or show)"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=deleteWantedItemResponse}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/db/wanted [delete]
+func DeleteWantedItem(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Remove Item From Wanted List", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response deleteWantedItemResponse
+	tmdbID := r.URL.Query().Get("tmdb_id")
+	itemType := r.URL.Query().Get("type")
+	if tmdbID == "" || itemType == "" {
+		logAction.SetError("Missing query parameters", "tmdb_id and type are required", map[string]any{
+			"tmdb_id": tmdbID,
+			"type":    itemType,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	Err := database.DeleteWantedItem(ctx, tmdbID, itemType)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response.Message = "Removed item from the wanted list successfully"
+	httpx.SendResponse(w, ld, response)
+}
@@ -0,0 +1,64 @@
+package routes_download
+
+import (
+	downloadqueue "aura/download/queue"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+type ApplyBoxset_Request struct {
+	Username      string               `json:"username"`       // MediUX username of the boxset creator
+	BoxsetID      string               `json:"boxset_id"`      // MediUX boxset ID
+	LibraryTitles []string             `json:"library_titles"` // Optional list of libraries to match against, defaults to all
+	SelectedTypes models.SelectedTypes `json:"selected_types"` // Image types applied to every matched item
+	AutoDownload  bool                 `json:"auto_download"`  // AutoDownload setting saved for every matched item
+	DryRun        bool                 `json:"dry_run"`        // Only resolve the boxset, do not queue anything
+}
+
+// ApplyBoxset godoc
+// @Summary      Download Queue - Apply Boxset
+// @Description  Resolve every member set of a MediUX boxset against the libraries and add all matched items to the download queue as one tracked batch. Members that are not in the library are reported as missing. Use dry_run to only see what would be queued.
+// @Tags         Download
+// @Accept       json
+// @Produce      json
+// @Param        req  body      ApplyBoxset_Request  true  "Apply Boxset Request"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200           {object}  httpx.JSONResponse{data=downloadqueue.BoxsetApplyResult}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/download/boxset [post]
+func ApplyBoxset(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Download Queue - Apply Boxset", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var req ApplyBoxset_Request
+	var response downloadqueue.BoxsetApplyResult
+
+	// Parse and validate request body
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Apply Boxset - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	if req.Username == "" || req.BoxsetID == "" {
+		logAction.SetError("Missing required fields", "username and boxset_id are required", map[string]any{
+			"username":  req.Username,
+			"boxset_id": req.BoxsetID,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response, Err = downloadqueue.ApplyBoxset(ctx, req.Username, req.BoxsetID, req.LibraryTitles, req.SelectedTypes, req.AutoDownload, req.DryRun)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
@@ -1,6 +1,7 @@
 package routes_download
 
 import (
+	"aura/artworkhistory"
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/models"
@@ -11,8 +12,9 @@ import (
 )
 
 type DownloadImageFileForMediaItem_Request struct {
-	ImageFile models.ImageFile `json:"image_file"`
-	MediaItem models.MediaItem `json:"media_item"`
+	ImageFile models.ImageFile    `json:"image_file"`
+	MediaItem models.MediaItem    `json:"media_item"`
+	Set       *models.BaseSetInfo `json:"set,omitempty"` // Optional set the image comes from, recorded in the artwork history
 }
 
 type DownloadImageFileForMediaItem_Response struct {
@@ -75,6 +77,9 @@ func DownloadImageFileForMediaItem(w http.ResponseWriter, r *http.Request) {
 	actionValidate.Complete()
 
 	// Make the download and apply the image
+	if req.Set != nil {
+		ctx = artworkhistory.WithReplacingSet(ctx, *req.Set)
+	}
 	Err = mediaserver.DownloadApplyImageToMediaItem(ctx, &req.MediaItem, req.ImageFile)
 	if Err.Message != "" {
 		httpx.SendResponse(w, ld, response)
@@ -0,0 +1,46 @@
+package routes_download
+
+import (
+	downloadqueue "aura/download/queue"
+	"aura/logging"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+// GetDownloadQueueBatch godoc
+// @Summary      Download Queue - Get Batch
+// @Description  Get the aggregate result of a batch of queue items, such as an applied boxset. Batches are kept in memory and are not available after a restart.
+// @Tags         Download
+// @Produce      json
+// @Param        id  query     string  true  "Batch ID"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200           {object}  httpx.JSONResponse{data=downloadqueue.Batch}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/download/queue/batch [get]
+func GetDownloadQueueBatch(w http.ResponseWriter, r *http.Request) {
+	_, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Download Queue - Get Batch", logging.LevelTrace)
+
+	var response downloadqueue.Batch
+
+	batchID := r.URL.Query().Get("id")
+	if batchID == "" {
+		logAction.SetError("Missing batch ID", "Provide the batch ID in the 'id' query parameter", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	batch, found := downloadqueue.GetBatch(batchID)
+	if !found {
+		logAction.SetError("Batch not found", "Batches are only tracked until the server restarts", map[string]any{
+			"id": batchID,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response = batch
+	httpx.SendResponse(w, ld, response)
+}
@@ -14,6 +14,9 @@ type GetDownloadQueueStatus_Response struct {
 	Message  string               `json:"message"`
 	Warnings []string             `json:"warnings"`
 	Errors   []string             `json:"errors"`
+
+	Verified   []string `json:"verified,omitempty"`   // Images of the latest set the media server was checked to show (Images.VerifyApply)
+	Unverified []string `json:"unverified,omitempty"` // Images of the latest set the media server did not show after applying them (Images.VerifyApply)
 }
 
 // GetDownloadQueueStatus godoc
@@ -39,6 +42,8 @@ func GetDownloadQueueStatus(w http.ResponseWriter, r *http.Request) {
 	response.Message = downloadqueue.LatestInfo.Message
 	response.Warnings = downloadqueue.LatestInfo.Warnings
 	response.Errors = downloadqueue.LatestInfo.Errors
+	response.Verified = downloadqueue.LatestInfo.Verified
+	response.Unverified = downloadqueue.LatestInfo.Unverified
 
 	httpx.SendResponse(w, ld, response)
 }
@@ -0,0 +1,100 @@
+package routes_download
+
+import (
+	"aura/logging"
+	"aura/restore"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+type StartRestoreOriginals_Request struct {
+	LibraryTitle string `json:"library_title"` // Only restore items in this library
+	Creator      string `json:"creator"`       // Only restore sets by this MediUX user
+	SetID        string `json:"set_id"`        // Only restore this set
+}
+
+type GetRestoreOriginals_Response struct {
+	Jobs []restore.Job `json:"jobs,omitempty"`
+	Job  *restore.Job  `json:"job,omitempty"`
+}
+
+// StartRestoreOriginals godoc
+// @Summary      Restore Originals - Start
+// @Description  Put back the artwork the media server had before AURA changed it, for every saved set in a library, by a creator or for a set ID. The oldest captured artwork history version is used when there is one, otherwise Plex selects the agent provided image and Emby/Jellyfin refresh the images. The restored saved sets are removed afterwards. The job runs in the background and is resumed after a restart. Images saved next to the media files are not deleted.
+// @Tags         Download
+// @Accept       json
+// @Produce      json
+// @Param        req  body      StartRestoreOriginals_Request  true  "Restore Originals Request"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200           {object}  httpx.JSONResponse{data=restore.Job}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/download/restore-originals [post]
+func StartRestoreOriginals(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Restore Originals - Start", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var req StartRestoreOriginals_Request
+	var response restore.Job
+
+	// Parse and validate request body
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Restore Originals - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response, Err = restore.StartJob(ctx, restore.Scope{
+		LibraryTitle: req.LibraryTitle,
+		Creator:      req.Creator,
+		SetID:        req.SetID,
+	})
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
+
+// GetRestoreOriginals godoc
+// @Summary      Restore Originals - Get Jobs
+// @Description  Get every restore originals job without the per item report, or the full report of a single job when an ID is given
+// @Tags         Download
+// @Produce      json
+// @Param        id  query     string  false  "Job ID"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200           {object}  httpx.JSONResponse{data=GetRestoreOriginals_Response}
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/download/restore-originals [get]
+func GetRestoreOriginals(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Restore Originals - Get Jobs", logging.LevelTrace)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response GetRestoreOriginals_Response
+
+	jobID := r.URL.Query().Get("id")
+	if jobID != "" {
+		job, Err := restore.GetJob(ctx, jobID)
+		if Err.Message != "" {
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+		response.Job = &job
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	jobs, Err := restore.GetAllJobs(ctx)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	response.Jobs = jobs
+	httpx.SendResponse(w, ld, response)
+}
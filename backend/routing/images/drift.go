@@ -0,0 +1,36 @@
+package routes_images
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+// GetArtworkDriftReport godoc
+// @Summary      Get Artwork Drift Report
+// @Description  Get the result of the last artwork drift check. Images applied by AURA that no longer match what the media server shows are listed, along with whether they were re-applied. The check runs on the Images.DriftDetection.Cron schedule and can be started from the jobs page.
+// @Tags         Images
+// @Produce      json
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=mediaserver.DriftReport}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/drift [get]
+func GetArtworkDriftReport(w http.ResponseWriter, r *http.Request) {
+	_, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get Artwork Drift Report", logging.LevelTrace)
+
+	var response mediaserver.DriftReport
+
+	if !config.Latest().Images.DriftDetection.Enabled {
+		logAction.SetError("Drift detection is disabled", "Enable Images.DriftDetection to record fingerprints of applied images", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response = mediaserver.GetLatestDriftReport()
+	httpx.SendResponse(w, ld, response)
+}
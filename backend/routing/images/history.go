@@ -0,0 +1,180 @@
+package routes_images
+
+import (
+	"aura/artworkhistory"
+	"aura/cache"
+	"aura/imagesource"
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+type RevertArtwork_Request struct {
+	RatingKey string `json:"rating_key"` // Rating Key of the media item
+	VersionID string `json:"version_id"` // ID of the captured version to re-apply
+}
+
+type RevertArtwork_Response struct {
+	Version artworkhistory.Version `json:"version"`
+}
+
+// GetArtworkHistory godoc
+// @Summary      Get Artwork History
+// @Description  Get every captured version of the artwork that AURA replaced for a media item, newest first. Versions are only captured when Images.ArtworkHistory is enabled.
+// @Tags         Images
+// @Produce      json
+// @Param        rating_key   query     string  true  "Rating Key of the media item"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=artworkhistory.ItemHistory}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/history [get]
+func GetArtworkHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get Artwork History", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var response artworkhistory.ItemHistory
+
+	ratingKey := r.URL.Query().Get("rating_key")
+	if ratingKey == "" {
+		logAction.SetError("Missing Query Parameters", "rating_key is required", nil)
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	item, found := cache.LibraryStore.GetMediaItemByRatingKey(ratingKey)
+	if !found {
+		logAction.SetError("Media Item Not Found", "No media item found matching the provided rating key",
+			map[string]any{
+				"rating_key": ratingKey,
+			})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response, Err := artworkhistory.GetItemHistory(ctx, *item)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
+
+// GetArtworkHistoryImage godoc
+// @Summary      Get Artwork History Image
+// @Description  Get the image of a captured artwork version for a media item
+// @Tags         Images
+// @Produce      image/jpeg
+// @Param        rating_key   query     string  true  "Rating Key of the media item"
+// @Param        version_id   query     string  true  "ID of the captured version"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Success      200  {string}  string "Image data"
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/history/item [get]
+func GetArtworkHistoryImage(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get Artwork History Image", logging.LevelDebug)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	ratingKey := r.URL.Query().Get("rating_key")
+	versionID := r.URL.Query().Get("version_id")
+	if ratingKey == "" || versionID == "" {
+		logAction.SetError("Missing Query Parameters", "rating_key and version_id are required",
+			map[string]any{
+				"rating_key": ratingKey,
+				"version_id": versionID,
+			})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	item, found := cache.LibraryStore.GetMediaItemByRatingKey(ratingKey)
+	if !found {
+		logAction.SetError("Media Item Not Found", "No media item found matching the provided rating key",
+			map[string]any{
+				"rating_key": ratingKey,
+			})
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	version, Err := artworkhistory.GetVersion(ctx, *item, versionID)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	// Versions of images AURA applied are read from their image source
+	imageData, imageType, Err := imagesource.GetImage(ctx, artworkhistory.ImageFileForVersion(*item, version))
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", imageType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(imageData)
+}
+
+// RevertArtwork godoc
+// @Summary      Revert Artwork
+// @Description  Re-upload a captured artwork version to the media server. The artwork it replaces is captured as well, so the revert can be undone.
+// @Tags         Images
+// @Accept       json
+// @Produce      json
+// @Param        req  body      RevertArtwork_Request  true  "Revert Artwork Request"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=RevertArtwork_Response}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/history/revert [post]
+func RevertArtwork(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Revert Artwork", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var req RevertArtwork_Request
+	var response RevertArtwork_Response
+
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Revert Artwork - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	if req.RatingKey == "" || req.VersionID == "" {
+		logAction.SetError("Missing required fields", "rating_key and version_id are required",
+			map[string]any{
+				"rating_key": req.RatingKey,
+				"version_id": req.VersionID,
+			})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	item, found := cache.LibraryStore.GetMediaItemByRatingKey(req.RatingKey)
+	if !found {
+		logAction.SetError("Media Item Not Found", "No media item found matching the provided rating key",
+			map[string]any{
+				"rating_key": req.RatingKey,
+			})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	// Work on a copy so that fetching show details doesn't change the cached item
+	mediaItem := *item
+	response.Version, Err = mediaserver.RevertArtwork(ctx, &mediaItem, req.VersionID)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
@@ -0,0 +1,44 @@
+package routes_images
+
+import (
+	"aura/localartwork"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+// GetLocalArtworkImage godoc
+// @Summary      Get Local Artwork Image
+// @Description  Get an image from the local artwork folder by image ID
+// @Tags         Images
+// @Produce      image/jpeg
+// @Param        image_id   query     string  true  "ID of the local artwork image (local_<relative path>)"
+// @Param        modified   query     string  false  "Modified date of the image, only used to bust browser caches"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Success      200  {string}  string "Image data"
+// @Failure      500           {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/images/local/item [get]
+func GetLocalArtworkImage(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Get Local Artwork Image", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	imageID := r.URL.Query().Get("image_id")
+	if imageID == "" {
+		logAction.SetError("Missing Query Parameters", "image_id is required", nil)
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	imageData, imageType, Err := localartwork.GetImage(ctx, models.ImageFile{ID: imageID})
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", imageType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(imageData)
+}
@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"os"
 	"path"
-	"strings"
 )
 
 type ClearLogFiles_Response struct {
@@ -76,25 +75,19 @@ func ClearLogFiles(w http.ResponseWriter, r *http.Request) {
 			response.Message = "No current log file to clear"
 		}
 	case "old":
-		// Delete all log files except the current one
-		files, err := os.ReadDir(logging.LogFolder)
-		if err != nil {
-			logAction.SetError("Failed to read log directory", "Ensure the logs folder exists and is accessible", map[string]any{"error": err.Error()})
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-
+		// Delete all rotated log files (compressed or not), keeping the current one
 		deletedFiles := 0
-		for _, file := range files {
-			if strings.HasSuffix(file.Name(), ".log") && file.Name() != "aura.log" {
-				err := os.Remove(path.Join(logging.LogFolder, file.Name()))
-				if err != nil {
-					logAction.SetError(fmt.Sprintf("Failed to delete log file: %s", file.Name()), "Ensure the file is not in use and you have permissions", map[string]any{"error": err.Error()})
-					httpx.SendResponse(w, ld, response)
-					return
-				}
-				deletedFiles++
+		for _, file := range logging.LogFiles() {
+			if file.RotatedAt.IsZero() {
+				continue
+			}
+			err := os.Remove(file.Path)
+			if err != nil && !os.IsNotExist(err) {
+				logAction.SetError(fmt.Sprintf("Failed to delete log file: %s", path.Base(file.Path)), "Ensure the file is not in use and you have permissions", map[string]any{"error": err.Error()})
+				httpx.SendResponse(w, ld, response)
+				return
 			}
+			deletedFiles++
 		}
 		if deletedFiles == 0 {
 			ld.Status = logging.StatusWarn
@@ -3,11 +3,9 @@ package routes_logging
 import (
 	"aura/logging"
 	"aura/utils/httpx"
-	"bufio"
-	"encoding/json"
-	"io"
+	"fmt"
+	"maps"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -267,18 +265,23 @@ type GetLogContents_Response struct {
 	PossibleActionsPaths map[string]structActionLabelSection `json:"possible_actions_paths"`
 	LogEntries           []*logging.LogData                  `json:"log_entries"`
 	TotalLogEntries      int                                 `json:"total_log_entries"`
+	NextCursor           string                              `json:"next_cursor,omitempty"`
 }
 
 // GetLogContents godoc
 // @Summary      Get Log Entries
-// @Description  Retrieve log entries from the server's log file with optional filtering by log level, status, and route/action. This endpoint allows clients to access and analyze logs for monitoring and debugging purposes.
+// @Description  Retrieve log entries, newest written first, from the current and rotated log files with optional filtering by time range, log level, status, route/action and free text. Without page_number the results are paged with a cursor: pass the returned next_cursor to get the next page, only the files needed for the page are read. With page_number every log file is read so total_log_entries can be counted.
 // @Tags         Logging
 // @Produce      json
 // @Param        log_levels  query     string  false  "Comma-separated list of log levels to filter by (e.g. info,error,debug)"
 // @Param        statuses    query     string  false  "Comma-separated list of statuses to filter by (e.g. success,error)"
 // @Param        actions     query     string  false  "Comma-separated list of route paths or action names to filter by (e.g. GET:/api/db,User Login)"
+// @Param        from        query     string  false  "Only entries logged at or after this time (RFC3339)"
+// @Param        to          query     string  false  "Only entries logged at or before this time (RFC3339)"
+// @Param        search      query     string  false  "Only entries containing this text (case-insensitive)"
+// @Param        cursor      query     string  false  "next_cursor from the previous page"
 // @Param        items_per_page query   int     false  "Number of log entries to return per page (default: 20)"
-// @Param        page_number query     int     false  "Page number to return (default: 1)"
+// @Param        page_number query     int     false  "Page number to return, instead of a cursor"
 // @Security     SessionCookie
 // @Security     ApiKeyAuth
 // @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
@@ -291,247 +294,107 @@ func GetLogContents(w http.ResponseWriter, r *http.Request) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	var response GetLogContents_Response
 
-	// Read the log file
-	readContentsAction := logAction.AddSubAction("Read Log File", logging.LevelTrace)
-	file, err := os.Open(logging.LogFilePath)
-	if err != nil {
-		readContentsAction.SetError("Failed to open log file", "Make sure the log file exists and is accessible", map[string]any{
-			"path":  logging.LogFilePath,
-			"error": err.Error()})
-		httpx.SendResponse(w, ld, response)
-		return
-	}
-	defer file.Close()
-	readContentsAction.Complete()
-
-	// Query Param - Log Level Filter
-	filteredLogLevelsStr := r.URL.Query().Get("log_levels")
-	var filteredLogLevels []string
-	if filteredLogLevelsStr != "" {
-		filteredLogLevels = strings.Split(filteredLogLevelsStr, ",")
+	// Query Params - Filters
+	params := r.URL.Query()
+	query := logQuery{
+		levels:   splitQueryList(params.Get("log_levels")),
+		statuses: splitQueryList(params.Get("statuses")),
+		actions:  splitQueryList(params.Get("actions")),
+		search:   strings.ToLower(strings.TrimSpace(params.Get("search"))),
 	}
-
-	// Query Param - Status Filter
-	filteredStatusesStr := r.URL.Query().Get("statuses")
-	var filteredStatuses []string
-	if filteredStatusesStr != "" {
-		filteredStatuses = strings.Split(filteredStatusesStr, ",")
-	}
-
-	// Query Param - Route/Action Filter
-	filteredActionsStr := r.URL.Query().Get("actions")
-	var filteredActions []string
-	if filteredActionsStr != "" {
-		filteredActions = strings.Split(filteredActionsStr, ",")
+	for name, target := range map[string]*time.Time{"from": &query.from, "to": &query.to} {
+		value := params.Get(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			logAction.SetError(fmt.Sprintf("Invalid '%s' time", name), "Use an RFC3339 time, e.g. 2024-01-02T15:04:05Z", map[string]any{
+				name:    value,
+				"error": err.Error(),
+			})
+			httpx.SendResponse(w, ld, response)
+			return
+		}
+		*target = t
 	}
 
 	// Query Param - Pagination
 	itemsPerPage := 20
-	pageNumber := 1
-	ippStr := r.URL.Query().Get("items_per_page")
+	pageNumber := 0
+	ippStr := params.Get("items_per_page")
 	if ippStr != "" {
 		if val, err := strconv.Atoi(ippStr); err == nil && val > 0 {
 			itemsPerPage = val
 		}
 	}
 
-	pnStr := r.URL.Query().Get("page_number")
+	pnStr := params.Get("page_number")
 	if pnStr != "" {
 		if val, err := strconv.Atoi(pnStr); err == nil && val > 0 {
 			pageNumber = val
 		}
 	}
 
-	var logEntries []*logging.LogData
-	parseLogAction := logAction.AddSubAction("Parse Log Entries", logging.LevelTrace)
-	reader := bufio.NewReader(file)
-	for {
-		line, err := reader.ReadString('\n')
-		if err == io.EOF {
-			break
-		}
+	var cursor *logCursor
+	if cursorStr := params.Get("cursor"); cursorStr != "" && pageNumber == 0 {
+		decoded, err := decodeLogCursor(cursorStr)
 		if err != nil {
-			parseLogAction.SetError("Failed to read log file line", err.Error(), nil)
+			logAction.SetError("Invalid cursor", "Use the next_cursor returned with the previous page", map[string]any{"error": err.Error()})
 			httpx.SendResponse(w, ld, response)
 			return
 		}
-		var entry logging.LogData
-		if err := json.Unmarshal([]byte(line), &entry); err == nil {
-			// If there is no Route info and No Actions, skip this log entry
-			if entry.Route == nil && len(entry.Actions) == 0 {
-				continue
-			}
-			// If there is Route info and Actions are present, skip "Route Not Found" entries
-			if entry.Route != nil && entry.Route.Path != "" && len(entry.Actions) != 0 && entry.Actions[0].Name == "Route Not Found" {
-				continue
-			}
-
-			// Auto-register seen route paths so filters stay in sync with router changes
-			if entry.Route != nil && entry.Route.Path != "" {
-				path := entry.Route.Path
-				key := routeActionKey(entry.Route.Method, path)
-
-				label := key
-				if len(entry.Actions) > 0 && entry.Actions[0] != nil && strings.TrimSpace(entry.Actions[0].Name) != "" {
-					label = entry.Actions[0].Name
-				}
-
-				possibleActionsMutex.Lock()
-				if _, exists := possible_actions_paths[key]; !exists {
-					possible_actions_paths[key] = structActionLabelSection{
-						Label:   label,
-						Section: inferRouteSection(path),
-					}
-				}
-				possibleActionsMutex.Unlock()
-			}
-
-			if entry.Route == nil && len(entry.Actions) != 0 {
-				// If there is no Route info but Actions are present, append Action Name to possible_actions_paths
-				actionName := entry.Message
-				possibleActionsMutex.Lock()
-				if _, exists := possible_actions_paths[actionName]; !exists {
-					possible_actions_paths[actionName] = structActionLabelSection{
-						Label:   actionName,
-						Section: "AURA BACKGROUND TASK",
-					}
-				}
-				possibleActionsMutex.Unlock()
-			}
-
-			if entry.Timestamp.Equal((time.Time{})) && entry.Time != (time.Time{}) {
-				entry.Timestamp = entry.Time
-				entry.Time = time.Time{} // Clear the Time field so it doesn't show up in JSON
-			}
-			logEntries = append(logEntries, &entry)
-		} else {
-			continue
-		}
+		cursor = &decoded
 	}
-	parseLogAction.Complete()
-
-	// Apply Log Level Filter
-	if len(filteredLogLevels) > 0 {
-		filterLogLevelAction := logAction.AddSubAction("Apply Log Level Filter", logging.LevelTrace)
-		filteredEntries := make([]*logging.LogData, 0, len(logEntries))
-		for _, entry := range logEntries {
-			// If the level is error, always keep the entry
-			if strings.EqualFold(entry.Level, "error") {
-				filteredEntries = append(filteredEntries, entry)
-				continue
-			}
-			filteredActions := make([]*logging.LogAction, 0, len(entry.Actions))
-			for _, action := range entry.Actions {
-				filtered := filterLogActionByLevels(action, filteredLogLevels)
-				if filtered != nil {
-					filteredActions = append(filteredActions, filtered)
-				}
-			}
-			entry.Actions = filteredActions
-			if len(entry.Actions) > 0 {
-				filteredEntries = append(filteredEntries, entry)
-			}
-		}
-		logEntries = filteredEntries
-		filterLogLevelAction.Complete()
-	}
-
-	// Apply Status Filter
-	if len(filteredStatuses) > 0 {
-		filterStatusAction := logAction.AddSubAction("Apply Status Filter", logging.LevelTrace)
-		filteredEntries := make([]*logging.LogData, 0, len(logEntries))
-		for _, entry := range logEntries {
-			// If the status is error, always keep the entry
-			if strings.EqualFold(entry.Status, "error") {
-				filteredEntries = append(filteredEntries, entry)
-				continue
-			}
-			filteredActions := make([]*logging.LogAction, 0, len(entry.Actions))
-			for _, action := range entry.Actions {
-				filtered := filterLogActionByStatuses(action, filteredStatuses)
-				if filtered != nil {
-					filteredActions = append(filteredActions, filtered)
-				}
-			}
-			entry.Actions = filteredActions
-			if len(entry.Actions) > 0 {
-				filteredEntries = append(filteredEntries, entry)
-			}
-		}
-		logEntries = filteredEntries
-		filterStatusAction.Complete()
-	}
-
-	// Apply Route/Action Filter
-	if len(filteredActions) > 0 {
-		filterRouteAction := logAction.AddSubAction("Apply Route/Action Filter", logging.LevelTrace)
-		filteredEntries := make([]*logging.LogData, 0, len(logEntries))
-		for _, entry := range logEntries {
-			entryMatches := false
-
-			if entry.Route != nil && entry.Route.Path != "" {
-				key := routeActionKey(entry.Route.Method, entry.Route.Path)
-				for _, actionFilter := range filteredActions {
-					f := strings.TrimSpace(actionFilter)
-					if strings.EqualFold(key, f) || strings.EqualFold(entry.Route.Path, f) {
-						entryMatches = true
-						break
-					}
-				}
-			} else if entry.Message != "" {
-				// Background Task Name
-				for _, actionFilter := range filteredActions {
-					if strings.EqualFold(entry.Message, strings.TrimSpace(actionFilter)) {
-						entryMatches = true
-						break
-					}
-				}
-			}
 
-			if entryMatches {
-				filteredEntries = append(filteredEntries, entry)
+	logEntries := []*logging.LogData{}
+	scanLogAction := logAction.AddSubAction("Scan Log Files", logging.LevelTrace)
+	var scanErr error
+	if pageNumber > 0 {
+		// Page Number: every matching entry is needed to count them
+		totalNumberOfLogEntries := 0
+		startIndex := (pageNumber - 1) * itemsPerPage
+		scanErr = scanLogEntries(query, nil, func(entry *logging.LogData, _ logCursor) bool {
+			if totalNumberOfLogEntries >= startIndex && totalNumberOfLogEntries < startIndex+itemsPerPage {
+				logEntries = append(logEntries, entry)
 			}
-		}
-		logEntries = filteredEntries
-		filterRouteAction.Complete()
-	}
-
-	// Sort log entries by timestamp descending
-	for i, j := 0, len(logEntries)-1; i < j; i, j = i+1, j-1 {
-		logEntries[i], logEntries[j] = logEntries[j], logEntries[i]
-	}
-
-	// Get the total number of log entries before pagination
-	totalNumberOfLogEntries := len(logEntries)
-
-	// Apply Pagination
-	startIndex := (pageNumber - 1) * itemsPerPage
-	if startIndex >= totalNumberOfLogEntries {
-		logEntries = []*logging.LogData{}
+			totalNumberOfLogEntries++
+			return true
+		})
+		response.TotalLogEntries = totalNumberOfLogEntries
 	} else {
-		endIndex := startIndex + itemsPerPage
-		if endIndex > totalNumberOfLogEntries {
-			endIndex = totalNumberOfLogEntries
-		}
-		logEntries = logEntries[startIndex:endIndex]
+		// Cursor: stop as soon as the page is full
+		var last logCursor
+		scanErr = scanLogEntries(query, cursor, func(entry *logging.LogData, position logCursor) bool {
+			if len(logEntries) == itemsPerPage {
+				response.NextCursor = last.encode()
+				return false
+			}
+			logEntries = append(logEntries, entry)
+			last = position
+			return true
+		})
+		response.TotalLogEntries = len(logEntries)
 	}
-
-	pageStart := 0
-	pageEnd := 0
-	if len(logEntries) > 0 {
-		pageStart = startIndex + 1
-		pageEnd = startIndex + len(logEntries)
+	if scanErr != nil {
+		scanLogAction.SetError("Failed to read log files", "Make sure the log files exist and are accessible", map[string]any{
+			"path":  logging.LogFolder,
+			"error": scanErr.Error()})
+		httpx.SendResponse(w, ld, response)
+		return
 	}
+	scanLogAction.Complete()
 
-	logging.LOGGER.Debug().Timestamp().Msgf("Retrieved %d-%d of %d log entries after filtering and pagination",
-		pageStart, pageEnd, totalNumberOfLogEntries)
-	logAction.AppendResult("log_entries_total", totalNumberOfLogEntries)
+	logging.LOGGER.Debug().Timestamp().Msgf("Retrieved %d of %d log entries after filtering and pagination",
+		len(logEntries), response.TotalLogEntries)
+	logAction.AppendResult("log_entries_total", response.TotalLogEntries)
 	logAction.AppendResult("log_entries_returned", len(logEntries))
-	logAction.AppendResult("log_entries_filtered", totalNumberOfLogEntries-len(logEntries))
+	logAction.AppendResult("log_entries_filtered", response.TotalLogEntries-len(logEntries))
 
+	possibleActionsMutex.Lock()
+	response.PossibleActionsPaths = maps.Clone(possible_actions_paths)
+	possibleActionsMutex.Unlock()
 	response.LogEntries = logEntries
-	response.PossibleActionsPaths = possible_actions_paths
-	response.TotalLogEntries = totalNumberOfLogEntries
 	httpx.SendResponse(w, ld, response)
 }
 
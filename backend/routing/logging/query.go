@@ -0,0 +1,293 @@
+package routes_logging
+
+import (
+	"aura/logging"
+	"bufio"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logQuery holds the filters of a log query
+type logQuery struct {
+	levels   []string
+	statuses []string
+	actions  []string
+	from     time.Time // Zero for no lower bound
+	to       time.Time // Zero for no upper bound
+	search   string    // Lowercase text to look for anywhere in the entry
+}
+
+// logCursor is the write position of the last entry of a page: the log file it was written to and its line in that file.
+// Entries are written when their operation completes, so the line order is the write order, not the Timestamp
+// (start time) order. The next page starts at the line written before it, so a long operation that was written
+// late doesn't hide the entries written after it.
+// A log file is identified by when it was started (the rotation before it), which doesn't change when it is rotated.
+type logCursor struct {
+	FileStart time.Time // Zero for the oldest log file
+	Line      int
+}
+
+func (c logCursor) encode() string {
+	var nanos int64
+	if !c.FileStart.IsZero() {
+		nanos = c.FileStart.UnixNano()
+	}
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d.%d", nanos, c.Line))
+}
+
+func decodeLogCursor(value string) (cursor logCursor, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	nanos, line, found := strings.Cut(string(raw), ".")
+	if !found {
+		return cursor, fmt.Errorf("malformed cursor")
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return cursor, err
+	}
+	cursor.Line, err = strconv.Atoi(line)
+	if err != nil || cursor.Line < 0 {
+		return cursor, fmt.Errorf("malformed cursor")
+	}
+	if n != 0 {
+		cursor.FileStart = time.Unix(0, n)
+	}
+	return cursor, nil
+}
+
+// logLine is a matching entry and the line of its log file it was read from
+type logLine struct {
+	entry *logging.LogData
+	line  int
+}
+
+// scanLogEntries calls visit with every entry matching q and its write position, newest written first,
+// across the current and rotated log files. With a cursor, only the entries written before it are visited.
+// Files that can't hold an entry in the time range or before the cursor are not read.
+// The scan stops when visit returns false.
+func scanLogEntries(q logQuery, cursor *logCursor, visit func(entry *logging.LogData, position logCursor) bool) error {
+	files := logging.LogFiles()
+	for i, file := range files {
+		// A rotated file holds the entries written between the rotation before it (start) and its own rotation (end)
+		var start, end time.Time
+		if !file.RotatedAt.IsZero() {
+			end = file.RotatedAt
+		}
+		if i+1 < len(files) {
+			start = files[i+1].RotatedAt
+		}
+		// Entries are written after they start, so a file that ended before from holds nothing in range.
+		// There is no such shortcut for to: a long operation that started before it can be written to any newer file.
+		if !q.from.IsZero() && !end.IsZero() && end.Before(q.from) {
+			break // Every older file is out of range as well
+		}
+
+		before := -1 // Read every line
+		if cursor != nil {
+			if start.After(cursor.FileStart) {
+				continue // Written after the cursor
+			}
+			if end.IsZero() || cursor.FileStart.Before(end) {
+				// The file of the cursor. Its start is later than the cursor's when the file before it was removed.
+				before = cursor.Line
+			}
+		}
+
+		lines, err := readLogFile(file, q, before)
+		if err != nil {
+			return err
+		}
+		for j := len(lines) - 1; j >= 0; j-- {
+			if !visit(lines[j].entry, logCursor{FileStart: start, Line: lines[j].line}) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// readLogFile returns the entries of a log file that match q, oldest first.
+// Only the lines before line number before are read, or every line if it is negative.
+func readLogFile(file logging.LogFile, q logQuery, before int) ([]logLine, error) {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Rotated away or removed by the retention policy since it was listed
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if file.Compressed {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	lines := []logLine{}
+	reader := bufio.NewReader(r)
+	for lineNumber := 0; before < 0 || lineNumber < before; lineNumber++ {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if entry := parseLogLine(line, q); entry != nil {
+				lines = append(lines, logLine{entry: entry, line: lineNumber})
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+// parseLogLine returns the entry on line if it matches q, or nil
+func parseLogLine(line string, q logQuery) *logging.LogData {
+	// Free text is matched against the raw line, so it also finds results, warnings and params
+	if q.search != "" && !strings.Contains(strings.ToLower(line), q.search) {
+		return nil
+	}
+
+	var entry logging.LogData
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return nil
+	}
+	// If there is no Route info and No Actions, skip this log entry
+	if entry.Route == nil && len(entry.Actions) == 0 {
+		return nil
+	}
+	// If there is Route info and Actions are present, skip "Route Not Found" entries
+	if entry.Route != nil && entry.Route.Path != "" && len(entry.Actions) != 0 && entry.Actions[0].Name == "Route Not Found" {
+		return nil
+	}
+
+	registerPossibleAction(&entry)
+
+	if entry.Timestamp.Equal((time.Time{})) && entry.Time != (time.Time{}) {
+		entry.Timestamp = entry.Time
+		entry.Time = time.Time{} // Clear the Time field so it doesn't show up in JSON
+	}
+
+	if !q.from.IsZero() && entry.Timestamp.Before(q.from) {
+		return nil
+	}
+	if !q.to.IsZero() && entry.Timestamp.After(q.to) {
+		return nil
+	}
+	if !matchesLogFilters(&entry, q) {
+		return nil
+	}
+	return &entry
+}
+
+// registerPossibleAction auto-registers seen route paths and background tasks so filters stay in sync with router changes
+func registerPossibleAction(entry *logging.LogData) {
+	if entry.Route != nil && entry.Route.Path != "" {
+		path := entry.Route.Path
+		key := routeActionKey(entry.Route.Method, path)
+
+		label := key
+		if len(entry.Actions) > 0 && entry.Actions[0] != nil && strings.TrimSpace(entry.Actions[0].Name) != "" {
+			label = entry.Actions[0].Name
+		}
+
+		possibleActionsMutex.Lock()
+		if _, exists := possible_actions_paths[key]; !exists {
+			possible_actions_paths[key] = structActionLabelSection{
+				Label:   label,
+				Section: inferRouteSection(path),
+			}
+		}
+		possibleActionsMutex.Unlock()
+	}
+
+	if entry.Route == nil && len(entry.Actions) != 0 {
+		// If there is no Route info but Actions are present, append Action Name to possible_actions_paths
+		actionName := entry.Message
+		possibleActionsMutex.Lock()
+		if _, exists := possible_actions_paths[actionName]; !exists {
+			possible_actions_paths[actionName] = structActionLabelSection{
+				Label:   actionName,
+				Section: "AURA BACKGROUND TASK",
+			}
+		}
+		possibleActionsMutex.Unlock()
+	}
+}
+
+// matchesLogFilters applies the level, status and route/action filters.
+// The level and status filters drop the actions of the entry that don't match.
+func matchesLogFilters(entry *logging.LogData, q logQuery) bool {
+	// Log Level Filter (error entries are always kept)
+	if len(q.levels) > 0 && !strings.EqualFold(entry.Level, "error") {
+		filteredActions := make([]*logging.LogAction, 0, len(entry.Actions))
+		for _, action := range entry.Actions {
+			if filtered := filterLogActionByLevels(action, q.levels); filtered != nil {
+				filteredActions = append(filteredActions, filtered)
+			}
+		}
+		entry.Actions = filteredActions
+		if len(entry.Actions) == 0 {
+			return false
+		}
+	}
+
+	// Status Filter (error entries are always kept)
+	if len(q.statuses) > 0 && !strings.EqualFold(entry.Status, "error") {
+		filteredActions := make([]*logging.LogAction, 0, len(entry.Actions))
+		for _, action := range entry.Actions {
+			if filtered := filterLogActionByStatuses(action, q.statuses); filtered != nil {
+				filteredActions = append(filteredActions, filtered)
+			}
+		}
+		entry.Actions = filteredActions
+		if len(entry.Actions) == 0 {
+			return false
+		}
+	}
+
+	// Route/Action Filter
+	if len(q.actions) > 0 {
+		if entry.Route != nil && entry.Route.Path != "" {
+			key := routeActionKey(entry.Route.Method, entry.Route.Path)
+			return slices.ContainsFunc(q.actions, func(f string) bool {
+				return strings.EqualFold(key, f) || strings.EqualFold(entry.Route.Path, f)
+			})
+		}
+		// Background Task Name
+		return entry.Message != "" && slices.ContainsFunc(q.actions, func(f string) bool {
+			return strings.EqualFold(entry.Message, f)
+		})
+	}
+
+	return true
+}
+
+// splitQueryList splits a comma-separated query parameter, dropping empty values
+func splitQueryList(value string) []string {
+	list := []string{}
+	for part := range strings.SplitSeq(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			list = append(list, part)
+		}
+	}
+	return list
+}
@@ -0,0 +1,61 @@
+package routes_ms
+
+import (
+	"aura/logging"
+	"aura/mediaserver"
+	"aura/utils/httpx"
+	"net/http"
+)
+
+type CreateCollectionFromSet_Request struct {
+	SetID        string `json:"set_id"`        // MediUX movie collection set ID
+	TMDB_ID      string `json:"tmdb_id"`       // TMDB ID of a movie in the collection
+	LibraryTitle string `json:"library_title"` // Library to create the collection in
+	Title        string `json:"title"`         // Optional collection title, defaults to the set title
+}
+
+// CreateCollectionFromSet godoc
+// @Summary      Create Collection From Set
+// @Description  Create a media server collection (Plex Collection or Emby/Jellyfin BoxSet) for a MediUX movie collection set. The member movies found in the library are added and the collection poster and backdrop are applied. If the collection already exists, the member movies are added to it.
+// @Tags         MediaServer
+// @Accept       json
+// @Produce      json
+// @Param        req  body      CreateCollectionFromSet_Request  true  "Create Collection From Set Request"
+// @Security     SessionCookie
+// @Security     ApiKeyAuth
+// @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
+// @Success      200  {object}  httpx.JSONResponse{data=mediaserver.CreateCollectionFromSetResult}
+// @Failure      500  {object}  httpx.JSONResponse "Internal Server Error"
+// @Router       /api/mediaserver/collections [post]
+func CreateCollectionFromSet(w http.ResponseWriter, r *http.Request) {
+	ctx, ld := logging.CreateLoggingContext(r.Context(), r.URL.Path)
+	logAction := ld.AddAction("Create Collection From Set", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, logAction)
+
+	var req CreateCollectionFromSet_Request
+	var response mediaserver.CreateCollectionFromSetResult
+
+	Err := httpx.DecodeRequestBodyToJSON(ctx, r.Body, &req, "Create Collection From Set - Decode Request Body")
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	if req.SetID == "" || req.TMDB_ID == "" || req.LibraryTitle == "" {
+		logAction.SetError("Missing required fields", "set_id, tmdb_id and library_title are required", map[string]any{
+			"set_id":        req.SetID,
+			"tmdb_id":       req.TMDB_ID,
+			"library_title": req.LibraryTitle,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	response, Err = mediaserver.CreateCollectionFromSet(ctx, req.SetID, req.TMDB_ID, req.LibraryTitle, req.Title)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	httpx.SendResponse(w, ld, response)
+}
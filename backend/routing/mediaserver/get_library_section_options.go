@@ -48,7 +48,7 @@ func GetLibrarySectionOptions(w http.ResponseWriter, r *http.Request) {
 	msConfig := req.MediaServer
 
 	if strings.HasPrefix(msConfig.ApiToken, "***") {
-		msConfig.ApiToken = config.Current.MediaServer.ApiToken
+		msConfig.ApiToken = config.Current(ctx).MediaServer.ApiToken
 	}
 
 	// Get all available library sections from the Media Server
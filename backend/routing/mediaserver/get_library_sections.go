@@ -31,7 +31,7 @@ func GetLibrarySections(w http.ResponseWriter, r *http.Request) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	var response GetLibrarySections_Response
 
-	for _, libSection := range config.Current.MediaServer.Libraries {
+	for _, libSection := range config.Current(ctx).MediaServer.Libraries {
 		base := models.LibrarySectionBase{
 			ID:    libSection.ID,
 			Title: libSection.Title,
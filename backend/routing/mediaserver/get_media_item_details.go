@@ -75,7 +75,7 @@ func GetMediaItemDetails(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.ServerType = config.Current.MediaServer.Type
+	response.ServerType = config.Current(ctx).MediaServer.Type
 	response.MediaItem = *mediaItem
 
 	// If the return type is item, return only the media item details
@@ -73,7 +73,7 @@ func GetAllCollectionChildrenItems(w http.ResponseWriter, r *http.Request) {
 	// Get Poster Sets for the collection items
 	// For Plex Servers, we need to get an array of unique TMDB IDs from the collection items
 	// For Emby/Jellyfin, we can use the existing TMDB IDs in the collection items
-	switch config.Current.MediaServer.Type {
+	switch config.Current(ctx).MediaServer.Type {
 	case "Plex":
 		uniqueMovieIDs := make([]string, 0)
 		for _, child := range collectionItem.MediaItems {
@@ -96,7 +96,7 @@ func GetAllCollectionChildrenItems(w http.ResponseWriter, r *http.Request) {
 
 	default:
 		logAction.SetError("Unsupported Media Server Type", "The media server type is not supported for fetching collection items", map[string]any{
-			"server_type": config.Current.MediaServer.Type,
+			"server_type": config.Current(ctx).MediaServer.Type,
 		})
 		httpx.SendResponse(w, ld, response)
 		return
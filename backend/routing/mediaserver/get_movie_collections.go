@@ -34,7 +34,7 @@ func GetMovieCollections(w http.ResponseWriter, r *http.Request) {
 	var response GetMovieCollections_Response
 
 	var Err logging.LogErrorInfo
-	switch config.Current.MediaServer.Type {
+	switch config.Current(ctx).MediaServer.Type {
 	case "Plex":
 		logging.DevMsg("Fetching movie collections from Plex media server")
 		response.Collections, Err = getPlexMovieCollections(ctx)
@@ -51,7 +51,7 @@ func GetMovieCollections(w http.ResponseWriter, r *http.Request) {
 		}
 	default:
 		logAction.SetError("Unsupported media server type", "The configured media server type is not supported for fetching collections", map[string]any{
-			"media_server_type": config.Current.MediaServer.Type,
+			"media_server_type": config.Current(ctx).MediaServer.Type,
 		})
 		httpx.SendResponse(w, ld, response)
 		return
@@ -64,7 +64,7 @@ func getPlexMovieCollections(ctx context.Context) (collections []models.Collecti
 	collections = []models.CollectionItem{}
 
 	// Get all Movie Library Sections
-	sections, Err := mediaserver.GetLibrarySections(ctx, &config.Current.MediaServer)
+	sections, Err := mediaserver.GetLibrarySections(ctx, &config.Current(ctx).MediaServer)
 	if Err.Message != "" {
 		return collections, Err
 	}
@@ -83,7 +83,7 @@ func getPlexMovieCollections(ctx context.Context) (collections []models.Collecti
 			continue
 		}
 		// Check to see if this section is the list of configured libraries
-		currentSections := config.Current.MediaServer.Libraries
+		currentSections := config.Current(ctx).MediaServer.Libraries
 		if len(currentSections) > 0 {
 			found := false
 			for _, currentSection := range currentSections {
@@ -35,7 +35,7 @@ func RateMediaItem(w http.ResponseWriter, r *http.Request) {
 	ctx = logging.WithCurrentAction(ctx, logAction)
 	var response RateMediaItem_Response
 
-	if config.Current.MediaServer.Type != "Plex" {
+	if config.Current(ctx).MediaServer.Type != "Plex" {
 		httpx.SendResponse(w, ld, response)
 		return
 	}
@@ -2,11 +2,14 @@ package routes_mediux
 
 import (
 	"aura/cache"
+	"aura/config"
+	"aura/imagesource"
 	"aura/logging"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils/httpx"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 type getItemSetsResponse struct {
@@ -16,7 +19,7 @@ type getItemSetsResponse struct {
 
 // GetItemSets godoc
 // @Summary      Get Mediux Item Sets
-// @Description  Retrieve item sets for a specific media item in the library. This endpoint accepts query parameters to identify the media item and its library, and returns any related item sets (such as show sets for TV shows or movie sets/collections for movies) that the media item belongs to, allowing clients to display related items and collections in the UI.
+// @Description  Retrieve item sets for a specific media item in the library. This endpoint accepts query parameters to identify the media item and its library, and returns any related item sets (such as show sets for TV shows or movie sets/collections for movies) that the media item belongs to, allowing clients to display related items and collections in the UI. If MediUX has no sets for the item and a TMDB API Token is configured, a single TMDB pseudo-set is returned instead. The TMDB images are filtered by the TMDB.Languages and TMDB.MinVoteAverage settings, unless the tmdb_ query params are sent.
 // @Tags         Mediux
 // @Accept       json
 // @Produce      json
@@ -24,6 +27,8 @@ type getItemSetsResponse struct {
 // @Param        item_type query string true "Type of the media item (movie or show)"
 // @Param        item_library_title query string true "Title of the library the media item belongs to"
 // @Param        edition query string false "Edition of the media item (e.g. Director's Cut), empty for the standard edition"
+// @Param        tmdb_languages query string false "Comma separated ISO 639-1 language codes to filter TMDB fallback images by (use 'none' for textless images)"
+// @Param        tmdb_min_vote_average query number false "Minimum TMDB vote average for TMDB fallback images"
 // @Security     SessionCookie
 // @Security     ApiKeyAuth
 // @Failure      401  {object}  httpx.UnauthorizedResponse "Unauthorized (only when Auth.Enabled=true)"
@@ -61,46 +66,47 @@ func GetItemSets(w http.ResponseWriter, r *http.Request) {
 	}
 	actionCheckCache.Complete()
 
-	switch itemType {
-	case "show":
-		// For Shows, we get just Show Sets
-		showSets, showItems, Err := mediux.GetShowItemSets(ctx, tmdbID, itemLibraryTitle, edition)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Sets = showSets
-		response.IncludedItems = showItems
-	case "movie":
-		setItems := map[string]models.IncludedItem{}
-		// For Movies, we get Movie Sets and Movie Collection Sets
-		movieSets, Err := mediux.GetMovieItemSets(ctx, tmdbID, itemLibraryTitle, edition, &setItems)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Sets = movieSets
-		collectionSets, Err := mediux.GetMovieItemCollectionSets(ctx, tmdbID, itemLibraryTitle, edition, &setItems)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Sets = append(response.Sets, collectionSets...)
-		if len(movieSets) == 0 && len(collectionSets) == 0 && len(setItems) == 0 {
-			logAction.SetError("No Sets Found", "No movie sets or collection sets found for the provided TMDB ID", map[string]any{
-				"tmdb_id": tmdbID,
+	if itemType != "show" && itemType != "movie" {
+		logAction.SetError("Invalid Item Type", "The provided item type is not valid", map[string]any{
+			"item_type": itemType,
+		})
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+
+	// The filter is only applied by image sources that support it (TMDB)
+	// The query params override the configured TMDB filter
+	filter := config.Current(ctx).TMDB.ImageFilter()
+	if languages := r.URL.Query().Get("tmdb_languages"); languages != "" {
+		filter.Languages = strings.Split(languages, ",")
+	}
+	if minVote := r.URL.Query().Get("tmdb_min_vote_average"); minVote != "" {
+		minVoteAverage, err := strconv.ParseFloat(minVote, 64)
+		if err != nil {
+			logAction.SetError("Invalid Query Parameter", "tmdb_min_vote_average must be a number", map[string]any{
+				"tmdb_min_vote_average": minVote,
 			})
 			httpx.SendResponse(w, ld, response)
 			return
 		}
-		response.IncludedItems = setItems
-	default:
-		logAction.SetError("Invalid Item Type", "The provided item type is not valid", map[string]any{
-			"item_type": itemType,
+		filter.MinVoteAverage = minVoteAverage
+	}
+
+	// If MediUX has no sets for this item, the image source falls back to TMDB images (if configured)
+	sets, includedItems, Err := imagesource.GetItemSets(ctx, tmdbID, itemType, itemLibraryTitle, edition, filter)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
+	}
+	if itemType == "movie" && len(sets) == 0 && len(includedItems) == 0 {
+		logAction.SetError("No Sets Found", "No movie sets or collection sets found for the provided TMDB ID", map[string]any{
+			"tmdb_id": tmdbID,
 		})
 		httpx.SendResponse(w, ld, response)
 		return
 	}
+	response.Sets = sets
+	response.IncludedItems = includedItems
 
 	httpx.SendResponse(w, ld, response)
 }
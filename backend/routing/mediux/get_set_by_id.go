@@ -1,8 +1,8 @@
 package routes_mediux
 
 import (
+	"aura/imagesource"
 	"aura/logging"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils/httpx"
 	"net/http"
@@ -60,33 +60,13 @@ func GetSetByID(w http.ResponseWriter, r *http.Request) {
 	}
 	actionGetQueryParams.Complete()
 
-	switch setType {
-	case "show":
-		showSet, includedItems, Err := mediux.GetShowSetByID(ctx, setID, itemLibraryTitle, edition)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Set = showSet
-		response.IncludedItems = includedItems
-	case "movie":
-		movieSet, includedItems, Err := mediux.GetMovieSetByID(ctx, setID, itemLibraryTitle, edition)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Set = movieSet
-		response.IncludedItems = includedItems
-
-	case "collection":
-		collectionSet, includedItems, Err := mediux.GetMovieCollectionSetByID(ctx, setID, tmdbID, itemLibraryTitle, edition, true)
-		if Err.Message != "" {
-			httpx.SendResponse(w, ld, response)
-			return
-		}
-		response.Set = collectionSet
-		response.IncludedItems = includedItems
+	set, includedItems, Err := imagesource.GetSetByID(ctx, models.BaseSetInfo{ID: setID, Type: setType}, tmdbID, itemLibraryTitle, edition)
+	if Err.Message != "" {
+		httpx.SendResponse(w, ld, response)
+		return
 	}
+	response.Set = set
+	response.IncludedItems = includedItems
 
 	httpx.SendResponse(w, ld, response)
 }
@@ -17,6 +17,8 @@ import (
 var publicExactPaths = []string{
 	"/api",
 	"/api/health",
+	"/api/health/live",
+	"/api/health/ready",
 	"/api/login",
 	"/api/logout",
 	"/api/config/auth-methods",
@@ -41,7 +43,7 @@ var publicPathPrefixes = []string{
 func Authenticator(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Skip if auth globally disabled
-		if !config.Current.Auth.Enabled {
+		if !config.Latest().Auth.Enabled {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -147,6 +149,11 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 			ld.Route.Params = r.URL.Query()
 		}
 
+		// Continue the caller's trace (e.g. Sonarr or a reverse proxy), if it sent one
+		if traceparent := r.Header.Get("traceparent"); traceparent != "" {
+			ld.SetRemoteParent(traceparent)
+		}
+
 		ctx = logging.WithLogData(r.Context(), ld)
 		next.ServeHTTP(wrapped, r.WithContext(ctx))
 
@@ -36,7 +36,7 @@ func Configure(r *chi.Mux) {
 	// Auth.AllowedOrigins lets an admin opt a specific extra origin in if they're calling the API
 	// directly from somewhere else. Router is rebuilt on every config change (see NewRouter), so
 	// this always reflects the current config.
-	allowedOrigins := config.Current.Auth.AllowedOrigins
+	allowedOrigins := config.Latest().Auth.AllowedOrigins
 	cors := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			return slices.Contains(allowedOrigins, origin)
@@ -30,8 +30,8 @@ import (
 
 func AddRoutes(r *chi.Mux) {
 	// If the config is not valid, only allow access to the /onboarding routes
-	if !(config.Loaded && config.Valid) {
-		logging.LOGGER.Warn().Timestamp().Bool("loaded", config.Loaded).Bool("valid", config.Valid).Msg("Configuration invalid or not loaded, adding onboarding routes only")
+	if !(config.Latest().Status.Loaded && config.Latest().Status.Valid) {
+		logging.LOGGER.Warn().Timestamp().Bool("loaded", config.Latest().Status.Loaded).Bool("valid", config.Latest().Status.Valid).Msg("Configuration invalid or not loaded, adding onboarding routes only")
 		addOnboardingRoutes(r)
 		return
 	} else {
@@ -52,6 +52,8 @@ func AddRoutes(r *chi.Mux) {
 		// Base Routes
 		r.Get("/", routes_base.HealthCheck)
 		r.Get("/health", routes_base.HealthCheck)
+		r.Get("/health/live", routes_base.LivenessCheck)
+		r.Get("/health/ready", routes_base.ReadinessCheck)
 
 		// Login - starts a browser session
 		r.Post("/login", routes_auth.AttemptLogin)
@@ -75,6 +77,9 @@ func AddRoutes(r *chi.Mux) {
 			r.Get("/template-variables", routes_config.GetNotificationTemplateVariables)
 			r.Post("/", routes_config.UpdateAppConfig)
 			r.Patch("/", routes_config.ReloadAppConfig)
+			r.Get("/revisions", routes_config.GetConfigRevisions)
+			r.Get("/revisions/diff", routes_config.GetConfigRevisionDiff)
+			r.Post("/revisions/rollback", routes_config.RollbackConfigRevision)
 			r.Get("/auth-methods", routes_auth.GetAuthMethods)
 			r.Post("/auth/api-key", routes_auth.GenerateAPIKey)
 		})
@@ -88,6 +93,14 @@ func AddRoutes(r *chi.Mux) {
 			r.Patch("/ignore", routes_db.IgnoreItemInDB)
 			r.Patch("/ignore/stop", routes_db.StopIgnoringItemInDB)
 			r.Post("/force-check", routes_db.AutoDownloadForceCheck)
+			r.Get("/auto-apply/dry-run", routes_db.AutoApplyDryRun)
+			r.Get("/coverage", routes_db.GetCoverageReport)
+			r.Get("/wanted", routes_db.GetAllWantedItems)
+			r.Post("/wanted", routes_db.SaveWantedItem)
+			r.Delete("/wanted", routes_db.DeleteWantedItem)
+			r.Get("/collection", routes_db.GetAllCollections)
+			r.Post("/collection", routes_db.SaveCollection)
+			r.Delete("/collection", routes_db.DeleteCollection)
 		})
 
 		// Download Routes
@@ -95,6 +108,9 @@ func AddRoutes(r *chi.Mux) {
 			// Download
 			r.Post("/image/item", routes_download.DownloadImageFileForMediaItem)
 			r.Post("/image/collection", routes_download.DownloadImageFileForCollectionItem)
+			r.Post("/boxset", routes_download.ApplyBoxset)
+			r.Get("/restore-originals", routes_download.GetRestoreOriginals)
+			r.Post("/restore-originals", routes_download.StartRestoreOriginals)
 
 			// Download Queue Routes
 			r.Route("/queue", func(r chi.Router) {
@@ -102,6 +118,7 @@ func AddRoutes(r *chi.Mux) {
 				r.Get("/item", routes_download.GetAllDownloadQueueItems)
 				r.Post("/item", routes_download.AddItemToDownloadQueue)
 				r.Delete("/item", routes_download.RemoveItemFromDownloadQueue)
+				r.Get("/batch", routes_download.GetDownloadQueueBatch)
 			})
 		})
 
@@ -111,6 +128,11 @@ func AddRoutes(r *chi.Mux) {
 			r.Get("/media/collection", routes_images.GetCollectionItemImage)
 			r.Get("/mediux/item", routes_images.GetMediuxImage)
 			r.Get("/mediux/avatar", routes_images.GetMediuxAvatarImage)
+			r.Get("/local/item", routes_images.GetLocalArtworkImage)
+			r.Get("/history", routes_images.GetArtworkHistory)
+			r.Get("/history/item", routes_images.GetArtworkHistoryImage)
+			r.Post("/history/revert", routes_images.RevertArtwork)
+			r.Get("/drift", routes_images.GetArtworkDriftReport)
 			r.Delete("/temp", routes_images.DeleteTempImages)
 		})
 
@@ -140,6 +162,7 @@ func AddRoutes(r *chi.Mux) {
 			r.Get("/library/items", routes_ms.GetLibrarySectionItems)
 			r.Get("/item", routes_ms.GetMediaItemDetails)
 			r.Get("/collections", routes_ms.GetMovieCollections)
+			r.Post("/collections", routes_ms.CreateCollectionFromSet)
 			r.Get("/collections/item", routes_ms.GetAllCollectionChildrenItems)
 			r.Patch("/rate", routes_ms.RateMediaItem)
 			r.Post("/refresh", routes_ms.RefreshMediaItemMetadata)
@@ -174,6 +197,8 @@ func addOnboardingRoutes(r *chi.Mux) {
 		// Base Routes
 		r.Get("/", routes_base.HealthCheck)
 		r.Get("/health", routes_base.HealthCheck)
+		r.Get("/health/live", routes_base.LivenessCheck)
+		r.Get("/health/ready", routes_base.ReadinessCheck)
 
 		// Config Routes
 		r.Route("/config", func(r chi.Router) {
@@ -210,7 +235,7 @@ func addOnboardingRoutes(r *chi.Mux) {
 			logAction := ld.AddAction("Finalize Onboarding", logging.LevelInfo)
 			ctx = logging.WithCurrentAction(ctx, logAction)
 
-			if !(config.Loaded && config.Valid) {
+			if !(config.Latest().Status.Loaded && config.Latest().Status.Valid) {
 				logAction.SetError("Configuration is not valid or not loaded", "", nil)
 				ld.Status = logging.StatusError
 				httpx.SendResponse(w, ld, nil)
@@ -12,19 +12,19 @@ import (
 
 func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPosterSetDetail, image models.ImageFile, isUpgrade bool, result string) {
 	// If notifications are disabled, skip
-	if !config.Current.Notifications.Enabled {
+	if !config.Latest().Notifications.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Notifications are disabled, skipping app start notification")
 		return
 	}
 
 	// If notification providers are not configured, skip
-	if len(config.Current.Notifications.Providers) == 0 {
+	if len(config.Latest().Notifications.Providers) == 0 {
 		logging.LOGGER.Debug().Timestamp().Msg("No notification providers configured, skipping app start notification")
 		return
 	}
 
 	// If Sonarr/Radarr notification is disabled, skip
-	if !config.Current.Notifications.NotificationTemplate.SonarrNotification.Enabled {
+	if !config.Latest().Notifications.NotificationTemplate.SonarrNotification.Enabled {
 		logging.LOGGER.Debug().Timestamp().Msg("Sonarr/Radarr notification is disabled, skipping app start notification")
 		return
 	}
@@ -37,10 +37,10 @@ func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPoste
 	}
 
 	vars := utils.TemplateVars_SonarrNotification(mediaItem, set, image, reasonTitle, reason, result)
-	title := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.SonarrNotification.Title, vars)
-	message := utils.RenderTemplate(config.Current.Notifications.NotificationTemplate.SonarrNotification.Message, vars)
+	title := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.SonarrNotification.Title, vars)
+	message := utils.RenderTemplate(config.Latest().Notifications.NotificationTemplate.SonarrNotification.Message, vars)
 	imageURL := ""
-	if config.Current.Notifications.NotificationTemplate.SonarrNotification.IncludeImage {
+	if config.Latest().Notifications.NotificationTemplate.SonarrNotification.IncludeImage {
 		imageURL = fmt.Sprintf("%s/%s?v=%s&key=jpg",
 			"https://images.mediux.io/assets",
 			image.ID,
@@ -53,7 +53,7 @@ func sendFileDownloadNotification(mediaItem models.MediaItem, set models.DBPoste
 	ctx = logging.WithCurrentAction(ctx, logAction)
 
 	// Send a notification to all configured providers
-	for _, provider := range config.Current.Notifications.Providers {
+	for _, provider := range config.Current(ctx).Notifications.Providers {
 		if provider.Enabled {
 			switch provider.Provider {
 			case "Discord":
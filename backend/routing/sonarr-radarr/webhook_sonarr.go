@@ -1,11 +1,13 @@
 package routes_sonarr_radarr
 
 import (
+	"aura/artworkhistory"
 	"aura/cache"
 	"aura/database"
+	autodownload "aura/download/auto"
+	"aura/imagesource"
 	"aura/logging"
 	"aura/mediaserver"
-	"aura/mediux"
 	"aura/models"
 	"aura/utils"
 	"aura/utils/httpx"
@@ -25,6 +27,7 @@ type SonarrWebHookOnUpgradePayload struct {
 	InstanceName string          `json:"instanceName"`
 	IsUpgrade    bool            `json:"isUpgrade"`
 	Series       SonarrSeries    `json:"series"`
+	Movie        RadarrMovie     `json:"movie"` // Only sent by Radarr
 }
 
 type SonarrFile struct {
@@ -43,6 +46,11 @@ type SonarrSeries struct {
 	TmdbID int    `json:"tmdbId"`
 }
 
+type RadarrMovie struct {
+	Title  string `json:"title"`
+	TmdbID int    `json:"tmdbId"`
+}
+
 type mediaItemFetchInfo struct {
 	FromCache bool
 	FetchErr  string
@@ -84,7 +92,36 @@ func SonarrWebhookHandler(w http.ResponseWriter, r *http.Request) {
 		logAction.AppendResult("event_type", payload.EventType)
 		w.WriteHeader(http.StatusOK)
 		return
-	} else if payload.Series == (SonarrSeries{}) || payload.Series.TmdbID == 0 {
+	}
+
+	// Items on the wanted list are not in the library yet, so there is nothing saved for them in the DB
+	// The wanted entry decides the library, so the item is looked up in the same library CheckWantedItems uses
+	tmdbID, itemType := getPayloadTMDBIDAndType(payload)
+	if wanted, found := autodownload.GetWantedItem(ctx, tmdbID, itemType); tmdbID != "" && found {
+		logAction.AppendResult("wanted_item", fmt.Sprintf("%s | %s", itemType, tmdbID))
+		w.WriteHeader(http.StatusOK)
+		receivedAt := time.Now()
+		go func() {
+			bgCtx, bgLd := logging.CreateLoggingContext(context.Background(), "Handle Sonarr Webhook Background Task")
+			bgLd.ContinueTraceFrom(ctx)
+			bgAction := bgLd.AddAction(fmt.Sprintf("Sonarr Webhook: Checking Wanted Item %s | %s", itemType, tmdbID), logging.LevelInfo)
+			bgCtx = logging.WithCurrentAction(bgCtx, bgAction)
+
+			// Handle Panic to prevent crashing the app since this is running in the background
+			defer func() {
+				if r := recover(); r != nil {
+					logging.LOGGER.Error().Timestamp().Msgf("PANIC: in SonarrWebhookHandler wanted item processing: %v", r)
+				}
+			}()
+
+			processWantedItemDownloadEvent(bgCtx, wanted, receivedAt)
+			bgAction.Complete()
+			bgLd.Log()
+		}()
+		return
+	}
+
+	if payload.Series == (SonarrSeries{}) || payload.Series.TmdbID == 0 {
 		logAction.AppendResult("series_info", "missing or invalid")
 		w.WriteHeader(http.StatusOK)
 		return
@@ -195,6 +232,7 @@ func SonarrWebhookHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		bgCtx, bgLd := logging.CreateLoggingContext(context.Background(), "Handle Sonarr Webhook Background Task")
+		bgLd.ContinueTraceFrom(ctx)
 		bgAction := bgLd.AddAction(fmt.Sprintf("Sonarr Webhook: Processing %s for %s", eventTypeStr, utils.MediaItemInfo(dbItem.MediaItem)), logging.LevelInfo)
 		bgCtx = logging.WithCurrentAction(bgCtx, bgAction)
 
@@ -288,8 +326,8 @@ func processSonarrDownloadEvent(ctx context.Context, payload SonarrWebHookOnUpgr
 			continue
 		}
 
-		// Get the latest set details from MediUX
-		mediuxSet, _, Err := mediux.GetShowSetByID(ctx, dbSet.ID, mediaItem.LibraryTitle, mediaItem.Edition)
+		// Get the latest set details from the image source the set came from
+		mediuxSet, _, Err := imagesource.GetSetByID(ctx, dbSet.BaseSetInfo, mediaItem.TMDB_ID, mediaItem.LibraryTitle, mediaItem.Edition)
 		if Err.Message != "" {
 			logging.LOGGER.Error().Timestamp().Msgf("Error fetching set details from MediUX for set ID %s: %s", dbSet.ID, Err.Message)
 			continue
@@ -381,7 +419,7 @@ func processSonarrDownloadEvent(ctx context.Context, payload SonarrWebHookOnUpgr
 		dbUpdateRequired := false
 		for _, image := range imagesToDownload {
 			result := ""
-			Err := mediaserver.DownloadApplyImageToMediaItem(ctx, mediaItem, image)
+			Err := mediaserver.DownloadApplyImageToMediaItem(mediaserver.WithArtworkChanged(artworkhistory.WithReplacingSet(ctx, dbSet.BaseSetInfo)), mediaItem, image)
 			if Err.Message != "" {
 				logging.LOGGER.Error().Timestamp().Msgf("Error downloading/applying image from set ID %s to media item %s: %s", dbSet.ID, utils.MediaItemInfo(*mediaItem), Err.Message)
 				result = "Error: " + Err.Message
@@ -430,3 +468,51 @@ func processSonarrDownloadEvent(ctx context.Context, payload SonarrWebHookOnUpgr
 		}
 	}
 }
+
+// getPayloadTMDBIDAndType returns the TMDB ID and item type of a Sonarr series or a Radarr movie
+func getPayloadTMDBIDAndType(payload SonarrWebHookOnUpgradePayload) (tmdbID string, itemType string) {
+	if payload.Series.TmdbID != 0 {
+		return strconv.Itoa(payload.Series.TmdbID), "show"
+	}
+	if payload.Movie.TmdbID != 0 {
+		return strconv.Itoa(payload.Movie.TmdbID), "movie"
+	}
+	return "", ""
+}
+
+// Items changed a little before the webhook was received are fetched too, in case the media server clock is behind
+const wantedItemRefreshOverlap = 5 * time.Minute
+
+// processWantedItemDownloadEvent refreshes the library of the wanted item until the downloaded item shows up,
+// then saves and queues the set chosen for it on the wanted list.
+// Only the items of that library changed since the webhook was received (minus a small overlap) are fetched.
+func processWantedItemDownloadEvent(ctx context.Context, wanted models.DBWantedItem, receivedAt time.Time) {
+	retrySleep := 30 * time.Second
+	maxRetries := 4
+	since := receivedAt.Add(-wantedItemRefreshOverlap).Unix()
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		// Give the media server time to ingest the new files
+		_, sleepAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Sleeping for %v to give time for media server to update", retrySleep), logging.LevelTrace)
+		select {
+		case <-ctx.Done():
+			sleepAction.Complete()
+			return
+		case <-time.After(retrySleep):
+		}
+		sleepAction.Complete()
+
+		if !mediaserver.RefreshLibrarySection(ctx, wanted.LibraryTitle, since) {
+			continue
+		}
+		autodownload.CheckWantedItems(ctx)
+		if _, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBID(wanted.LibraryTitle, wanted.TMDB_ID); found {
+			return
+		}
+	}
+
+	logging.LOGGER.Warn().Timestamp().
+		Str("library_title", wanted.LibraryTitle).
+		Str("tmdb_id", wanted.TMDB_ID).
+		Msg("Sonarr Webhook: Wanted item did not show up in the library, it will be checked again on the next refresh")
+}
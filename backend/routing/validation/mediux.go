@@ -47,7 +47,7 @@ func ValidateMediuxInfo(w http.ResponseWriter, r *http.Request) {
 
 	// If the MediUX Token is masked, retrieve the actual token from the config
 	if config.IsMaskedField(mediuxInfo.ApiToken) {
-		mediuxInfo.ApiToken = config.Current.Mediux.ApiToken
+		mediuxInfo.ApiToken = config.Current(ctx).Mediux.ApiToken
 	}
 
 	isValid, Err := mediux.ValidateToken(ctx, mediuxInfo.ApiToken)
@@ -9,6 +9,7 @@ import (
 	"aura/utils/httpx"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type SendTestNotification_Request struct {
@@ -199,6 +200,41 @@ func SendTestNotification(w http.ResponseWriter, r *http.Request) {
 		title = utils.RenderTemplate(req.Template.Title, vars)
 		message = utils.RenderTemplate(req.Template.Message, vars)
 		imageURL = ""
+	case config.TemplateTypeWantedItemAvailable:
+		vars = utils.MergeTemplateVars(
+			utils.BaseTemplateVars(),
+			map[string]string{
+				"MediaItemTitle":        sampleMediaItem.Title,
+				"MediaItemYear":         fmt.Sprintf("%d", sampleMediaItem.Year),
+				"MediaItemTMDBID":       sampleMediaItem.TMDB_ID,
+				"MediaItemLibraryTitle": sampleMediaItem.LibraryTitle,
+				"MediaItemRatingKey":    sampleMediaItem.RatingKey,
+				"MediaItemType":         sampleMediaItem.Type,
+				"SetID":                 sampleSet.ID,
+				"SetTitle":              sampleSet.Title,
+				"SetType":               sampleSet.Type,
+				"SetCreator":            sampleSet.UserCreated,
+			},
+		)
+		title = utils.RenderTemplate(req.Template.Title, vars)
+		message = utils.RenderTemplate(req.Template.Message, vars)
+		imageURL = ""
+	case config.TemplateTypeIgnoredItemExpired:
+		vars = utils.MergeTemplateVars(
+			utils.BaseTemplateVars(),
+			map[string]string{
+				"MediaItemTitle":        sampleMediaItem.Title,
+				"MediaItemYear":         fmt.Sprintf("%d", sampleMediaItem.Year),
+				"MediaItemTMDBID":       sampleMediaItem.TMDB_ID,
+				"MediaItemLibraryTitle": sampleMediaItem.LibraryTitle,
+				"MediaItemRatingKey":    sampleMediaItem.RatingKey,
+				"MediaItemType":         sampleMediaItem.Type,
+				"IgnoredUntil":          time.Now().Format("2006-01-02 15:04"),
+			},
+		)
+		title = utils.RenderTemplate(req.Template.Title, vars)
+		message = utils.RenderTemplate(req.Template.Message, vars)
+		imageURL = ""
 	default:
 		logAction.SetError("Unsupported template type", fmt.Sprintf("The template type '%s' is not supported", req.TemplateType), nil)
 		httpx.SendResponse(w, ld, response)
@@ -286,7 +322,7 @@ func SendTestNotification(w http.ResponseWriter, r *http.Request) {
 }
 
 func getUnmaskedPushoverField(field, currentValue string) string {
-	for _, existingProvider := range config.Current.Notifications.Providers {
+	for _, existingProvider := range config.Latest().Notifications.Providers {
 		if existingProvider.Provider == "Pushover" && existingProvider.Pushover != nil {
 			switch field {
 			case "UserKey":
@@ -314,7 +350,7 @@ func getUnmaskedPushoverField(field, currentValue string) string {
 }
 
 func getUnmaskedDiscordWebhook(currentValue string) string {
-	for _, existingProvider := range config.Current.Notifications.Providers {
+	for _, existingProvider := range config.Latest().Notifications.Providers {
 		if existingProvider.Provider == "Discord" && existingProvider.Discord != nil {
 			if existingProvider.Discord.Webhook != "" {
 				// Make sure that the last few characters match the masked value
@@ -330,7 +366,7 @@ func getUnmaskedDiscordWebhook(currentValue string) string {
 }
 
 func getUnmaskedGotifyField(field, currentValue string) string {
-	for _, existingProvider := range config.Current.Notifications.Providers {
+	for _, existingProvider := range config.Latest().Notifications.Providers {
 		if existingProvider.Provider == "Gotify" && existingProvider.Gotify != nil {
 			switch field {
 			case "URL":
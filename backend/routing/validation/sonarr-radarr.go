@@ -49,7 +49,7 @@ func ValidateSonarrRadarrInfo(w http.ResponseWriter, r *http.Request) {
 	fullToken := srInfo.ApiToken
 	if config.IsMaskedField(srInfo.ApiToken) {
 		// If the token is masked, we need to grab it from the existing config
-		for _, existingApp := range config.Current.SonarrRadarr.Applications {
+		for _, existingApp := range config.Current(ctx).SonarrRadarr.Applications {
 			if existingApp.Type == srInfo.Type && existingApp.URL == srInfo.URL {
 				fullToken = existingApp.ApiToken
 				break
@@ -20,7 +20,7 @@ func (r *RadarrApp) HandleTags(ctx context.Context, app config.Config_SonarrRada
 
 func HandleTags(ctx context.Context, item models.MediaItem, selectedTypes models.SelectedTypes) (Err logging.LogErrorInfo) {
 	Err = logging.LogErrorInfo{}
-	if len(config.Current.SonarrRadarr.Applications) == 0 {
+	if len(config.Current(ctx).SonarrRadarr.Applications) == 0 {
 		return Err
 	}
 
@@ -52,7 +52,7 @@ func HandleTags(ctx context.Context, item models.MediaItem, selectedTypes models
 		return *logAction.Error
 	}
 
-	for _, srApp := range config.Current.SonarrRadarr.Applications {
+	for _, srApp := range config.Current(ctx).SonarrRadarr.Applications {
 		// Make sure all the app info is present
 		Err = MakeSureAllAppInfoPresent(ctx, &srApp)
 		if Err.Message != "" {
@@ -124,7 +124,7 @@ func srHandleTags(ctx context.Context, app config.Config_SonarrRadarrApp, item m
 	}
 
 	// Get all of the applications configured for labels and tags
-	for _, labelApp := range config.Current.LabelsAndTags.Applications {
+	for _, labelApp := range config.Current(ctx).LabelsAndTags.Applications {
 		// If it is not Sonarr/Radarr, continue
 		if labelApp.Application != "Sonarr" && labelApp.Application != "Radarr" {
 			continue
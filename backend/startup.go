@@ -11,6 +11,7 @@ import (
 	"aura/logging"
 	"aura/mediaserver"
 	"aura/mediux"
+	"aura/restore"
 	"aura/utils"
 	"context"
 	"fmt"
@@ -21,7 +22,7 @@ import (
 func runBootstrap() (success bool) {
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Bootstrap")
 	defer ld.Log()
-	config.AppLoadingStep = "Bootstrapping Application"
+	config.AppLoadingStep.Store("Bootstrapping Application")
 
 	logAction := ld.AddAction("Application Startup", logging.LevelInfo)
 	ctx = logging.WithCurrentAction(ctx, logAction)
@@ -34,24 +35,26 @@ func runBootstrap() (success bool) {
 	config.AppVersion = APP_VERSION
 
 	// Set Umask for file permissions (if needed)
-	config.AppLoadingStep = "Setting UMask for File Permissions"
+	config.AppLoadingStep.Store("Setting UMask for File Permissions")
 	utils.SetUMask(ctx)
 
 	// Load the config file
-	config.AppLoadingStep = "Loading Configuration"
+	config.AppLoadingStep.Store("Loading Configuration")
 	config.LoadYAML(ctx)
 	logAction.Complete()
 
 	// Print the config details (sanitized)
-	config.Current.PrintDetails()
+	config.Current(ctx).PrintDetails()
 
 	// If the config is loaded, validate it
-	if config.Loaded {
-		config.AppLoadingStep = "Validating Configuration"
-		config.Current.Validate(ctx)
+	if config.Latest().Status.Loaded {
+		config.AppLoadingStep.Store("Validating Configuration")
+		config.Update(ctx, func(cfg *config.Config) {
+			cfg.Validate(ctx)
+		})
 	}
 
-	if config.Loaded && config.Valid {
+	if config.Latest().Status.Loaded && config.Latest().Status.Valid {
 		success = true
 	}
 
@@ -61,51 +64,63 @@ func runBootstrap() (success bool) {
 func runPreFlight() (success bool) {
 	ctx, ld := logging.CreateLoggingContext(context.Background(), "Preflight")
 	defer ld.Log()
-	config.AppLoadingStep = "Performing Pre-Flight Checks"
+	config.AppLoadingStep.Store("Performing Pre-Flight Checks")
 
 	action := ld.AddAction("Checking Services", logging.LevelInfo)
 	ctx = logging.WithCurrentAction(ctx, action)
 	defer action.Complete()
 
 	success = false
-	config.AppFullyLoaded = false
+	config.AppFullyLoaded.Store(false)
 
 	// Validate Media Server Connection
-	config.AppLoadingStep = "Validating Media Server Connection"
-	connectionOk, serverName, serverVersion, msErr := mediaserver.TestConnection(ctx, &config.Current.MediaServer)
+	config.AppLoadingStep.Store("Validating Media Server Connection")
+	connectionOk, serverName, serverVersion, msErr := mediaserver.TestConnection(ctx, &config.Current(ctx).MediaServer)
 	if msErr.Message != "" || !connectionOk || serverVersion == "" || serverName == "" {
-		config.MediaServerValid = false
+		config.Update(ctx, func(cfg *config.Config) {
+			cfg.Status.MediaServerValid = false
+		})
 		return success
 	}
-	if config.Current.MediaServer.Type == "Jellyfin" || config.Current.MediaServer.Type == "Emby" {
+	if config.Current(ctx).MediaServer.Type == "Jellyfin" || config.Current(ctx).MediaServer.Type == "Emby" {
 		// Get Admin User for Emby/Jellyfin
-		config.AppLoadingStep = "Retrieving Media Server Admin User"
-		ejUserID, initErr := mediaserver.GetAdminUser(ctx, &config.Current.MediaServer)
+		config.AppLoadingStep.Store("Retrieving Media Server Admin User")
+		ejUserID, initErr := mediaserver.GetAdminUser(ctx, &config.Current(ctx).MediaServer)
 		if initErr.Message != "" {
-			config.MediaServerValid = false
+			config.Update(ctx, func(cfg *config.Config) {
+				cfg.Status.MediaServerValid = false
+			})
 			return success
 		} else if ejUserID == "" {
-			config.MediaServerValid = false
+			config.Update(ctx, func(cfg *config.Config) {
+				cfg.Status.MediaServerValid = false
+			})
 			logging.LOGGER.Error().Timestamp().Msg("Failed to retrieve admin user ID from Emby/Jellyfin server")
 			return success
 		}
-		config.Current.MediaServer.UserID = ejUserID
+		config.Update(ctx, func(cfg *config.Config) {
+			cfg.MediaServer.UserID = ejUserID
+		})
 	}
-	config.MediaServerName = serverName
+	config.Update(ctx, func(cfg *config.Config) {
+		cfg.Status.MediaServerName = serverName
+		cfg.Status.MediaServerValid = true
+	})
 	logging.LOGGER.Trace().Timestamp().Str("media_server_name", serverName).
 		Str("media_server_version", serverVersion).
 		Msg("Media Server connection validated successfully")
-	config.MediaServerValid = true
 
 	// Validate MediUX Token
-	config.AppLoadingStep = "Validating MediUX Token"
-	mediuxTokenValid, mediuxErr := mediux.ValidateToken(ctx, config.Current.Mediux.ApiToken)
+	config.AppLoadingStep.Store("Validating MediUX Token")
+	mediuxTokenValid, mediuxErr := mediux.ValidateToken(ctx, config.Current(ctx).Mediux.ApiToken)
 	if mediuxErr.Message != "" || !mediuxTokenValid {
-		config.MediuxValid = false
+		config.Update(ctx, func(cfg *config.Config) {
+			cfg.Status.MediuxValid = false
+		})
 		return success
 	}
 
-	if config.MediaServerValid || config.MediuxValid {
+	if config.Latest().Status.MediaServerValid || config.Latest().Status.MediuxValid {
 		success = true
 	}
 
@@ -117,20 +132,20 @@ func runWarmup() (success bool) {
 
 	action := ld.AddAction("Initializing Application", logging.LevelInfo)
 	ctx = logging.WithCurrentAction(ctx, action)
-	config.AppLoadingStep = "Warming Up Application"
+	config.AppLoadingStep.Store("Warming Up Application")
 
 	success = false
 
 	// Cache: Add all MediUX users
-	config.AppLoadingStep = "Preloading MediUX Users into Cache"
+	config.AppLoadingStep.Store("Preloading MediUX Users into Cache")
 	mediux.PreloadMediuxUsers(ctx)
 
 	// Cache: Get a list of all items in MediUX that has a set
-	config.AppLoadingStep = "Preloading MediUX Items with Sets into Cache"
+	config.AppLoadingStep.Store("Preloading MediUX Items with Sets into Cache")
 	mediux.PreLoadMediuxItemsWithSets(ctx)
 
 	// Database: Initialize
-	config.AppLoadingStep = "Initializing Database"
+	config.AppLoadingStep.Store("Initializing Database")
 	newDB, dbInitErr := database.Init(ctx)
 	if dbInitErr.Message != "" {
 		return false
@@ -139,20 +154,28 @@ func runWarmup() (success bool) {
 
 	// Database-Migration: If not a new DB, run migrations
 	if !newDB {
-		config.AppLoadingStep = "Running Database Migrations"
+		config.AppLoadingStep.Store("Running Database Migrations")
 		migrationsCompleted, _ := migration.RunMigrations()
 		logging.LOGGER.Info().Timestamp().Msgf("%d database migrations performed", migrationsCompleted)
 	}
 
 	// Cache: Add all media server sections and items
-	config.AppLoadingStep = "Preloading Media Server Data into Cache"
-	_ = mediaserver.GetAllLibrarySectionsAndItems(ctx, false)
+	// If a snapshot from the last run is found it is served right away and refreshed in the background
+	config.AppLoadingStep.Store("Preloading Media Server Data into Cache")
+	if cache.LoadLibrarySnapshot(ctx) {
+		logging.LOGGER.Info().Timestamp().
+			Time("snapshot_time", time.Unix(cache.LibraryStore.LastFullUpdate, 0)).
+			Msg("Serving Media Server data from the cache snapshot, refreshing in the background")
+		go refreshLibraryCacheInBackground()
+	} else {
+		_ = mediaserver.GetAllLibrarySectionsAndItems(ctx, false)
+	}
 	logging.LOGGER.Info().Timestamp().Int("sections", cache.LibraryStore.GetSectionsCount()).Int("items", cache.LibraryStore.GetItemsCount()).Msg("Loaded Media Server sections and items into cache")
 	logging.LOGGER.Info().Timestamp().Int("collection_items", len(cache.CollectionsStore.GetAllCollections())).
 		Msg("Loaded Media Server collections into cache")
 
 	// Database: Vacuum
-	config.AppLoadingStep = "Optimizing Database"
+	config.AppLoadingStep.Store("Optimizing Database")
 	vacuumErr := database.Vacuum(ctx)
 	if vacuumErr.Message != "" {
 		logging.LOGGER.Error().Timestamp().Msgf("Database VACUUM failed: %s", vacuumErr.Message)
@@ -163,7 +186,7 @@ func runWarmup() (success bool) {
 	ld.Log()
 
 	// Cronjob: Auto Download Processing
-	config.AppLoadingStep = "Starting Background Jobs"
+	config.AppLoadingStep.Store("Starting Background Jobs")
 	jobs.StartAutoDownloadJob()
 
 	// Cronjob: Download Queue Processing
@@ -210,18 +233,31 @@ func runWarmup() (success bool) {
 		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Handle Temp Ignored Items cron job")
 	}
 
+	// Cronjob: Artwork Drift Detection
+	err = jobs.StartArtworkDriftJob()
+	if err != nil {
+		logging.LOGGER.Error().Timestamp().Err(err).Msg("Failed to schedule Artwork Drift Detection cron job")
+	}
+
 	// Cron: Start Jobs Scheduler
 	jobs.StartJobs()
 
 	// Check MediUX Site Link Availability immediately on startup
-	config.AppLoadingStep = "Checking MediUX Site Link Availability"
+	config.AppLoadingStep.Store("Checking MediUX Site Link Availability")
 	mediux.CheckSiteLinkAvailability()
 
-	// Initialize MediUX WebSocket Listener
-	//go autodownload.StartMediuxWebSocketClient()
+	// Initialize MediUX WebSocket Listener (if enabled)
+	autodownload.StartOrRestartMediuxWebSocketClient()
 
 	// Initialize Media Server WebSocket Listener (if supported)
 	autodownload.StartOrRestartPlexWebSocketClient()
+	autodownload.StartOrRestartEJWebSocketClient()
+
+	// Restore Originals: Resume jobs that were interrupted by a restart
+	restore.ResumeJobs(context.Background())
+
+	// Restart jobs and listeners when the settings they use change
+	subscribeToConfigChanges()
 
 	success = true
 	return success
@@ -230,7 +266,7 @@ func runWarmup() (success bool) {
 func startAPI() {
 	// Start HTTP Server
 	logging.LOGGER.Info().Timestamp().Int("port", APP_PORT).
-		Bool("full_routes", config.Loaded && config.Valid).
+		Bool("full_routes", config.Latest().Status.Loaded && config.Latest().Status.Valid).
 		Str("log_level", logging.LOGGER.GetLevel().String()).
 		Msg("Starting HTTP Server")
 	if err := http.ListenAndServe(fmt.Sprintf(":%d", APP_PORT), http.HandlerFunc(dispatch)); err != nil {
@@ -254,3 +290,18 @@ func dispatch(w http.ResponseWriter, r *http.Request) {
 
 	h.ServeHTTP(w, r)
 }
+
+// refreshLibraryCacheInBackground reconciles the library cache loaded from the snapshot with the media server
+func refreshLibraryCacheInBackground() {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.LOGGER.Error().Timestamp().Interface("recover", r).Msg("PANIC: in background library cache refresh")
+		}
+	}()
+	ctx, ld := logging.CreateLoggingContext(context.Background(), "Startup")
+	action := ld.AddAction("Refresh Cache Snapshot", logging.LevelInfo)
+	ctx = logging.WithCurrentAction(ctx, action)
+	_ = mediaserver.GetAllLibrarySectionsAndItems(ctx, true)
+	action.Complete()
+	ld.Log()
+}
@@ -0,0 +1,283 @@
+package tmdb
+
+import (
+	"aura/cache"
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type imageEntry struct {
+	FilePath    string  `json:"file_path"`
+	Iso639_1    *string `json:"iso_639_1"`
+	VoteAverage float64 `json:"vote_average"`
+	VoteCount   int     `json:"vote_count"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+}
+
+type imagesResponse struct {
+	Posters   []imageEntry `json:"posters"`
+	Backdrops []imageEntry `json:"backdrops"`
+	Stills    []imageEntry `json:"stills"`
+}
+
+type itemDetailsResponse struct {
+	ID           int            `json:"id"`
+	Title        string         `json:"title"`
+	Name         string         `json:"name"`
+	Tagline      string         `json:"tagline"`
+	Status       string         `json:"status"`
+	ReleaseDate  string         `json:"release_date"`
+	FirstAirDate string         `json:"first_air_date"`
+	PosterPath   string         `json:"poster_path"`
+	BackdropPath string         `json:"backdrop_path"`
+	Seasons      []seasonInfo   `json:"seasons"`
+	Images       imagesResponse `json:"images"`
+}
+
+type seasonInfo struct {
+	SeasonNumber int `json:"season_number"`
+}
+
+type seasonDetailsResponse struct {
+	SeasonNumber int            `json:"season_number"`
+	Episodes     []episodeInfo  `json:"episodes"`
+	Images       imagesResponse `json:"images"`
+}
+
+type episodeInfo struct {
+	EpisodeNumber int    `json:"episode_number"`
+	Name          string `json:"name"`
+	StillPath     string `json:"still_path"`
+}
+
+// GetItemSet builds a single pseudo-set from the best rated TMDB images for an item.
+// For shows, the set also includes one poster per season and one still (titlecard) per episode.
+func GetItemSet(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string, filter models.ImageFilter) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("TMDB: Get %s Item Set for TMDB ID '%s'", itemType, tmdbID), logging.LevelInfo)
+	defer logAction.Complete()
+
+	sets = []models.SetRef{}
+	includedItems = map[string]models.IncludedItem{}
+	Err = logging.LogErrorInfo{}
+
+	if !IsEnabled(ctx) {
+		logAction.SetError("TMDB API Token not configured", "Set the TMDB API Token in the settings to use TMDB as a fallback image source", nil)
+		return sets, includedItems, *logAction.Error
+	}
+
+	var endpoint string
+	switch itemType {
+	case "movie":
+		endpoint = fmt.Sprintf("movie/%s", tmdbID)
+	case "show":
+		endpoint = fmt.Sprintf("tv/%s", tmdbID)
+	default:
+		logAction.SetError("Invalid Item Type", "Item type must be 'movie' or 'show'", map[string]any{"item_type": itemType})
+		return sets, includedItems, *logAction.Error
+	}
+
+	_, respBody, Err := makeRequest(ctx, endpoint, imageQuery(filter))
+	if Err.Message != "" {
+		return sets, includedItems, Err
+	}
+
+	var details itemDetailsResponse
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &details, "TMDB Item Details Response")
+	if Err.Message != "" {
+		return sets, includedItems, Err
+	}
+
+	title := details.Title
+	releaseDate := details.ReleaseDate
+	if itemType == "show" {
+		title = details.Name
+		releaseDate = details.FirstAirDate
+	}
+
+	// Populate the included item so that the set can be displayed like a MediUX set
+	includedItem := models.IncludedItem{
+		MediuxInfo: models.BaseMediuxItemInfo{
+			TMDB_ID:           tmdbID,
+			Type:              itemType,
+			Status:            details.Status,
+			Title:             title,
+			Tagline:           details.Tagline,
+			ReleaseDate:       releaseDate,
+			TMDB_PosterPath:   details.PosterPath,
+			TMDB_BackdropPath: details.BackdropPath,
+		},
+	}
+	mediaItem, found := cache.LibraryStore.GetMediaItemFromSectionByTMDBIDAndEdition(itemLibraryTitle, tmdbID, edition)
+	if found {
+		includedItem.MediaItem = *mediaItem
+	}
+	includedItems[tmdbID] = includedItem
+
+	images := []models.ImageFile{}
+	if best, ok := pickBestImage(details.Images.Posters, filter); ok {
+		images = append(images, newImageFile(best, "poster", tmdbID, nil, nil, ""))
+	}
+	if best, ok := pickBestImage(details.Images.Backdrops, filter); ok {
+		images = append(images, newImageFile(best, "backdrop", tmdbID, nil, nil, ""))
+	}
+
+	if itemType == "show" {
+		for _, season := range details.Seasons {
+			// Only look up seasons that exist in the library (if we know the library item)
+			if found && mediaItem.Series != nil && !seriesHasSeason(mediaItem.Series, season.SeasonNumber) {
+				continue
+			}
+			images = append(images, getSeasonImages(ctx, tmdbID, season.SeasonNumber, filter)...)
+		}
+	}
+
+	logAction.AppendResult("images_found", len(images))
+	if len(images) == 0 {
+		return sets, includedItems, Err
+	}
+
+	sets = append(sets, models.SetRef{
+		PosterSet: models.PosterSet{
+			BaseSetInfo: models.BaseSetInfo{
+				ID:          BuildSetID(itemType, tmdbID),
+				Title:       fmt.Sprintf("%s (TMDB)", title),
+				Type:        itemType,
+				UserCreated: SetUserCreated,
+				Source:      SourceName,
+				DateCreated: time.Now().UTC(),
+				DateUpdated: time.Now().UTC(),
+			},
+			Images: images,
+		},
+		ItemIDs: []string{tmdbID},
+	})
+	return sets, includedItems, Err
+}
+
+func getSeasonImages(ctx context.Context, tmdbID string, seasonNumber int, filter models.ImageFilter) (images []models.ImageFile) {
+	images = []models.ImageFile{}
+
+	_, respBody, Err := makeRequest(ctx, fmt.Sprintf("tv/%s/season/%d", tmdbID, seasonNumber), imageQuery(filter))
+	if Err.Message != "" {
+		return images
+	}
+
+	var season seasonDetailsResponse
+	Err = httpx.DecodeResponseToJSON(ctx, respBody, &season, "TMDB Season Details Response")
+	if Err.Message != "" {
+		return images
+	}
+
+	sn := seasonNumber
+	if best, ok := pickBestImage(season.Images.Posters, filter); ok {
+		images = append(images, newImageFile(best, "season_poster", tmdbID, &sn, nil, ""))
+	}
+
+	// TMDB returns the primary still for each episode with the season details.
+	// Stills are rarely localized and the season details don't include their votes, so the filters don't apply to them.
+	// Filtering them by votes would take a request per episode on every lookup.
+	for _, episode := range season.Episodes {
+		if episode.StillPath == "" {
+			continue
+		}
+		en := episode.EpisodeNumber
+		images = append(images, newImageFile(imageEntry{FilePath: episode.StillPath}, "titlecard", tmdbID, &sn, &en, episode.Name))
+	}
+	return images
+}
+
+// imageQuery returns the query params to include the images in the details response
+func imageQuery(filter models.ImageFilter) url.Values {
+	query := url.Values{}
+	query.Set("append_to_response", "images")
+	if len(filter.Languages) > 0 {
+		// TMDB uses "null" for textless images
+		languages := make([]string, 0, len(filter.Languages))
+		for _, language := range filter.Languages {
+			language = strings.ToLower(strings.TrimSpace(language))
+			if language == "none" {
+				language = "null"
+			}
+			languages = append(languages, language)
+		}
+		query.Set("include_image_language", strings.Join(languages, ","))
+	}
+	return query
+}
+
+// pickBestImage returns the highest rated image that passes the filter
+func pickBestImage(entries []imageEntry, filter models.ImageFilter) (imageEntry, bool) {
+	candidates := []imageEntry{}
+	for _, entry := range entries {
+		if entry.FilePath == "" || entry.VoteAverage < filter.MinVoteAverage {
+			continue
+		}
+		if !languageAllowed(entry.Iso639_1, filter.Languages) {
+			continue
+		}
+		candidates = append(candidates, entry)
+	}
+	if len(candidates) == 0 {
+		return imageEntry{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].VoteAverage != candidates[j].VoteAverage {
+			return candidates[i].VoteAverage > candidates[j].VoteAverage
+		}
+		return candidates[i].VoteCount > candidates[j].VoteCount
+	})
+	return candidates[0], true
+}
+
+func languageAllowed(language *string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	lang := "none"
+	if language != nil && *language != "" {
+		lang = strings.ToLower(*language)
+	}
+	for _, a := range allowed {
+		if strings.ToLower(strings.TrimSpace(a)) == lang {
+			return true
+		}
+	}
+	return false
+}
+
+func newImageFile(entry imageEntry, imageType, tmdbID string, seasonNumber, episodeNumber *int, title string) models.ImageFile {
+	language := ""
+	if entry.Iso639_1 != nil {
+		language = *entry.Iso639_1
+	}
+	imageFile := models.ImageFile{
+		ID:            buildImageID(entry.FilePath),
+		Type:          imageType,
+		Language:      language,
+		ItemTMDB_ID:   tmdbID,
+		Title:         title,
+		SeasonNumber:  seasonNumber,
+		EpisodeNumber: episodeNumber,
+		Source:        SourceName,
+	}
+	imageFile.Src = GetImageURL(imageFile)
+	return imageFile
+}
+
+func seriesHasSeason(series *models.MediaItemSeries, seasonNumber int) bool {
+	for _, season := range series.Seasons {
+		if season.SeasonNumber == seasonNumber {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package tmdb
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/models"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ParseSetID splits a TMDB pseudo-set ID into its item type and TMDB ID
+func ParseSetID(setID string) (itemType, tmdbID string, ok bool) {
+	if !IsTMDBSet(setID) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(setID, SetIDPrefix), "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// GetSetByID rebuilds a TMDB pseudo-set from its ID
+// The configured TMDB image filter is applied, so the set matches the one offered when it was saved
+func GetSetByID(ctx context.Context, setID, itemLibraryTitle, edition string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("TMDB: Get Set By ID '%s'", setID), logging.LevelInfo)
+	defer logAction.Complete()
+
+	itemType, tmdbID, ok := ParseSetID(setID)
+	if !ok {
+		logAction.SetError("Invalid TMDB Set ID", "TMDB Set IDs must be in the format tmdb_<type>_<tmdb_id>", map[string]any{"set_id": setID})
+		return set, includedItems, *logAction.Error
+	}
+
+	sets, includedItems, Err := GetItemSet(ctx, tmdbID, itemType, itemLibraryTitle, edition, config.Current(ctx).TMDB.ImageFilter())
+	if Err.Message != "" {
+		return set, includedItems, Err
+	}
+	if len(sets) == 0 {
+		logAction.SetError("No TMDB images found", "TMDB did not return any images for this item", map[string]any{"set_id": setID})
+		return set, includedItems, *logAction.Error
+	}
+
+	return sets[0], includedItems, logging.LogErrorInfo{}
+}
@@ -0,0 +1,42 @@
+package tmdb
+
+import (
+	"aura/logging"
+	"aura/models"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetImage downloads the original quality image from TMDB
+func GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("TMDB: Getting Image '%s'", imageFile.ID), logging.LevelDebug)
+	defer logAction.Complete()
+
+	imageURL := GetImageURL(imageFile)
+	resp, respBody, Err := httpx.MakeHTTPRequest(ctx, imageURL, http.MethodGet, nil, 60, nil, "TMDB")
+	if Err.Message != "" {
+		return nil, "", Err
+	}
+
+	if resp.StatusCode != http.StatusOK || len(respBody) == 0 {
+		logAction.SetError("TMDB returned an empty image response",
+			"Ensure the image still exists on TMDB",
+			map[string]any{
+				"URL":         imageURL,
+				"status_code": resp.StatusCode,
+			})
+		return nil, "", *logAction.Error
+	}
+
+	imageType = resp.Header.Get("Content-Type")
+	if imageType == "" {
+		imageType = "image/jpeg"
+	}
+
+	logAction.AppendResult("size", len(respBody))
+	logAction.AppendResult("imageType", imageType)
+	logAction.AppendResult("source", "TMDB")
+	return respBody, imageType, logging.LogErrorInfo{}
+}
@@ -0,0 +1,57 @@
+package tmdb
+
+import (
+	"aura/config"
+	"aura/logging"
+	"aura/utils/httpx"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+func makeRequest(ctx context.Context, endpoint string, query url.Values) (*http.Response, []byte, logging.LogErrorInfo) {
+	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("TMDB: Making request to %s", endpoint), logging.LevelTrace)
+	defer logAction.Complete()
+
+	u, err := url.Parse(TMDBApiURL)
+	if err != nil {
+		logAction.SetError("Failed to parse base URL", "Ensure the URL is valid", map[string]any{"error": err.Error()})
+		return nil, nil, *logAction.Error
+	}
+	u.Path = path.Join(u.Path, endpoint)
+	if query == nil {
+		query = url.Values{}
+	}
+
+	// TMDB accepts either a v4 Read Access Token (sent as a Bearer token)
+	// or a v3 API Key (sent as a query parameter)
+	headers := map[string]string{}
+	token := strings.TrimSpace(config.Current(ctx).TMDB.ApiToken)
+	if strings.HasPrefix(token, "eyJ") {
+		headers["Authorization"] = "Bearer " + token
+	} else {
+		query.Set("api_key", token)
+	}
+	u.RawQuery = query.Encode()
+
+	resp, respBody, Err := httpx.MakeHTTPRequest(ctx, u.String(), http.MethodGet, headers, 60, nil, "TMDB")
+	if Err.Message != "" {
+		return resp, respBody, Err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		logAction.SetError(fmt.Sprintf("TMDB returned status code %d", resp.StatusCode),
+			"Ensure the TMDB API Token is valid and the item exists on TMDB",
+			map[string]any{
+				"endpoint":      endpoint,
+				"status_code":   resp.StatusCode,
+				"response_body": string(respBody),
+			})
+		return resp, respBody, *logAction.Error
+	}
+
+	return resp, respBody, logging.LogErrorInfo{}
+}
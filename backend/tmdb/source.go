@@ -0,0 +1,33 @@
+package tmdb
+
+import (
+	"aura/logging"
+	"aura/models"
+	"context"
+	"time"
+)
+
+// TMDB is the fallback image source for items that have no sets on MediUX
+type TMDB struct{}
+
+func (t *TMDB) GetItemSets(ctx context.Context, tmdbID, itemType, itemLibraryTitle, edition string, filter models.ImageFilter) (sets []models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	return GetItemSet(ctx, tmdbID, itemType, itemLibraryTitle, edition, filter)
+}
+
+func (t *TMDB) GetSetByID(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (set models.SetRef, includedItems map[string]models.IncludedItem, Err logging.LogErrorInfo) {
+	return GetSetByID(ctx, setID, itemLibraryTitle, edition)
+}
+
+func (t *TMDB) GetImage(ctx context.Context, imageFile models.ImageFile) (imageData []byte, imageType string, Err logging.LogErrorInfo) {
+	return GetImage(ctx, imageFile)
+}
+
+func (t *TMDB) GetImageURL(ctx context.Context, imageFile models.ImageFile) (imageURL string, Err logging.LogErrorInfo) {
+	return GetImageURL(imageFile), logging.LogErrorInfo{}
+}
+
+// TMDB does not expose when an image was last changed, and a TMDB file path never changes its content.
+// The zero time is returned so that TMDB sets are never treated as updated.
+func (t *TMDB) GetSetUpdatedAt(ctx context.Context, setID, setType, itemTMDB_ID, itemLibraryTitle, edition string) (updatedAt time.Time, Err logging.LogErrorInfo) {
+	return time.Time{}, logging.LogErrorInfo{}
+}
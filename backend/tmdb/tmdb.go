@@ -0,0 +1,53 @@
+package tmdb
+
+import (
+	"aura/config"
+	"aura/models"
+	"context"
+	"fmt"
+	"strings"
+)
+
+var TMDBApiURL string = "https://api.themoviedb.org/3"
+var TMDBImageURL string = "https://image.tmdb.org/t/p"
+
+const (
+	SourceName = "tmdb"
+	// SetIDPrefix is prepended to every TMDB pseudo-set ID so that it can never collide with a MediUX set ID
+	SetIDPrefix = "tmdb_"
+	// ImageIDPrefix is prepended to every TMDB image ID (the TMDB file path without the leading slash)
+	ImageIDPrefix = "tmdb_"
+	// SetUserCreated is used as the "creator" of all TMDB pseudo-sets
+	SetUserCreated = "TMDB"
+)
+
+// IsEnabled returns true when a TMDB API token has been configured
+func IsEnabled(ctx context.Context) bool {
+	return strings.TrimSpace(config.Current(ctx).TMDB.ApiToken) != ""
+}
+
+// BuildSetID returns the pseudo-set ID for a TMDB item
+func BuildSetID(itemType, tmdbID string) string {
+	return fmt.Sprintf("%s%s_%s", SetIDPrefix, itemType, tmdbID)
+}
+
+// IsTMDBSet returns true if the set ID belongs to a TMDB pseudo-set
+func IsTMDBSet(setID string) bool {
+	return strings.HasPrefix(setID, SetIDPrefix)
+}
+
+// IsTMDBImage returns true if the image file was sourced from TMDB
+func IsTMDBImage(imageFile models.ImageFile) bool {
+	return strings.HasPrefix(imageFile.ID, ImageIDPrefix)
+}
+
+// GetImageURL returns the original quality TMDB URL for an image file
+// The URL is rebuilt from the image ID so that it also works for images loaded from the database
+func GetImageURL(imageFile models.ImageFile) string {
+	filePath := strings.TrimPrefix(imageFile.ID, ImageIDPrefix)
+	return fmt.Sprintf("%s/original/%s", TMDBImageURL, filePath)
+}
+
+func buildImageID(filePath string) string {
+	return ImageIDPrefix + strings.TrimPrefix(filePath, "/")
+}
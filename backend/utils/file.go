@@ -6,8 +6,26 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
 )
 
+// InvalidFolderChars matches the characters that can't be used in folder names on most file systems
+var InvalidFolderChars = regexp.MustCompile(`[<>:"/\\|?*]`)
+
+// PathInFolder joins a relative path (with forward slashes) to root.
+// An empty path is returned if the result points outside of root.
+func PathInFolder(root, relativePath string) string {
+	root = filepath.Clean(root)
+	fullPath := filepath.Join(root, filepath.FromSlash(relativePath))
+	rel, err := filepath.Rel(root, fullPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return fullPath
+}
+
 func CreateFolderIfNotExists(ctx context.Context, folderPath string) (Err logging.LogErrorInfo) {
 	ctx, logAction := logging.AddSubActionToContext(ctx, "Checking if folder exists", logging.LevelTrace)
 	defer logAction.Complete()
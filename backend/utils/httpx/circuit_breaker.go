@@ -0,0 +1,164 @@
+package httpx
+
+import (
+	"aura/logging"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type CircuitState string
+
+const (
+	CIRCUIT_CLOSED    CircuitState = "closed"    // Requests are sent
+	CIRCUIT_OPEN      CircuitState = "open"      // Requests fail right away, the host is down
+	CIRCUIT_HALF_OPEN CircuitState = "half_open" // One trial request is sent to see if the host is back
+)
+
+const (
+	// circuitFailureThreshold is how many attempts in a row have to fail before the circuit opens
+	circuitFailureThreshold = 5
+	// circuitOpenDuration is how long requests fail right away before a trial request is sent
+	circuitOpenDuration = 30 * time.Second
+)
+
+// CircuitBreakerState is the state of the circuit breaker of a host
+type CircuitBreakerState struct {
+	Host                string       `json:"host"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenUntil           time.Time    `json:"open_until,omitzero"`
+	LastError           string       `json:"last_error,omitempty"`
+}
+
+// CircuitOpenError is returned instead of sending a request while the circuit of its host is open
+type CircuitOpenError struct {
+	Host      string
+	OpenUntil time.Time
+	LastError string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("requests to %s are paused until %s after repeated failures (last error: %s)",
+		e.Host, e.OpenUntil.Format(time.TimeOnly), e.LastError)
+}
+
+type circuitBreaker struct {
+	mu            sync.Mutex
+	state         CircuitBreakerState
+	trialInFlight bool
+}
+
+var circuitBreakers = struct {
+	mu    sync.Mutex
+	hosts map[string]*circuitBreaker
+}{
+	hosts: make(map[string]*circuitBreaker),
+}
+
+func circuitBreakerFor(host string) *circuitBreaker {
+	host = strings.ToLower(host)
+	circuitBreakers.mu.Lock()
+	defer circuitBreakers.mu.Unlock()
+	breaker, ok := circuitBreakers.hosts[host]
+	if !ok {
+		breaker = &circuitBreaker{state: CircuitBreakerState{Host: host, State: CIRCUIT_CLOSED}}
+		circuitBreakers.hosts[host] = breaker
+	}
+	return breaker
+}
+
+// allow returns true if a request can be sent to the host
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state.State {
+	case CIRCUIT_OPEN:
+		if time.Now().Before(b.state.OpenUntil) {
+			return false
+		}
+		// Let a single trial request through
+		b.state.State = CIRCUIT_HALF_OPEN
+		b.trialInFlight = true
+		return true
+	case CIRCUIT_HALF_OPEN:
+		if b.trialInFlight {
+			return false
+		}
+		b.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record counts the result of an attempt and opens or closes the circuit
+func (b *circuitBreaker) record(failed bool, reason string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialInFlight = false
+	if !failed {
+		if b.state.State != CIRCUIT_CLOSED {
+			logging.LOGGER.Info().Timestamp().Str("host", b.state.Host).
+				Msg("Circuit breaker closed, the host answers again")
+		}
+		b.state.State = CIRCUIT_CLOSED
+		b.state.ConsecutiveFailures = 0
+		b.state.OpenUntil = time.Time{}
+		return
+	}
+
+	b.state.ConsecutiveFailures++
+	b.state.LastError = reason
+	if b.state.State == CIRCUIT_HALF_OPEN || b.state.ConsecutiveFailures >= circuitFailureThreshold {
+		if b.state.State != CIRCUIT_OPEN {
+			logging.LOGGER.Warn().Timestamp().Str("host", b.state.Host).
+				Int("consecutive_failures", b.state.ConsecutiveFailures).
+				Str("last_error", reason).
+				Dur("open_for", circuitOpenDuration).
+				Msg("Circuit breaker opened, requests to the host fail right away until it recovers")
+		}
+		b.state.State = CIRCUIT_OPEN
+		b.state.OpenUntil = time.Now().Add(circuitOpenDuration)
+	}
+}
+
+// release ends an attempt without counting it, e.g. when the caller cancelled it.
+// A trial request that isn't counted must still be released, or the circuit stays half open for good.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trialInFlight = false
+}
+
+func (b *circuitBreaker) openError() *CircuitOpenError {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &CircuitOpenError{Host: b.state.Host, OpenUntil: b.state.OpenUntil, LastError: b.state.LastError}
+}
+
+// OpenCircuitBreakers returns the hosts whose circuit is open or half open, sorted by host
+func OpenCircuitBreakers() []CircuitBreakerState {
+	circuitBreakers.mu.Lock()
+	breakers := make([]*circuitBreaker, 0, len(circuitBreakers.hosts))
+	for _, breaker := range circuitBreakers.hosts {
+		breakers = append(breakers, breaker)
+	}
+	circuitBreakers.mu.Unlock()
+
+	states := []CircuitBreakerState{}
+	for _, breaker := range breakers {
+		breaker.mu.Lock()
+		state := breaker.state
+		breaker.mu.Unlock()
+		if state.State != CIRCUIT_CLOSED {
+			states = append(states, state)
+		}
+	}
+	slices.SortFunc(states, func(a, b CircuitBreakerState) int { return strings.Compare(a.Host, b.Host) })
+	return states
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,13 +32,11 @@ func MakeHTTPRequest(ctx context.Context, url, method string, headers map[string
 	ctx, logAction := logging.AddSubActionToContext(ctx, fmt.Sprintf("Making %s request to %s", method, siteName), logging.LevelTrace)
 	defer logAction.Complete()
 
-	// Create a context with a timeout
-	timeoutInterval := time.Duration(timeout) * time.Second
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutInterval)
-	defer cancel()
+	// Taken before ctx is replaced, so the request is traced as a child of this action
+	traceparent := logging.TraceParent(ctx)
 
-	// Create a new request with context
-	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
+	// Create the request without a context, every attempt gets its own timeout
+	req, err := http.NewRequest(method, url, nil)
 	if err != nil {
 		logAction.SetError(fmt.Sprintf("Failed to create %s request to %s", method, siteName),
 			"Check error and try again",
@@ -52,6 +51,9 @@ func MakeHTTPRequest(ctx context.Context, url, method string, headers map[string
 	// Add a User-Agent header to the request
 	req.Header.Set("User-Agent", "aura/1.0")
 	req.Header.Set("X-Request", "mediux-aura")
+	if traceparent != "" {
+		req.Header.Set("traceparent", traceparent)
+	}
 
 	possibleSensitiveHeaders := []string{
 		"authorization",
@@ -89,34 +91,70 @@ func MakeHTTPRequest(ctx context.Context, url, method string, headers map[string
 	// Add common headers
 	req.Header.Set("Connection", "keep-alive")
 
-	// Send the HTTP request
-	resp, err := sharedClient.Do(req)
-	if err != nil {
-		logAction.SetError(fmt.Sprintf("Failed to send %s request to %s", method, siteName),
-			"Check error and try again",
+	// Send the HTTP request, retrying with the retry policy of the site
+	timeoutInterval := time.Duration(timeout) * time.Second
+	var resp *http.Response
+	var respBody []byte
+	var readErr error
+	attempts, err := WithRetries(ctx, siteName, method, req.URL.Host, func(ctx context.Context) (int, http.Header, error) {
+		attemptCtx, cancel := context.WithTimeout(ctx, timeoutInterval)
+		defer cancel()
+
+		attemptReq := req.Clone(attemptCtx)
+		attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		attemptReq.ContentLength = int64(len(body))
+
+		resp, readErr = nil, nil
+		attemptResp, err := sharedClient.Do(attemptReq)
+		if err != nil {
+			return 0, nil, err
+		}
+		defer attemptResp.Body.Close()
+
+		// Read the response body before the attempt's timeout is cancelled
+		attemptBody, err := io.ReadAll(attemptResp.Body)
+		if err != nil {
+			resp, readErr = attemptResp, err
+			return 0, nil, err
+		}
+		resp, respBody = attemptResp, attemptBody
+		return attemptResp.StatusCode, attemptResp.Header, nil
+	})
+	if attempts > 1 {
+		logAction.AppendResult("attempts", attempts)
+	}
+	if circuitErr := (*CircuitOpenError)(nil); errors.As(err, &circuitErr) {
+		logAction.SetError(fmt.Sprintf("Requests to %s are paused", siteName),
+			fmt.Sprintf("%s failed repeatedly, requests are sent again after %s", circuitErr.Host, circuitErr.OpenUntil.Format(time.TimeOnly)),
 			map[string]any{
-				"method": method,
-				"url":    url,
-				"error":  err.Error(),
+				"method":     method,
+				"url":        url,
+				"last_error": circuitErr.LastError,
 			})
 		return nil, nil, *logAction.Error
 	}
-
-	// Read the response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		resp.Body.Close()
+	if readErr != nil && resp != nil {
 		logAction.SetError(fmt.Sprintf("Failed to read response body from %s", siteName),
 			"Check error and try again",
 			map[string]any{
 				"method":      method,
 				"url":         url,
-				"error":       err.Error(),
+				"error":       readErr.Error(),
 				"status_code": resp.StatusCode,
 			})
 		return nil, nil, *logAction.Error
 	}
-	defer resp.Body.Close()
+	if err != nil {
+		logAction.SetError(fmt.Sprintf("Failed to send %s request to %s", method, siteName),
+			"Check error and try again",
+			map[string]any{
+				"method":   method,
+				"url":      url,
+				"error":    err.Error(),
+				"attempts": attempts,
+			})
+		return nil, nil, *logAction.Error
+	}
 
 	// Add the Status Code the logging context Result
 	logAction.AppendResult("status_code", resp.StatusCode)
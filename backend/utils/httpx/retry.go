@@ -0,0 +1,194 @@
+package httpx
+
+import (
+	"aura/logging"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides how often and how long requests to a site are retried
+type RetryPolicy struct {
+	MaxAttempts     int           // Attempts including the first one
+	BaseDelay       time.Duration // Delay before the first retry, doubled for every retry after it
+	MaxDelay        time.Duration // Longest wait between two attempts. A longer Retry-After is not waited for.
+	RetryStatuses   []int         // Status codes that are retried
+	RetryAllMethods bool          // Also retry POST and PATCH requests (only for sites where they are safe to repeat)
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:   3,
+	BaseDelay:     500 * time.Millisecond,
+	MaxDelay:      10 * time.Second,
+	RetryStatuses: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+}
+
+var mediaServerRetryPolicy = RetryPolicy{
+	MaxAttempts:   3,
+	BaseDelay:     500 * time.Millisecond,
+	MaxDelay:      5 * time.Second,
+	RetryStatuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+}
+
+// retryPolicies holds the policy of every site that doesn't use DefaultRetryPolicy, keyed by site name
+var retryPolicies = map[string]RetryPolicy{
+	"MediUX": {
+		MaxAttempts:   4,
+		BaseDelay:     time.Second,
+		MaxDelay:      30 * time.Second,
+		RetryStatuses: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	},
+	// GraphQL requests are queries, so they can be sent again even though they are POSTs.
+	// A single query can take long, so there are fewer attempts and shorter waits than for other MediUX requests.
+	"MediUX GraphQL": {
+		MaxAttempts:     3,
+		BaseDelay:       time.Second,
+		MaxDelay:        10 * time.Second,
+		RetryStatuses:   []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		RetryAllMethods: true,
+	},
+	"TMDB": {
+		MaxAttempts:   4,
+		BaseDelay:     time.Second,
+		MaxDelay:      20 * time.Second,
+		RetryStatuses: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	},
+	"Plex":     mediaServerRetryPolicy,
+	"Emby":     mediaServerRetryPolicy,
+	"Jellyfin": mediaServerRetryPolicy,
+}
+
+// RetryPolicyFor returns the retry policy of a site
+func RetryPolicyFor(siteName string) RetryPolicy {
+	if policy, ok := retryPolicies[siteName]; ok {
+		return policy
+	}
+	return DefaultRetryPolicy
+}
+
+// AttemptFunc sends a single attempt of a request and returns its status code and headers
+type AttemptFunc func(ctx context.Context) (statusCode int, header http.Header, err error)
+
+// WithRetries sends a request to host with the retry policy of siteName and the circuit breaker of host.
+// Retries wait with jittered exponential backoff, or as long as Retry-After asks.
+// The response of the last attempt is the one the caller keeps (attempt stores it), even if its status was retried.
+// The error is a *CircuitOpenError when the circuit breaker of host is open.
+func WithRetries(ctx context.Context, siteName, method, host string, attempt AttemptFunc) (attempts int, err error) {
+	policy := RetryPolicyFor(siteName)
+	breaker := circuitBreakerFor(host)
+
+	for attempts = 1; ; attempts++ {
+		if !breaker.allow() {
+			return attempts - 1, breaker.openError()
+		}
+
+		statusCode, header, err := attempt(ctx)
+		callerCancelled := ctx.Err() != nil
+		if callerCancelled {
+			// The attempt says nothing about the host, but it may have been the trial request
+			breaker.release()
+		} else {
+			breaker.record(isHostFailure(statusCode, err), failureReason(statusCode, err))
+		}
+
+		if callerCancelled || attempts >= policy.MaxAttempts || !shouldRetry(policy, method, statusCode, err) {
+			return attempts, err
+		}
+		delay, ok := retryDelay(policy, attempts, header)
+		if !ok {
+			return attempts, err
+		}
+
+		if action := logging.CurrentActionFromContext(ctx); action != nil {
+			action.AppendWarning(fmt.Sprintf("attempt_%d", attempts), map[string]any{
+				"reason":   failureReason(statusCode, err),
+				"retry_in": delay.String(),
+			})
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempts, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func shouldRetry(policy RetryPolicy, method string, statusCode int, err error) bool {
+	canRepeat := policy.RetryAllMethods || isIdempotent(method)
+	if err != nil {
+		return canRepeat
+	}
+	if !slices.Contains(policy.RetryStatuses, statusCode) {
+		return false
+	}
+	// 429 and 503 mean the request was not handled, so it is safe to send it again
+	return canRepeat || statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// retryDelay returns how long to wait before the next attempt.
+// Returns false if the site asked to wait longer than the policy allows.
+func retryDelay(policy RetryPolicy, attempt int, header http.Header) (time.Duration, bool) {
+	if retryAfter, ok := parseRetryAfter(header); ok {
+		if retryAfter > policy.MaxDelay {
+			return 0, false
+		}
+		return retryAfter, true
+	}
+
+	delay := policy.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	// Wait between half and the full delay, so requests that failed together are not all sent again together
+	return delay/2 + rand.N(delay/2+1), true
+}
+
+// parseRetryAfter reads the Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// isHostFailure returns true if the attempt failed because the host is down or overloaded
+func isHostFailure(statusCode int, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func failureReason(statusCode int, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("status code %d", statusCode)
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ImageFingerprint returns a SHA-256 hash of the image data and a 64 bit perceptual difference hash (dHash).
+// The perceptual hash stays almost the same when the media server re-encodes or resizes an image.
+// It is empty when the image format can't be decoded (e.g. WebP), see ImagesMatch.
+func ImageFingerprint(imageData []byte) (hash string, perceptualHash string) {
+	sum := sha256.Sum256(imageData)
+	hash = hex.EncodeToString(sum[:])
+
+	img, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return hash, ""
+	}
+	return hash, fmt.Sprintf("%016x", differenceHash(img))
+}
+
+// PerceptualHashDistance returns the number of differing bits between two perceptual hashes (0 to 64).
+// ok is false when either hash is missing or invalid.
+func PerceptualHashDistance(a, b string) (distance int, ok bool) {
+	if a == "" || b == "" {
+		return 0, false
+	}
+	aVal, errA := strconv.ParseUint(a, 16, 64)
+	bVal, errB := strconv.ParseUint(b, 16, 64)
+	if errA != nil || errB != nil {
+		return 0, false
+	}
+	return bits.OnesCount64(aVal ^ bVal), true
+}
+
+// ImagesMatch returns true when both fingerprints describe the same image.
+// Identical hashes always match, otherwise the perceptual hashes have to be within maxDistance bits.
+// comparable is false when the hashes differ and a perceptual hash is missing (the format could not be decoded),
+// in that case it is unknown whether the images match.
+func ImagesMatch(hashA, perceptualA, hashB, perceptualB string, maxDistance int) (match bool, comparable bool) {
+	if hashA != "" && hashA == hashB {
+		return true, true
+	}
+	distance, ok := PerceptualHashDistance(perceptualA, perceptualB)
+	if !ok {
+		return false, false
+	}
+	return distance <= maxDistance, true
+}
+
+// differenceHashSamples is how many pixels are sampled in each direction of a cell.
+// Posters are thousands of pixels wide, so averaging every pixel would be slow and is not needed for 9x8 cells.
+const differenceHashSamples = 8
+
+// differenceHash shrinks the image to 9x8 grayscale pixels and sets a bit for every pixel brighter than its right neighbour
+func differenceHash(img image.Image) uint64 {
+	const width, height = 9, 8
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return 0
+	}
+
+	var pixels [height][width]float64
+	for y := range height {
+		for x := range width {
+			// Average a grid of source pixels that fall into this cell
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+			y0 := bounds.Min.Y + y*bounds.Dy()/height
+			y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+			xStep := max((x1-x0)/differenceHashSamples, 1)
+			yStep := max((y1-y0)/differenceHashSamples, 1)
+
+			var sum float64
+			var count int
+			for sy := y0; sy < y1; sy += yStep {
+				for sx := x0; sx < x1; sx += xStep {
+					sum += grayAt(img, sx, sy)
+					count++
+				}
+			}
+			pixels[y][x] = sum / float64(count)
+		}
+	}
+
+	var hash uint64
+	for y := range height {
+		for x := range width - 1 {
+			hash <<= 1
+			if pixels[y][x] > pixels[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// grayAt returns the brightness of a pixel. JPEG images are read from their luma plane, which avoids a color conversion.
+func grayAt(img image.Image, x, y int) float64 {
+	if ycbcr, ok := img.(*image.YCbCr); ok {
+		return float64(ycbcr.Y[ycbcr.YOffset(x, y)]) * 0x101
+	}
+	r, g, b, _ := img.At(x, y).RGBA()
+	return 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+}
@@ -47,8 +47,8 @@ func BaseTemplateVars() map[string]string {
 		"AppPort":         fmt.Sprintf("%d", config.AppPort),
 		"AppAuthor":       config.AppAuthor,
 		"AppLicense":      config.AppLicense,
-		"MediaServerName": config.MediaServerName,
-		"MediaServerType": config.Current.MediaServer.Type,
+		"MediaServerName": config.Latest().Status.MediaServerName,
+		"MediaServerType": config.Latest().MediaServer.Type,
 		"Timestamp":       time.Now().Format("2006-01-02 15:04:05"),
 		"NewLine":         "\n",
 		"Tab":             "\t",
@@ -184,3 +184,36 @@ func TemplateVars_SonarrNotification(mediaItem models.MediaItem, setItem models.
 		},
 	)
 }
+
+func TemplateVars_WantedItemAvailable(mediaItem models.MediaItem, setItem models.BaseSetInfo) map[string]string {
+	return MergeTemplateVars(
+		BaseTemplateVars(),
+		map[string]string{
+			"MediaItemTitle":        mediaItem.Title,
+			"MediaItemYear":         fmt.Sprintf("%d", mediaItem.Year),
+			"MediaItemTMDBID":       mediaItem.TMDB_ID,
+			"MediaItemLibraryTitle": mediaItem.LibraryTitle,
+			"MediaItemRatingKey":    mediaItem.RatingKey,
+			"MediaItemType":         mediaItem.Type,
+			"SetID":                 setItem.ID,
+			"SetTitle":              setItem.Title,
+			"SetType":               setItem.Type,
+			"SetCreator":            setItem.UserCreated,
+		},
+	)
+}
+
+func TemplateVars_IgnoredItemExpired(mediaItem models.MediaItem, ignoredUntil time.Time) map[string]string {
+	return MergeTemplateVars(
+		BaseTemplateVars(),
+		map[string]string{
+			"MediaItemTitle":        mediaItem.Title,
+			"MediaItemYear":         fmt.Sprintf("%d", mediaItem.Year),
+			"MediaItemTMDBID":       mediaItem.TMDB_ID,
+			"MediaItemLibraryTitle": mediaItem.LibraryTitle,
+			"MediaItemRatingKey":    mediaItem.RatingKey,
+			"MediaItemType":         mediaItem.Type,
+			"IgnoredUntil":          ignoredUntil.Local().Format("2006-01-02 15:04"),
+		},
+	)
+}
@@ -2,6 +2,7 @@
 
 import type { CollectionItem } from "@/app/collections/page";
 import { formatDownloadSize } from "@/helper/format-download-size";
+import { SaveCollectionToDB } from "@/services/database/save-collection";
 import { DownloadImageFileForCollectionItem } from "@/services/downloads/download-collection-image";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { Check, Download, LoaderIcon, User, X } from "lucide-react";
@@ -50,6 +51,7 @@ export interface CollectionsDownloadModalProps {
 const downloadSchema = z.object({
   poster: z.boolean(),
   backdrop: z.boolean(),
+  autoDownload: z.boolean(),
 });
 
 const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ item, set }) => {
@@ -69,6 +71,7 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
   const [errors, setErrors] = useState<{
     poster_error?: string;
     backdrop_error?: string;
+    save_error?: string;
   }>({});
 
   // User Preferences
@@ -82,6 +85,7 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
     defaultValues: {
       poster: downloadDefaults.includes("poster") && !!posterImage,
       backdrop: downloadDefaults.includes("backdrop") && !!backdropImage,
+      autoDownload: false,
     },
   });
 
@@ -90,6 +94,7 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
     form.reset({
       poster: downloadDefaults.includes("poster") && !!posterImage,
       backdrop: downloadDefaults.includes("backdrop") && !!backdropImage,
+      autoDownload: false,
     });
   }, [form, downloadDefaults, posterImage, backdropImage]);
 
@@ -155,6 +160,24 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
     });
   };
 
+  const saveCollection = async (data: z.infer<typeof downloadSchema>) => {
+    const response = await SaveCollectionToDB({
+      collection_item: item,
+      poster_set: set,
+      selected_types: {
+        poster: data.poster && !!posterImage,
+        backdrop: data.backdrop && !!backdropImage,
+      },
+      auto_download: data.autoDownload,
+    });
+    if (response.status === "error") {
+      setErrors((prev) => ({
+        ...prev,
+        save_error: response.error?.message || "Failed to save collection for Auto Download.",
+      }));
+    }
+  };
+
   const downloadImageFileAndApply = async (
     imageType: TYPE_DOWNLOAD_COLLECTION_IMAGE_TYPE_OPTIONS,
     collectionItem: CollectionItem,
@@ -196,6 +219,7 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
         ...prev,
         [`${imageType}_progress`]: `Downloaded ${imageLabel}`,
       }));
+      return true;
     } catch {
       isDone = true;
       if (interval) clearInterval(interval);
@@ -207,6 +231,7 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
         ...prev,
         [`${imageType}_error`]: `Failed to download ${imageLabel}.`,
       }));
+      return false;
     } finally {
       updateProgressValue(progressRef.current + progressIncrementRef.current);
     }
@@ -240,12 +265,18 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
       const totalItemsToDownload = (data.poster && posterImage ? 1 : 0) + (data.backdrop && backdropImage ? 1 : 0);
       progressIncrementRef.current = 95 / (totalItemsToDownload * 2); // Multiply by 2 for start and end progress
 
+      let applied = false;
       if (data.poster && posterImage) {
-        await downloadImageFileAndApply(posterImage.type, item, posterImage);
+        applied = (await downloadImageFileAndApply(posterImage.type, item, posterImage)) || applied;
       }
 
       if (data.backdrop && backdropImage) {
-        await downloadImageFileAndApply(backdropImage.type, item, backdropImage);
+        applied = (await downloadImageFileAndApply(backdropImage.type, item, backdropImage)) || applied;
+      }
+
+      // Save the collection so that AutoDownload can keep the images up to date
+      if (data.autoDownload && applied) {
+        await saveCollection(data);
       }
 
       updateProgressValue(100);
@@ -347,6 +378,19 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
                 )}
               </div>
 
+              <FormField
+                control={form.control}
+                name="autoDownload"
+                render={({ field }) => (
+                  <FormItem className="flex flex-row items-center space-x-2">
+                    <FormControl>
+                      <Checkbox checked={field.value} onCheckedChange={field.onChange} />
+                    </FormControl>
+                    <FormLabel className="text-md font-normal cursor-pointer">Auto Download</FormLabel>
+                  </FormItem>
+                )}
+              />
+
               {numberOfImagesSelected > 0 ? (
                 <>
                   <div className="text-sm text-muted-foreground">Number of Images: {numberOfImagesSelected}</div>
@@ -378,7 +422,7 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
                     </div>
                   )}
 
-                  {(errors.poster_error || errors.backdrop_error) && (
+                  {(errors.poster_error || errors.backdrop_error || errors.save_error) && (
                     <div className="space-y-1 mt-2">
                       <div className="text-xs font-semibold mb-1 text-red-600">Errors</div>
                       {errors.poster_error && (
@@ -393,6 +437,12 @@ const CollectionsDownloadModal: React.FC<CollectionsDownloadModalProps> = ({ ite
                           Backdrop: {errors.backdrop_error}
                         </div>
                       )}
+                      {errors.save_error && (
+                        <div className="text-red-600 flex items-center gap-1">
+                          <X className="mr-1 h-4 w-4" />
+                          Auto Download: {errors.save_error}
+                        </div>
+                      )}
                     </div>
                   )}
                 </div>
@@ -27,6 +27,7 @@ import type { MediaItem } from "@/types/media-and-posters/media-item-and-library
 export interface CollectionItem {
   rating_key: string;
   index: string;
+  tmdb_id?: string;
   title: string;
   summary?: string;
   child_count: number;
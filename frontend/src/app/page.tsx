@@ -304,6 +304,8 @@ export default function Home() {
         items = items.filter((item) => item.ignored_in_db && item.ignored_mode === "until-set-available");
       } else if (filterIgnored === "until-new-set-available") {
         items = items.filter((item) => item.ignored_in_db && item.ignored_mode === "until-new-set-available");
+      } else if (filterIgnored === "until-date") {
+        items = items.filter((item) => item.ignored_in_db && item.ignored_mode === "until-date");
       } else if (filterIgnored === "ignored") {
         items = items.filter((item) => item.ignored_in_db);
       } else if (filterIgnored === "not_ignored") {
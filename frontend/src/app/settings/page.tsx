@@ -20,6 +20,7 @@ import { ConfigSectionMediaServer } from "@/components/settings-onboarding/Confi
 import { ConfigSectionMediux } from "@/components/settings-onboarding/ConfigSectionMediux";
 import { ConfigSectionNotifications } from "@/components/settings-onboarding/ConfigSectionNotifications";
 import { ConfigSectionSonarrRadarr } from "@/components/settings-onboarding/ConfigSectionSonarrRadarr";
+import { ConfigSectionTMDB } from "@/components/settings-onboarding/ConfigSectionTMDB";
 import { UserPreferencesCard } from "@/components/settings-onboarding/UserPreferences";
 import { ConfirmDestructiveDialogActionButton } from "@/components/shared/dialog-destructive-action";
 import { ErrorMessage } from "@/components/shared/error-message";
@@ -581,13 +582,13 @@ const SettingsPage: React.FC = () => {
                   errorsUpdate={(errs) => updateSectionErrors("auto_download", errs as Record<string, string>)}
                 />
 
-                {/* <ConfigSectionTMDB
-									value={newConfig.TMDB}
-									editing={editing}
-									dirtyFields={dirty.TMDB}
-									onChange={(f, v) => updateConfigField("TMDB", f, v)}
-									errorsUpdate={(errs) => updateSectionErrors("TMDB", errs as Record<string, string>)}
-								/> */}
+                <ConfigSectionTMDB
+                  value={newConfig.tmdb}
+                  editing={editing}
+                  dirtyFields={dirty.tmdb}
+                  onChange={(f, v) => updateConfigField("tmdb", f, v)}
+                  errorsUpdate={(errs) => updateSectionErrors("tmdb", errs as Record<string, string>)}
+                />
 
                 <ConfigSectionSonarrRadarr
                   value={newConfig.sonarr_radarr}
@@ -1,12 +1,14 @@
 "use client";
 
 import cronstrue from "cronstrue";
+import { Plus, Trash2 } from "lucide-react";
 
 import React, { useEffect, useRef } from "react";
 
 import Link from "next/link";
 
 import { PopoverHelp } from "@/components/shared/popover-help";
+import { Button } from "@/components/ui/button";
 import { Card } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
@@ -14,7 +16,13 @@ import { Switch } from "@/components/ui/switch";
 
 import { cn } from "@/lib/cn";
 
-import type { AppConfigAutoDownload } from "@/types/config/config";
+import { defaultAppConfig } from "@/types/config/config-default-app";
+import type {
+  AppConfigAutoApply,
+  AppConfigAutoApplyCreator,
+  AppConfigAutoApplyTypes,
+  AppConfigAutoDownload,
+} from "@/types/config/config";
 
 interface ConfigSectionAutoDownloadProps {
   value: AppConfigAutoDownload;
@@ -24,6 +32,20 @@ interface ConfigSectionAutoDownloadProps {
   errorsUpdate?: (errors: Partial<Record<keyof AppConfigAutoDownload, string>>) => void;
 }
 
+const AUTO_APPLY_TYPES: { key: keyof AppConfigAutoApplyTypes; label: string }[] = [
+  { key: "poster", label: "Poster" },
+  { key: "backdrop", label: "Backdrop" },
+  { key: "season_poster", label: "Season Posters" },
+  { key: "special_season_poster", label: "Special Season Poster" },
+  { key: "titlecard", label: "Titlecards" },
+];
+
+const splitList = (text: string): string[] =>
+  text
+    .split(",")
+    .map((s) => s.trim())
+    .filter(Boolean);
+
 const validateCron = (expr: string): string | null => {
   if (!expr) return null;
   const trimmed = expr.trim();
@@ -50,8 +72,23 @@ export const ConfigSectionAutoDownload: React.FC<ConfigSectionAutoDownloadProps>
       const cronErr = validateCron(value.cron);
       if (cronErr) errs.cron = cronErr;
     }
+    const autoApply = value.auto_apply;
+    if (autoApply?.enabled) {
+      if (autoApply.creators.length === 0 && !autoApply.fallback_to_most_popular) {
+        errs.auto_apply = "Add at least one creator or enable the most popular fallback.";
+      } else if (autoApply.creators.some((c) => !c.username.trim())) {
+        errs.auto_apply = "Each creator needs a username.";
+      } else if (!Object.values(autoApply.selected_types).some(Boolean)) {
+        errs.auto_apply = "Select at least one image type to apply.";
+      }
+    }
     return errs;
-  }, [value.enabled, value.cron]);
+  }, [value.enabled, value.cron, value.auto_apply]);
+
+  const autoApply: AppConfigAutoApply = value.auto_apply ?? defaultAppConfig().auto_download.auto_apply!;
+  const updateAutoApply = (patch: Partial<AppConfigAutoApply>) => onChange("auto_apply", { ...autoApply, ...patch });
+  const updateCreator = (idx: number, patch: Partial<AppConfigAutoApplyCreator>) =>
+    updateAutoApply({ creators: autoApply.creators.map((c, i) => (i === idx ? { ...c, ...patch } : c)) });
 
   useEffect(() => {
     if (!errorsUpdate) return;
@@ -121,6 +158,132 @@ export const ConfigSectionAutoDownload: React.FC<ConfigSectionAutoDownloadProps>
           <p className="text-xs text-muted-foreground">{cronstrue.toString(value.cron)}</p>
         )}
       </div>
+
+      {/* Auto Apply */}
+      <div
+        className={cn(
+          "space-y-3 border rounded-md p-3 transition",
+          errors.auto_apply ? "border-red-500" : "border-muted",
+          dirtyFields.auto_apply && !errors.auto_apply && "border-amber-500"
+        )}
+      >
+        <div className="flex items-center justify-between">
+          <Label className="mr-2">Auto Apply Sets to New Items</Label>
+          <div className="flex items-center gap-2">
+            <Switch
+              disabled={!editing}
+              checked={autoApply.enabled}
+              onCheckedChange={(v) => updateAutoApply({ enabled: v })}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-auto-download-auto-apply">
+                <p>
+                  When a new item shows up in your library and nothing is saved for it yet, aura picks a set by your
+                  preferred creators (highest ranked first) and applies it. Creators can be limited to certain
+                  libraries, item types and image languages.
+                </p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+
+        {autoApply.enabled && (
+          <>
+            {autoApply.creators.length === 0 && (
+              <p className="text-sm text-muted-foreground">No preferred creators added yet.</p>
+            )}
+            {autoApply.creators.map((creator, idx) => (
+              <div key={idx} className="space-y-2 rounded-md border border-muted p-2">
+                <div className="flex items-center gap-2">
+                  <span className="text-sm text-muted-foreground w-6">#{idx + 1}</span>
+                  <Input
+                    disabled={!editing}
+                    placeholder="MediUX username"
+                    value={creator.username}
+                    onChange={(e) => updateCreator(idx, { username: e.target.value })}
+                  />
+                  {editing && (
+                    <Button
+                      variant="ghost"
+                      size="icon"
+                      onClick={() => updateAutoApply({ creators: autoApply.creators.filter((_, i) => i !== idx) })}
+                      aria-label="auto-apply-remove-creator"
+                      className="bg-red-700"
+                    >
+                      <Trash2 className="h-4 w-4" />
+                    </Button>
+                  )}
+                </div>
+                <div className="grid grid-cols-1 gap-2 sm:grid-cols-3">
+                  <Input
+                    disabled={!editing}
+                    placeholder="Libraries (all)"
+                    value={(creator.libraries ?? []).join(", ")}
+                    onChange={(e) => updateCreator(idx, { libraries: splitList(e.target.value) })}
+                  />
+                  <Input
+                    disabled={!editing}
+                    placeholder="Types: movie, show (all)"
+                    value={(creator.types ?? []).join(", ")}
+                    onChange={(e) => updateCreator(idx, { types: splitList(e.target.value) })}
+                  />
+                  <Input
+                    disabled={!editing}
+                    placeholder="Languages (any)"
+                    value={(creator.languages ?? []).join(", ")}
+                    onChange={(e) => updateCreator(idx, { languages: splitList(e.target.value) })}
+                  />
+                </div>
+              </div>
+            ))}
+            {editing && (
+              <Button
+                type="button"
+                variant="outline"
+                size="sm"
+                onClick={() => updateAutoApply({ creators: [...autoApply.creators, { username: "" }] })}
+              >
+                <Plus className="h-4 w-4 mr-1" />
+                Add Creator
+              </Button>
+            )}
+
+            <div className="flex items-center justify-between">
+              <Label className="mr-2">Fall Back to Most Popular Set</Label>
+              <Switch
+                disabled={!editing}
+                checked={autoApply.fallback_to_most_popular}
+                onCheckedChange={(v) => updateAutoApply({ fallback_to_most_popular: v })}
+              />
+            </div>
+
+            <div className="flex items-center justify-between">
+              <Label className="mr-2">Auto Download Saved Sets</Label>
+              <Switch
+                disabled={!editing}
+                checked={autoApply.auto_download}
+                onCheckedChange={(v) => updateAutoApply({ auto_download: v })}
+              />
+            </div>
+
+            <div className="flex flex-wrap gap-4">
+              {AUTO_APPLY_TYPES.map(({ key, label }) => (
+                <div key={key} className="flex items-center gap-2">
+                  <Switch
+                    disabled={!editing}
+                    checked={autoApply.selected_types[key]}
+                    onCheckedChange={(v) =>
+                      updateAutoApply({ selected_types: { ...autoApply.selected_types, [key]: v } })
+                    }
+                  />
+                  <Label>{label}</Label>
+                </div>
+              ))}
+            </div>
+          </>
+        )}
+        {errors.auto_apply && <p className="text-xs text-red-500">{errors.auto_apply}</p>}
+      </div>
     </Card>
   );
 };
@@ -38,6 +38,22 @@ interface ConfigSectionImagesProps {
       path?: boolean;
       episode_naming_convention?: boolean;
     };
+    local_artwork?: {
+      enabled?: boolean;
+      path?: boolean;
+    };
+    artwork_history?: {
+      enabled?: boolean;
+      max_versions?: boolean;
+    };
+    drift_detection?: {
+      enabled?: boolean;
+      cron?: boolean;
+      reapply?: boolean;
+    };
+    verify_apply?: {
+      enabled?: boolean;
+    };
   };
   onChange: <K extends keyof AppConfigImages, F extends keyof AppConfigImages[K]>(
     group: K,
@@ -86,8 +102,26 @@ export const ConfigSectionImages: React.FC<ConfigSectionImagesProps> = ({
       }
     }
 
+    // If Local Artwork is enabled, the path is required
+    if (value.local_artwork?.enabled && !value.local_artwork.path?.trim()) {
+      errs.local_artwork = "Local artwork path is required.";
+    }
+
+    // If Drift Detection is enabled, the cron expression is required
+    if (value.drift_detection?.enabled && !value.drift_detection.cron?.trim()) {
+      errs.drift_detection = "Drift detection cron expression is required.";
+    }
+
     return errs;
-  }, [mediaServerType, value.save_images_locally.enabled, value.save_images_locally.episode_naming_convention]);
+  }, [
+    mediaServerType,
+    value.save_images_locally.enabled,
+    value.save_images_locally.episode_naming_convention,
+    value.local_artwork?.enabled,
+    value.local_artwork?.path,
+    value.drift_detection?.enabled,
+    value.drift_detection?.cron,
+  ]);
 
   // Emit errors upward
   useEffect(() => {
@@ -256,6 +290,212 @@ export const ConfigSectionImages: React.FC<ConfigSectionImagesProps> = ({
           )}
         </div>
       )}
+
+      {/* Local Artwork */}
+      <div
+        className={cn(
+          "border rounded-md p-3 transition",
+          "border-muted",
+          dirtyFields.local_artwork?.enabled && "border-amber-500"
+        )}
+      >
+        <div className="flex items-center justify-between mb-2">
+          <Label className="mr-2">Local Artwork Folder</Label>
+          <div className="flex items-center gap-2">
+            <Switch
+              disabled={!editing}
+              checked={!!value.local_artwork?.enabled}
+              onCheckedChange={(v) => onChange("local_artwork", "enabled", v)}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-images-local-artwork">
+                <p>
+                  Use a local folder of your own artwork as an image source. Each item needs its own folder named by
+                  TMDB ID or &quot;Title (Year)&quot;, containing files like poster.png, Season01.png and S01E01.png.
+                </p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+
+        {value.local_artwork?.enabled && (
+          <div className="mt-2">
+            <div className="flex items-center justify-between mb-2">
+              <Label className="mr-2">Path</Label>
+              {editing && (
+                <PopoverHelp ariaLabel="help-images-local-artwork-path">
+                  <p>Enter the root folder of your local artwork. This must be accessible by the Aura server.</p>
+                </PopoverHelp>
+              )}
+            </div>
+            <Input
+              type="text"
+              disabled={!editing}
+              value={value.local_artwork.path || ""}
+              onChange={(e) => onChange("local_artwork", "path", e.target.value)}
+              className={cn(
+                "w-full px-3 py-2 border rounded-md focus:outline-none focus:ring-2 focus:ring-primary disabled:opacity-50 transition",
+                dirtyFields.local_artwork?.path && "border-amber-500"
+              )}
+              placeholder="/path/to/artwork"
+            />
+          </div>
+        )}
+      </div>
+
+      {/* Artwork History */}
+      <div
+        className={cn(
+          "border rounded-md p-3 transition",
+          "border-muted",
+          dirtyFields.artwork_history?.enabled && "border-amber-500"
+        )}
+      >
+        <div className="flex items-center justify-between mb-2">
+          <Label className="mr-2">Artwork History</Label>
+          <div className="flex items-center gap-2">
+            <Switch
+              disabled={!editing}
+              checked={!!value.artwork_history?.enabled}
+              onCheckedChange={(v) => onChange("artwork_history", "enabled", v)}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-images-artwork-history">
+                <p>
+                  Keep a copy of the artwork on your media server before AURA replaces it. Previous versions can be
+                  viewed and reverted from the Artwork History of each item.
+                </p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+
+        {value.artwork_history?.enabled && (
+          <div className="mt-2">
+            <div className="flex items-center justify-between mb-2">
+              <Label className="mr-2">Versions to Keep</Label>
+              {editing && (
+                <PopoverHelp ariaLabel="help-images-artwork-history-max-versions">
+                  <p>Number of previous versions kept for each image (e.g. each poster or titlecard). Defaults to 5.</p>
+                </PopoverHelp>
+              )}
+            </div>
+            <Input
+              type="number"
+              min={1}
+              disabled={!editing}
+              value={value.artwork_history.max_versions || ""}
+              onChange={(e) => onChange("artwork_history", "max_versions", parseInt(e.target.value, 10) || 0)}
+              className={cn(
+                "w-full px-3 py-2 border rounded-md focus:outline-none focus:ring-2 focus:ring-primary disabled:opacity-50 transition",
+                dirtyFields.artwork_history?.max_versions && "border-amber-500"
+              )}
+              placeholder="5"
+            />
+          </div>
+        )}
+      </div>
+
+      {/* Drift Detection */}
+      <div
+        className={cn(
+          "border rounded-md p-3 transition",
+          "border-muted",
+          dirtyFields.drift_detection?.enabled && "border-amber-500"
+        )}
+      >
+        <div className="flex items-center justify-between mb-2">
+          <Label className="mr-2">Drift Detection</Label>
+          <div className="flex items-center gap-2">
+            <Switch
+              disabled={!editing}
+              checked={!!value.drift_detection?.enabled}
+              onCheckedChange={(v) => onChange("drift_detection", "enabled", v)}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-images-drift-detection">
+                <p>
+                  Record a fingerprint of every image AURA applies and check on a schedule if it was changed by
+                  something else, like another tool or a metadata refresh.
+                </p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+
+        {value.drift_detection?.enabled && (
+          <div className="mt-2 space-y-3">
+            <div>
+              <div className="flex items-center justify-between mb-2">
+                <Label className="mr-2">Cron</Label>
+                {editing && (
+                  <PopoverHelp ariaLabel="help-images-drift-detection-cron">
+                    <p>Cron expression for the drift check. Defaults to every day at 3 AM (0 3 * * *).</p>
+                  </PopoverHelp>
+                )}
+              </div>
+              <Input
+                type="text"
+                disabled={!editing}
+                value={value.drift_detection.cron}
+                onChange={(e) => onChange("drift_detection", "cron", e.target.value)}
+                className={cn(
+                  "w-full px-3 py-2 border rounded-md focus:outline-none focus:ring-2 focus:ring-primary disabled:opacity-50 transition",
+                  dirtyFields.drift_detection?.cron && "border-amber-500"
+                )}
+                placeholder="0 3 * * *"
+              />
+            </div>
+
+            <div
+              className={cn(
+                "flex items-center justify-between rounded-md",
+                dirtyFields.drift_detection?.reapply && "border border-amber-500 p-2"
+              )}
+            >
+              <Label className="mr-2">Re-apply Changed Images</Label>
+              <div className="flex items-center gap-2">
+                <Switch
+                  disabled={!editing}
+                  checked={!!value.drift_detection.reapply}
+                  onCheckedChange={(v) => onChange("drift_detection", "reapply", v)}
+                />
+                {editing && (
+                  <PopoverHelp ariaLabel="help-images-drift-detection-reapply">
+                    <p>Apply the saved image again when the drift check finds that it was changed.</p>
+                  </PopoverHelp>
+                )}
+              </div>
+            </div>
+          </div>
+        )}
+      </div>
+
+      {/* Verify Apply */}
+      <div
+        className={cn(
+          "flex items-center justify-between border rounded-md p-3 transition",
+          "border-muted",
+          dirtyFields.verify_apply?.enabled && "border-amber-500"
+        )}
+      >
+        <Label className="mr-2">Verify Applied Images</Label>
+        <div className="flex items-center gap-2">
+          <Switch
+            disabled={!editing}
+            checked={!!value.verify_apply?.enabled}
+            onCheckedChange={(v) => onChange("verify_apply", "enabled", v)}
+          />
+          {editing && (
+            <PopoverHelp ariaLabel="help-images-verify-apply">
+              <p>
+                After applying an image, fetch it back from the media server and check that it was taken. Images that
+                don't match are refreshed once and marked as unverified in the queue and AutoDownload results.
+              </p>
+            </PopoverHelp>
+          )}
+        </div>
+      </div>
     </Card>
   );
 };
@@ -14,6 +14,7 @@ import { Card } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "@/components/ui/select";
+import { Switch } from "@/components/ui/switch";
 
 import { cn } from "@/lib/cn";
 
@@ -29,6 +30,27 @@ interface ConfigSectionLoggingProps {
 
 const LOG_LEVEL_OPTIONS = ["TRACE", "DEBUG", "INFO", "WARN", "ERROR"] as const;
 
+const LOG_ROTATION_FIELDS = [
+  {
+    field: "max_size_mb",
+    label: "Rotate At (MB)",
+    placeholder: "25",
+    help: "The log file is rotated when it reaches this size. Defaults to 25 MB.",
+  },
+  {
+    field: "max_backups",
+    label: "Rotated Files to Keep",
+    placeholder: "7",
+    help: "Number of rotated log files kept. Older ones are deleted. Defaults to 7.",
+  },
+  {
+    field: "max_age_days",
+    label: "Days to Keep",
+    placeholder: "14",
+    help: "Rotated log files older than this are deleted. Defaults to 14 days.",
+  },
+] as const;
+
 export const ConfigSectionLogging: React.FC<ConfigSectionLoggingProps> = ({
   value,
   editing,
@@ -44,8 +66,11 @@ export const ConfigSectionLogging: React.FC<ConfigSectionLoggingProps> = ({
     if (!value.level?.trim()) errs.level = "Level is required.";
     else if (!LOG_LEVEL_OPTIONS.includes(value.level as (typeof LOG_LEVEL_OPTIONS)[number]))
       errs.level = "Invalid log level.";
+    for (const { field } of LOG_ROTATION_FIELDS) {
+      if ((value[field] ?? 0) < 0) errs[field] = "Must be 0 or greater.";
+    }
     return errs;
-  }, [value.level]);
+  }, [value]);
 
   useEffect(() => {
     if (!errorsUpdate) return;
@@ -129,6 +154,48 @@ export const ConfigSectionLogging: React.FC<ConfigSectionLoggingProps> = ({
         {errors.level && <p className="text-xs text-red-500">{errors.level}</p>}
       </div>
 
+      {/* Rotation */}
+      <div className="space-y-3 border rounded-md p-3">
+        <div className="flex items-center justify-between">
+          <Label>Rotation</Label>
+          <div className="flex items-center gap-2">
+            <Label className="text-sm text-muted-foreground">Compress</Label>
+            <Switch
+              disabled={!editing}
+              checked={!!value.compress}
+              onCheckedChange={(v) => onChange("compress", v)}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-logging-compress">
+                <p>Gzip rotated log files to save disk space. They can still be searched from the Logs page.</p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+        {LOG_ROTATION_FIELDS.map(({ field, label, placeholder, help }) => (
+          <div key={field} className="space-y-1">
+            <div className="flex items-center justify-between">
+              <Label className="text-sm">{label}</Label>
+              {editing && (
+                <PopoverHelp ariaLabel={`help-logging-${field}`}>
+                  <p>{help}</p>
+                </PopoverHelp>
+              )}
+            </div>
+            <Input
+              type="number"
+              min={0}
+              disabled={!editing}
+              value={value[field] || ""}
+              onChange={(e) => onChange(field, parseInt(e.target.value, 10) || 0)}
+              className={cn(dirtyFields[field] && "border-amber-500")}
+              placeholder={placeholder}
+            />
+            {errors[field] && <p className="text-xs text-red-500">{errors[field]}</p>}
+          </div>
+        ))}
+      </div>
+
       {/* File (read-only) */}
       <div className="space-y-1 border rounded-md p-3">
         <div className="flex items-center justify-between mb-1">
@@ -680,6 +680,38 @@ export const ConfigSectionMediaServer: React.FC<ConfigSectionMediaServerProps> =
           </div>
         </div>
       )}
+
+      {/* Emby/Jellyfin Websocket Listener */}
+      {(value.type === "Emby" || value.type === "Jellyfin") && (
+        <div
+          className={cn(
+            "flex items-center justify-between border rounded-md p-3 transition",
+            "border-muted",
+            dirtyFields.enable_emby_jellyfin_event_listener && "border-amber-500"
+          )}
+        >
+          <Label>Enable {value.type} Websocket Listener</Label>
+          <div className="flex items-center gap-2">
+            <Switch
+              disabled={!editing}
+              checked={value.enable_emby_jellyfin_event_listener}
+              onCheckedChange={(c) => onChange("enable_emby_jellyfin_event_listener", c)}
+            />
+            {editing && (
+              <PopoverHelp ariaLabel="help-emby-jellyfin-websocket-listener">
+                <p className="mb-2">
+                  When enabled, Aura will listen for {value.type} library change events and automatically reapply saved
+                  images to refreshed items.
+                </p>
+                <p className="text-muted-foreground">
+                  This requires an extra websocket connection from Aura to your {value.type} server. It can be safely
+                  disabled if you don&apos;t want this functionality or if you experience any issues.
+                </p>
+              </PopoverHelp>
+            )}
+          </div>
+        </div>
+      )}
     </Card>
   );
 };
@@ -13,6 +13,7 @@ import { Card } from "@/components/ui/card";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "@/components/ui/select";
+import { Switch } from "@/components/ui/switch";
 
 import { cn } from "@/lib/cn";
 
@@ -210,6 +211,36 @@ export const ConfigSectionMediux: React.FC<ConfigSectionMediuxProps> = ({
         </Select>
         {errors.download_quality && <p className="text-xs text-red-500">{errors.download_quality}</p>}
       </div>
+
+      {/* MediUX Websocket Listener */}
+      <div
+        className={cn(
+          "flex items-center justify-between border rounded-md p-3 transition",
+          "border-muted",
+          dirtyFields.enable_event_listener && "border-amber-500"
+        )}
+      >
+        <Label>Enable MediUX Websocket Listener</Label>
+        <div className="flex items-center gap-2">
+          <Switch
+            disabled={!editing}
+            checked={value.enable_event_listener ?? false}
+            onCheckedChange={(c) => onChange("enable_event_listener", c)}
+          />
+          {editing && (
+            <PopoverHelp ariaLabel="help-mediux-websocket-listener">
+              <p className="mb-2">
+                When enabled, Aura will listen for updates to your saved AutoDownload sets on MediUX and check the items
+                that use them right away, instead of waiting for the next AutoDownload run.
+              </p>
+              <p className="text-muted-foreground">
+                This requires an extra websocket connection from Aura to MediUX. It can be safely disabled if you
+                don&apos;t want this functionality or if you experience any issues.
+              </p>
+            </PopoverHelp>
+          )}
+        </div>
+      </div>
     </Card>
   );
 };
@@ -72,6 +72,8 @@ const TEMPLATE_TITLES: Partial<Record<keyof AppConfigNotificationTemplate, strin
   new_sets_available_for_ignored_items: "New Sets Available for Ignored Item",
   check_for_media_item_changes_job: "Check For Media Item Changes Job",
   sonarr_notification: "Sonarr Notification",
+  wanted_item_available: "Wanted Item Available",
+  ignored_item_expired: "Ignored Item Expired",
 };
 
 const TEMPLATE_SUPPORTS_IMAGE: Partial<Record<keyof AppConfigNotificationTemplate, boolean>> = {
@@ -82,6 +84,8 @@ const TEMPLATE_SUPPORTS_IMAGE: Partial<Record<keyof AppConfigNotificationTemplat
   new_sets_available_for_ignored_items: true,
   check_for_media_item_changes_job: false,
   sonarr_notification: true,
+  wanted_item_available: true,
+  ignored_item_expired: true,
 };
 
 const TEMPLATE_VAR_REGEX = /\{\{\s*([a-zA-Z0-9_.-]+)\s*\}\}/g;
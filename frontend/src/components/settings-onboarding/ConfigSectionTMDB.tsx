@@ -1,6 +1,6 @@
 "use client";
 
-import React, { useEffect, useRef } from "react";
+import React, { useEffect, useRef, useState } from "react";
 
 import { PopoverHelp } from "@/components/shared/popover-help";
 import { Button } from "@/components/ui/button";
@@ -32,8 +32,17 @@ export const ConfigSectionTMDB: React.FC<ConfigSectionTMDBProps> = ({
   const prevErrorsRef = useRef<string>("");
 
   const errors = React.useMemo<Partial<Record<keyof AppConfigTMDB, string>>>(() => {
-    return {};
-  }, []);
+    const errs: Partial<Record<keyof AppConfigTMDB, string>> = {};
+    const minVote = value.min_vote_average ?? 0;
+    if (Number.isNaN(minVote) || minVote < 0 || minVote > 10) errs.min_vote_average = "Must be between 0 and 10.";
+    return errs;
+  }, [value.min_vote_average]);
+
+  // Keep the raw text while typing, so a trailing comma isn't removed right away
+  const [languagesText, setLanguagesText] = useState((value.languages ?? []).join(", "));
+  useEffect(() => {
+    setLanguagesText((value.languages ?? []).join(", "));
+  }, [value.languages]);
 
   useEffect(() => {
     if (!errorsUpdate) return;
@@ -44,10 +53,7 @@ export const ConfigSectionTMDB: React.FC<ConfigSectionTMDBProps> = ({
   }, [errors, errorsUpdate]);
 
   return (
-    <Card
-      hidden={true} // Hide TMDB settings for now since it's not used yet
-      className="p-5 space-y-1"
-    >
+    <Card className="p-5 space-y-1">
       <div className="flex items-center justify-between">
         <h2 className="text-xl font-semibold">TMDB</h2>
         <Button
@@ -97,6 +103,79 @@ export const ConfigSectionTMDB: React.FC<ConfigSectionTMDBProps> = ({
         />
         {errors.api_token && <p className="text-xs text-red-500">{errors.api_token}</p>}
       </div>
+
+      {/* Languages */}
+      <div
+        className={cn(
+          "space-y-1 border rounded-md p-3 transition",
+          dirtyFields.languages ? "border-amber-500" : "border-muted"
+        )}
+      >
+        <div className="flex items-center justify-between">
+          <Label>Image Languages</Label>
+          {editing && (
+            <PopoverHelp ariaLabel="help-tmdb-languages">
+              <p className="mb-2">
+                Comma separated ISO 639-1 codes of the TMDB posters and backdrops to offer (e.g. <code>en, none</code>).
+              </p>
+              <p className="text-muted-foreground">
+                Use <code>none</code> for textless images. Leave empty to offer images in every language.
+              </p>
+            </PopoverHelp>
+          )}
+        </div>
+        <Input
+          disabled={!editing}
+          placeholder="All languages"
+          value={languagesText}
+          onChange={(e) => setLanguagesText(e.target.value)}
+          onBlur={() =>
+            onChange(
+              "languages",
+              languagesText
+                .split(",")
+                .map((l) => l.trim().toLowerCase())
+                .filter((l) => l !== "")
+            )
+          }
+        />
+      </div>
+
+      {/* Minimum Vote Average */}
+      <div
+        className={cn(
+          "space-y-1 border rounded-md p-3 transition",
+          errors.min_vote_average
+            ? "border-red-500"
+            : dirtyFields.min_vote_average
+              ? "border-amber-500"
+              : "border-muted"
+        )}
+      >
+        <div className="flex items-center justify-between">
+          <Label>Minimum Vote Average</Label>
+          {editing && (
+            <PopoverHelp ariaLabel="help-tmdb-min-vote-average">
+              <p className="mb-2">Only offer TMDB images with at least this vote average (0-10).</p>
+              <p className="text-muted-foreground">
+                Episode stills are checked one episode at a time when this is above 0, which makes more requests to
+                TMDB.
+              </p>
+            </PopoverHelp>
+          )}
+        </div>
+        <Input
+          type="number"
+          min={0}
+          max={10}
+          step={0.5}
+          disabled={!editing}
+          value={value.min_vote_average ?? 0}
+          onChange={(e) => onChange("min_vote_average", e.target.value === "" ? 0 : Number(e.target.value))}
+          aria-invalid={!!errors.min_vote_average}
+        />
+        {errors.min_vote_average && <p className="text-xs text-red-500">{errors.min_vote_average}</p>}
+      </div>
     </Card>
   );
 };
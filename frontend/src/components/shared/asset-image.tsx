@@ -16,6 +16,7 @@ import { Skeleton } from "@/components/ui/skeleton";
 
 import { cn } from "@/lib/cn";
 import { type AspectRatio, getAspectRatioClass, getImageSizes } from "@/lib/image-sizes";
+import { getImageFileURL } from "@/lib/image-source";
 import { log } from "@/lib/logger";
 import { useUserPreferencesStore } from "@/lib/stores/global-user-preferences";
 
@@ -106,7 +107,7 @@ export function AssetImage({
   if (imageType === "url") {
     imageSrc = image as string;
   } else if (imageType === "mediux") {
-    imageSrc = `/api/images/mediux/item?asset_id=${(image as ImageFile).id}&modified_date=${(image as ImageFile).modified}`;
+    imageSrc = getImageFileURL(image as ImageFile);
   } else if (imageType === "item") {
     imageSrc = `/api/images/media/item?rating_key=${(image as MediaItem).rating_key}&image_type=${aspect}`;
   } else if (imageType === "collection") {
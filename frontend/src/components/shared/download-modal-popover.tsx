@@ -2,7 +2,12 @@ import { PopoverHelp } from "@/components/shared/popover-help";
 
 export interface DownloadModalPopoverProps {
   type:
-    "autodownload" | "add-to-db-only" | "add-to-queue-only" | "auto-add-new-collection-items" | "possible-future-types";
+    | "autodownload"
+    | "add-to-db-only"
+    | "add-to-queue-only"
+    | "auto-add-new-collection-items"
+    | "create-media-server-collection"
+    | "possible-future-types";
 }
 
 const downloadModalPopoverHelpText = {
@@ -14,6 +19,8 @@ const downloadModalPopoverHelpText = {
     "Add to Queue will add the set to the download queue. This is helpful if you want to quickly add sets without waiting for downloads to finish. Downloads in the queue will be processed the same way as normal downloads. Download queue runs every 1 minute.",
   "auto-add-new-collection-items":
     "Auto Add New Collection Items will check periodically for new items added to this collection and automatically download the images for them",
+  "create-media-server-collection":
+    "Create Collection on Media Server will create this collection (a Plex Collection or Emby/Jellyfin BoxSet) if it does not exist yet, add the movies from this set that are in your library, and apply the collection poster and backdrop. If the collection already exists, the movies are added to it.",
   "possible-future-types":
     "These image types are not currently available in this Set or Media Item. Selecting them saves the type in your database for this set, so future auto-download checks can download them when they are added.",
 };
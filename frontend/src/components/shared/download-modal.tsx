@@ -4,8 +4,12 @@ import { formatDownloadSize } from "@/helper/format-download-size";
 import { makePlural } from "@/helper/make_plural";
 import { upsertSavedSets } from "@/helper/media-item-update-saved-sets";
 import { AddNewItemToDB } from "@/services/database/add";
-import { downloadImageFileForMediaItem } from "@/services/downloads/download-image";
+import {
+  type DownloadImageFileForMediaItem_Set,
+  downloadImageFileForMediaItem,
+} from "@/services/downloads/download-image";
 import { AddItemToDownloadQueue } from "@/services/downloads/queue-add";
+import { CreateCollectionFromSet } from "@/services/mediaserver/create-collection-from-set";
 import { GetMediaItemDetails } from "@/services/mediaserver/get-media-item-details";
 import { zodResolver } from "@hookform/resolvers/zod";
 import {
@@ -90,6 +94,7 @@ type DownloadTaskPayload = {
   imageFile: ImageFile;
   fileName: string;
   mediaItem: MediaItem;
+  set?: DownloadImageFileForMediaItem_Set;
 };
 
 type AddToDBTaskPayload = {
@@ -296,6 +301,9 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
   // State - Add New Collection Items
   const [autoAddNewCollectionItems, setAutoAddNewCollectionItems] = useState(false);
 
+  // State - Create the collection on the Media Server
+  const [createMediaServerCollection, setCreateMediaServerCollection] = useState(false);
+
   // User Preferences
   const { downloadDefaults } = useUserPreferencesStore();
 
@@ -694,7 +702,7 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
     }));
 
     try {
-      const response = await downloadImageFileForMediaItem(payload.imageFile, payload.mediaItem, payload.fileName);
+      const response = await downloadImageFileForMediaItem(payload.imageFile, payload.mediaItem, payload.fileName, payload.set);
 
       if (response.status === "error") {
         throw new Error(response.error?.message || "Unknown error");
@@ -1446,6 +1454,7 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
                 imageFile: posterImg,
                 fileName: "Poster",
                 mediaItem: latestMediaItem,
+                set: item.Set,
               };
 
               addTask(item.MediaItem.rating_key, item.MediaItem.title, {
@@ -1471,6 +1480,7 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
                 imageFile: backdropImg,
                 fileName: "Backdrop",
                 mediaItem: latestMediaItem,
+                set: item.Set,
               };
 
               addTask(item.MediaItem.rating_key, item.MediaItem.title, {
@@ -1504,6 +1514,7 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
                   imageFile: sp,
                   fileName: `Season ${seasonNumber} Poster`,
                   mediaItem: latestMediaItem,
+                  set: item.Set,
                 };
 
                 addTask(item.MediaItem.rating_key, item.MediaItem.title, {
@@ -1538,6 +1549,7 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
                   imageFile: sp,
                   fileName: "Specials Season Poster",
                   mediaItem: latestMediaItem,
+                  set: item.Set,
                 };
 
                 addTask(item.MediaItem.rating_key, item.MediaItem.title, {
@@ -1579,6 +1591,7 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
                   imageFile: tc,
                   fileName: `S${seasonNumber}E${episodeNumber} Titlecard`,
                   mediaItem: latestMediaItem,
+                  set: item.Set,
                 };
 
                 addTask(item.MediaItem.rating_key, item.MediaItem.title, {
@@ -1765,6 +1778,38 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
         }
       }
 
+      // Create the collection on the Media Server (or add the movies to it) and apply the collection images
+      if (createMediaServerCollection && baseSetInfo.type === "collection" && formItems.length > 0 && !cancelRef.current) {
+        const firstItem = formItems[0].MediaItem;
+        const taskId = newId();
+        addTask("collection", "Collection", {
+          id: taskId,
+          status: "in-progress",
+          label: "Create collection on media server",
+          attempts: 1,
+          payload: { kind: "note", itemKey: "collection", itemTitle: "Collection" },
+        });
+        setCurrentText("Creating collection on media server...");
+        const createResp = await CreateCollectionFromSet({
+          set_id: baseSetInfo.id,
+          tmdb_id: firstItem.tmdb_id,
+          library_title: firstItem.library_title,
+        });
+        if (createResp.status === "error" || !createResp.data) {
+          updateTask(taskId, (t) => ({
+            ...t,
+            status: "failed",
+            error: createResp.error?.message || "Unknown error creating collection on media server",
+          }));
+        } else {
+          updateTask(taskId, (t) => ({
+            ...t,
+            status: "completed",
+            label: `${createResp.data?.created ? "Created" : "Updated"} collection "${createResp.data?.collection_item.title}"`,
+          }));
+        }
+      }
+
       setCurrentText("Completed!");
       setButtonTexts({
         cancel: "Close",
@@ -1879,6 +1924,22 @@ const DownloadModal: React.FC<DownloadModalProps> = ({
                     </FormItem>
                   )}
 
+                {/* Create Collection on Media Server
+									Only show this button for collection sets of movies
+								*/}
+                {baseSetInfo.type === "collection" && formItems.every((item) => item.MediaItem.type === "movie") && (
+                  <FormItem className="flex items-center space-x-2 mb-4">
+                    <FormControl>
+                      <Checkbox
+                        checked={createMediaServerCollection}
+                        onCheckedChange={(checked) => setCreateMediaServerCollection(checked ? true : false)}
+                      />
+                    </FormControl>
+                    <FormLabel className="text-md font-normal cursor-pointer">Create Collection on Media Server</FormLabel>
+                    <DownloadModalPopover type="create-media-server-collection" />
+                  </FormItem>
+                )}
+
                 {/* Add to Queue 
 									Only show this button if at least one item has types selected for download and not set to Add to DB Only
 								*/}
@@ -268,7 +268,8 @@ function FilterHomeContent({
                         filterIgnored === "ignored" ||
                         filterIgnored === "always" ||
                         filterIgnored === "until-set-available" ||
-                        filterIgnored === "until-new-set-available"
+                        filterIgnored === "until-new-set-available" ||
+                        filterIgnored === "until-date"
                       ) {
                         setFilterIgnored("");
                       }
@@ -309,6 +310,9 @@ function FilterHomeContent({
                   filterIgnored === option.value &&
                     option.value === "until-new-set-available" &&
                     "bg-yellow-500 text-primary-foreground",
+                  filterIgnored === option.value &&
+                    option.value === "until-date" &&
+                    "bg-blue-500 text-primary-foreground",
                   filterIgnored === option.value &&
                     option.value === "ignored" &&
                     "bg-orange-500 text-primary-foreground",
@@ -326,7 +330,8 @@ function FilterHomeContent({
                       option.value === "ignored" ||
                       option.value === "always" ||
                       option.value === "until-set-available" ||
-                      option.value === "until-new-set-available"
+                      option.value === "until-new-set-available" ||
+                      option.value === "until-date"
                     ) {
                       if (filterInDB === "inDB") {
                         setFilterInDB("");
@@ -0,0 +1,157 @@
+"use client";
+
+import {
+  type ArtworkHistory,
+  type ArtworkHistoryVersion,
+  GetArtworkHistory,
+  GetArtworkHistoryImageURL,
+  RevertArtwork,
+} from "@/services/images/artwork-history";
+import { Undo2 } from "lucide-react";
+import { toast } from "sonner";
+
+import { useEffect, useState } from "react";
+
+import { AssetImage } from "@/components/shared/asset-image";
+import { ErrorMessage } from "@/components/shared/error-message";
+import { Button } from "@/components/ui/button";
+import { Dialog, DialogContent, DialogDescription, DialogHeader, DialogTitle } from "@/components/ui/dialog";
+
+import { cn } from "@/lib/cn";
+import type { AspectRatio } from "@/lib/image-sizes";
+
+import type { APIResponse } from "@/types/api/api-response";
+import type { MediaItem } from "@/types/media-and-posters/media-item-and-library";
+
+interface ArtworkHistoryModalProps {
+  mediaItem: MediaItem;
+  isOpen: boolean;
+  onClose: () => void;
+}
+
+const versionLabel = (version: ArtworkHistoryVersion) => {
+  switch (version.image_type) {
+    case "poster":
+      return "Poster";
+    case "backdrop":
+      return "Backdrop";
+    case "season_poster":
+    case "special_season_poster":
+      return `Season ${version.season_number ?? ""} Poster`;
+    case "titlecard":
+      return `S${String(version.season_number ?? 0).padStart(2, "0")}E${String(version.episode_number ?? 0).padStart(2, "0")} Titlecard`;
+    default:
+      return version.image_type;
+  }
+};
+
+const versionAspect = (version: ArtworkHistoryVersion): AspectRatio => {
+  if (version.image_type === "backdrop") return "backdrop";
+  if (version.image_type === "titlecard") return "titlecard";
+  return "poster";
+};
+
+export function ArtworkHistoryModal({ mediaItem, isOpen, onClose }: ArtworkHistoryModalProps) {
+  const [history, setHistory] = useState<ArtworkHistory | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [revertingID, setRevertingID] = useState<string | null>(null);
+  const [error, setError] = useState<APIResponse<unknown> | null>(null);
+
+  const loadHistory = async () => {
+    setLoading(true);
+    setError(null);
+    const response = await GetArtworkHistory(mediaItem);
+    if (response.status === "error") {
+      setError(response);
+      setHistory(null);
+    } else {
+      setHistory(response.data ?? null);
+    }
+    setLoading(false);
+  };
+
+  useEffect(() => {
+    if (!isOpen) return;
+    loadHistory();
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [isOpen, mediaItem.rating_key]);
+
+  const handleRevert = async (version: ArtworkHistoryVersion) => {
+    setRevertingID(version.id);
+    setError(null);
+    const response = await RevertArtwork(mediaItem, version);
+    if (response.status === "error") {
+      setError(response);
+    } else {
+      toast.success(`Reverted ${versionLabel(version)} for '${mediaItem.title}'`);
+      // The artwork that was replaced by the revert is now in the history as well
+      await loadHistory();
+    }
+    setRevertingID(null);
+  };
+
+  const versions = history?.versions ?? [];
+
+  return (
+    <Dialog
+      open={isOpen}
+      onOpenChange={(open) => {
+        if (!open) onClose();
+      }}
+    >
+      <DialogContent className={cn("max-h-[80vh] overflow-y-auto sm:max-w-[700px]", "border border-primary")}>
+        <DialogHeader>
+          <DialogTitle className="text-lg font-bold">Artwork History</DialogTitle>
+          <DialogDescription className="text-sm text-muted-foreground">
+            Artwork that was on the media server before AURA replaced it for{" "}
+            <span className="font-semibold text-foreground">
+              '{mediaItem.title} ({mediaItem.year})'
+            </span>
+            . Artwork is only kept when Artwork History is enabled in the Images settings.
+          </DialogDescription>
+        </DialogHeader>
+
+        {loading && <p className="text-sm text-muted-foreground">Loading artwork history...</p>}
+
+        {!loading && !error && versions.length === 0 && (
+          <p className="text-sm text-muted-foreground">No artwork has been replaced for this item yet.</p>
+        )}
+
+        <div className="grid grid-cols-2 sm:grid-cols-3 gap-4">
+          {versions.map((version) => (
+            <div key={version.id} className="flex flex-col gap-2 rounded-md border border-border/60 p-2">
+              <AssetImage
+                image={GetArtworkHistoryImageURL(mediaItem, version)}
+                imageType="url"
+                aspect={versionAspect(version)}
+                className="w-full rounded shadow"
+              />
+              <div className="text-sm font-semibold">{versionLabel(version)}</div>
+              <div className="text-xs text-muted-foreground">
+                Replaced {new Date(version.captured_at).toLocaleString()}
+                {version.replaced_by.set_title && (
+                  <>
+                    {" "}
+                    by &quot;{version.replaced_by.set_title}&quot;
+                    {version.replaced_by.set_creator && ` (${version.replaced_by.set_creator})`}
+                  </>
+                )}
+              </div>
+              <Button
+                variant="outline"
+                size="sm"
+                disabled={revertingID !== null}
+                onClick={() => handleRevert(version)}
+              >
+                <Undo2 className="mr-2 h-4 w-4" />
+                {revertingID === version.id ? "Reverting..." : "Revert"}
+              </Button>
+            </div>
+          ))}
+        </div>
+
+        {error && <ErrorMessage error={error} />}
+      </DialogContent>
+    </Dialog>
+  );
+}
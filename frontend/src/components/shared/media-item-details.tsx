@@ -7,6 +7,7 @@ import {
   EyeClosedIcon,
   EyeOffIcon,
   FileIcon,
+  History,
   ImageIcon,
   RefreshCcw,
   Star,
@@ -20,6 +21,7 @@ import Link from "next/link";
 import { useRouter } from "next/navigation";
 
 import { AssetImage } from "@/components/shared/asset-image";
+import { ArtworkHistoryModal } from "@/components/shared/media-item-artwork-history-modal";
 import { ViewCurrentImagesModal } from "@/components/shared/media-item-images-modal";
 import { ManualImportModal } from "@/components/shared/media-item-manual-import-modal";
 import { MediaItemRatingModal } from "@/components/shared/media-item-rating-modal";
@@ -83,6 +85,7 @@ export function MediaItemDetails({
   const [isRatingModalOpen, setIsRatingModalOpen] = useState(false);
   const [isManualImportModalOpen, setIsManualImportModalOpen] = useState(false);
   const [isViewCurrentImagesModalOpen, setIsViewCurrentImagesModalOpen] = useState(false);
+  const [isArtworkHistoryModalOpen, setIsArtworkHistoryModalOpen] = useState(false);
 
   const touchStartXRef = useRef<number | undefined>(undefined);
   const mouseStartXRef = useRef<number | undefined>(undefined);
@@ -123,13 +126,14 @@ export function MediaItemDetails({
     }
   };
 
-  const handleAddToIgnoredClick = async (ignoreMode: string) => {
+  const handleAddToIgnoredClick = async (ignoreMode: string, duration?: string) => {
     const addToDBResp = await IgnoreItemInDB(
       tmdbID,
       libraryTitle,
       ignoreMode,
       currentSetsAvailable,
-      mediaItem?.edition || ""
+      mediaItem?.edition || "",
+      { duration }
     );
     if (addToDBResp.status === "error") {
       toast.error(`Failed to add ${title} to DB: ${addToDBResp.error?.message || "Unknown error"}`);
@@ -145,6 +149,11 @@ export function MediaItemDetails({
       toastMessage = `Will ignore ${title} until a set is available`;
     } else if (ignoreMode === "until-new-set-available") {
       toastMessage = `Will ignore ${title} until a new set is available`;
+    } else if (ignoreMode === "until-date") {
+      const ignoreUntil = addToDBResp.data?.ignore_until;
+      toastMessage = ignoreUntil
+        ? `Will ignore ${title} until ${new Date(ignoreUntil).toLocaleDateString()}`
+        : `Will ignore ${title} for ${duration}`;
     }
     toast.success(toastMessage);
   };
@@ -306,6 +315,11 @@ export function MediaItemDetails({
               <EyeClosedIcon className="inline ml-1 mr-1" size={16} /> This item is set to be temporarily ignored until
               a set is available
             </Lead>
+          ) : ignoreModeLocal === "until-date" ? (
+            <Lead className="text-md text-blue-500">
+              <EyeClosedIcon className="inline ml-1 mr-1" size={16} /> This item is set to be temporarily ignored until{" "}
+              {mediaItem.ignored_until ? new Date(mediaItem.ignored_until).toLocaleDateString() : "a date"}
+            </Lead>
           ) : (
             <Lead className="text-md text-yellow-500">
               <EyeClosedIcon className="inline ml-1 mr-1" size={16} /> This item is set to be temporarily ignored until
@@ -348,6 +362,10 @@ export function MediaItemDetails({
                 View Current Images
               </DropdownMenuItem>
             )}
+            <DropdownMenuItem className="cursor-pointer" onSelect={() => setIsArtworkHistoryModalOpen(true)}>
+              <History className="mr-2 h-4 w-4" />
+              Artwork History
+            </DropdownMenuItem>
             <DropdownMenuSeparator />
             {isInDBLocal ? (
               <DropdownMenuItem
@@ -405,6 +423,16 @@ export function MediaItemDetails({
                     Ignore Until New Set is Available
                   </DropdownMenuItem>
                 )}
+
+                <DropdownMenuItem
+                  className="cursor-pointer text-blue-500 focus:text-blue-800"
+                  onSelect={() => {
+                    void handleAddToIgnoredClick("until-date", "30d");
+                  }}
+                >
+                  <EyeClosedIcon className="text-blue-500" size={20} />
+                  Ignore for 30 Days
+                </DropdownMenuItem>
               </>
             )}
           </DropdownMenuContent>
@@ -497,6 +525,15 @@ export function MediaItemDetails({
           onClose={() => setIsViewCurrentImagesModalOpen(false)}
         />
       )}
+
+      {/* Artwork History Modal */}
+      {mediaItem && (
+        <ArtworkHistoryModal
+          mediaItem={mediaItem}
+          isOpen={isArtworkHistoryModalOpen}
+          onClose={() => setIsArtworkHistoryModalOpen(false)}
+        />
+      )}
     </div>
   );
 }
@@ -17,6 +17,7 @@ import { Label } from "@/components/ui/label";
 import { Switch } from "@/components/ui/switch";
 import { H1, Lead } from "@/components/ui/typography";
 
+import { getImageFileURL } from "@/lib/image-source";
 import { log } from "@/lib/logger";
 import { useOnboardingStore } from "@/lib/stores/global-store-onboarding";
 import { useUserPreferencesStore } from "@/lib/stores/global-user-preferences";
@@ -473,7 +474,7 @@ const ShowFullSetDetails: React.FC<{
     if (allBackdrops.length > 0) {
       // Randomly select one of the backdrops from the sets
       const randomIndex = Math.floor(Math.random() * allBackdrops.length);
-      selectedBackdropURL = `/api/images/mediux/item?asset_id=${allBackdrops[randomIndex].id}&modified_date=${allBackdrops[randomIndex].modified}&quality=optimized`;
+      selectedBackdropURL = getImageFileURL(allBackdrops[randomIndex], "optimized");
     } else {
       // No backdrops in the sets -> pick a TMDB backdrop for ANY unique item_tmdb_id found in posterSets images
       const tmdbIDs = new Set<string>();
@@ -0,0 +1,26 @@
+import type { ImageFile } from "@/types/media-and-posters/sets";
+
+/**
+ * Returns the source an image file came from (mediux, tmdb or local)
+ * Images saved before sources were tracked are identified by their ID prefix
+ */
+export function getImageFileSource(image: ImageFile): string {
+  if (image.source) return image.source;
+  if (image.id.startsWith("tmdb_")) return "tmdb";
+  if (image.id.startsWith("local_")) return "local";
+  return "mediux";
+}
+
+/**
+ * Returns the URL used to display an image file in the UI
+ */
+export function getImageFileURL(image: ImageFile, quality?: "thumb" | "optimized" | "original"): string {
+  switch (getImageFileSource(image)) {
+    case "tmdb":
+      return `https://image.tmdb.org/t/p/${quality === "original" ? "original" : "w780"}/${image.id.replace(/^tmdb_/, "")}`;
+    case "local":
+      return `/api/images/local/item?image_id=${encodeURIComponent(image.id)}&modified=${encodeURIComponent(image.modified)}`;
+    default:
+      return `/api/images/mediux/item?asset_id=${image.id}&modified_date=${image.modified}${quality ? `&quality=${quality}` : ""}`;
+  }
+}
@@ -0,0 +1,101 @@
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+import type { AppStatusResponse } from "@/types/config/response-status";
+
+export interface ConfigChange {
+  path: string;
+  type: "added" | "removed" | "changed";
+  old?: unknown;
+  new?: unknown;
+}
+
+export interface ConfigRevision {
+  id: string;
+  created_at: string;
+  restored_from?: string;
+  changes: ConfigChange[];
+}
+
+export interface GetConfigRevisions_Response {
+  revisions: ConfigRevision[];
+}
+
+export interface GetConfigRevisionDiff_Response {
+  from: string;
+  to?: string;
+  changes: ConfigChange[];
+}
+
+export interface RollbackConfigRevision_Response {
+  message: string;
+  status: AppStatusResponse;
+}
+
+export const GetConfigRevisions = async (): Promise<APIResponse<GetConfigRevisions_Response>> => {
+  try {
+    const response = await apiClient.get<APIResponse<GetConfigRevisions_Response>>(`/config/revisions`);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error getting config revisions");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Config",
+      "Config Revisions",
+      `Failed to get config revisions: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<GetConfigRevisions_Response>(error);
+  }
+};
+
+export const GetConfigRevisionDiff = async (
+  from: string,
+  to?: string
+): Promise<APIResponse<GetConfigRevisionDiff_Response>> => {
+  try {
+    const response = await apiClient.get<APIResponse<GetConfigRevisionDiff_Response>>(`/config/revisions/diff`, {
+      params: { from, to },
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error comparing config revisions");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Config",
+      "Config Revisions",
+      `Failed to compare config revisions: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<GetConfigRevisionDiff_Response>(error);
+  }
+};
+
+export const RollbackConfigRevision = async (id: string): Promise<APIResponse<RollbackConfigRevision_Response>> => {
+  log("INFO", "API - Config", "Config Revisions", `Rolling back config to revision ${id}`);
+  try {
+    const response = await apiClient.post<APIResponse<RollbackConfigRevision_Response>>(`/config/revisions/rollback`, {
+      id,
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error rolling back config");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Config",
+      "Config Revisions",
+      `Failed to roll back config to revision ${id}: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<RollbackConfigRevision_Response>(error);
+  }
+};
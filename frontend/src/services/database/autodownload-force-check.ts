@@ -17,8 +17,10 @@ export interface AutodownloadSetResult {
   id: string;
   title: string;
   user_created: string;
-  result: "success" | "skipped" | "error";
+  result: "success" | "warning" | "skipped" | "error";
   reason: string;
+  verified?: string[];
+  unverified?: string[];
 }
 
 export interface AutoDownloadForceCheck_Request {
@@ -0,0 +1,96 @@
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+
+export type CoverageStatus =
+  | "saved"
+  | "mediux_available"
+  | "local_available"
+  | "no_sets"
+  | "ignored";
+
+export interface CoverageGap {
+  image_type: "season_poster" | "titlecard";
+  season_number: number;
+  episode_number?: number;
+}
+
+export interface CoverageItem {
+  tmdb_id: string;
+  library_title: string;
+  edition?: string;
+  rating_key: string;
+  type: string;
+  title: string;
+  year: number;
+  status: CoverageStatus;
+  set_ids?: string[];
+  gaps?: CoverageGap[];
+  tmdb_fallback?: boolean;
+}
+
+export interface CoverageLibrary {
+  library_title: string;
+  type: string;
+  total: number;
+  saved: number;
+  mediux_available: number;
+  local_available: number;
+  no_sets: number;
+  tmdb_fallback: number;
+  ignored: number;
+  with_gaps: number;
+}
+
+export interface CoverageReport {
+  generated_at: string;
+  libraries: CoverageLibrary[];
+  items: CoverageItem[];
+}
+
+export interface CoverageReport_Filter {
+  library_titles?: string[];
+  type?: "movie" | "show";
+  statuses?: CoverageStatus[];
+  gaps_only?: boolean;
+}
+
+const buildCoverageParams = (filter: CoverageReport_Filter, format?: "csv" | "json") => ({
+  library_title: filter.library_titles?.length ? filter.library_titles.join(",") : undefined,
+  type: filter.type || undefined,
+  status: filter.statuses?.length ? filter.statuses.join(",") : undefined,
+  gaps_only: filter.gaps_only ? "true" : undefined,
+  format,
+});
+
+export const GetCoverageReport = async (filter: CoverageReport_Filter = {}): Promise<APIResponse<CoverageReport>> => {
+  try {
+    const response = await apiClient.get<APIResponse<CoverageReport>>(`/db/coverage`, {
+      params: buildCoverageParams(filter),
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error getting coverage report");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - DB",
+      "Coverage Report",
+      `Failed to get coverage report: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<CoverageReport>(error);
+  }
+};
+
+export const ExportCoverageReport = async (filter: CoverageReport_Filter, format: "csv" | "json"): Promise<Blob> => {
+  const response = await apiClient.get(`/db/coverage`, {
+    params: buildCoverageParams(filter, format),
+    responseType: "blob",
+  });
+  return response.data as Blob;
+};
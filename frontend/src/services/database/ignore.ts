@@ -11,6 +11,12 @@ export interface IgnoreItem_Response {
   tmdb_id: string;
   library_title: string;
   mode?: string;
+  ignore_until?: string;
+}
+
+export interface IgnoreItem_UntilOptions {
+  until?: string; // YYYY-MM-DD or RFC3339, used for "until-date" mode
+  duration?: string; // e.g. "30d", "2w", "12h", used for "until-date" mode when until is empty
 }
 
 export const StopIgnoringItemInDB = async (
@@ -64,7 +70,8 @@ export const IgnoreItemInDB = async (
   libraryTitle: string,
   ignoreMode: string,
   currentSetsAvailable: string[],
-  edition: string = ""
+  edition: string = "",
+  untilOptions: IgnoreItem_UntilOptions = {}
 ): Promise<APIResponse<IgnoreItem_Response>> => {
   log(
     "INFO",
@@ -79,6 +86,8 @@ export const IgnoreItemInDB = async (
       edition,
       mode: ignoreMode,
       current_sets: currentSetsAvailable.join(",") || "",
+      until: untilOptions.until || undefined,
+      duration: untilOptions.duration || undefined,
     };
     const response = await apiClient.patch<APIResponse<IgnoreItem_Response>>(`/db/ignore`, null, { params: params });
     if (response.data.status === "error") {
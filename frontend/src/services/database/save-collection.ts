@@ -0,0 +1,75 @@
+import type { CollectionItem } from "@/app/collections/page";
+import { collectionItemInfo } from "@/helper/item-info";
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+import type { CollectionItemSetRef } from "@/types/media-and-posters/sets";
+
+export interface SaveCollection_Request {
+  collection_item: CollectionItem;
+  poster_set: CollectionItemSetRef;
+  selected_types: {
+    poster: boolean;
+    backdrop: boolean;
+  };
+  auto_download: boolean;
+}
+
+export interface SaveCollection_Response {
+  saved_collection: {
+    collection_item: CollectionItem;
+    poster_set: CollectionItemSetRef;
+    selected_types: {
+      poster: boolean;
+      backdrop: boolean;
+    };
+    auto_download: boolean;
+    last_downloaded: string;
+  };
+}
+
+export const SaveCollectionToDB = async (
+  req: SaveCollection_Request
+): Promise<APIResponse<SaveCollection_Response>> => {
+  log(
+    "INFO",
+    "API - DB",
+    "Save Collection",
+    `Saving ${collectionItemInfo(req.collection_item)} with set ${req.poster_set.id} in DB`,
+    req
+  );
+  try {
+    // The collection children are fetched from the media server when needed, so they are not sent
+    const body: SaveCollection_Request = {
+      ...req,
+      collection_item: { ...req.collection_item, media_items: [] },
+    };
+    const response = await apiClient.post<APIResponse<SaveCollection_Response>>(`/db/collection`, body);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error saving collection in DB");
+    } else {
+      log(
+        "INFO",
+        "API - DB",
+        "Save Collection",
+        `Saved ${collectionItemInfo(req.collection_item)} in DB`,
+        response.data
+      );
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - DB",
+      "Save Collection",
+      `Failed to save ${collectionItemInfo(req.collection_item)} in DB: ${
+        error instanceof Error ? error.message : "Unknown error"
+      }`,
+      error
+    );
+    return ReturnErrorMessage<SaveCollection_Response>(error);
+  }
+};
@@ -0,0 +1,98 @@
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+import type { SelectedTypes } from "@/types/media-and-posters/media-item-and-library";
+import type { BaseSetInfo } from "@/types/media-and-posters/sets";
+
+export interface WantedItem {
+  tmdb_id: string;
+  type: "movie" | "show";
+  title?: string;
+  year?: number;
+  library_title: string;
+  set: BaseSetInfo;
+  selected_types: SelectedTypes;
+  auto_download: boolean;
+  created_at?: string;
+}
+
+export interface SaveWantedItem_Response {
+  wanted_item: WantedItem;
+  queued: boolean;
+}
+
+export interface GetAllWantedItems_Response {
+  wanted_items: WantedItem[];
+}
+
+export interface DeleteWantedItem_Response {
+  message: string;
+}
+
+export const GetAllWantedItems = async (): Promise<APIResponse<GetAllWantedItems_Response>> => {
+  try {
+    const response = await apiClient.get<APIResponse<GetAllWantedItems_Response>>(`/db/wanted`);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error getting wanted list");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - DB",
+      "Wanted List",
+      `Failed to get wanted list: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<GetAllWantedItems_Response>(error);
+  }
+};
+
+export const SaveWantedItem = async (item: WantedItem): Promise<APIResponse<SaveWantedItem_Response>> => {
+  log("INFO", "API - DB", "Wanted List", `Adding ${item.title || item.tmdb_id} with set ${item.set.id} to wanted list`, item);
+  try {
+    const response = await apiClient.post<APIResponse<SaveWantedItem_Response>>(`/db/wanted`, item);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error adding item to wanted list");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - DB",
+      "Wanted List",
+      `Failed to add ${item.title || item.tmdb_id} to wanted list: ${
+        error instanceof Error ? error.message : "Unknown error"
+      }`,
+      error
+    );
+    return ReturnErrorMessage<SaveWantedItem_Response>(error);
+  }
+};
+
+export const DeleteWantedItem = async (
+  tmdbID: string,
+  type: WantedItem["type"]
+): Promise<APIResponse<DeleteWantedItem_Response>> => {
+  try {
+    const response = await apiClient.delete<APIResponse<DeleteWantedItem_Response>>(`/db/wanted`, {
+      params: { tmdb_id: tmdbID, type },
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error removing item from wanted list");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - DB",
+      "Wanted List",
+      `Failed to remove ${type} ${tmdbID} from wanted list: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<DeleteWantedItem_Response>(error);
+  }
+};
@@ -0,0 +1,105 @@
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+import type { SelectedTypes } from "@/types/media-and-posters/media-item-and-library";
+
+export interface ApplyBoxset_Request {
+  username: string;
+  boxset_id: string;
+  library_titles?: string[];
+  selected_types: SelectedTypes;
+  auto_download: boolean;
+  dry_run?: boolean;
+}
+
+export interface BoxsetMember {
+  set_id: string;
+  set_type: string;
+  set_title: string;
+  tmdb_id: string;
+  title: string;
+  library_title?: string;
+  edition?: string;
+  result: "queued" | "would-queue" | "missing" | "skipped" | "error";
+  reason?: string;
+}
+
+export interface DownloadQueueBatch {
+  id: string;
+  title: string;
+  created_at: string;
+  status: string;
+  total: number;
+  pending: number;
+  success: number;
+  warning: number;
+  error: number;
+  items: {
+    item: string;
+    set_id: string;
+    file: string;
+    status: "Pending" | "Success" | "Warning" | "Error";
+  }[];
+}
+
+export interface ApplyBoxset_Response {
+  boxset: { id: string; title: string };
+  matched: BoxsetMember[];
+  missing: BoxsetMember[];
+  skipped: BoxsetMember[];
+  batch?: DownloadQueueBatch;
+}
+
+export const ApplyBoxset = async (req: ApplyBoxset_Request): Promise<APIResponse<ApplyBoxset_Response>> => {
+  log("INFO", "API - Download", "Apply Boxset", `Applying boxset ${req.boxset_id} by ${req.username}`, req);
+  try {
+    const response = await apiClient.post<APIResponse<ApplyBoxset_Response>>(`/download/boxset`, req);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error applying boxset");
+    } else {
+      log(
+        "INFO",
+        "API - Download",
+        "Apply Boxset",
+        `Applied boxset ${req.boxset_id}: ${response.data.data?.matched.length ?? 0} matched, ${
+          response.data.data?.missing.length ?? 0
+        } missing`,
+        response.data
+      );
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Download",
+      "Apply Boxset",
+      `Failed to apply boxset ${req.boxset_id}: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<ApplyBoxset_Response>(error);
+  }
+};
+
+export const GetDownloadQueueBatch = async (id: string): Promise<APIResponse<DownloadQueueBatch>> => {
+  try {
+    const response = await apiClient.get<APIResponse<DownloadQueueBatch>>(`/download/queue/batch`, {
+      params: { id },
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error getting download queue batch");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Download",
+      "Get Batch",
+      `Failed to get download queue batch ${id}: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<DownloadQueueBatch>(error);
+  }
+};
@@ -7,9 +7,18 @@ import type { APIResponse } from "@/types/api/api-response";
 import type { MediaItem } from "@/types/media-and-posters/media-item-and-library";
 import type { ImageFile } from "@/types/media-and-posters/sets";
 
+// The set the image comes from, recorded in the artwork history of the item
+export interface DownloadImageFileForMediaItem_Set {
+  id: string;
+  title: string;
+  user_created: string;
+  source?: string;
+}
+
 export interface DownloadImageFileForMediaItem_Request {
   media_item: MediaItem;
   image_file: ImageFile;
+  set?: DownloadImageFileForMediaItem_Set;
 }
 
 export interface DownloadImageFileForMediaItem_Response {
@@ -19,12 +28,14 @@ export interface DownloadImageFileForMediaItem_Response {
 export const downloadImageFileForMediaItem = async (
   imageFile: ImageFile,
   mediaItem: MediaItem,
-  fileName: string
+  fileName: string,
+  set?: DownloadImageFileForMediaItem_Set
 ): Promise<APIResponse<DownloadImageFileForMediaItem_Response>> => {
   try {
     const req: DownloadImageFileForMediaItem_Request = {
       image_file: imageFile,
       media_item: mediaItem,
+      set,
     };
     const response = await apiClient.post<APIResponse<DownloadImageFileForMediaItem_Response>>(
       `/download/image/item`,
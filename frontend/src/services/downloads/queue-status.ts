@@ -10,6 +10,8 @@ export interface GetDownloadQueueStatus_Response {
   message: string;
   warnings: string[];
   errors: string[];
+  verified?: string[];
+  unverified?: string[];
 }
 
 export const GetDownloadQueueStatus = async (): Promise<APIResponse<GetDownloadQueueStatus_Response>> => {
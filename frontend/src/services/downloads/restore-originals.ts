@@ -0,0 +1,103 @@
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+
+export interface RestoreOriginals_Scope {
+  library_title?: string;
+  creator?: string;
+  set_id?: string;
+}
+
+type RestoreStatus = "Pending" | "Restored" | "Error";
+
+export interface RestoreOriginalsJob {
+  id: string;
+  scope: RestoreOriginals_Scope;
+  status: "Pending" | "Running" | "Success" | "Completed With Errors";
+  created_at: string;
+  started_at?: string;
+  finished_at?: string;
+  total: number;
+  pending: number;
+  restored: number;
+  error: number;
+  items?: {
+    tmdb_id: string;
+    library_title: string;
+    edition?: string;
+    type: string;
+    title: string;
+    year: number;
+    rating_key: string;
+    set_ids: string[];
+    status: RestoreStatus;
+    error?: string;
+    images: {
+      image_type: string;
+      season_number?: number;
+      episode_number?: number;
+      status: RestoreStatus;
+      method?: "artwork_history" | "media_server";
+      error?: string;
+    }[];
+  }[];
+}
+
+export interface GetRestoreOriginals_Response {
+  jobs?: RestoreOriginalsJob[];
+  job?: RestoreOriginalsJob;
+}
+
+export const StartRestoreOriginals = async (
+  scope: RestoreOriginals_Scope
+): Promise<APIResponse<RestoreOriginalsJob>> => {
+  log("INFO", "API - Download", "Restore Originals", "Starting restore originals job", scope);
+  try {
+    const response = await apiClient.post<APIResponse<RestoreOriginalsJob>>(`/download/restore-originals`, scope);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error starting restore originals job");
+    } else {
+      log(
+        "INFO",
+        "API - Download",
+        "Restore Originals",
+        `Started restore originals job ${response.data.data?.id} for ${response.data.data?.total ?? 0} items`,
+        response.data
+      );
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Download",
+      "Restore Originals",
+      `Failed to start restore originals job: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<RestoreOriginalsJob>(error);
+  }
+};
+
+export const GetRestoreOriginals = async (id?: string): Promise<APIResponse<GetRestoreOriginals_Response>> => {
+  try {
+    const response = await apiClient.get<APIResponse<GetRestoreOriginals_Response>>(`/download/restore-originals`, {
+      params: id ? { id } : {},
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error getting restore originals jobs");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Download",
+      "Restore Originals",
+      `Failed to get restore originals jobs: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<GetRestoreOriginals_Response>(error);
+  }
+};
@@ -0,0 +1,48 @@
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+
+export interface DriftedImage {
+  tmdb_id: string;
+  library_title: string;
+  edition?: string;
+  title: string;
+  year: number;
+  rating_key: string;
+  slot: string;
+  image_type: string;
+  set_id?: string;
+  reapplied: boolean;
+  error?: string;
+}
+
+export interface ArtworkDriftReport {
+  checked_at: string;
+  checked: number;
+  drifted: number;
+  reapplied: number;
+  errors: number;
+  images: DriftedImage[];
+}
+
+export const GetArtworkDriftReport = async (): Promise<APIResponse<ArtworkDriftReport>> => {
+  try {
+    const response = await apiClient.get<APIResponse<ArtworkDriftReport>>(`/images/drift`);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error getting artwork drift report");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Images",
+      "Artwork Drift",
+      `Failed to get artwork drift report: ${error instanceof Error ? error.message : "Unknown error"}`,
+      error
+    );
+    return ReturnErrorMessage<ArtworkDriftReport>(error);
+  }
+};
@@ -0,0 +1,115 @@
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+import type { MediaItem } from "@/types/media-and-posters/media-item-and-library";
+
+export interface ArtworkHistoryVersion {
+  id: string;
+  image_type: string;
+  season_number?: number;
+  episode_number?: number;
+  file_name?: string;
+  image?: {
+    source: string;
+    image_id: string;
+    modified: string;
+  };
+  content_type: string;
+  hash: string;
+  captured_at: string;
+  original?: boolean;
+  replaced_by: {
+    source: string;
+    image_id: string;
+    image_modified: string;
+    set_id?: string;
+    set_title?: string;
+    set_creator?: string;
+  };
+}
+
+export interface ArtworkHistory {
+  tmdb_id: string;
+  library_title: string;
+  type: string;
+  title: string;
+  year: number;
+  edition?: string;
+  versions: ArtworkHistoryVersion[];
+}
+
+export interface RevertArtwork_Response {
+  version: ArtworkHistoryVersion;
+}
+
+export const GetArtworkHistoryImageURL = (mediaItem: MediaItem, version: ArtworkHistoryVersion) =>
+  `/api/images/history/item?rating_key=${encodeURIComponent(mediaItem.rating_key)}&version_id=${encodeURIComponent(
+    version.id
+  )}`;
+
+export const GetArtworkHistory = async (mediaItem: MediaItem): Promise<APIResponse<ArtworkHistory>> => {
+  try {
+    const response = await apiClient.get<APIResponse<ArtworkHistory>>(`/images/history`, {
+      params: { rating_key: mediaItem.rating_key },
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error getting artwork history");
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Images",
+      "Artwork History",
+      `Failed to get artwork history for '${mediaItem.title}' (TMDB ID: ${mediaItem.tmdb_id}): ${
+        error instanceof Error ? error.message : "Unknown error"
+      }`,
+      error
+    );
+    return ReturnErrorMessage<ArtworkHistory>(error);
+  }
+};
+
+export const RevertArtwork = async (
+  mediaItem: MediaItem,
+  version: ArtworkHistoryVersion
+): Promise<APIResponse<RevertArtwork_Response>> => {
+  log(
+    "INFO",
+    "API - Images",
+    "Revert Artwork",
+    `Reverting ${version.image_type} for '${mediaItem.title}' (TMDB ID: ${mediaItem.tmdb_id}) to version ${version.id}`
+  );
+  try {
+    const response = await apiClient.post<APIResponse<RevertArtwork_Response>>(`/images/history/revert`, {
+      rating_key: mediaItem.rating_key,
+      version_id: version.id,
+    });
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error reverting artwork");
+    } else {
+      log(
+        "INFO",
+        "API - Images",
+        "Revert Artwork",
+        `Reverted ${version.image_type} for '${mediaItem.title}' (TMDB ID: ${mediaItem.tmdb_id})`,
+        response.data
+      );
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Images",
+      "Revert Artwork",
+      `Failed to revert ${version.image_type} for '${mediaItem.title}' (TMDB ID: ${mediaItem.tmdb_id}): ${
+        error instanceof Error ? error.message : "Unknown error"
+      }`,
+      error
+    );
+    return ReturnErrorMessage<RevertArtwork_Response>(error);
+  }
+};
@@ -9,6 +9,14 @@ export interface GetLogContents_Response {
   possible_actions_paths: Record<string, { label: string; section: string }>;
   log_entries: LogData[];
   total_log_entries: number;
+  next_cursor?: string;
+}
+
+export interface GetLogContents_Options {
+  from?: string; // RFC3339
+  to?: string; // RFC3339
+  search?: string;
+  cursor?: string; // next_cursor from the previous page, used when pageNumber is 0
 }
 
 export const GetLogContents = async (
@@ -16,7 +24,8 @@ export const GetLogContents = async (
   filteredStatuses: string[],
   filteredActions: string[],
   itemsPerPage: number,
-  pageNumber: number
+  pageNumber: number,
+  options: GetLogContents_Options = {}
 ): Promise<APIResponse<GetLogContents_Response>> => {
   log("INFO", "API - Logs", "Fetch Log Contents", "Fetching log contents");
   try {
@@ -25,7 +34,11 @@ export const GetLogContents = async (
       statuses: filteredStatuses.join(","),
       actions: filteredActions.join(","),
       items_per_page: itemsPerPage,
-      page_number: pageNumber,
+      page_number: pageNumber > 0 ? pageNumber : undefined,
+      from: options.from || undefined,
+      to: options.to || undefined,
+      search: options.search || undefined,
+      cursor: options.cursor || undefined,
     };
     const response = await apiClient.get<APIResponse<GetLogContents_Response>>(`/logs`, { params: params });
     if (response.data.status === "error") {
@@ -0,0 +1,61 @@
+import type { CollectionItem } from "@/app/collections/page";
+import apiClient from "@/services/api-client";
+import { ReturnErrorMessage } from "@/services/api-error-return";
+
+import { log } from "@/lib/logger";
+
+import type { APIResponse } from "@/types/api/api-response";
+
+export interface CreateCollectionFromSet_Request {
+  set_id: string;
+  tmdb_id: string;
+  library_title: string;
+  title?: string;
+}
+
+export interface CreateCollectionFromSet_Response {
+  collection_item: CollectionItem;
+  created: boolean;
+  added_items: string[];
+  missing_items: string[];
+  applied_images: string[];
+  image_errors: string[];
+}
+
+export const CreateCollectionFromSet = async (
+  req: CreateCollectionFromSet_Request
+): Promise<APIResponse<CreateCollectionFromSet_Response>> => {
+  log(
+    "INFO",
+    "API - Media Server",
+    "Create Collection",
+    `Creating collection for set ${req.set_id} in ${req.library_title}`,
+    req
+  );
+  try {
+    const response = await apiClient.post<APIResponse<CreateCollectionFromSet_Response>>(`/mediaserver/collections`, req);
+    if (response.data.status === "error") {
+      throw new Error(response.data.error?.message || "Unknown error creating collection on media server");
+    } else {
+      log(
+        "INFO",
+        "API - Media Server",
+        "Create Collection",
+        `${response.data.data?.created ? "Created" : "Updated"} collection for set ${req.set_id} in ${req.library_title}`,
+        response.data
+      );
+    }
+    return response.data;
+  } catch (error) {
+    log(
+      "ERROR",
+      "API - Media Server",
+      "Create Collection",
+      `Failed to create collection for set ${req.set_id} in ${req.library_title}: ${
+        error instanceof Error ? error.message : "Unknown error"
+      }`,
+      error
+    );
+    return ReturnErrorMessage<CreateCollectionFromSet_Response>(error);
+  }
+};
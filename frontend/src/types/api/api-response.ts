@@ -15,6 +15,7 @@ export interface LogData {
   route?: LogRouteInfo;
   actions?: LogAction[];
   time: string;
+  trace_id?: string;
 }
 
 export interface LogRouteInfo {
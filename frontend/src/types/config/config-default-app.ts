@@ -22,6 +22,10 @@ export const defaultAppConfig = (): AppConfig =>
     logging: {
       level: "",
       file: "",
+      max_size_mb: 25,
+      max_backups: 7,
+      max_age_days: 14,
+      compress: true,
     },
     media_server: {
       type: "",
@@ -33,10 +37,24 @@ export const defaultAppConfig = (): AppConfig =>
     mediux: {
       api_token: "",
       download_quality: "",
+      enable_event_listener: false,
     },
     auto_download: {
       enabled: false,
       cron: "",
+      auto_apply: {
+        enabled: false,
+        creators: [],
+        fallback_to_most_popular: false,
+        selected_types: {
+          poster: true,
+          backdrop: true,
+          season_poster: true,
+          special_season_poster: true,
+          titlecard: true,
+        },
+        auto_download: true,
+      },
     },
     images: {
       cache_images: { enabled: false },
@@ -45,9 +63,27 @@ export const defaultAppConfig = (): AppConfig =>
         path: "",
         episode_naming_convention: "",
       },
+      local_artwork: {
+        enabled: false,
+        path: "",
+      },
+      artwork_history: {
+        enabled: false,
+        max_versions: 5,
+      },
+      drift_detection: {
+        enabled: false,
+        cron: "0 3 * * *",
+        reapply: false,
+      },
+      verify_apply: {
+        enabled: false,
+      },
     },
     tmdb: {
       api_token: "",
+      languages: [],
+      min_vote_average: 0,
     },
     labels_and_tags: {
       applications: [],
@@ -104,6 +140,20 @@ export const defaultAppConfig = (): AppConfig =>
             "{{MediaItemTitle}}{{NewLine}}{{ImageName}}{{NewLine}}Set ID: {{SetID}}{{NewLine}}Reason:{{NewLine}}{{Reason}}{{NewLine}}{{Result}}",
           include_image: true,
         },
+        wanted_item_available: {
+          enabled: true,
+          title: "Wanted Item Available",
+          message:
+            "{{MediaItemTitle}} ({{MediaItemYear}}) is now in {{MediaItemLibraryTitle}}.{{NewLine}}The set '{{SetTitle}}' by {{SetCreator}} has been saved and added to the download queue.",
+          include_image: true,
+        },
+        ignored_item_expired: {
+          enabled: true,
+          title: "Ignored Item Expired",
+          message:
+            "{{MediaItemTitle}} ({{MediaItemLibraryTitle}}) was ignored until {{IgnoredUntil}} and will no longer be ignored in {{AppName}}.",
+          include_image: true,
+        },
       },
     },
     sonarr_radarr: {
@@ -9,6 +9,7 @@ export interface AppConfig {
   labels_and_tags: AppConfigLabelsAndTags; // Labels and tags management settings
   notifications: AppConfigNotifications; // Notification settings
   sonarr_radarr: AppConfigSonarrRadarrApps; // List of Sonarr/Radarr instances to integrate with
+  health?: AppConfigHealth; // Readiness check settings
 }
 
 export interface AppConfigAuth {
@@ -33,6 +34,18 @@ export interface AppConfigAuthOIDC {
 export interface AppConfigLogging {
   level: string; // Logging level (e.g., DEBUG, INFO, WARN, ERROR)
   file?: string; // Log file path
+  max_size_mb?: number; // Size in megabytes the log file is rotated at (default 25)
+  max_backups?: number; // Number of rotated log files to keep (default 7)
+  max_age_days?: number; // Days to keep rotated log files (default 14)
+  compress?: boolean; // Gzip rotated log files
+  tracing?: AppConfigLoggingTracing; // OpenTelemetry trace export settings
+}
+
+export interface AppConfigLoggingTracing {
+  enabled: boolean; // Export every route and job as a trace over OTLP/HTTP
+  endpoint?: string; // OTLP/HTTP endpoint of the collector (e.g. http://tempo:4318)
+  service_name?: string; // service.name of the exported spans (default aura)
+  headers?: Record<string, string>; // Extra headers sent to the collector
 }
 
 export interface AppConfigMediaServer {
@@ -43,6 +56,7 @@ export interface AppConfigMediaServer {
   user_id?: string; // User ID for accessing the media server (optional for Emby/Jellyfin)
   enable_sort_by_episode_added_date?: boolean; // Whether to enable sorting shows by latest episode added date (Plex only)
   enable_plex_event_listener?: boolean; // Whether to enable the Plex event listener for reapplying images on refresh (Plex only)
+  enable_emby_jellyfin_event_listener?: boolean; // Whether to enable the Emby/Jellyfin event listener for reapplying images on refresh (Emby/Jellyfin only)
 }
 
 export interface AppConfigMediaServerLibrary {
@@ -55,16 +69,45 @@ export interface AppConfigMediaServerLibrary {
 export interface AppConfigMediux {
   api_token: string; // Authentication token for accessing MediUX services
   download_quality: string; // Preferred download quality (e.g., "original", "optimized")
+  enable_event_listener?: boolean; // Whether to check saved sets as soon as MediUX reports an update to them
 }
 
 export interface AppConfigAutoDownload {
   enabled: boolean; // Whether auto-download is enabled
   cron: string; // Cron expression for scheduling auto-downloads
+  auto_apply?: AppConfigAutoApply; // Settings for automatically applying sets to newly added items
+}
+
+export interface AppConfigAutoApply {
+  enabled: boolean; // Whether to apply a set to newly added items automatically
+  creators: AppConfigAutoApplyCreator[]; // Preferred set creators, highest ranked first
+  fallback_to_most_popular: boolean; // Use the most popular set when none of the preferred creators has a set
+  selected_types: AppConfigAutoApplyTypes; // Image types to apply from the picked set
+  auto_download: boolean; // Whether the saved set is checked for updates by AutoDownload
+}
+
+export interface AppConfigAutoApplyCreator {
+  username: string; // MediUX username of the creator
+  libraries?: string[]; // Only use this creator for these libraries
+  types?: string[]; // Only use this creator for these item types (movie, show)
+  languages?: string[]; // Only use sets with images in one of these languages
+}
+
+export interface AppConfigAutoApplyTypes {
+  poster: boolean;
+  backdrop: boolean;
+  season_poster: boolean;
+  special_season_poster: boolean;
+  titlecard: boolean;
 }
 
 export interface AppConfigImages {
   cache_images: AppConfigCacheImages;
   save_images_locally: AppConfigSaveImagesLocally;
+  local_artwork: AppConfigLocalArtwork;
+  artwork_history: AppConfigArtworkHistory;
+  drift_detection: AppConfigDriftDetection;
+  verify_apply: AppConfigVerifyApply;
 }
 
 export interface AppConfigCacheImages {
@@ -77,8 +120,30 @@ export interface AppConfigSaveImagesLocally {
   episode_naming_convention: string; // Naming convention for episode images.
 }
 
+export interface AppConfigLocalArtwork {
+  enabled: boolean; // Whether to index the local artwork folder as an image source.
+  path: string; // Root folder containing one sub folder per item, named by TMDB ID or "Title (Year)".
+}
+
+export interface AppConfigArtworkHistory {
+  enabled: boolean; // Whether to save the current media server artwork before AURA replaces it.
+  max_versions: number; // Number of versions kept per image (e.g. per poster or titlecard). Defaults to 5.
+}
+
+export interface AppConfigDriftDetection {
+  enabled: boolean; // Whether to record a fingerprint of applied images and check them on a schedule.
+  cron: string; // Cron expression for the drift check. Defaults to every day at 3 AM.
+  reapply: boolean; // Whether to re-apply images that were changed outside of AURA.
+}
+
+export interface AppConfigVerifyApply {
+  enabled: boolean; // Whether to fetch every applied image back from the media server and compare it with the uploaded image.
+}
+
 export interface AppConfigTMDB {
   api_token: string; // API key for accessing TMDB services
+  languages?: string[]; // ISO 639-1 codes of the TMDB images to offer ("none" for textless images). Empty means all languages
+  min_vote_average?: number; // Minimum TMDB vote average (0-10) of the TMDB images to offer
 }
 
 export interface AppConfigLabelsAndTags {
@@ -140,6 +205,8 @@ export interface AppConfigNotificationTemplate {
   new_sets_available_for_ignored_items: AppConfigNotificationCustomNotification;
   check_for_media_item_changes_job: AppConfigNotificationCustomNotification;
   sonarr_notification: AppConfigNotificationCustomNotification;
+  wanted_item_available: AppConfigNotificationCustomNotification;
+  ignored_item_expired: AppConfigNotificationCustomNotification;
 }
 
 export interface NotificationTemplateVariablesCatalog {
@@ -162,3 +229,7 @@ export interface AppConfigSonarrRadarrApp {
   url: string; // Base URL of the Sonarr/Radarr server.
   api_token: string; // API key for accessing the Sonarr/Radarr server.
 }
+
+export interface AppConfigHealth {
+  criticality?: Record<string, "critical" | "optional" | "ignore">; // How much a failing readiness check matters, keyed by check name
+}
@@ -1,5 +1,10 @@
 import type { AppConfig } from "@/types/config/config";
 
+export interface AppConfigSource {
+  source: "env" | "file";
+  variable: string;
+}
+
 export interface AppStatusResponse {
   config_loaded: boolean;
   config_valid: boolean;
@@ -11,4 +16,5 @@ export interface AppStatusResponse {
   app_version: string;
   app_loading_step: string;
   api_key_configured: boolean;
+  config_sources?: Record<string, AppConfigSource>;
 }
@@ -24,6 +24,7 @@ export interface MediaItem {
   db_saved_sets: DBSavedSet[];
   ignored_in_db: boolean;
   ignored_mode: string;
+  ignored_until?: string;
 
   has_mediux_sets: boolean;
   updated_at: number;
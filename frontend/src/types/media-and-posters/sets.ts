@@ -30,6 +30,7 @@ export interface BaseSetInfo {
   date_updated: string;
   popularity: number;
   popularity_global: number;
+  source?: string;
 }
 
 export interface ImageFile {
@@ -44,6 +45,7 @@ export interface ImageFile {
   title?: string;
   season_number?: number;
   episode_number?: number;
+  source?: string;
 }
 
 export interface SetRef extends Omit<BaseSetInfo, "type"> {
@@ -65,7 +65,7 @@ export const USER_PAGE_FILTER_IN_DB_OPTIONS: {
 
 // Ignored Filter Options
 export type TYPE_FILTER_IGNORED_OPTIONS =
-  "" | "ignored" | "always" | "until-set-available" | "until-new-set-available" | "not_ignored";
+  "" | "ignored" | "always" | "until-set-available" | "until-new-set-available" | "until-date" | "not_ignored";
 export const FILTER_IGNORED_OPTIONS: {
   value: TYPE_FILTER_IGNORED_OPTIONS;
   label: string;
@@ -74,6 +74,7 @@ export const FILTER_IGNORED_OPTIONS: {
   { value: "always", label: "Always Ignored" },
   { value: "until-set-available", label: "Until Set Available" },
   { value: "until-new-set-available", label: "Until New Set Available" },
+  { value: "until-date", label: "Until Date" },
   { value: "not_ignored", label: "Not Ignored" },
 ];
 